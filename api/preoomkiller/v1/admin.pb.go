@@ -0,0 +1,1515 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        (unknown)
+// source: preoomkiller/v1/admin.proto
+
+package preoomkillerv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatusRequest) Reset() {
+	*x = GetStatusRequest{}
+	mi := &file_preoomkiller_v1_admin_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatusRequest) ProtoMessage() {}
+
+func (x *GetStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_preoomkiller_v1_admin_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetStatusRequest) Descriptor() ([]byte, []int) {
+	return file_preoomkiller_v1_admin_proto_rawDescGZIP(), []int{0}
+}
+
+type GetStatusResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// State is the application state, e.g. "running".
+	State     string                 `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
+	StartTime *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	Uptime    *durationpb.Duration   `protobuf:"bytes,3,opt,name=uptime,proto3" json:"uptime,omitempty"`
+	// SuspendedWorkloads are the workloads whose evictions are suspended.
+	SuspendedWorkloads []*SuspendedWorkload `protobuf:"bytes,4,rep,name=suspended_workloads,json=suspendedWorkloads,proto3" json:"suspended_workloads,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *GetStatusResponse) Reset() {
+	*x = GetStatusResponse{}
+	mi := &file_preoomkiller_v1_admin_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatusResponse) ProtoMessage() {}
+
+func (x *GetStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_preoomkiller_v1_admin_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetStatusResponse) Descriptor() ([]byte, []int) {
+	return file_preoomkiller_v1_admin_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetStatusResponse) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *GetStatusResponse) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *GetStatusResponse) GetUptime() *durationpb.Duration {
+	if x != nil {
+		return x.Uptime
+	}
+	return nil
+}
+
+func (x *GetStatusResponse) GetSuspendedWorkloads() []*SuspendedWorkload {
+	if x != nil {
+		return x.SuspendedWorkloads
+	}
+	return nil
+}
+
+// SuspendedWorkload is a workload whose evictions are suspended because no replacement of an evicted
+// pod became Ready.
+type SuspendedWorkload struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Cluster   string                 `protobuf:"bytes,1,opt,name=cluster,proto3" json:"cluster,omitempty"`
+	Namespace string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	OwnerKind string                 `protobuf:"bytes,3,opt,name=owner_kind,json=ownerKind,proto3" json:"owner_kind,omitempty"`
+	OwnerName string                 `protobuf:"bytes,4,opt,name=owner_name,json=ownerName,proto3" json:"owner_name,omitempty"`
+	// Pod is the evicted pod whose replacement did not become Ready.
+	Pod           string                 `protobuf:"bytes,5,opt,name=pod,proto3" json:"pod,omitempty"`
+	SuspendedAt   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=suspended_at,json=suspendedAt,proto3" json:"suspended_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuspendedWorkload) Reset() {
+	*x = SuspendedWorkload{}
+	mi := &file_preoomkiller_v1_admin_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuspendedWorkload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuspendedWorkload) ProtoMessage() {}
+
+func (x *SuspendedWorkload) ProtoReflect() protoreflect.Message {
+	mi := &file_preoomkiller_v1_admin_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuspendedWorkload.ProtoReflect.Descriptor instead.
+func (*SuspendedWorkload) Descriptor() ([]byte, []int) {
+	return file_preoomkiller_v1_admin_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SuspendedWorkload) GetCluster() string {
+	if x != nil {
+		return x.Cluster
+	}
+	return ""
+}
+
+func (x *SuspendedWorkload) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *SuspendedWorkload) GetOwnerKind() string {
+	if x != nil {
+		return x.OwnerKind
+	}
+	return ""
+}
+
+func (x *SuspendedWorkload) GetOwnerName() string {
+	if x != nil {
+		return x.OwnerName
+	}
+	return ""
+}
+
+func (x *SuspendedWorkload) GetPod() string {
+	if x != nil {
+		return x.Pod
+	}
+	return ""
+}
+
+func (x *SuspendedWorkload) GetSuspendedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SuspendedAt
+	}
+	return nil
+}
+
+type ListPodsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPodsRequest) Reset() {
+	*x = ListPodsRequest{}
+	mi := &file_preoomkiller_v1_admin_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPodsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPodsRequest) ProtoMessage() {}
+
+func (x *ListPodsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_preoomkiller_v1_admin_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPodsRequest.ProtoReflect.Descriptor instead.
+func (*ListPodsRequest) Descriptor() ([]byte, []int) {
+	return file_preoomkiller_v1_admin_proto_rawDescGZIP(), []int{3}
+}
+
+type ListPodsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pods          []*Pod                 `protobuf:"bytes,1,rep,name=pods,proto3" json:"pods,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPodsResponse) Reset() {
+	*x = ListPodsResponse{}
+	mi := &file_preoomkiller_v1_admin_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPodsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPodsResponse) ProtoMessage() {}
+
+func (x *ListPodsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_preoomkiller_v1_admin_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPodsResponse.ProtoReflect.Descriptor instead.
+func (*ListPodsResponse) Descriptor() ([]byte, []int) {
+	return file_preoomkiller_v1_admin_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListPodsResponse) GetPods() []*Pod {
+	if x != nil {
+		return x.Pods
+	}
+	return nil
+}
+
+// Pod is a pod selected by a controller.
+type Pod struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Cluster is the kubeconfig context of the controller; empty unless several clusters are watched.
+	Cluster   string `protobuf:"bytes,1,opt,name=cluster,proto3" json:"cluster,omitempty"`
+	Namespace string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name      string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// MemoryThreshold is the memory-threshold annotation, including defaults; empty when unset.
+	MemoryThreshold string `protobuf:"bytes,4,opt,name=memory_threshold,json=memoryThreshold,proto3" json:"memory_threshold,omitempty"`
+	// RestartSchedule is the restart-schedule or restart-window annotation; empty when unset.
+	RestartSchedule string `protobuf:"bytes,5,opt,name=restart_schedule,json=restartSchedule,proto3" json:"restart_schedule,omitempty"`
+	// RestartAt is the restart-at annotation; unset when the pod has none.
+	RestartAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=restart_at,json=restartAt,proto3" json:"restart_at,omitempty"`
+	// MemoryUsageBytes is the usage of the last threshold check; 0 before the first check.
+	MemoryUsageBytes int64 `protobuf:"varint,7,opt,name=memory_usage_bytes,json=memoryUsageBytes,proto3" json:"memory_usage_bytes,omitempty"`
+	// MemoryThresholdBytes is the effective threshold of the last threshold check; 0 before the first check.
+	MemoryThresholdBytes int64 `protobuf:"varint,8,opt,name=memory_threshold_bytes,json=memoryThresholdBytes,proto3" json:"memory_threshold_bytes,omitempty"`
+	// CheckedAt is when the last threshold check ran; unset before the first check.
+	CheckedAt *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=checked_at,json=checkedAt,proto3" json:"checked_at,omitempty"`
+	// SuspendedAt is when evictions of the pod's workload were suspended; unset when they are not.
+	SuspendedAt   *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=suspended_at,json=suspendedAt,proto3" json:"suspended_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Pod) Reset() {
+	*x = Pod{}
+	mi := &file_preoomkiller_v1_admin_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Pod) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pod) ProtoMessage() {}
+
+func (x *Pod) ProtoReflect() protoreflect.Message {
+	mi := &file_preoomkiller_v1_admin_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pod.ProtoReflect.Descriptor instead.
+func (*Pod) Descriptor() ([]byte, []int) {
+	return file_preoomkiller_v1_admin_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Pod) GetCluster() string {
+	if x != nil {
+		return x.Cluster
+	}
+	return ""
+}
+
+func (x *Pod) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *Pod) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Pod) GetMemoryThreshold() string {
+	if x != nil {
+		return x.MemoryThreshold
+	}
+	return ""
+}
+
+func (x *Pod) GetRestartSchedule() string {
+	if x != nil {
+		return x.RestartSchedule
+	}
+	return ""
+}
+
+func (x *Pod) GetRestartAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RestartAt
+	}
+	return nil
+}
+
+func (x *Pod) GetMemoryUsageBytes() int64 {
+	if x != nil {
+		return x.MemoryUsageBytes
+	}
+	return 0
+}
+
+func (x *Pod) GetMemoryThresholdBytes() int64 {
+	if x != nil {
+		return x.MemoryThresholdBytes
+	}
+	return 0
+}
+
+func (x *Pod) GetCheckedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CheckedAt
+	}
+	return nil
+}
+
+func (x *Pod) GetSuspendedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SuspendedAt
+	}
+	return nil
+}
+
+type ListPendingEvictionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPendingEvictionsRequest) Reset() {
+	*x = ListPendingEvictionsRequest{}
+	mi := &file_preoomkiller_v1_admin_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPendingEvictionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPendingEvictionsRequest) ProtoMessage() {}
+
+func (x *ListPendingEvictionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_preoomkiller_v1_admin_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPendingEvictionsRequest.ProtoReflect.Descriptor instead.
+func (*ListPendingEvictionsRequest) Descriptor() ([]byte, []int) {
+	return file_preoomkiller_v1_admin_proto_rawDescGZIP(), []int{6}
+}
+
+type ListPendingEvictionsResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	PendingEvictions []*PendingEviction     `protobuf:"bytes,1,rep,name=pending_evictions,json=pendingEvictions,proto3" json:"pending_evictions,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ListPendingEvictionsResponse) Reset() {
+	*x = ListPendingEvictionsResponse{}
+	mi := &file_preoomkiller_v1_admin_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPendingEvictionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPendingEvictionsResponse) ProtoMessage() {}
+
+func (x *ListPendingEvictionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_preoomkiller_v1_admin_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPendingEvictionsResponse.ProtoReflect.Descriptor instead.
+func (*ListPendingEvictionsResponse) Descriptor() ([]byte, []int) {
+	return file_preoomkiller_v1_admin_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListPendingEvictionsResponse) GetPendingEvictions() []*PendingEviction {
+	if x != nil {
+		return x.PendingEvictions
+	}
+	return nil
+}
+
+// PendingEviction is a pending scheduled eviction.
+type PendingEviction struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Cluster   string                 `protobuf:"bytes,1,opt,name=cluster,proto3" json:"cluster,omitempty"`
+	Namespace string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name      string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// RestartAt is the restart-at annotation; unset when unknown.
+	RestartAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=restart_at,json=restartAt,proto3" json:"restart_at,omitempty"`
+	// FireAt is when the eviction runs, after jitter and blackout deferral.
+	FireAt        *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=fire_at,json=fireAt,proto3" json:"fire_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PendingEviction) Reset() {
+	*x = PendingEviction{}
+	mi := &file_preoomkiller_v1_admin_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PendingEviction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PendingEviction) ProtoMessage() {}
+
+func (x *PendingEviction) ProtoReflect() protoreflect.Message {
+	mi := &file_preoomkiller_v1_admin_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PendingEviction.ProtoReflect.Descriptor instead.
+func (*PendingEviction) Descriptor() ([]byte, []int) {
+	return file_preoomkiller_v1_admin_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PendingEviction) GetCluster() string {
+	if x != nil {
+		return x.Cluster
+	}
+	return ""
+}
+
+func (x *PendingEviction) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *PendingEviction) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PendingEviction) GetRestartAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RestartAt
+	}
+	return nil
+}
+
+func (x *PendingEviction) GetFireAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FireAt
+	}
+	return nil
+}
+
+type ListEvictionsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Namespace restricts the evictions to one namespace when set.
+	Namespace     string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEvictionsRequest) Reset() {
+	*x = ListEvictionsRequest{}
+	mi := &file_preoomkiller_v1_admin_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEvictionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEvictionsRequest) ProtoMessage() {}
+
+func (x *ListEvictionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_preoomkiller_v1_admin_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEvictionsRequest.ProtoReflect.Descriptor instead.
+func (*ListEvictionsRequest) Descriptor() ([]byte, []int) {
+	return file_preoomkiller_v1_admin_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListEvictionsRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+type ListEvictionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Evictions     []*Eviction            `protobuf:"bytes,1,rep,name=evictions,proto3" json:"evictions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEvictionsResponse) Reset() {
+	*x = ListEvictionsResponse{}
+	mi := &file_preoomkiller_v1_admin_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEvictionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEvictionsResponse) ProtoMessage() {}
+
+func (x *ListEvictionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_preoomkiller_v1_admin_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEvictionsResponse.ProtoReflect.Descriptor instead.
+func (*ListEvictionsResponse) Descriptor() ([]byte, []int) {
+	return file_preoomkiller_v1_admin_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListEvictionsResponse) GetEvictions() []*Eviction {
+	if x != nil {
+		return x.Evictions
+	}
+	return nil
+}
+
+// Eviction is a recorded eviction.
+type Eviction struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Time      *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	Cluster   string                 `protobuf:"bytes,2,opt,name=cluster,proto3" json:"cluster,omitempty"`
+	Namespace string                 `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name      string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	Uid       string                 `protobuf:"bytes,5,opt,name=uid,proto3" json:"uid,omitempty"`
+	// OwnerKind and OwnerName identify the controlling owner; empty when the pod has none.
+	OwnerKind string `protobuf:"bytes,6,opt,name=owner_kind,json=ownerKind,proto3" json:"owner_kind,omitempty"`
+	OwnerName string `protobuf:"bytes,7,opt,name=owner_name,json=ownerName,proto3" json:"owner_name,omitempty"`
+	// Trigger is what evicted the pod: threshold, schedule, promql or manual.
+	Trigger string `protobuf:"bytes,8,opt,name=trigger,proto3" json:"trigger,omitempty"`
+	// Reason describes what triggered the eviction.
+	Reason string `protobuf:"bytes,9,opt,name=reason,proto3" json:"reason,omitempty"`
+	// MemoryUsageBytes and MemoryThresholdBytes are set for threshold evictions.
+	MemoryUsageBytes          int64  `protobuf:"varint,10,opt,name=memory_usage_bytes,json=memoryUsageBytes,proto3" json:"memory_usage_bytes,omitempty"`
+	MemoryThresholdBytes      int64  `protobuf:"varint,11,opt,name=memory_threshold_bytes,json=memoryThresholdBytes,proto3" json:"memory_threshold_bytes,omitempty"`
+	MemoryThresholdAnnotation string `protobuf:"bytes,12,opt,name=memory_threshold_annotation,json=memoryThresholdAnnotation,proto3" json:"memory_threshold_annotation,omitempty"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *Eviction) Reset() {
+	*x = Eviction{}
+	mi := &file_preoomkiller_v1_admin_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Eviction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Eviction) ProtoMessage() {}
+
+func (x *Eviction) ProtoReflect() protoreflect.Message {
+	mi := &file_preoomkiller_v1_admin_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Eviction.ProtoReflect.Descriptor instead.
+func (*Eviction) Descriptor() ([]byte, []int) {
+	return file_preoomkiller_v1_admin_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Eviction) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *Eviction) GetCluster() string {
+	if x != nil {
+		return x.Cluster
+	}
+	return ""
+}
+
+func (x *Eviction) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *Eviction) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Eviction) GetUid() string {
+	if x != nil {
+		return x.Uid
+	}
+	return ""
+}
+
+func (x *Eviction) GetOwnerKind() string {
+	if x != nil {
+		return x.OwnerKind
+	}
+	return ""
+}
+
+func (x *Eviction) GetOwnerName() string {
+	if x != nil {
+		return x.OwnerName
+	}
+	return ""
+}
+
+func (x *Eviction) GetTrigger() string {
+	if x != nil {
+		return x.Trigger
+	}
+	return ""
+}
+
+func (x *Eviction) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *Eviction) GetMemoryUsageBytes() int64 {
+	if x != nil {
+		return x.MemoryUsageBytes
+	}
+	return 0
+}
+
+func (x *Eviction) GetMemoryThresholdBytes() int64 {
+	if x != nil {
+		return x.MemoryThresholdBytes
+	}
+	return 0
+}
+
+func (x *Eviction) GetMemoryThresholdAnnotation() string {
+	if x != nil {
+		return x.MemoryThresholdAnnotation
+	}
+	return ""
+}
+
+type ReconcileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReconcileRequest) Reset() {
+	*x = ReconcileRequest{}
+	mi := &file_preoomkiller_v1_admin_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReconcileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconcileRequest) ProtoMessage() {}
+
+func (x *ReconcileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_preoomkiller_v1_admin_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconcileRequest.ProtoReflect.Descriptor instead.
+func (*ReconcileRequest) Descriptor() ([]byte, []int) {
+	return file_preoomkiller_v1_admin_proto_rawDescGZIP(), []int{12}
+}
+
+type ReconcileResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReconcileResponse) Reset() {
+	*x = ReconcileResponse{}
+	mi := &file_preoomkiller_v1_admin_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReconcileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconcileResponse) ProtoMessage() {}
+
+func (x *ReconcileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_preoomkiller_v1_admin_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconcileResponse.ProtoReflect.Descriptor instead.
+func (*ReconcileResponse) Descriptor() ([]byte, []int) {
+	return file_preoomkiller_v1_admin_proto_rawDescGZIP(), []int{13}
+}
+
+type EvictRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Cluster selects the controller; required when several clusters are watched.
+	Cluster       string `protobuf:"bytes,1,opt,name=cluster,proto3" json:"cluster,omitempty"`
+	Namespace     string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name          string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EvictRequest) Reset() {
+	*x = EvictRequest{}
+	mi := &file_preoomkiller_v1_admin_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EvictRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvictRequest) ProtoMessage() {}
+
+func (x *EvictRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_preoomkiller_v1_admin_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvictRequest.ProtoReflect.Descriptor instead.
+func (*EvictRequest) Descriptor() ([]byte, []int) {
+	return file_preoomkiller_v1_admin_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *EvictRequest) GetCluster() string {
+	if x != nil {
+		return x.Cluster
+	}
+	return ""
+}
+
+func (x *EvictRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *EvictRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type EvictResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Outcome is "evicted", or "skipped" or "failed" with a reason.
+	Outcome       string `protobuf:"bytes,1,opt,name=outcome,proto3" json:"outcome,omitempty"`
+	Reason        string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EvictResponse) Reset() {
+	*x = EvictResponse{}
+	mi := &file_preoomkiller_v1_admin_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EvictResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvictResponse) ProtoMessage() {}
+
+func (x *EvictResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_preoomkiller_v1_admin_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvictResponse.ProtoReflect.Descriptor instead.
+func (*EvictResponse) Descriptor() ([]byte, []int) {
+	return file_preoomkiller_v1_admin_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *EvictResponse) GetOutcome() string {
+	if x != nil {
+		return x.Outcome
+	}
+	return ""
+}
+
+func (x *EvictResponse) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type CancelPendingEvictionRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Cluster selects the controller; required when several clusters are watched.
+	Cluster       string `protobuf:"bytes,1,opt,name=cluster,proto3" json:"cluster,omitempty"`
+	Namespace     string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name          string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelPendingEvictionRequest) Reset() {
+	*x = CancelPendingEvictionRequest{}
+	mi := &file_preoomkiller_v1_admin_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelPendingEvictionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelPendingEvictionRequest) ProtoMessage() {}
+
+func (x *CancelPendingEvictionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_preoomkiller_v1_admin_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelPendingEvictionRequest.ProtoReflect.Descriptor instead.
+func (*CancelPendingEvictionRequest) Descriptor() ([]byte, []int) {
+	return file_preoomkiller_v1_admin_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CancelPendingEvictionRequest) GetCluster() string {
+	if x != nil {
+		return x.Cluster
+	}
+	return ""
+}
+
+func (x *CancelPendingEvictionRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *CancelPendingEvictionRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type CancelPendingEvictionResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// RestartAt is the next scheduled restart of the pod.
+	RestartAt     *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=restart_at,json=restartAt,proto3" json:"restart_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelPendingEvictionResponse) Reset() {
+	*x = CancelPendingEvictionResponse{}
+	mi := &file_preoomkiller_v1_admin_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelPendingEvictionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelPendingEvictionResponse) ProtoMessage() {}
+
+func (x *CancelPendingEvictionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_preoomkiller_v1_admin_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelPendingEvictionResponse.ProtoReflect.Descriptor instead.
+func (*CancelPendingEvictionResponse) Descriptor() ([]byte, []int) {
+	return file_preoomkiller_v1_admin_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CancelPendingEvictionResponse) GetRestartAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RestartAt
+	}
+	return nil
+}
+
+type ReloadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReloadRequest) Reset() {
+	*x = ReloadRequest{}
+	mi := &file_preoomkiller_v1_admin_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReloadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReloadRequest) ProtoMessage() {}
+
+func (x *ReloadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_preoomkiller_v1_admin_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReloadRequest.ProtoReflect.Descriptor instead.
+func (*ReloadRequest) Descriptor() ([]byte, []int) {
+	return file_preoomkiller_v1_admin_proto_rawDescGZIP(), []int{18}
+}
+
+type ReloadResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Reloaded lists the changed settings that were applied.
+	Reloaded []string `protobuf:"bytes,1,rep,name=reloaded,proto3" json:"reloaded,omitempty"`
+	// RestartRequired lists the changed settings that only apply after a restart.
+	RestartRequired []string `protobuf:"bytes,2,rep,name=restart_required,json=restartRequired,proto3" json:"restart_required,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ReloadResponse) Reset() {
+	*x = ReloadResponse{}
+	mi := &file_preoomkiller_v1_admin_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReloadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReloadResponse) ProtoMessage() {}
+
+func (x *ReloadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_preoomkiller_v1_admin_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReloadResponse.ProtoReflect.Descriptor instead.
+func (*ReloadResponse) Descriptor() ([]byte, []int) {
+	return file_preoomkiller_v1_admin_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ReloadResponse) GetReloaded() []string {
+	if x != nil {
+		return x.Reloaded
+	}
+	return nil
+}
+
+func (x *ReloadResponse) GetRestartRequired() []string {
+	if x != nil {
+		return x.RestartRequired
+	}
+	return nil
+}
+
+type ResumeWorkloadRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Cluster selects the controller; required when several clusters are watched.
+	Cluster   string `protobuf:"bytes,1,opt,name=cluster,proto3" json:"cluster,omitempty"`
+	Namespace string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// OwnerKind is the kind of the controlling owner of the workload's pods, e.g. "ReplicaSet"; case insensitive.
+	OwnerKind     string `protobuf:"bytes,3,opt,name=owner_kind,json=ownerKind,proto3" json:"owner_kind,omitempty"`
+	OwnerName     string `protobuf:"bytes,4,opt,name=owner_name,json=ownerName,proto3" json:"owner_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResumeWorkloadRequest) Reset() {
+	*x = ResumeWorkloadRequest{}
+	mi := &file_preoomkiller_v1_admin_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResumeWorkloadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeWorkloadRequest) ProtoMessage() {}
+
+func (x *ResumeWorkloadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_preoomkiller_v1_admin_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeWorkloadRequest.ProtoReflect.Descriptor instead.
+func (*ResumeWorkloadRequest) Descriptor() ([]byte, []int) {
+	return file_preoomkiller_v1_admin_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ResumeWorkloadRequest) GetCluster() string {
+	if x != nil {
+		return x.Cluster
+	}
+	return ""
+}
+
+func (x *ResumeWorkloadRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *ResumeWorkloadRequest) GetOwnerKind() string {
+	if x != nil {
+		return x.OwnerKind
+	}
+	return ""
+}
+
+func (x *ResumeWorkloadRequest) GetOwnerName() string {
+	if x != nil {
+		return x.OwnerName
+	}
+	return ""
+}
+
+type ResumeWorkloadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Resumed       *SuspendedWorkload     `protobuf:"bytes,1,opt,name=resumed,proto3" json:"resumed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResumeWorkloadResponse) Reset() {
+	*x = ResumeWorkloadResponse{}
+	mi := &file_preoomkiller_v1_admin_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResumeWorkloadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeWorkloadResponse) ProtoMessage() {}
+
+func (x *ResumeWorkloadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_preoomkiller_v1_admin_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeWorkloadResponse.ProtoReflect.Descriptor instead.
+func (*ResumeWorkloadResponse) Descriptor() ([]byte, []int) {
+	return file_preoomkiller_v1_admin_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ResumeWorkloadResponse) GetResumed() *SuspendedWorkload {
+	if x != nil {
+		return x.Resumed
+	}
+	return nil
+}
+
+var File_preoomkiller_v1_admin_proto protoreflect.FileDescriptor
+
+const file_preoomkiller_v1_admin_proto_rawDesc = "" +
+	"\n" +
+	"\x1bpreoomkiller/v1/admin.proto\x12\x0fpreoomkiller.v1\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x12\n" +
+	"\x10GetStatusRequest\"\xec\x01\n" +
+	"\x11GetStatusResponse\x12\x14\n" +
+	"\x05state\x18\x01 \x01(\tR\x05state\x129\n" +
+	"\n" +
+	"start_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x121\n" +
+	"\x06uptime\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\x06uptime\x12S\n" +
+	"\x13suspended_workloads\x18\x04 \x03(\v2\".preoomkiller.v1.SuspendedWorkloadR\x12suspendedWorkloads\"\xda\x01\n" +
+	"\x11SuspendedWorkload\x12\x18\n" +
+	"\acluster\x18\x01 \x01(\tR\acluster\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x1d\n" +
+	"\n" +
+	"owner_kind\x18\x03 \x01(\tR\townerKind\x12\x1d\n" +
+	"\n" +
+	"owner_name\x18\x04 \x01(\tR\townerName\x12\x10\n" +
+	"\x03pod\x18\x05 \x01(\tR\x03pod\x12=\n" +
+	"\fsuspended_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\vsuspendedAt\"\x11\n" +
+	"\x0fListPodsRequest\"<\n" +
+	"\x10ListPodsResponse\x12(\n" +
+	"\x04pods\x18\x01 \x03(\v2\x14.preoomkiller.v1.PodR\x04pods\"\xc0\x03\n" +
+	"\x03Pod\x12\x18\n" +
+	"\acluster\x18\x01 \x01(\tR\acluster\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12)\n" +
+	"\x10memory_threshold\x18\x04 \x01(\tR\x0fmemoryThreshold\x12)\n" +
+	"\x10restart_schedule\x18\x05 \x01(\tR\x0frestartSchedule\x129\n" +
+	"\n" +
+	"restart_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\trestartAt\x12,\n" +
+	"\x12memory_usage_bytes\x18\a \x01(\x03R\x10memoryUsageBytes\x124\n" +
+	"\x16memory_threshold_bytes\x18\b \x01(\x03R\x14memoryThresholdBytes\x129\n" +
+	"\n" +
+	"checked_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcheckedAt\x12=\n" +
+	"\fsuspended_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\vsuspendedAt\"\x1d\n" +
+	"\x1bListPendingEvictionsRequest\"m\n" +
+	"\x1cListPendingEvictionsResponse\x12M\n" +
+	"\x11pending_evictions\x18\x01 \x03(\v2 .preoomkiller.v1.PendingEvictionR\x10pendingEvictions\"\xcd\x01\n" +
+	"\x0fPendingEviction\x12\x18\n" +
+	"\acluster\x18\x01 \x01(\tR\acluster\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x129\n" +
+	"\n" +
+	"restart_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\trestartAt\x123\n" +
+	"\afire_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x06fireAt\"4\n" +
+	"\x14ListEvictionsRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\"P\n" +
+	"\x15ListEvictionsResponse\x127\n" +
+	"\tevictions\x18\x01 \x03(\v2\x19.preoomkiller.v1.EvictionR\tevictions\"\xac\x03\n" +
+	"\bEviction\x12.\n" +
+	"\x04time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12\x18\n" +
+	"\acluster\x18\x02 \x01(\tR\acluster\x12\x1c\n" +
+	"\tnamespace\x18\x03 \x01(\tR\tnamespace\x12\x12\n" +
+	"\x04name\x18\x04 \x01(\tR\x04name\x12\x10\n" +
+	"\x03uid\x18\x05 \x01(\tR\x03uid\x12\x1d\n" +
+	"\n" +
+	"owner_kind\x18\x06 \x01(\tR\townerKind\x12\x1d\n" +
+	"\n" +
+	"owner_name\x18\a \x01(\tR\townerName\x12\x18\n" +
+	"\atrigger\x18\b \x01(\tR\atrigger\x12\x16\n" +
+	"\x06reason\x18\t \x01(\tR\x06reason\x12,\n" +
+	"\x12memory_usage_bytes\x18\n" +
+	" \x01(\x03R\x10memoryUsageBytes\x124\n" +
+	"\x16memory_threshold_bytes\x18\v \x01(\x03R\x14memoryThresholdBytes\x12>\n" +
+	"\x1bmemory_threshold_annotation\x18\f \x01(\tR\x19memoryThresholdAnnotation\"\x12\n" +
+	"\x10ReconcileRequest\"\x13\n" +
+	"\x11ReconcileResponse\"Z\n" +
+	"\fEvictRequest\x12\x18\n" +
+	"\acluster\x18\x01 \x01(\tR\acluster\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\"A\n" +
+	"\rEvictResponse\x12\x18\n" +
+	"\aoutcome\x18\x01 \x01(\tR\aoutcome\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"j\n" +
+	"\x1cCancelPendingEvictionRequest\x12\x18\n" +
+	"\acluster\x18\x01 \x01(\tR\acluster\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\"Z\n" +
+	"\x1dCancelPendingEvictionResponse\x129\n" +
+	"\n" +
+	"restart_at\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\trestartAt\"\x0f\n" +
+	"\rReloadRequest\"W\n" +
+	"\x0eReloadResponse\x12\x1a\n" +
+	"\breloaded\x18\x01 \x03(\tR\breloaded\x12)\n" +
+	"\x10restart_required\x18\x02 \x03(\tR\x0frestartRequired\"\x8d\x01\n" +
+	"\x15ResumeWorkloadRequest\x12\x18\n" +
+	"\acluster\x18\x01 \x01(\tR\acluster\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x1d\n" +
+	"\n" +
+	"owner_kind\x18\x03 \x01(\tR\townerKind\x12\x1d\n" +
+	"\n" +
+	"owner_name\x18\x04 \x01(\tR\townerName\"V\n" +
+	"\x16ResumeWorkloadResponse\x12<\n" +
+	"\aresumed\x18\x01 \x01(\v2\".preoomkiller.v1.SuspendedWorkloadR\aresumed2\xca\x06\n" +
+	"\fAdminService\x12R\n" +
+	"\tGetStatus\x12!.preoomkiller.v1.GetStatusRequest\x1a\".preoomkiller.v1.GetStatusResponse\x12O\n" +
+	"\bListPods\x12 .preoomkiller.v1.ListPodsRequest\x1a!.preoomkiller.v1.ListPodsResponse\x12s\n" +
+	"\x14ListPendingEvictions\x12,.preoomkiller.v1.ListPendingEvictionsRequest\x1a-.preoomkiller.v1.ListPendingEvictionsResponse\x12^\n" +
+	"\rListEvictions\x12%.preoomkiller.v1.ListEvictionsRequest\x1a&.preoomkiller.v1.ListEvictionsResponse\x12R\n" +
+	"\tReconcile\x12!.preoomkiller.v1.ReconcileRequest\x1a\".preoomkiller.v1.ReconcileResponse\x12F\n" +
+	"\x05Evict\x12\x1d.preoomkiller.v1.EvictRequest\x1a\x1e.preoomkiller.v1.EvictResponse\x12v\n" +
+	"\x15CancelPendingEviction\x12-.preoomkiller.v1.CancelPendingEvictionRequest\x1a..preoomkiller.v1.CancelPendingEvictionResponse\x12I\n" +
+	"\x06Reload\x12\x1e.preoomkiller.v1.ReloadRequest\x1a\x1f.preoomkiller.v1.ReloadResponse\x12a\n" +
+	"\x0eResumeWorkload\x12&.preoomkiller.v1.ResumeWorkloadRequest\x1a'.preoomkiller.v1.ResumeWorkloadResponseBRZPgithub.com/skillcoder/preoomkiller-controller/api/preoomkiller/v1;preoomkillerv1b\x06proto3"
+
+var (
+	file_preoomkiller_v1_admin_proto_rawDescOnce sync.Once
+	file_preoomkiller_v1_admin_proto_rawDescData []byte
+)
+
+func file_preoomkiller_v1_admin_proto_rawDescGZIP() []byte {
+	file_preoomkiller_v1_admin_proto_rawDescOnce.Do(func() {
+		file_preoomkiller_v1_admin_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_preoomkiller_v1_admin_proto_rawDesc), len(file_preoomkiller_v1_admin_proto_rawDesc)))
+	})
+	return file_preoomkiller_v1_admin_proto_rawDescData
+}
+
+var file_preoomkiller_v1_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
+var file_preoomkiller_v1_admin_proto_goTypes = []any{
+	(*GetStatusRequest)(nil),              // 0: preoomkiller.v1.GetStatusRequest
+	(*GetStatusResponse)(nil),             // 1: preoomkiller.v1.GetStatusResponse
+	(*SuspendedWorkload)(nil),             // 2: preoomkiller.v1.SuspendedWorkload
+	(*ListPodsRequest)(nil),               // 3: preoomkiller.v1.ListPodsRequest
+	(*ListPodsResponse)(nil),              // 4: preoomkiller.v1.ListPodsResponse
+	(*Pod)(nil),                           // 5: preoomkiller.v1.Pod
+	(*ListPendingEvictionsRequest)(nil),   // 6: preoomkiller.v1.ListPendingEvictionsRequest
+	(*ListPendingEvictionsResponse)(nil),  // 7: preoomkiller.v1.ListPendingEvictionsResponse
+	(*PendingEviction)(nil),               // 8: preoomkiller.v1.PendingEviction
+	(*ListEvictionsRequest)(nil),          // 9: preoomkiller.v1.ListEvictionsRequest
+	(*ListEvictionsResponse)(nil),         // 10: preoomkiller.v1.ListEvictionsResponse
+	(*Eviction)(nil),                      // 11: preoomkiller.v1.Eviction
+	(*ReconcileRequest)(nil),              // 12: preoomkiller.v1.ReconcileRequest
+	(*ReconcileResponse)(nil),             // 13: preoomkiller.v1.ReconcileResponse
+	(*EvictRequest)(nil),                  // 14: preoomkiller.v1.EvictRequest
+	(*EvictResponse)(nil),                 // 15: preoomkiller.v1.EvictResponse
+	(*CancelPendingEvictionRequest)(nil),  // 16: preoomkiller.v1.CancelPendingEvictionRequest
+	(*CancelPendingEvictionResponse)(nil), // 17: preoomkiller.v1.CancelPendingEvictionResponse
+	(*ReloadRequest)(nil),                 // 18: preoomkiller.v1.ReloadRequest
+	(*ReloadResponse)(nil),                // 19: preoomkiller.v1.ReloadResponse
+	(*ResumeWorkloadRequest)(nil),         // 20: preoomkiller.v1.ResumeWorkloadRequest
+	(*ResumeWorkloadResponse)(nil),        // 21: preoomkiller.v1.ResumeWorkloadResponse
+	(*timestamppb.Timestamp)(nil),         // 22: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),           // 23: google.protobuf.Duration
+}
+var file_preoomkiller_v1_admin_proto_depIdxs = []int32{
+	22, // 0: preoomkiller.v1.GetStatusResponse.start_time:type_name -> google.protobuf.Timestamp
+	23, // 1: preoomkiller.v1.GetStatusResponse.uptime:type_name -> google.protobuf.Duration
+	2,  // 2: preoomkiller.v1.GetStatusResponse.suspended_workloads:type_name -> preoomkiller.v1.SuspendedWorkload
+	22, // 3: preoomkiller.v1.SuspendedWorkload.suspended_at:type_name -> google.protobuf.Timestamp
+	5,  // 4: preoomkiller.v1.ListPodsResponse.pods:type_name -> preoomkiller.v1.Pod
+	22, // 5: preoomkiller.v1.Pod.restart_at:type_name -> google.protobuf.Timestamp
+	22, // 6: preoomkiller.v1.Pod.checked_at:type_name -> google.protobuf.Timestamp
+	22, // 7: preoomkiller.v1.Pod.suspended_at:type_name -> google.protobuf.Timestamp
+	8,  // 8: preoomkiller.v1.ListPendingEvictionsResponse.pending_evictions:type_name -> preoomkiller.v1.PendingEviction
+	22, // 9: preoomkiller.v1.PendingEviction.restart_at:type_name -> google.protobuf.Timestamp
+	22, // 10: preoomkiller.v1.PendingEviction.fire_at:type_name -> google.protobuf.Timestamp
+	11, // 11: preoomkiller.v1.ListEvictionsResponse.evictions:type_name -> preoomkiller.v1.Eviction
+	22, // 12: preoomkiller.v1.Eviction.time:type_name -> google.protobuf.Timestamp
+	22, // 13: preoomkiller.v1.CancelPendingEvictionResponse.restart_at:type_name -> google.protobuf.Timestamp
+	2,  // 14: preoomkiller.v1.ResumeWorkloadResponse.resumed:type_name -> preoomkiller.v1.SuspendedWorkload
+	0,  // 15: preoomkiller.v1.AdminService.GetStatus:input_type -> preoomkiller.v1.GetStatusRequest
+	3,  // 16: preoomkiller.v1.AdminService.ListPods:input_type -> preoomkiller.v1.ListPodsRequest
+	6,  // 17: preoomkiller.v1.AdminService.ListPendingEvictions:input_type -> preoomkiller.v1.ListPendingEvictionsRequest
+	9,  // 18: preoomkiller.v1.AdminService.ListEvictions:input_type -> preoomkiller.v1.ListEvictionsRequest
+	12, // 19: preoomkiller.v1.AdminService.Reconcile:input_type -> preoomkiller.v1.ReconcileRequest
+	14, // 20: preoomkiller.v1.AdminService.Evict:input_type -> preoomkiller.v1.EvictRequest
+	16, // 21: preoomkiller.v1.AdminService.CancelPendingEviction:input_type -> preoomkiller.v1.CancelPendingEvictionRequest
+	18, // 22: preoomkiller.v1.AdminService.Reload:input_type -> preoomkiller.v1.ReloadRequest
+	20, // 23: preoomkiller.v1.AdminService.ResumeWorkload:input_type -> preoomkiller.v1.ResumeWorkloadRequest
+	1,  // 24: preoomkiller.v1.AdminService.GetStatus:output_type -> preoomkiller.v1.GetStatusResponse
+	4,  // 25: preoomkiller.v1.AdminService.ListPods:output_type -> preoomkiller.v1.ListPodsResponse
+	7,  // 26: preoomkiller.v1.AdminService.ListPendingEvictions:output_type -> preoomkiller.v1.ListPendingEvictionsResponse
+	10, // 27: preoomkiller.v1.AdminService.ListEvictions:output_type -> preoomkiller.v1.ListEvictionsResponse
+	13, // 28: preoomkiller.v1.AdminService.Reconcile:output_type -> preoomkiller.v1.ReconcileResponse
+	15, // 29: preoomkiller.v1.AdminService.Evict:output_type -> preoomkiller.v1.EvictResponse
+	17, // 30: preoomkiller.v1.AdminService.CancelPendingEviction:output_type -> preoomkiller.v1.CancelPendingEvictionResponse
+	19, // 31: preoomkiller.v1.AdminService.Reload:output_type -> preoomkiller.v1.ReloadResponse
+	21, // 32: preoomkiller.v1.AdminService.ResumeWorkload:output_type -> preoomkiller.v1.ResumeWorkloadResponse
+	24, // [24:33] is the sub-list for method output_type
+	15, // [15:24] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
+}
+
+func init() { file_preoomkiller_v1_admin_proto_init() }
+func file_preoomkiller_v1_admin_proto_init() {
+	if File_preoomkiller_v1_admin_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_preoomkiller_v1_admin_proto_rawDesc), len(file_preoomkiller_v1_admin_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   22,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_preoomkiller_v1_admin_proto_goTypes,
+		DependencyIndexes: file_preoomkiller_v1_admin_proto_depIdxs,
+		MessageInfos:      file_preoomkiller_v1_admin_proto_msgTypes,
+	}.Build()
+	File_preoomkiller_v1_admin_proto = out.File
+	file_preoomkiller_v1_admin_proto_goTypes = nil
+	file_preoomkiller_v1_admin_proto_depIdxs = nil
+}
@@ -2,26 +2,80 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"runtime/debug"
+	"syscall"
 	"time"
 
+	"github.com/spf13/pflag"
+
 	"github.com/skillcoder/preoomkiller-controller/internal/app"
 	"github.com/skillcoder/preoomkiller-controller/internal/config"
 	"github.com/skillcoder/preoomkiller-controller/internal/infra/appstate"
 	"github.com/skillcoder/preoomkiller-controller/internal/infra/logging"
+	"github.com/skillcoder/preoomkiller-controller/internal/infra/metrics"
 	"github.com/skillcoder/preoomkiller-controller/internal/infra/pinger"
 	"github.com/skillcoder/preoomkiller-controller/internal/infra/shutdown"
+	"github.com/skillcoder/preoomkiller-controller/internal/infra/tracing"
+)
+
+const (
+	// commandOnce is the subcommand equivalent of the --once flag.
+	commandOnce = "once"
+	// commandSimulate prints would-be evictions without modifying anything.
+	commandSimulate = "simulate"
+	// commandCheck validates the configuration and the cluster access, and exits.
+	commandCheck = "check"
+	// commandStatus prints the state of a running controller, queried through its admin endpoints.
+	commandStatus = "status"
+
+	// tracingFlushTimeout bounds exporting the buffered spans on exit.
+	tracingFlushTimeout = 5 * time.Second
+)
+
+// version and commit are set at build time with -ldflags "-X main.version=... -X main.commit=...".
+// When unset, they are taken from the build information embedded by the Go toolchain.
+var (
+	version string
+	commit  string
 )
 
 func main() {
 	appStart := time.Now()
 	// Start listening for signals immediately as first thing, before any other initialization
 	signals := shutdown.Notify()
+	// SIGHUP reloads the configuration; caught early too, as it terminates the process otherwise.
+	reloadSignals := make(chan os.Signal, 1)
+	signal.Notify(reloadSignals, syscall.SIGHUP)
+
+	flags, args, err := config.ParseFlags(filepath.Base(os.Args[0]), os.Args[1:], os.Stderr)
+	if errors.Is(err, pflag.ErrHelp) {
+		os.Exit(0)
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\nrun with --help for usage\n", err)
+		os.Exit(2)
+	}
+
 	ctx := context.Background()
 
-	err := run(ctx, signals, appStart)
+	var command string
+	if len(args) > 0 {
+		command = args[0]
+	}
+
+	if command == commandStatus {
+		err = runStatus(ctx, args[1:])
+	} else {
+		err = run(ctx, signals, reloadSignals, appStart, command, flags)
+	}
+
 	if err != nil {
 		slog.ErrorContext(ctx, "failed to run", "reason", err)
 		// Give the logger some time to flush
@@ -32,20 +86,128 @@ func main() {
 	slog.InfoContext(ctx, "bye")
 }
 
-func run(ctx context.Context, signals <-chan os.Signal, appStart time.Time) error {
-	cfg, err := config.Load()
+func run(
+	ctx context.Context,
+	signals,
+	reloadSignals <-chan os.Signal,
+	appStart time.Time,
+	command string,
+	flags config.Flags,
+) error {
+	switch command {
+	case "", commandOnce, commandSimulate:
+	case commandCheck:
+		return app.Check(ctx, os.Stdout, flags)
+	default:
+		return fmt.Errorf("unknown command %q", command)
+	}
+
+	cfg, err := config.Load(flags)
 	if err != nil {
 		return fmt.Errorf("load config: %w", err)
 	}
 
+	cfg.RunOnce = cfg.RunOnce || command == commandOnce
+
 	logger := logging.New(cfg.LogFormat, cfg.LogLevel)
-	pingers := pinger.New(logger, cfg.PingerInterval)
-	appState := appstate.New(logger, appStart, "/mnt/signal/terminating", signals, pingers)
+
+	buildVersion, buildCommit := buildInfo()
+	metrics.RecordBuildInfo(buildVersion, buildCommit)
+
+	if err := metrics.RegisterPingerLatencyHistogram(cfg.PingerLatencyBuckets); err != nil {
+		return err
+	}
+
+	logger.InfoContext(ctx, "starting preoomkiller-controller", "version", buildVersion, "commit", buildCommit)
+
+	pingers := pinger.New(logger, cfg.PingerInterval,
+		pinger.WithJitter(cfg.PingerJitter),
+		pinger.WithFlapDetection(cfg.PingerFlapTransitions, cfg.PingerFlapWindow, cfg.PingerFlapHoldReady),
+		pinger.WithThresholds(cfg.PingerFailureThreshold, cfg.PingerSuccessThreshold),
+		pinger.WithStartupGrace(cfg.PingerStartupGrace),
+	)
+	appState := appstate.New(logger, appStart, cfg.TerminationFile, signals, pingers,
+		appstate.WithDrainDelay(cfg.ShutdownDrainDelay),
+	)
+
+	if cfg.TracingEnabled {
+		tracer, err := tracing.New(ctx, buildVersion)
+		if err != nil {
+			return fmt.Errorf("set up tracing: %w", err)
+		}
+
+		// Shut down with the sinks, after the controllers ended their spans.
+		if err := appState.RegisterShutdowner(tracer); err != nil {
+			return fmt.Errorf("register tracing shutdowner: %w", err)
+		}
+
+		// Single runs do not shut down gracefully; flush their spans before exiting.
+		defer flushTraces(context.WithoutCancel(ctx), logger, tracer)
+	}
 
 	application, err := app.New(logger, cfg, appState)
 	if err != nil {
 		return fmt.Errorf("new application: %w", err)
 	}
 
+	if command == commandSimulate {
+		return application.Simulate(ctx, os.Stdout)
+	}
+
+	if cfg.RunOnce {
+		return application.RunOnce(ctx)
+	}
+
+	go application.HandleReloadSignals(ctx, reloadSignals)
+
 	return application.Run(ctx)
 }
+
+// runStatus prints the state of the controller whose HTTP server is at the URL of args, by default
+// DefaultStatusURL.
+func runStatus(ctx context.Context, args []string) error {
+	baseURL := app.DefaultStatusURL
+	if len(args) > 0 {
+		baseURL = args[0]
+	}
+
+	return app.Status(ctx, os.Stdout, baseURL)
+}
+
+// flushTraces exports the buffered spans, bounded by tracingFlushTimeout.
+func flushTraces(ctx context.Context, logger *slog.Logger, tracer *tracing.Provider) {
+	ctx, cancel := context.WithTimeout(ctx, tracingFlushTimeout)
+	defer cancel()
+
+	if err := tracer.Shutdown(ctx); err != nil {
+		logger.WarnContext(ctx, "flush traces failed", "reason", err)
+	}
+}
+
+// buildInfo returns the version and commit of the binary, "unknown" when neither the linker flags nor
+// the embedded build information provide them.
+func buildInfo() (string, string) {
+	v, c := version, commit
+
+	if info, ok := debug.ReadBuildInfo(); ok {
+		if v == "" && info.Main.Version != "" {
+			v = info.Main.Version
+		}
+
+		for _, setting := range info.Settings {
+			if c == "" && setting.Key == "vcs.revision" {
+				c = setting.Value
+			}
+		}
+	}
+
+	if v == "" {
+		v = "unknown"
+	}
+
+	if c == "" {
+		c = "unknown"
+	}
+
+	return v, c
+}
@@ -0,0 +1,104 @@
+package alertmanager
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/skillcoder/preoomkiller-controller/internal/logic/controller"
+)
+
+// Alert names.
+const (
+	alertRepeatedlyEvicted = "PreoomkillerWorkloadRepeatedlyEvicted"
+	alertEvictionFailing   = "PreoomkillerEvictionFailing"
+)
+
+// alert is an alert of the Alertmanager v2 API.
+type alert struct {
+	Labels      map[string]string `json:"labels"`
+	Annotations map[string]string `json:"annotations"`
+	StartsAt    time.Time         `json:"startsAt"`
+	EndsAt      time.Time         `json:"endsAt"`
+}
+
+// workload identifies the owner of an evicted pod; bare pods are their own workload. The notifier is
+// shared by all clusters, so the cluster is part of the identity.
+type workload struct {
+	cluster   string
+	namespace string
+	kind      string
+	name      string
+}
+
+func workloadOf(decision controller.EvictionDecision) workload {
+	if decision.Owner == nil {
+		return workload{cluster: decision.Cluster, namespace: decision.Namespace, kind: "Pod", name: decision.Pod}
+	}
+
+	return workload{
+		cluster:   decision.Cluster,
+		namespace: decision.Namespace,
+		kind:      decision.Owner.Kind,
+		name:      decision.Owner.Name,
+	}
+}
+
+// repeatedlyEvictedAlert reports that the workload was evicted evictions times within window.
+func repeatedlyEvictedAlert(
+	w workload,
+	evictions int,
+	window time.Duration,
+	startsAt,
+	endsAt time.Time,
+) alert {
+	labels := map[string]string{
+		"alertname":  alertRepeatedlyEvicted,
+		"severity":   "warning",
+		"namespace":  w.namespace,
+		"owner_kind": w.kind,
+		"owner_name": w.name,
+	}
+	if w.cluster != "" {
+		labels["cluster"] = w.cluster
+	}
+
+	return alert{
+		Labels: labels,
+		Annotations: map[string]string{
+			"summary": fmt.Sprintf("%s %s/%s is repeatedly evicted by preoomkiller-controller", w.kind, w.namespace, w.name),
+			"description": fmt.Sprintf("%d pods of %s %s/%s were evicted within %s; "+
+				"its memory limits or thresholds are likely too low.", evictions, w.kind, w.namespace, w.name, window),
+		},
+		StartsAt: startsAt,
+		EndsAt:   endsAt,
+	}
+}
+
+// evictionFailingAlert reports that failures consecutive evictions of the pod failed.
+func evictionFailingAlert(
+	decision controller.EvictionDecision,
+	failures int,
+	startsAt,
+	endsAt time.Time,
+) alert {
+	labels := map[string]string{
+		"alertname": alertEvictionFailing,
+		"severity":  "warning",
+		"namespace": decision.Namespace,
+		"pod":       decision.Pod,
+	}
+	if decision.Cluster != "" {
+		labels["cluster"] = decision.Cluster
+	}
+
+	return alert{
+		Labels: labels,
+		Annotations: map[string]string{
+			"summary": fmt.Sprintf("Eviction of pod %s/%s keeps failing", decision.Namespace, decision.Pod),
+			"description": fmt.Sprintf("The last %d evictions of pod %s/%s failed (%s).",
+				failures, decision.Namespace, decision.Pod, decision.Reason),
+		},
+		StartsAt: startsAt,
+		EndsAt:   endsAt,
+	}
+}
@@ -0,0 +1,5 @@
+package alertmanager
+
+import "errors"
+
+var errUnexpectedStatus = errors.New("unexpected alertmanager response status")
@@ -0,0 +1,204 @@
+// Package alertmanager raises Alertmanager alerts for workloads that are repeatedly evicted and
+// pods whose eviction fails persistently, so that existing alert routing and silencing apply.
+package alertmanager
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/skillcoder/preoomkiller-controller/internal/logic/controller"
+)
+
+// alertsPath is the alerts endpoint of the Alertmanager v2 API.
+const alertsPath = "/api/v2/alerts"
+
+// failureState counts the consecutive failed evictions of a pod.
+type failureState struct {
+	count    int
+	since    time.Time
+	lastSeen time.Time
+}
+
+// notifier turns eviction decisions into alerts. An alert ends window after it was last sent, so it
+// resolves on its own once the condition stops recurring.
+type notifier struct {
+	client            *http.Client
+	alertsURL         string
+	evictionThreshold int
+	failureThreshold  int
+	window            time.Duration
+	now               func() time.Time
+
+	mu sync.Mutex
+	// evictions holds the eviction times of each workload within the window.
+	evictions map[workload][]time.Time
+	// failures holds the consecutive eviction failures of each pod by "cluster/namespace/name".
+	failures map[string]*failureState
+}
+
+// Option configures optional notifier behavior.
+type Option func(*notifier)
+
+// WithTransport sends the requests through rt instead of http.DefaultTransport.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(n *notifier) {
+		n.client.Transport = rt
+	}
+}
+
+// New returns a notifier sending alerts to the Alertmanager at url. A workload alert fires once
+// evictionThreshold of its pods are evicted within window; a pod alert fires once failureThreshold
+// consecutive evictions of the pod failed. A request is aborted after timeout.
+func New(
+	url string,
+	evictionThreshold,
+	failureThreshold int,
+	window,
+	timeout time.Duration,
+	opts ...Option,
+) controller.Notifier {
+	n := &notifier{
+		client:            &http.Client{Timeout: timeout},
+		alertsURL:         strings.TrimSuffix(url, "/") + alertsPath,
+		evictionThreshold: evictionThreshold,
+		failureThreshold:  failureThreshold,
+		window:            window,
+		now:               time.Now,
+		evictions:         make(map[workload][]time.Time),
+		failures:          make(map[string]*failureState),
+	}
+
+	for _, opt := range opts {
+		opt(n)
+	}
+
+	return n
+}
+
+func (n *notifier) Name() string {
+	return "alertmanager"
+}
+
+func (n *notifier) NotifyCommand(ctx context.Context, decision controller.EvictionDecision) error {
+	alerts := n.alerts(decision)
+	if len(alerts) == 0 {
+		return nil
+	}
+
+	return n.post(ctx, alerts)
+}
+
+// NotifyMissedOOMCommand ignores missed OOMs; they are alerted on from the preoomkiller_missed_oom_total metric.
+func (n *notifier) NotifyMissedOOMCommand(_ context.Context, _ controller.MissedOOM) error {
+	return nil
+}
+
+// alerts updates the eviction history with the decision and returns the alerts to send.
+func (n *notifier) alerts(decision controller.EvictionDecision) []alert {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	now := n.now()
+	n.prune(now)
+
+	podKey := decision.Cluster + "/" + decision.Namespace + "/" + decision.Pod
+
+	switch decision.Outcome {
+	case controller.OutcomeEvicted:
+		var alerts []alert
+
+		if failure, ok := n.failures[podKey]; ok {
+			delete(n.failures, podKey)
+
+			if failure.count >= n.failureThreshold {
+				// Resolve the alert of the pod, now evicted.
+				alerts = append(alerts, evictionFailingAlert(decision, failure.count, failure.since, now))
+			}
+		}
+
+		w := workloadOf(decision)
+		n.evictions[w] = append(n.evictions[w], now)
+
+		if evicted := n.evictions[w]; len(evicted) >= n.evictionThreshold {
+			alerts = append(alerts, repeatedlyEvictedAlert(w, len(evicted), n.window, evicted[0], now.Add(n.window)))
+		}
+
+		return alerts
+	case controller.OutcomeFailed:
+		failure, ok := n.failures[podKey]
+		if !ok {
+			failure = &failureState{since: now}
+			n.failures[podKey] = failure
+		}
+
+		failure.count++
+		failure.lastSeen = now
+
+		if failure.count >= n.failureThreshold {
+			return []alert{evictionFailingAlert(decision, failure.count, failure.since, now.Add(n.window))}
+		}
+
+		return nil
+	case controller.OutcomeSkipped, controller.OutcomeDeferred:
+		return nil
+	default:
+		return nil
+	}
+}
+
+// prune forgets evictions older than the window and failures not seen within it.
+func (n *notifier) prune(now time.Time) {
+	cutoff := now.Add(-n.window)
+
+	for w, evicted := range n.evictions {
+		i := 0
+		for i < len(evicted) && !evicted[i].After(cutoff) {
+			i++
+		}
+
+		if i == len(evicted) {
+			delete(n.evictions, w)
+		} else {
+			n.evictions[w] = evicted[i:]
+		}
+	}
+
+	for key, failure := range n.failures {
+		if !failure.lastSeen.After(cutoff) {
+			delete(n.failures, key)
+		}
+	}
+}
+
+// post sends the alerts to the Alertmanager.
+func (n *notifier) post(ctx context.Context, alerts []alert) error {
+	body, err := json.Marshal(alerts)
+	if err != nil {
+		return fmt.Errorf("marshal alerts: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.alertsURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create alertmanager request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("send alertmanager request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: %s", errUnexpectedStatus, resp.Status)
+	}
+
+	return nil
+}
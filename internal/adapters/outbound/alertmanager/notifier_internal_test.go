@@ -0,0 +1,109 @@
+package alertmanager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skillcoder/preoomkiller-controller/internal/logic/controller"
+)
+
+func Test_notifier_alerts(t *testing.T) {
+	t.Parallel()
+
+	owner := &controller.Owner{Kind: "ReplicaSet", Name: "api-7d9c"}
+	decision := func(outcome controller.Outcome, pod string) controller.EvictionDecision {
+		return controller.EvictionDecision{
+			Outcome:   outcome,
+			Namespace: "default",
+			Pod:       pod,
+			Owner:     owner,
+			Reason:    "api_error",
+		}
+	}
+
+	t.Run("workload alert fires once evictions reach the threshold within the window", func(t *testing.T) {
+		t.Parallel()
+
+		now := time.Date(2026, 1, 12, 3, 0, 0, 0, time.UTC)
+		n, ok := New("http://alertmanager:9093/", 3, 3, time.Hour, time.Second).(*notifier)
+		require.True(t, ok)
+		n.now = func() time.Time { return now }
+
+		require.Empty(t, n.alerts(decision(controller.OutcomeEvicted, "api-1")))
+
+		// Evictions older than the window are forgotten.
+		now = now.Add(2 * time.Hour)
+		require.Empty(t, n.alerts(decision(controller.OutcomeEvicted, "api-2")))
+
+		now = now.Add(10 * time.Minute)
+		require.Empty(t, n.alerts(decision(controller.OutcomeEvicted, "api-3")))
+		require.Empty(t, n.alerts(decision(controller.OutcomeSkipped, "api-4")))
+
+		now = now.Add(10 * time.Minute)
+		alerts := n.alerts(decision(controller.OutcomeEvicted, "api-5"))
+		require.Len(t, alerts, 1)
+		require.Equal(t, alertRepeatedlyEvicted, alerts[0].Labels["alertname"])
+		require.Equal(t, "ReplicaSet", alerts[0].Labels["owner_kind"])
+		require.Equal(t, "api-7d9c", alerts[0].Labels["owner_name"])
+		require.Equal(t, now.Add(-20*time.Minute), alerts[0].StartsAt)
+		require.Equal(t, now.Add(time.Hour), alerts[0].EndsAt)
+		require.Equal(t, "http://alertmanager:9093/api/v2/alerts", n.alertsURL)
+	})
+
+	t.Run("pod alert fires on consecutive failures and resolves on eviction", func(t *testing.T) {
+		t.Parallel()
+
+		now := time.Date(2026, 1, 12, 3, 0, 0, 0, time.UTC)
+		n, ok := New("http://alertmanager:9093", 3, 2, time.Hour, time.Second).(*notifier)
+		require.True(t, ok)
+		n.now = func() time.Time { return now }
+
+		require.Empty(t, n.alerts(decision(controller.OutcomeFailed, "api-1")))
+
+		now = now.Add(5 * time.Minute)
+		alerts := n.alerts(decision(controller.OutcomeFailed, "api-1"))
+		require.Len(t, alerts, 1)
+		require.Equal(t, alertEvictionFailing, alerts[0].Labels["alertname"])
+		require.Equal(t, "api-1", alerts[0].Labels["pod"])
+		require.Equal(t, now.Add(-5*time.Minute), alerts[0].StartsAt)
+		require.Equal(t, now.Add(time.Hour), alerts[0].EndsAt)
+
+		now = now.Add(5 * time.Minute)
+		alerts = n.alerts(decision(controller.OutcomeEvicted, "api-1"))
+		require.Len(t, alerts, 1)
+		require.Equal(t, alertEvictionFailing, alerts[0].Labels["alertname"])
+		require.Equal(t, now, alerts[0].EndsAt)
+	})
+	t.Run("same workload and pod names in two clusters are tracked apart", func(t *testing.T) {
+		t.Parallel()
+
+		now := time.Date(2026, 1, 12, 3, 0, 0, 0, time.UTC)
+		n, ok := New("http://alertmanager:9093", 2, 2, time.Hour, time.Second).(*notifier)
+		require.True(t, ok)
+		n.now = func() time.Time { return now }
+
+		inCluster := func(cluster string, outcome controller.Outcome, pod string) controller.EvictionDecision {
+			d := decision(outcome, pod)
+			d.Cluster = cluster
+
+			return d
+		}
+
+		require.Empty(t, n.alerts(inCluster("eu", controller.OutcomeEvicted, "api-1")))
+		require.Empty(t, n.alerts(inCluster("us", controller.OutcomeEvicted, "api-2")))
+		require.Empty(t, n.alerts(inCluster("eu", controller.OutcomeFailed, "api-3")))
+		require.Empty(t, n.alerts(inCluster("us", controller.OutcomeFailed, "api-3")))
+
+		alerts := n.alerts(inCluster("us", controller.OutcomeEvicted, "api-4"))
+		require.Len(t, alerts, 1)
+		require.Equal(t, alertRepeatedlyEvicted, alerts[0].Labels["alertname"])
+		require.Equal(t, "us", alerts[0].Labels["cluster"])
+
+		alerts = n.alerts(inCluster("eu", controller.OutcomeFailed, "api-3"))
+		require.Len(t, alerts, 1)
+		require.Equal(t, alertEvictionFailing, alerts[0].Labels["alertname"])
+		require.Equal(t, "eu", alerts[0].Labels["cluster"])
+	})
+}
@@ -0,0 +1,36 @@
+package evictionhistory
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// evictionsResponse is the body of the /-/evictions endpoint.
+type evictionsResponse struct {
+	Evictions []Eviction `json:"evictions"`
+}
+
+// HandleEvictions returns an http.HandlerFunc for the /-/evictions endpoint, listing the recorded
+// evictions newest first, optionally filtered with the "namespace" query parameter.
+func HandleEvictions(logger *slog.Logger, history *History) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		logger := logger.With("traceID", middleware.GetReqID(ctx))
+
+		response := evictionsResponse{
+			Evictions: history.List(r.URL.Query().Get("namespace")),
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			logger.ErrorContext(ctx, "failed to encode evictions response",
+				"error", err,
+			)
+		}
+	}
+}
@@ -0,0 +1,237 @@
+// Package evictionhistory keeps a bounded history of recent evictions, optionally persisted to a
+// file, and serves it over HTTP.
+package evictionhistory
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/skillcoder/preoomkiller-controller/internal/logic/controller"
+)
+
+// Eviction is a recorded eviction.
+type Eviction struct {
+	Time      time.Time `json:"time"`
+	Cluster   string    `json:"cluster,omitempty"`
+	Namespace string    `json:"namespace"`
+	Pod       string    `json:"pod"`
+	PodUID    string    `json:"podUid"`
+	Owner     *Owner    `json:"owner,omitempty"`
+	Trigger   string    `json:"trigger"`
+	// Reason describes what triggered the eviction.
+	Reason                    string `json:"reason"`
+	MemoryUsageBytes          *int64 `json:"memoryUsageBytes,omitempty"`
+	MemoryThresholdBytes      *int64 `json:"memoryThresholdBytes,omitempty"`
+	MemoryThresholdAnnotation string `json:"memoryThresholdAnnotation,omitempty"`
+}
+
+// Owner identifies the controlling owner of the evicted pod.
+type Owner struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+}
+
+// History records the last evictions. It implements controller.Notifier to learn about them.
+type History struct {
+	mu   sync.Mutex
+	size int
+	// evictions holds at most size evictions, oldest first.
+	evictions []Eviction
+	// path is the file the history is persisted to as JSON lines; empty keeps it in memory only.
+	path string
+	// persisted is the number of lines in the file, which is compacted once it doubles size.
+	persisted int
+}
+
+// New returns a history of the last size evictions. When path is set, the history is loaded from
+// and appended to that file, so that it survives restarts.
+func New(size int, path string) (*History, error) {
+	h := &History{size: size, path: path}
+
+	if path == "" {
+		return h, nil
+	}
+
+	if err := h.load(); err != nil {
+		return nil, fmt.Errorf("load eviction history: %w", err)
+	}
+
+	if err := h.compact(); err != nil {
+		return nil, fmt.Errorf("compact eviction history: %w", err)
+	}
+
+	return h, nil
+}
+
+func (h *History) Name() string {
+	return "eviction-history"
+}
+
+// NotifyCommand records evictions; other decisions are ignored.
+func (h *History) NotifyCommand(_ context.Context, decision controller.EvictionDecision) error {
+	if decision.Outcome != controller.OutcomeEvicted {
+		return nil
+	}
+
+	eviction := newEviction(decision)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.evictions = append(h.evictions, eviction)
+	if len(h.evictions) > h.size {
+		h.evictions = h.evictions[len(h.evictions)-h.size:]
+	}
+
+	if h.path == "" {
+		return nil
+	}
+
+	if h.persisted >= 2*h.size {
+		return h.compact()
+	}
+
+	return h.appendLine(eviction)
+}
+
+func (h *History) NotifyMissedOOMCommand(context.Context, controller.MissedOOM) error {
+	return nil
+}
+
+// List returns the recorded evictions of the namespace (all namespaces when empty), newest first.
+func (h *History) List(namespace string) []Eviction {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	evictions := make([]Eviction, 0, len(h.evictions))
+
+	for i := len(h.evictions) - 1; i >= 0; i-- {
+		if namespace == "" || h.evictions[i].Namespace == namespace {
+			evictions = append(evictions, h.evictions[i])
+		}
+	}
+
+	return evictions
+}
+
+// load reads the last size evictions from the file; a missing file is an empty history.
+func (h *History) load() error {
+	file, err := os.Open(h.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("open: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var eviction Eviction
+		if err := json.Unmarshal(scanner.Bytes(), &eviction); err != nil {
+			return fmt.Errorf("decode line %d: %w", len(h.evictions)+1, err)
+		}
+
+		h.evictions = append(h.evictions, eviction)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("read: %w", err)
+	}
+
+	if len(h.evictions) > h.size {
+		h.evictions = h.evictions[len(h.evictions)-h.size:]
+	}
+
+	return nil
+}
+
+// compact rewrites the file with the evictions in memory.
+func (h *History) compact() error {
+	tmp, err := os.CreateTemp(filepath.Dir(h.path), filepath.Base(h.path)+".*")
+	if err != nil {
+		return fmt.Errorf("create temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	encoder := json.NewEncoder(tmp)
+	for i := range h.evictions {
+		if err := encoder.Encode(h.evictions[i]); err != nil {
+			tmp.Close()
+
+			return fmt.Errorf("write eviction: %w", err)
+		}
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close temporary file: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), h.path); err != nil {
+		return fmt.Errorf("replace file: %w", err)
+	}
+
+	h.persisted = len(h.evictions)
+
+	return nil
+}
+
+// appendLine appends the eviction to the file.
+func (h *History) appendLine(eviction Eviction) error {
+	//nolint:gosec // the path is operator configuration.
+	file, err := os.OpenFile(h.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return fmt.Errorf("open eviction history: %w", err)
+	}
+
+	if err := json.NewEncoder(file).Encode(eviction); err != nil {
+		file.Close()
+
+		return fmt.Errorf("write eviction history: %w", err)
+	}
+
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("close eviction history: %w", err)
+	}
+
+	h.persisted++
+
+	return nil
+}
+
+func newEviction(decision controller.EvictionDecision) Eviction {
+	eviction := Eviction{
+		Time:                      decision.Time,
+		Cluster:                   decision.Cluster,
+		Namespace:                 decision.Namespace,
+		Pod:                       decision.Pod,
+		PodUID:                    decision.PodUID,
+		Trigger:                   string(decision.Trigger),
+		Reason:                    decision.Detail,
+		MemoryThresholdAnnotation: decision.MemoryThresholdAnnotation,
+	}
+
+	if decision.Owner != nil {
+		eviction.Owner = &Owner{Kind: decision.Owner.Kind, Name: decision.Owner.Name}
+	}
+
+	if decision.MemoryUsage != nil {
+		usage := decision.MemoryUsage.Value()
+		eviction.MemoryUsageBytes = &usage
+	}
+
+	if decision.MemoryThreshold != nil {
+		threshold := decision.MemoryThreshold.Value()
+		eviction.MemoryThresholdBytes = &threshold
+	}
+
+	return eviction
+}
@@ -0,0 +1,145 @@
+package evictionhistory_test
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/skillcoder/preoomkiller-controller/internal/adapters/outbound/evictionhistory"
+	"github.com/skillcoder/preoomkiller-controller/internal/logic/controller"
+)
+
+var testTime = time.Date(2026, 1, 12, 3, 0, 0, 0, time.UTC)
+
+func evicted(namespace, pod string, minute int) controller.EvictionDecision {
+	return controller.EvictionDecision{
+		Time:            testTime.Add(time.Duration(minute) * time.Minute),
+		Outcome:         controller.OutcomeEvicted,
+		Trigger:         controller.TriggerThreshold,
+		Namespace:       namespace,
+		Pod:             pod,
+		PodUID:          pod + "-uid",
+		Owner:           &controller.Owner{Kind: "ReplicaSet", Name: pod + "-rs"},
+		Detail:          "memory usage 600Mi exceeded threshold 512Mi",
+		MemoryUsage:     resource.NewQuantity(600<<20, resource.BinarySI),
+		MemoryThreshold: resource.NewQuantity(512<<20, resource.BinarySI),
+	}
+}
+
+func podNames(evictions []evictionhistory.Eviction) []string {
+	names := make([]string, 0, len(evictions))
+	for i := range evictions {
+		names = append(names, evictions[i].Pod)
+	}
+
+	return names
+}
+
+func TestHistory_NotifyCommand(t *testing.T) {
+	t.Parallel()
+
+	t.Run("only evictions are recorded, newest first", func(t *testing.T) {
+		t.Parallel()
+
+		history, err := evictionhistory.New(10, "")
+		require.NoError(t, err)
+
+		skipped := evicted("default", "skipped", 1)
+		skipped.Outcome = controller.OutcomeSkipped
+
+		require.NoError(t, history.NotifyCommand(t.Context(), evicted("default", "api-1", 0)))
+		require.NoError(t, history.NotifyCommand(t.Context(), skipped))
+		require.NoError(t, history.NotifyCommand(t.Context(), evicted("payments", "api-2", 2)))
+
+		require.Equal(t, []string{"api-2", "api-1"}, podNames(history.List("")))
+		require.Equal(t, []string{"api-1"}, podNames(history.List("default")))
+
+		eviction := history.List("default")[0]
+		require.Equal(t, "threshold", eviction.Trigger)
+		require.Equal(t, "memory usage 600Mi exceeded threshold 512Mi", eviction.Reason)
+		require.Equal(t, int64(600<<20), *eviction.MemoryUsageBytes)
+		require.Equal(t, int64(512<<20), *eviction.MemoryThresholdBytes)
+		require.Equal(t, &evictionhistory.Owner{Kind: "ReplicaSet", Name: "api-1-rs"}, eviction.Owner)
+	})
+
+	t.Run("history is bounded", func(t *testing.T) {
+		t.Parallel()
+
+		history, err := evictionhistory.New(2, "")
+		require.NoError(t, err)
+
+		for i, pod := range []string{"api-1", "api-2", "api-3"} {
+			require.NoError(t, history.NotifyCommand(t.Context(), evicted("default", pod, i)))
+		}
+
+		require.Equal(t, []string{"api-3", "api-2"}, podNames(history.List("")))
+	})
+
+	t.Run("persisted history survives a restart", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "evictions.jsonl")
+
+		history, err := evictionhistory.New(2, path)
+		require.NoError(t, err)
+
+		for i, pod := range []string{"api-1", "api-2", "api-3", "api-4", "api-5"} {
+			require.NoError(t, history.NotifyCommand(t.Context(), evicted("default", pod, i)))
+		}
+
+		restarted, err := evictionhistory.New(2, path)
+		require.NoError(t, err)
+		require.Equal(t, []string{"api-5", "api-4"}, podNames(restarted.List("")))
+
+		// Loading compacts the file to the kept evictions.
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.Len(t, strings.Split(strings.TrimSpace(string(data)), "\n"), 2)
+	})
+
+	t.Run("corrupted history file fails", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "evictions.jsonl")
+		require.NoError(t, os.WriteFile(path, []byte("not json\n"), 0o600))
+
+		_, err := evictionhistory.New(2, path)
+		require.Error(t, err)
+	})
+}
+
+func TestHandleEvictions(t *testing.T) {
+	t.Parallel()
+
+	history, err := evictionhistory.New(10, "")
+	require.NoError(t, err)
+	require.NoError(t, history.NotifyCommand(t.Context(), evicted("default", "api-1", 0)))
+	require.NoError(t, history.NotifyCommand(t.Context(), evicted("payments", "api-2", 1)))
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/-/evictions?namespace=payments", http.NoBody)
+
+	evictionhistory.HandleEvictions(slog.Default(), history).ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var body struct {
+		Evictions []map[string]any `json:"evictions"`
+	}
+
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Len(t, body.Evictions, 1)
+	require.Equal(t, "api-2", body.Evictions[0]["pod"])
+	require.Equal(t, "2026-01-12T03:01:00Z", body.Evictions[0]["time"])
+	require.InDelta(t, float64(600<<20), body.Evictions[0]["memoryUsageBytes"], 0)
+}
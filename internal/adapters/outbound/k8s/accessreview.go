@@ -0,0 +1,99 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	authnv1 "k8s.io/api/authentication/v1"
+	authzv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// AccessReviewer authenticates bearer tokens with a TokenReview and authorizes their user with a
+// SubjectAccessReview of the requested non-resource path or pod eviction, like the API server itself
+// does.
+type AccessReviewer struct {
+	clientset kubernetes.Interface
+	timeout   time.Duration
+}
+
+// NewAccessReviewer returns an AccessReviewer; each review request is bounded by timeout when set.
+func NewAccessReviewer(clientset kubernetes.Interface, timeout time.Duration) *AccessReviewer {
+	return &AccessReviewer{clientset: clientset, timeout: timeout}
+}
+
+// ReviewAccess returns the user name of token when that user may send verb requests (e.g. "post")
+// to path. It fails with an UnauthenticatedError for an invalid token and a ForbiddenError for a
+// user without permission.
+func (r *AccessReviewer) ReviewAccess(ctx context.Context, token, verb, path string) (string, error) {
+	return r.review(ctx, token, authzv1.SubjectAccessReviewSpec{
+		NonResourceAttributes: &authzv1.NonResourceAttributes{
+			Path: path,
+			Verb: verb,
+		},
+	})
+}
+
+// ReviewEvictionAccess returns the user name of token when that user may evict the pod, i.e. create
+// its pods/eviction subresource, like the API server requires of kubectl drain. It fails like
+// ReviewAccess.
+func (r *AccessReviewer) ReviewEvictionAccess(ctx context.Context, token, namespace, name string) (string, error) {
+	return r.review(ctx, token, authzv1.SubjectAccessReviewSpec{
+		ResourceAttributes: &authzv1.ResourceAttributes{
+			Namespace:   namespace,
+			Verb:        "create",
+			Resource:    "pods",
+			Subresource: "eviction",
+			Name:        name,
+		},
+	})
+}
+
+// review authenticates token with a TokenReview and authorizes its user with a SubjectAccessReview of
+// the attributes of spec.
+func (r *AccessReviewer) review(ctx context.Context, token string, spec authzv1.SubjectAccessReviewSpec) (string, error) {
+	if r.timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+	}
+
+	review, err := r.clientset.AuthenticationV1().TokenReviews().Create(ctx, &authnv1.TokenReview{
+		Spec: authnv1.TokenReviewSpec{Token: token},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return "", fmt.Errorf("create token review: %w", err)
+	}
+
+	if !review.Status.Authenticated {
+		return "", &UnauthenticatedError{Reason: review.Status.Error}
+	}
+
+	user := review.Status.User
+
+	extra := make(map[string]authzv1.ExtraValue, len(user.Extra))
+	for key, values := range user.Extra {
+		extra[key] = authzv1.ExtraValue(values)
+	}
+
+	spec.User = user.Username
+	spec.UID = user.UID
+	spec.Groups = user.Groups
+	spec.Extra = extra
+
+	access, err := r.clientset.AuthorizationV1().SubjectAccessReviews().Create(ctx, &authzv1.SubjectAccessReview{
+		Spec: spec,
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return "", fmt.Errorf("create subject access review: %w", err)
+	}
+
+	if !access.Status.Allowed {
+		return "", &ForbiddenError{User: user.Username, Reason: access.Status.Reason}
+	}
+
+	return user.Username, nil
+}
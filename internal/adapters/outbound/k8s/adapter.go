@@ -2,15 +2,22 @@ package k8s
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"time"
 
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 	policy "k8s.io/api/policy/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
 	metricsv "k8s.io/metrics/pkg/client/clientset/versioned"
 
 	"github.com/skillcoder/preoomkiller-controller/internal/logic/controller"
@@ -19,12 +26,73 @@ import (
 const (
 	evictionKind       = "Eviction"
 	evictionAPIVersion = "policy/v1"
+	statefulSetKind    = "StatefulSet"
+	daemonSetKind      = "DaemonSet"
+	jobKind            = "Job"
 )
 
 type adapter struct {
-	logger           *slog.Logger
-	clientset        kubernetes.Interface
-	metricsClientset *metricsv.Clientset
+	logger                 *slog.Logger
+	clientset              kubernetes.Interface
+	metricsClientset       *metricsv.Clientset
+	restartRecordNamespace string
+	restartRecordName      string
+	pendingNamespace       string
+	pendingName            string
+	dynamicClient          dynamic.Interface
+	nodeName               string
+	callTimeout            time.Duration
+	cluster                string
+	discovery              *annotationDiscovery
+	pods                   podInformer
+}
+
+// Option configures optional adapter behavior.
+type Option func(*adapter)
+
+// WithRestartRecordConfigMap stores last-restart records in the given ConfigMap, creating it if missing.
+func WithRestartRecordConfigMap(namespace, name string) Option {
+	return func(a *adapter) {
+		a.restartRecordNamespace = namespace
+		a.restartRecordName = name
+	}
+}
+
+// WithPendingEvictionsConfigMap persists pending scheduled evictions in the given ConfigMap.
+func WithPendingEvictionsConfigMap(namespace, name string) Option {
+	return func(a *adapter) {
+		a.pendingNamespace = namespace
+		a.pendingName = name
+	}
+}
+
+// WithVPA enables VerticalPodAutoscaler lookups through dynamicClient.
+func WithVPA(dynamicClient dynamic.Interface) Option {
+	return func(a *adapter) {
+		a.dynamicClient = dynamicClient
+	}
+}
+
+// WithNodeName only lists pods scheduled on the given node, e.g. to run one controller per node.
+func WithNodeName(nodeName string) Option {
+	return func(a *adapter) {
+		a.nodeName = nodeName
+	}
+}
+
+// WithCallTimeout bounds each API request (each attempt, when retried) by timeout.
+func WithCallTimeout(timeout time.Duration) Option {
+	return func(a *adapter) {
+		a.callTimeout = timeout
+	}
+}
+
+// WithCluster names the cluster in logs and metrics when one process watches several clusters.
+func WithCluster(name string) Option {
+	return func(a *adapter) {
+		a.cluster = name
+		a.logger = a.logger.With("cluster", name)
+	}
 }
 
 // New creates a new K8s adapter.
@@ -32,27 +100,53 @@ func New(
 	logger *slog.Logger,
 	clientset kubernetes.Interface,
 	metricsClientset *metricsv.Clientset,
+	opts ...Option,
 ) controller.Repository {
-	return &adapter{
+	a := &adapter{
 		logger:           logger,
 		clientset:        clientset,
 		metricsClientset: metricsClientset,
 	}
+
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	return a
 }
 
 var _ controller.Repository = (*adapter)(nil)
 
+// callContext derives the context of one API request; without a call timeout it only adds cancellation.
+func (a *adapter) callContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if a.callTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, a.callTimeout)
+}
+
 func (a *adapter) ListPodsQuery(
 	ctx context.Context,
 	labelSelector string,
 ) ([]controller.Pod, error) {
+	if a.discovery != nil {
+		return a.listAnnotatedPods(ctx)
+	}
+
+	ctx, cancel := a.callContext(ctx)
+	defer cancel()
+
 	podList, err := a.clientset.CoreV1().Pods("").List(
 		ctx,
 		metav1.ListOptions{
 			LabelSelector: labelSelector,
+			FieldSelector: a.podFieldSelector(),
 		},
 	)
 	if err != nil {
+		a.recordAPIError(opListPods, err)
+
 		return nil, fmt.Errorf("list pods: %w", err)
 	}
 
@@ -64,17 +158,129 @@ func (a *adapter) ListPodsQuery(
 	return pods, nil
 }
 
+// podFieldSelector limits reconciled pods to running ones (on the shard's node, when set): pending and
+// completed pods have no memory usage to act on.
+func (a *adapter) podFieldSelector() string {
+	selectors := []fields.Selector{fields.OneTermEqualSelector("status.phase", string(corev1.PodRunning))}
+	if a.nodeName != "" {
+		selectors = append(selectors, fields.OneTermEqualSelector("spec.nodeName", a.nodeName))
+	}
+
+	return fields.AndSelectors(selectors...).String()
+}
+
+func (a *adapter) ListOwnerPodsQuery(
+	ctx context.Context,
+	namespace string,
+	owner controller.Owner,
+) ([]controller.Pod, error) {
+	selector, err := a.ownerPodSelector(ctx, namespace, owner)
+	if err != nil {
+		a.recordAPIError(opListOwnerPods, err)
+
+		return nil, fmt.Errorf("list owner pods: %w", err)
+	}
+
+	ctx, cancel := a.callContext(ctx)
+	defer cancel()
+
+	podList, err := a.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		a.recordAPIError(opListOwnerPods, err)
+
+		return nil, fmt.Errorf("list owner pods: %w", err)
+	}
+
+	pods := make([]controller.Pod, 0, len(podList.Items))
+
+	for i := range podList.Items {
+		ref := metav1.GetControllerOf(&podList.Items[i])
+		if ref == nil || string(ref.UID) != owner.UID {
+			continue
+		}
+
+		pods = append(pods, toDomainPod(&podList.Items[i]))
+	}
+
+	return pods, nil
+}
+
+// ownerPodSelector returns the pod label selector of the owner, so its pods are listed without
+// listing the whole namespace. It returns an empty selector for other kinds, for an owner that no
+// longer exists and when reading the owner is forbidden: its pods are then found by their controller
+// reference alone.
+func (a *adapter) ownerPodSelector(ctx context.Context, namespace string, owner controller.Owner) (string, error) {
+	ctx, cancel := a.callContext(ctx)
+	defer cancel()
+
+	var (
+		selector *metav1.LabelSelector
+		err      error
+	)
+
+	options := metav1.GetOptions{}
+
+	switch owner.Kind {
+	case replicaSetKind:
+		var rs *appsv1.ReplicaSet
+		if rs, err = a.clientset.AppsV1().ReplicaSets(namespace).Get(ctx, owner.Name, options); err == nil {
+			selector = rs.Spec.Selector
+		}
+	case statefulSetKind:
+		var sts *appsv1.StatefulSet
+		if sts, err = a.clientset.AppsV1().StatefulSets(namespace).Get(ctx, owner.Name, options); err == nil {
+			selector = sts.Spec.Selector
+		}
+	case daemonSetKind:
+		var ds *appsv1.DaemonSet
+		if ds, err = a.clientset.AppsV1().DaemonSets(namespace).Get(ctx, owner.Name, options); err == nil {
+			selector = ds.Spec.Selector
+		}
+	case jobKind:
+		var job *batchv1.Job
+		if job, err = a.clientset.BatchV1().Jobs(namespace).Get(ctx, owner.Name, options); err == nil {
+			selector = job.Spec.Selector
+		}
+	default:
+		return "", nil
+	}
+
+	if err != nil {
+		if apierrors.IsNotFound(err) || apierrors.IsForbidden(err) {
+			return "", nil
+		}
+
+		return "", fmt.Errorf("get %s: %w", owner.Kind, err)
+	}
+
+	if selector == nil {
+		return "", nil
+	}
+
+	parsed, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return "", fmt.Errorf("parse %s selector: %w", owner.Kind, err)
+	}
+
+	return parsed.String(), nil
+}
+
 func (a *adapter) GetPodQuery(
 	ctx context.Context,
 	namespace,
 	name string,
 ) (controller.Pod, error) {
+	ctx, cancel := a.callContext(ctx)
+	defer cancel()
+
 	pod, err := a.clientset.CoreV1().Pods(namespace).Get(
 		ctx,
 		name,
 		metav1.GetOptions{},
 	)
 	if err != nil {
+		a.recordAPIError(opGetPod, err)
+
 		if apierrors.IsNotFound(err) {
 			return controller.Pod{}, fmt.Errorf("get pod: %w", errPodNotFound)
 		}
@@ -85,17 +291,68 @@ func (a *adapter) GetPodQuery(
 	return toDomainPod(pod), nil
 }
 
+// PodSelectedQuery reports whether the pod is selected by the label selector, or by its discovery
+// annotations when pods are discovered by annotation. Pods on other nodes than the shard's are not
+// selected.
+func (a *adapter) PodSelectedQuery(
+	ctx context.Context,
+	namespace,
+	name,
+	labelSelector string,
+) (bool, error) {
+	ctx, cancel := a.callContext(ctx)
+	defer cancel()
+
+	pod, err := a.clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		a.recordAPIError(opGetPod, err)
+
+		if apierrors.IsNotFound(err) {
+			return false, fmt.Errorf("get pod: %w", errPodNotFound)
+		}
+
+		return false, fmt.Errorf("get pod: %w", err)
+	}
+
+	if a.nodeName != "" && pod.Spec.NodeName != a.nodeName {
+		return false, nil
+	}
+
+	if a.discovery != nil {
+		index, _ := a.discovery.annotated(pod)
+
+		return len(index) > 0, nil
+	}
+
+	selector, err := labels.Parse(labelSelector)
+	if err != nil {
+		return false, fmt.Errorf("parse label selector %q: %w", labelSelector, err)
+	}
+
+	return selector.Matches(labels.Set(pod.Labels)), nil
+}
+
 func (a *adapter) GetPodMetricsQuery(
 	ctx context.Context,
 	namespace,
 	name string,
 ) (*controller.PodMetrics, error) {
-	podMetrics, err := a.metricsClientset.MetricsV1beta1().PodMetricses(namespace).Get(
-		ctx,
-		name,
-		metav1.GetOptions{},
-	)
+	var podMetrics *metricsv1beta1.PodMetrics
+
+	err := a.withRetry(ctx, opGetPodMetrics, func(ctx context.Context) error {
+		var err error
+
+		podMetrics, err = a.metricsClientset.MetricsV1beta1().PodMetricses(namespace).Get(
+			ctx,
+			name,
+			metav1.GetOptions{},
+		)
+
+		return err
+	})
 	if err != nil {
+		a.recordAPIError(opGetPodMetrics, err)
+
 		if apierrors.IsNotFound(err) {
 			return nil, fmt.Errorf("get pod metrics: %w", errPodNotFound)
 		} else if apierrors.IsTooManyRequests(err) {
@@ -108,10 +365,46 @@ func (a *adapter) GetPodMetricsQuery(
 	return toDomainPodMetrics(ctx, a.logger, podMetrics), nil
 }
 
+func (a *adapter) ListPodMetricsQuery(
+	ctx context.Context,
+	namespace,
+	labelSelector string,
+) (map[string]*controller.PodMetrics, error) {
+	ctx, cancel := a.callContext(ctx)
+	defer cancel()
+
+	if a.discovery != nil {
+		// Annotated pods need not match the label selector.
+		labelSelector = ""
+	}
+
+	list, err := a.metricsClientset.MetricsV1beta1().PodMetricses(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labelSelector,
+	})
+	if err != nil {
+		a.recordAPIError(opListPodMetrics, err)
+
+		if apierrors.IsTooManyRequests(err) {
+			return nil, fmt.Errorf("list pod metrics: %w", errTooManyRequests)
+		}
+
+		return nil, fmt.Errorf("list pod metrics: %w", err)
+	}
+
+	out := make(map[string]*controller.PodMetrics, len(list.Items))
+	for i := range list.Items {
+		item := &list.Items[i]
+		out[item.Namespace+"/"+item.Name] = toDomainPodMetrics(ctx, a.logger, item)
+	}
+
+	return out, nil
+}
+
 func (a *adapter) EvictPodCommand(
 	ctx context.Context,
 	namespace,
-	name string,
+	name,
+	uid string,
 ) error {
 	eviction := &policy.Eviction{
 		TypeMeta: metav1.TypeMeta{
@@ -124,8 +417,33 @@ func (a *adapter) EvictPodCommand(
 		},
 	}
 
-	err := a.clientset.PolicyV1().Evictions(eviction.Namespace).Evict(ctx, eviction)
+	if uid != "" {
+		eviction.DeleteOptions = &metav1.DeleteOptions{
+			Preconditions: metav1.NewUIDPreconditions(uid),
+		}
+	}
+
+	failedBefore := false
+
+	err := a.withRetry(ctx, opEvictPod, func(ctx context.Context) error {
+		err := a.evict(ctx, eviction, uid)
+		if failedBefore && (apierrors.IsNotFound(err) || errors.Is(err, errPodNotFound)) {
+			// The failed attempt may have evicted the pod anyway, e.g. when it timed out on the
+			// client after the API server accepted it.
+			return nil
+		}
+
+		failedBefore = err != nil
+
+		return err
+	})
 	if err != nil {
+		if errors.Is(err, errPodNotFound) {
+			return fmt.Errorf("evict pod: %w", err)
+		}
+
+		a.recordAPIError(opEvictPod, err)
+
 		switch {
 		case apierrors.IsTooManyRequests(err):
 			return fmt.Errorf("evict pod: %w", errTooManyRequests)
@@ -139,39 +457,30 @@ func (a *adapter) EvictPodCommand(
 	return nil
 }
 
-func (a *adapter) SetAnnotationCommand(
-	ctx context.Context,
-	namespace,
-	name string,
-	key,
-	value string,
-) error {
-	annotations := map[string]any{key: value}
-	if value == "" {
-		annotations[key] = nil
-	}
-
-	patch := map[string]any{
-		"metadata": map[string]any{
-			"annotations": annotations,
-		},
+// evict posts the eviction once. A Conflict of an eviction with a UID precondition is checked
+// against the pod: when it is gone or has another UID, the precondition failed because the evaluated
+// pod was replaced, and errPodNotFound is returned; other conflicts are returned as is, and retried.
+func (a *adapter) evict(ctx context.Context, eviction *policy.Eviction, uid string) error {
+	err := a.clientset.PolicyV1().Evictions(eviction.Namespace).Evict(ctx, eviction)
+	if uid == "" || !apierrors.IsConflict(err) {
+		return err
 	}
 
-	patchBytes, err := json.Marshal(patch)
-	if err != nil {
-		return fmt.Errorf("marshal annotation patch: %w", err)
-	}
+	pod, getErr := a.clientset.CoreV1().Pods(eviction.Namespace).Get(ctx, eviction.Name, metav1.GetOptions{})
 
-	_, err = a.clientset.CoreV1().Pods(namespace).Patch(
-		ctx,
-		name,
-		types.MergePatchType,
-		patchBytes,
-		metav1.PatchOptions{},
-	)
-	if err != nil {
-		return fmt.Errorf("patch pod annotation: %w", err)
+	switch {
+	case apierrors.IsNotFound(getErr):
+		return errPodNotFound
+	case getErr != nil:
+		return err
+	case string(pod.UID) != uid:
+		return errPodNotFound
+	default:
+		return err
 	}
+}
 
-	return nil
+// EvaluatePromQLQuery is served by the Prometheus adapter; the Kubernetes API cannot evaluate PromQL.
+func (a *adapter) EvaluatePromQLQuery(context.Context, string) (bool, error) {
+	return false, errPromQLNotConfigured
 }
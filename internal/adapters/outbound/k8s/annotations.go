@@ -0,0 +1,94 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	corev1ac "k8s.io/client-go/applyconfigurations/core/v1"
+)
+
+// fieldManager owns the annotations the controller writes, so GitOps tools that prune fields
+// they manage leave them alone.
+const fieldManager = "preoomkiller-controller"
+
+// SetAnnotationCommand sets an annotation with server-side apply, or removes it when value is empty.
+// Each apply carries every annotation the controller already owns on the pod: fields the field
+// manager stops applying are removed by the API server.
+func (a *adapter) SetAnnotationCommand(
+	ctx context.Context,
+	namespace,
+	name string,
+	key,
+	value string,
+) error {
+	if value == "" {
+		return a.removeAnnotation(ctx, namespace, name, key)
+	}
+
+	// A conflict on the resourceVersion means the pod changed after it was read; the retry re-reads it.
+	err := a.withRetry(ctx, opSetAnnotation, func(ctx context.Context) error {
+		pod, err := a.clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("get pod: %w", err)
+		}
+
+		owned, err := corev1ac.ExtractPod(pod, fieldManager)
+		if err != nil {
+			return fmt.Errorf("extract owned fields: %w", err)
+		}
+
+		owned.WithAnnotations(map[string]string{key: value}).WithResourceVersion(pod.ResourceVersion)
+
+		_, err = a.clientset.CoreV1().Pods(namespace).Apply(ctx, owned, metav1.ApplyOptions{
+			FieldManager: fieldManager,
+			// Take over annotations written before the controller used server-side apply.
+			Force: true,
+		})
+
+		return err
+	})
+	if err != nil {
+		a.recordAPIError(opSetAnnotation, err)
+
+		return fmt.Errorf("apply pod annotation: %w", err)
+	}
+
+	return nil
+}
+
+// removeAnnotation deletes the annotation with a merge patch, which also works for annotations the
+// field manager does not own (e.g. written by an older controller version).
+func (a *adapter) removeAnnotation(ctx context.Context, namespace, name, key string) error {
+	patch := map[string]any{
+		"metadata": map[string]any{
+			"annotations": map[string]any{key: nil},
+		},
+	}
+
+	patchBytes, err := json.Marshal(patch)
+	if err != nil {
+		return fmt.Errorf("marshal annotation patch: %w", err)
+	}
+
+	err = a.withRetry(ctx, opSetAnnotation, func(ctx context.Context) error {
+		_, patchErr := a.clientset.CoreV1().Pods(namespace).Patch(
+			ctx,
+			name,
+			types.MergePatchType,
+			patchBytes,
+			metav1.PatchOptions{FieldManager: fieldManager},
+		)
+
+		return patchErr
+	})
+	if err != nil {
+		a.recordAPIError(opSetAnnotation, err)
+
+		return fmt.Errorf("patch pod annotation: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// patchConfigMapData merge-patches data into the ConfigMap and creates the ConfigMap when it does not exist.
+// When another writer created the ConfigMap in the meantime, the patch is retried.
+func (a *adapter) patchConfigMapData(ctx context.Context, namespace, name string, data map[string]string) error {
+	patchBytes, err := json.Marshal(map[string]any{"data": data})
+	if err != nil {
+		return fmt.Errorf("marshal configmap patch: %w", err)
+	}
+
+	configMaps := a.clientset.CoreV1().ConfigMaps(namespace)
+
+	_, err = configMaps.Patch(ctx, name, types.MergePatchType, patchBytes, metav1.PatchOptions{})
+	if err == nil {
+		return nil
+	}
+
+	if !apierrors.IsNotFound(err) {
+		return fmt.Errorf("patch configmap: %w", err)
+	}
+
+	_, err = configMaps.Create(ctx, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Data: data,
+	}, metav1.CreateOptions{})
+	if err == nil {
+		return nil
+	}
+
+	if !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("create configmap: %w", err)
+	}
+
+	if _, err := configMaps.Patch(ctx, name, types.MergePatchType, patchBytes, metav1.PatchOptions{}); err != nil {
+		return fmt.Errorf("patch created configmap: %w", err)
+	}
+
+	return nil
+}
+
+// deleteConfigMapKeys removes data keys from the ConfigMap. A missing ConfigMap is not an error.
+func (a *adapter) deleteConfigMapKeys(ctx context.Context, namespace, name string, keys ...string) error {
+	removed := make(map[string]any, len(keys))
+	for _, key := range keys {
+		removed[key] = nil
+	}
+
+	patchBytes, err := json.Marshal(map[string]any{"data": removed})
+	if err != nil {
+		return fmt.Errorf("marshal configmap patch: %w", err)
+	}
+
+	_, err = a.clientset.CoreV1().ConfigMaps(namespace).Patch(
+		ctx,
+		name,
+		types.MergePatchType,
+		patchBytes,
+		metav1.PatchOptions{},
+	)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("patch configmap: %w", err)
+	}
+
+	return nil
+}
@@ -3,22 +3,41 @@ package k8s
 import (
 	"context"
 	"log/slog"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
 
 	"github.com/skillcoder/preoomkiller-controller/internal/logic/controller"
 )
 
+const (
+	// reasonOOMKilled is the container termination reason set by the kubelet when the OOM killer fires.
+	reasonOOMKilled = "OOMKilled"
+	// reasonCrashLoopBackOff is the container waiting reason while the kubelet backs off restarts.
+	reasonCrashLoopBackOff = "CrashLoopBackOff"
+)
+
 func toDomainPod(pod *corev1.Pod) controller.Pod {
 	out := controller.Pod{
 		Name:        pod.Name,
 		Namespace:   pod.Namespace,
+		UID:         string(pod.UID),
 		Annotations: pod.Annotations,
 		CreatedAt:   pod.CreationTimestamp.Time,
 	}
 
+	if ref := metav1.GetControllerOf(pod); ref != nil {
+		out.Owner = &controller.Owner{
+			APIVersion: ref.APIVersion,
+			Kind:       ref.Kind,
+			Name:       ref.Name,
+			UID:        string(ref.UID),
+		}
+	}
+
 	totalLimit := resource.NewQuantity(0, resource.BinarySI)
 	hasLimit := false
 
@@ -26,6 +45,12 @@ func toDomainPod(pod *corev1.Pod) controller.Pod {
 		if limit, ok := pod.Spec.Containers[i].Resources.Limits[corev1.ResourceMemory]; ok {
 			totalLimit.Add(limit)
 
+			if out.ContainerMemoryLimits == nil {
+				out.ContainerMemoryLimits = make(map[string]resource.Quantity, len(pod.Spec.Containers))
+			}
+
+			out.ContainerMemoryLimits[pod.Spec.Containers[i].Name] = limit
+
 			hasLimit = true
 		}
 	}
@@ -34,15 +59,66 @@ func toDomainPod(pod *corev1.Pod) controller.Pod {
 		out.MemoryLimit = totalLimit
 	}
 
+	out.LastOOMKilledAt = lastOOMKilledAt(pod.Status.ContainerStatuses)
+	out.CrashLoopBackOff = isCrashLooping(pod.Status.InitContainerStatuses) || isCrashLooping(pod.Status.ContainerStatuses)
+	out.NotReady = !isPodReady(pod)
+	out.Terminating = pod.DeletionTimestamp != nil
+
 	return out
 }
 
+// isCrashLooping reports whether any container is waiting in CrashLoopBackOff.
+func isCrashLooping(statuses []corev1.ContainerStatus) bool {
+	for i := range statuses {
+		if waiting := statuses[i].State.Waiting; waiting != nil && waiting.Reason == reasonCrashLoopBackOff {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isPodReady reports whether the pod Ready condition is True.
+func isPodReady(pod *corev1.Pod) bool {
+	for i := range pod.Status.Conditions {
+		if pod.Status.Conditions[i].Type == corev1.PodReady {
+			return pod.Status.Conditions[i].Status == corev1.ConditionTrue
+		}
+	}
+
+	return false
+}
+
+// lastOOMKilledAt returns the latest finish time of an OOMKilled container termination, current or previous.
+func lastOOMKilledAt(statuses []corev1.ContainerStatus) *time.Time {
+	var last *time.Time
+
+	for i := range statuses {
+		for _, terminated := range []*corev1.ContainerStateTerminated{
+			statuses[i].State.Terminated,
+			statuses[i].LastTerminationState.Terminated,
+		} {
+			if terminated == nil || terminated.Reason != reasonOOMKilled {
+				continue
+			}
+
+			finishedAt := terminated.FinishedAt.Time
+			if last == nil || finishedAt.After(*last) {
+				last = &finishedAt
+			}
+		}
+	}
+
+	return last
+}
+
 func toDomainPodMetrics(
 	ctx context.Context,
 	logger *slog.Logger,
 	podMetrics *metricsv1beta1.PodMetrics,
 ) *controller.PodMetrics {
 	memoryUsage := resource.NewQuantity(0, resource.BinarySI)
+	containers := make([]controller.ContainerMetrics, 0, len(podMetrics.Containers))
 
 	for i := range podMetrics.Containers {
 		containerMemoryUsage := podMetrics.Containers[i].Usage.Memory()
@@ -57,6 +133,11 @@ func toDomainPodMetrics(
 		}
 
 		memoryUsage.Add(*containerMemoryUsage)
+		containers = append(containers, controller.ContainerMetrics{
+			Name:        podMetrics.Containers[i].Name,
+			MemoryUsage: containerMemoryUsage,
+			CPUUsage:    podMetrics.Containers[i].Usage.Cpu(),
+		})
 		logger.DebugContext(ctx, "container metrics",
 			"pod", podMetrics.Name,
 			"namespace", podMetrics.Namespace,
@@ -67,5 +148,6 @@ func toDomainPodMetrics(
 
 	return &controller.PodMetrics{
 		MemoryUsage: memoryUsage,
+		Containers:  containers,
 	}
 }
@@ -0,0 +1,180 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/skillcoder/preoomkiller-controller/internal/logic/controller"
+)
+
+// annotatedIndex is the informer index of the pods having at least one of the discovery annotations.
+const annotatedIndex = "annotated"
+
+var errPodCacheNotSynced = errors.New("pod cache not synced")
+
+// annotationDiscovery selects pods by the presence of the controller's annotations instead of by
+// label: all running pods are watched with the pod informer, indexed by whether they are annotated.
+type annotationDiscovery struct {
+	keys []string
+}
+
+// podInformer watches all running pods, for the annotation discovery and the annotation watch. It is
+// started once, by its first user.
+type podInformer struct {
+	once     sync.Once
+	informer cache.SharedIndexInformer
+	// err is the error of starting the informer.
+	err error
+	// stopCh is never closed: the informer runs for the lifetime of the process.
+	stopCh chan struct{}
+}
+
+// WithAnnotationDiscovery selects the pods having at least one of the annotation keys, e.g. a memory
+// threshold or restart schedule, whatever their labels: the label selector is ignored when listing
+// pods and their metrics. Pods are watched with an informer, started by the first list.
+func WithAnnotationDiscovery(keys ...string) Option {
+	return func(a *adapter) {
+		a.discovery = &annotationDiscovery{keys: keys}
+	}
+}
+
+// annotated returns the index values of a pod: annotatedIndex when it has a discovery annotation.
+func (d *annotationDiscovery) annotated(obj any) ([]string, error) {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		return nil, nil
+	}
+
+	for _, key := range d.keys {
+		if _, ok := pod.Annotations[key]; ok {
+			return []string{annotatedIndex}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// stripManagedFields drops the managed fields of cached pods, which the controller never reads, to
+// save memory.
+func stripManagedFields(obj any) (any, error) {
+	if pod, ok := obj.(*corev1.Pod); ok {
+		pod.ManagedFields = nil
+	}
+
+	return obj, nil
+}
+
+// startPodInformer starts the pod informer once, indexing the annotated pods for the annotation
+// discovery when enabled.
+func (a *adapter) startPodInformer() error {
+	a.pods.once.Do(func() {
+		factory := informers.NewSharedInformerFactoryWithOptions(a.clientset, 0,
+			informers.WithTweakListOptions(func(options *metav1.ListOptions) {
+				options.FieldSelector = a.podFieldSelector()
+			}),
+		)
+
+		informer := factory.Core().V1().Pods().Informer()
+
+		if err := informer.SetTransform(stripManagedFields); err != nil {
+			a.pods.err = fmt.Errorf("set pod informer transform: %w", err)
+
+			return
+		}
+
+		if a.discovery != nil {
+			if err := informer.AddIndexers(cache.Indexers{annotatedIndex: a.discovery.annotated}); err != nil {
+				a.pods.err = fmt.Errorf("add pod informer indexer: %w", err)
+
+				return
+			}
+		}
+
+		a.pods.informer = informer
+		a.pods.stopCh = make(chan struct{})
+		factory.Start(a.pods.stopCh)
+	})
+
+	return a.pods.err
+}
+
+// listAnnotatedPods lists the annotated pods from the informer cache, waiting for it to sync.
+func (a *adapter) listAnnotatedPods(ctx context.Context) ([]controller.Pod, error) {
+	if err := a.startPodInformer(); err != nil {
+		return nil, err
+	}
+
+	if !cache.WaitForCacheSync(ctx.Done(), a.pods.informer.HasSynced) {
+		return nil, fmt.Errorf("list pods: %w", errPodCacheNotSynced)
+	}
+
+	objs, err := a.pods.informer.GetIndexer().ByIndex(annotatedIndex, annotatedIndex)
+	if err != nil {
+		return nil, fmt.Errorf("list annotated pods: %w", err)
+	}
+
+	pods := make([]controller.Pod, 0, len(objs))
+
+	for _, obj := range objs {
+		if pod, ok := obj.(*corev1.Pod); ok {
+			pods = append(pods, toDomainPod(pod))
+		}
+	}
+
+	return pods, nil
+}
+
+// WatchPodAnnotationsQuery calls changed for each running pod whose value of one of the annotation
+// keys changed, until ctx is done. Pods are watched with the pod informer.
+func (a *adapter) WatchPodAnnotationsQuery(
+	ctx context.Context,
+	keys []string,
+	changed func(namespace, name string),
+) error {
+	if err := a.startPodInformer(); err != nil {
+		return err
+	}
+
+	registration, err := a.pods.informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldObj, newObj any) {
+			oldPod, oldOK := oldObj.(*corev1.Pod)
+			newPod, newOK := newObj.(*corev1.Pod)
+
+			if oldOK && newOK && annotationsChanged(oldPod, newPod, keys) {
+				changed(newPod.Namespace, newPod.Name)
+			}
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("add pod informer handler: %w", err)
+	}
+
+	<-ctx.Done()
+
+	if err := a.pods.informer.RemoveEventHandler(registration); err != nil {
+		return fmt.Errorf("remove pod informer handler: %w", err)
+	}
+
+	return nil
+}
+
+// annotationsChanged reports whether the value of one of the keys differs between the pods.
+func annotationsChanged(oldPod, newPod *corev1.Pod, keys []string) bool {
+	for _, key := range keys {
+		oldValue, oldOK := oldPod.Annotations[key]
+		newValue, newOK := newPod.Annotations[key]
+
+		if oldOK != newOK || oldValue != newValue {
+			return true
+		}
+	}
+
+	return false
+}
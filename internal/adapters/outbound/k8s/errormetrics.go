@@ -0,0 +1,53 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/skillcoder/preoomkiller-controller/internal/infra/metrics"
+)
+
+// Operations reported in the API error and retry metrics.
+const (
+	opListPods       = "list_pods"
+	opListOwnerPods  = "list_owner_pods"
+	opGetPod         = "get_pod"
+	opGetPodMetrics  = "get_pod_metrics"
+	opListPodMetrics = "list_pod_metrics"
+	opListNamespaces = "list_namespaces"
+	opEvictPod       = "evict_pod"
+	opSetAnnotation  = "set_annotation"
+)
+
+// recordAPIError counts a failed request of the operation by error type.
+func (a *adapter) recordAPIError(operation string, err error) {
+	metrics.RecordK8sAPIError(a.cluster, operation, apiErrorType(err))
+}
+
+// apiErrorType classifies a failed request as not_found, too_many_requests, timeout or other.
+func apiErrorType(err error) string {
+	switch {
+	case apierrors.IsNotFound(err):
+		return "not_found"
+	case apierrors.IsTooManyRequests(err):
+		return "too_many_requests"
+	case isTimeout(err):
+		return "timeout"
+	default:
+		return "other"
+	}
+}
+
+// isTimeout reports whether the request timed out on the client (call timeout) or the server.
+func isTimeout(err error) bool {
+	if apierrors.IsTimeout(err) || apierrors.IsServerTimeout(err) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var netErr net.Error
+
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
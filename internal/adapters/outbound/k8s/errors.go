@@ -1,5 +1,7 @@
 package k8s
 
+import "errors"
+
 // TooManyRequestsError represents a "too many requests" case that is not an error.
 type TooManyRequestsError struct{}
 
@@ -21,3 +23,49 @@ func (e *PodNotFoundError) Error() string {
 func (e *PodNotFoundError) IsNotFound() {}
 
 var errPodNotFound = &PodNotFoundError{}
+
+// NotConfiguredError means the request needs a feature the controller is not configured for.
+type NotConfiguredError struct {
+	Reason string
+}
+
+func (e *NotConfiguredError) Error() string {
+	return e.Reason
+}
+
+func (e *NotConfiguredError) IsNotConfigured() {}
+
+var errPromQLNotConfigured = &NotConfiguredError{Reason: "promql conditions need PREOOMKILLER_PROMETHEUS_URL"}
+
+var errVPANotConfigured = errors.New("vpa lookups need PREOOMKILLER_VPA_MODE")
+
+// UnauthenticatedError means a TokenReview rejected the token.
+type UnauthenticatedError struct {
+	Reason string
+}
+
+func (e *UnauthenticatedError) Error() string {
+	if e.Reason == "" {
+		return "token not authenticated"
+	}
+
+	return "token not authenticated: " + e.Reason
+}
+
+func (e *UnauthenticatedError) IsUnauthenticated() {}
+
+// ForbiddenError means a SubjectAccessReview denied the request of an authenticated user.
+type ForbiddenError struct {
+	User   string
+	Reason string
+}
+
+func (e *ForbiddenError) Error() string {
+	if e.Reason == "" {
+		return "user " + e.User + " is not allowed"
+	}
+
+	return "user " + e.User + " is not allowed: " + e.Reason
+}
+
+func (e *ForbiddenError) IsForbidden() {}
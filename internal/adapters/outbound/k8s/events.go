@@ -0,0 +1,87 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/skillcoder/preoomkiller-controller/internal/logic/controller"
+)
+
+// eventSourceComponent is reported as the source of Events created by the controller.
+const eventSourceComponent = "preoomkiller-controller"
+
+func (a *adapter) CreatePodEventCommand(
+	ctx context.Context,
+	pod controller.Pod,
+	event controller.PodEvent,
+) error {
+	err := a.createEvent(ctx, corev1.ObjectReference{
+		APIVersion: "v1",
+		Kind:       "Pod",
+		Namespace:  pod.Namespace,
+		Name:       pod.Name,
+		UID:        types.UID(pod.UID),
+	}, event)
+	if err != nil {
+		return fmt.Errorf("create pod event: %w", err)
+	}
+
+	return nil
+}
+
+func (a *adapter) CreateOwnerEventCommand(
+	ctx context.Context,
+	namespace string,
+	owner controller.Owner,
+	event controller.PodEvent,
+) error {
+	err := a.createEvent(ctx, corev1.ObjectReference{
+		APIVersion: owner.APIVersion,
+		Kind:       owner.Kind,
+		Namespace:  namespace,
+		Name:       owner.Name,
+		UID:        types.UID(owner.UID),
+	}, event)
+	if err != nil {
+		return fmt.Errorf("create owner event: %w", err)
+	}
+
+	return nil
+}
+
+// createEvent creates an Event on the object in its namespace.
+func (a *adapter) createEvent(
+	ctx context.Context,
+	object corev1.ObjectReference,
+	event controller.PodEvent,
+) error {
+	ctx, cancel := a.callContext(ctx)
+	defer cancel()
+
+	now := metav1.NewTime(time.Now())
+
+	_, err := a.clientset.CoreV1().Events(object.Namespace).Create(ctx, &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: object.Name + ".",
+			Namespace:    object.Namespace,
+		},
+		InvolvedObject: object,
+		Type:           string(event.Type),
+		Reason:         event.Reason,
+		Message:        event.Message,
+		Source:         corev1.EventSource{Component: eventSourceComponent},
+		FirstTimestamp: now,
+		LastTimestamp:  now,
+		Count:          1,
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("create event: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metricsv "k8s.io/metrics/pkg/client/clientset/versioned"
+
+	"github.com/skillcoder/preoomkiller-controller/internal/infra/metrics"
+)
+
+// MetricsAPIPinger checks that the metrics.k8s.io API serves pod metrics, so that a controller
+// running without memory usage is told apart from a healthy one. It neither fails readiness nor
+// health: restarting the controller would not bring metrics-server back.
+type MetricsAPIPinger struct {
+	metricsClientset *metricsv.Clientset
+	cluster          string
+	timeout          time.Duration
+}
+
+// NewMetricsAPIPinger returns a MetricsAPIPinger of the cluster; each check is bounded by timeout
+// when set.
+func NewMetricsAPIPinger(metricsClientset *metricsv.Clientset, cluster string, timeout time.Duration) *MetricsAPIPinger {
+	return &MetricsAPIPinger{metricsClientset: metricsClientset, cluster: cluster, timeout: timeout}
+}
+
+// Name returns the pinger name, suffixed with the cluster in multi-cluster mode.
+func (p *MetricsAPIPinger) Name() string {
+	if p.cluster != "" {
+		return "metrics-api/" + p.cluster
+	}
+
+	return "metrics-api"
+}
+
+// Ping lists a single PodMetrics of any namespace and records whether the API is available.
+func (p *MetricsAPIPinger) Ping(ctx context.Context) error {
+	_, err := p.metricsClientset.MetricsV1beta1().PodMetricses(metav1.NamespaceAll).List(ctx, metav1.ListOptions{Limit: 1})
+
+	metrics.SetMetricsAPIAvailable(p.cluster, err == nil)
+
+	if err != nil {
+		return fmt.Errorf("list pod metrics: %w", err)
+	}
+
+	return nil
+}
+
+// PingerReadyCritical returns false: the controller stays ready without metrics.
+func (p *MetricsAPIPinger) PingerReadyCritical() bool {
+	return false
+}
+
+// PingerCritical returns false: the controller stays healthy without metrics.
+func (p *MetricsAPIPinger) PingerCritical() bool {
+	return false
+}
+
+// PingerTimeout returns the API call timeout; zero means the pinger default.
+func (p *MetricsAPIPinger) PingerTimeout() time.Duration {
+	return p.timeout
+}
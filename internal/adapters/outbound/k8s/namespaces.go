@@ -0,0 +1,27 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func (a *adapter) ListNamespaceAnnotationsQuery(ctx context.Context) (map[string]map[string]string, error) {
+	ctx, cancel := a.callContext(ctx)
+	defer cancel()
+
+	namespaceList, err := a.clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		a.recordAPIError(opListNamespaces, err)
+
+		return nil, fmt.Errorf("list namespaces: %w", err)
+	}
+
+	annotations := make(map[string]map[string]string, len(namespaceList.Items))
+	for i := range namespaceList.Items {
+		annotations[namespaceList.Items[i].Name] = namespaceList.Items[i].Annotations
+	}
+
+	return annotations, nil
+}
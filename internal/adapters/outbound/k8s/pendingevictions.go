@@ -0,0 +1,141 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/skillcoder/preoomkiller-controller/internal/logic/controller"
+)
+
+var errPendingEvictionsNotConfigured = errors.New("pending evictions configmap not configured")
+
+// pendingEvictionValue is the JSON value stored per pod in the pending evictions ConfigMap. Node is
+// the node shard that scheduled the eviction, empty without one; shards sharing the ConfigMap only
+// list their own entries. Entries written before the node was recorded hold the bare RFC 3339 time.
+type pendingEvictionValue struct {
+	At   time.Time `json:"at"`
+	Node string    `json:"node,omitempty"`
+}
+
+// pendingEvictionKey returns the ConfigMap data key of a pod: "<namespace>.<name>".
+// Namespaces cannot contain dots, so the first dot separates namespace and name.
+func pendingEvictionKey(namespace, name string) string {
+	return namespace + "." + name
+}
+
+func (a *adapter) SavePendingEvictionCommand(
+	ctx context.Context,
+	namespace,
+	name string,
+	at time.Time,
+) error {
+	ctx, cancel := a.callContext(ctx)
+	defer cancel()
+
+	if a.pendingName == "" {
+		return errPendingEvictionsNotConfigured
+	}
+
+	value, err := json.Marshal(pendingEvictionValue{At: at.UTC(), Node: a.nodeName})
+	if err != nil {
+		return fmt.Errorf("marshal pending eviction: %w", err)
+	}
+
+	data := map[string]string{pendingEvictionKey(namespace, name): string(value)}
+
+	if err := a.patchConfigMapData(ctx, a.pendingNamespace, a.pendingName, data); err != nil {
+		return fmt.Errorf("save pending eviction: %w", err)
+	}
+
+	return nil
+}
+
+func (a *adapter) DeletePendingEvictionCommand(
+	ctx context.Context,
+	namespace,
+	name string,
+) error {
+	ctx, cancel := a.callContext(ctx)
+	defer cancel()
+
+	if a.pendingName == "" {
+		return errPendingEvictionsNotConfigured
+	}
+
+	if err := a.deleteConfigMapKeys(ctx, a.pendingNamespace, a.pendingName, pendingEvictionKey(namespace, name)); err != nil {
+		return fmt.Errorf("delete pending eviction: %w", err)
+	}
+
+	return nil
+}
+
+func (a *adapter) ListPendingEvictionsQuery(ctx context.Context) ([]controller.PendingEviction, error) {
+	ctx, cancel := a.callContext(ctx)
+	defer cancel()
+
+	if a.pendingName == "" {
+		return nil, errPendingEvictionsNotConfigured
+	}
+
+	configMap, err := a.clientset.CoreV1().ConfigMaps(a.pendingNamespace).Get(ctx, a.pendingName, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("get pending evictions configmap: %w", err)
+	}
+
+	pending := make([]controller.PendingEviction, 0, len(configMap.Data))
+
+	for key, value := range configMap.Data {
+		namespace, name, ok := strings.Cut(key, ".")
+		if !ok {
+			a.logger.WarnContext(ctx, "invalid pending eviction key", "key", key)
+
+			continue
+		}
+
+		entry, err := parsePendingEvictionValue(value)
+		if err != nil {
+			a.logger.WarnContext(ctx, "invalid pending eviction", "key", key, "reason", err)
+
+			continue
+		}
+
+		// Another node shard's eviction: restoring or forgetting it here would take it from that shard.
+		if a.nodeName != "" && entry.Node != a.nodeName {
+			continue
+		}
+
+		pending = append(pending, controller.PendingEviction{Namespace: namespace, Name: name, At: entry.At})
+	}
+
+	return pending, nil
+}
+
+// parsePendingEvictionValue parses a pending evictions ConfigMap value, JSON or a bare RFC 3339 time.
+func parsePendingEvictionValue(value string) (pendingEvictionValue, error) {
+	if !strings.HasPrefix(value, "{") {
+		at, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return pendingEvictionValue{}, fmt.Errorf("parse pending eviction time: %w", err)
+		}
+
+		return pendingEvictionValue{At: at}, nil
+	}
+
+	var entry pendingEvictionValue
+	if err := json.Unmarshal([]byte(value), &entry); err != nil {
+		return pendingEvictionValue{}, fmt.Errorf("unmarshal pending eviction: %w", err)
+	}
+
+	return entry, nil
+}
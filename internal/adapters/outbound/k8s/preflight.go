@@ -0,0 +1,128 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	authzv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Permission is a Kubernetes API permission the controller needs; an empty namespace means all
+// namespaces.
+type Permission struct {
+	Verb        string
+	Group       string
+	Resource    string
+	Subresource string
+	Namespace   string
+}
+
+func (p Permission) String() string {
+	resource := p.Resource
+	if p.Subresource != "" {
+		resource += "/" + p.Subresource
+	}
+
+	if p.Group != "" {
+		resource += "." + p.Group
+	}
+
+	if p.Namespace != "" {
+		return p.Verb + " " + resource + " in " + p.Namespace
+	}
+
+	return p.Verb + " " + resource
+}
+
+// Preflight checks a cluster before the controller runs against it: that its API server is
+// reachable and grants the needed permissions.
+type Preflight struct {
+	clientset kubernetes.Interface
+	timeout   time.Duration
+}
+
+// NewPreflight returns a Preflight; each request is bounded by timeout when set.
+func NewPreflight(clientset kubernetes.Interface, timeout time.Duration) *Preflight {
+	return &Preflight{clientset: clientset, timeout: timeout}
+}
+
+// ServerVersion returns the version of the API server, e.g. v1.33.1.
+func (p *Preflight) ServerVersion() (string, error) {
+	version, err := p.clientset.Discovery().ServerVersion()
+	if err != nil {
+		return "", fmt.Errorf("get server version: %w", err)
+	}
+
+	return version.GitVersion, nil
+}
+
+// MissingPermissions returns the permissions of perms the controller is not granted, reviewed with
+// SelfSubjectAccessReviews of its own identity.
+func (p *Preflight) MissingPermissions(ctx context.Context, perms []Permission) ([]Permission, error) {
+	var missing []Permission
+
+	for _, perm := range perms {
+		allowed, err := p.reviewPermission(ctx, perm)
+		if err != nil {
+			return nil, err
+		}
+
+		if !allowed {
+			missing = append(missing, perm)
+		}
+	}
+
+	return missing, nil
+}
+
+func (p *Preflight) reviewPermission(ctx context.Context, perm Permission) (bool, error) {
+	if p.timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, p.timeout)
+		defer cancel()
+	}
+
+	review, err := p.clientset.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, &authzv1.SelfSubjectAccessReview{
+		Spec: authzv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authzv1.ResourceAttributes{
+				Namespace:   perm.Namespace,
+				Verb:        perm.Verb,
+				Group:       perm.Group,
+				Resource:    perm.Resource,
+				Subresource: perm.Subresource,
+			},
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return false, fmt.Errorf("create self subject access review: %s: %w", perm, err)
+	}
+
+	return review.Status.Allowed, nil
+}
+
+// PodAnnotations returns the annotations of the pods matching labelSelector, keyed by
+// namespace/name.
+func (p *Preflight) PodAnnotations(ctx context.Context, labelSelector string) (map[string]map[string]string, error) {
+	if p.timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, p.timeout)
+		defer cancel()
+	}
+
+	pods, err := p.clientset.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		return nil, fmt.Errorf("list pods: %w", err)
+	}
+
+	annotations := make(map[string]map[string]string, len(pods.Items))
+	for i := range pods.Items {
+		annotations[pods.Items[i].Namespace+"/"+pods.Items[i].Name] = pods.Items[i].Annotations
+	}
+
+	return annotations, nil
+}
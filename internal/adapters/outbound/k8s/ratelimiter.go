@@ -0,0 +1,75 @@
+package k8s
+
+import (
+	"context"
+	"sync/atomic"
+
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/flowcontrol"
+)
+
+// RateLimiter is the client-side rate limiter of Kubernetes API requests; its limits can be changed
+// at runtime with Set. Set it as rest.Config.RateLimiter.
+type RateLimiter struct {
+	limiter atomic.Pointer[flowcontrol.RateLimiter]
+}
+
+var _ flowcontrol.RateLimiter = (*RateLimiter)(nil)
+
+// NewRateLimiter returns a RateLimiter of qps requests per second with bursts of burst requests. Like
+// rest.Config, zero values keep the client-go defaults, and a negative qps disables the limit.
+func NewRateLimiter(qps float32, burst int) *RateLimiter {
+	r := &RateLimiter{}
+	r.Set(qps, burst)
+
+	return r
+}
+
+// Set replaces the limits; requests already waiting keep the previous ones.
+func (r *RateLimiter) Set(qps float32, burst int) {
+	limiter := newTokenBucket(qps, burst)
+
+	if previous := r.limiter.Swap(&limiter); previous != nil {
+		(*previous).Stop()
+	}
+}
+
+func newTokenBucket(qps float32, burst int) flowcontrol.RateLimiter {
+	if qps < 0 {
+		return flowcontrol.NewFakeAlwaysRateLimiter()
+	}
+
+	if qps == 0 {
+		qps = rest.DefaultQPS
+	}
+
+	if burst == 0 {
+		burst = rest.DefaultBurst
+	}
+
+	return flowcontrol.NewTokenBucketRateLimiter(qps, burst)
+}
+
+func (r *RateLimiter) current() flowcontrol.RateLimiter {
+	return *r.limiter.Load()
+}
+
+func (r *RateLimiter) TryAccept() bool {
+	return r.current().TryAccept()
+}
+
+func (r *RateLimiter) Accept() {
+	r.current().Accept()
+}
+
+func (r *RateLimiter) Stop() {
+	r.current().Stop()
+}
+
+func (r *RateLimiter) QPS() float32 {
+	return r.current().QPS()
+}
+
+func (r *RateLimiter) Wait(ctx context.Context) error {
+	return r.current().Wait(ctx)
+}
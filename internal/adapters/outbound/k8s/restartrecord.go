@@ -0,0 +1,408 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/skillcoder/preoomkiller-controller/internal/logic/controller"
+)
+
+var errRestartRecordNotConfigured = errors.New("restart record configmap not configured")
+
+// restartRecordValue is the JSON value stored per workload in the restart record ConfigMap.
+type restartRecordValue struct {
+	Pod    string    `json:"pod"`
+	Reason string    `json:"reason"`
+	At     time.Time `json:"at"`
+}
+
+// suspendedKeyPrefix starts the ConfigMap data keys of workload suspensions, "suspended.<owner uid>". UIDs
+// have no dots, so they cannot be mistaken for restart records of a namespace named "suspended".
+const suspendedKeyPrefix = "suspended."
+
+// suspendedValue is the JSON value stored per suspended workload in the restart record ConfigMap.
+type suspendedValue struct {
+	Namespace  string    `json:"namespace"`
+	APIVersion string    `json:"apiVersion"`
+	Kind       string    `json:"kind"`
+	Name       string    `json:"name"`
+	Pod        string    `json:"pod"`
+	At         time.Time `json:"at"`
+}
+
+// tightenedKeyPrefix starts the ConfigMap data keys of tightened memory thresholds, "tightened.<owner uid>".
+const tightenedKeyPrefix = "tightened."
+
+// tightenedValue is the JSON value stored per workload with a tightened memory threshold in the restart
+// record ConfigMap.
+type tightenedValue struct {
+	Namespace  string    `json:"namespace"`
+	APIVersion string    `json:"apiVersion"`
+	Kind       string    `json:"kind"`
+	Name       string    `json:"name"`
+	Threshold  string    `json:"threshold"`
+	At         time.Time `json:"at"`
+}
+
+// restartRecordKey returns the ConfigMap data key of a workload: "<namespace>.<kind>.<name>".
+func restartRecordKey(record controller.RestartRecord) string {
+	return record.Namespace + "." + strings.ToLower(record.OwnerKind) + "." + record.OwnerName
+}
+
+func (a *adapter) RecordRestartCommand(
+	ctx context.Context,
+	record controller.RestartRecord,
+) error {
+	ctx, cancel := a.callContext(ctx)
+	defer cancel()
+
+	if a.restartRecordName == "" {
+		return errRestartRecordNotConfigured
+	}
+
+	value, err := json.Marshal(restartRecordValue{
+		Pod:    record.Pod,
+		Reason: string(record.Reason),
+		At:     record.At.UTC(),
+	})
+	if err != nil {
+		return fmt.Errorf("marshal restart record: %w", err)
+	}
+
+	data := map[string]string{restartRecordKey(record): string(value)}
+
+	if err := a.patchConfigMapData(ctx, a.restartRecordNamespace, a.restartRecordName, data); err != nil {
+		return fmt.Errorf("record restart: %w", err)
+	}
+
+	return nil
+}
+
+func (a *adapter) SuspendWorkloadCommand(
+	ctx context.Context,
+	workload controller.SuspendedWorkload,
+) error {
+	ctx, cancel := a.callContext(ctx)
+	defer cancel()
+
+	if a.restartRecordName == "" {
+		return errRestartRecordNotConfigured
+	}
+
+	value, err := json.Marshal(suspendedValue{
+		Namespace:  workload.Namespace,
+		APIVersion: workload.Owner.APIVersion,
+		Kind:       workload.Owner.Kind,
+		Name:       workload.Owner.Name,
+		Pod:        workload.Pod,
+		At:         workload.SuspendedAt.UTC(),
+	})
+	if err != nil {
+		return fmt.Errorf("marshal workload suspension: %w", err)
+	}
+
+	data := map[string]string{suspendedKeyPrefix + workload.Owner.UID: string(value)}
+
+	if err := a.patchConfigMapData(ctx, a.restartRecordNamespace, a.restartRecordName, data); err != nil {
+		return fmt.Errorf("suspend workload: %w", err)
+	}
+
+	return nil
+}
+
+func (a *adapter) ResumeWorkloadCommand(
+	ctx context.Context,
+	owner controller.Owner,
+) error {
+	ctx, cancel := a.callContext(ctx)
+	defer cancel()
+
+	if a.restartRecordName == "" {
+		return errRestartRecordNotConfigured
+	}
+
+	key := suspendedKeyPrefix + owner.UID
+
+	if err := a.deleteConfigMapKeys(ctx, a.restartRecordNamespace, a.restartRecordName, key); err != nil {
+		return fmt.Errorf("resume workload: %w", err)
+	}
+
+	return nil
+}
+
+func (a *adapter) ListSuspendedWorkloadsQuery(ctx context.Context) ([]controller.SuspendedWorkload, error) {
+	ctx, cancel := a.callContext(ctx)
+	defer cancel()
+
+	if a.restartRecordName == "" {
+		return nil, errRestartRecordNotConfigured
+	}
+
+	data, err := a.restartRecordData(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var workloads []controller.SuspendedWorkload
+
+	for key, entry := range data {
+		uid, ok := strings.CutPrefix(key, suspendedKeyPrefix)
+		if !ok || strings.Contains(uid, ".") {
+			continue
+		}
+
+		var value suspendedValue
+		if err := json.Unmarshal([]byte(entry), &value); err != nil {
+			a.logger.WarnContext(ctx, "invalid workload suspension", "key", key, "reason", err)
+
+			continue
+		}
+
+		workloads = append(workloads, controller.SuspendedWorkload{
+			Namespace: value.Namespace,
+			Owner: controller.Owner{
+				APIVersion: value.APIVersion,
+				Kind:       value.Kind,
+				Name:       value.Name,
+				UID:        uid,
+			},
+			Pod:         value.Pod,
+			SuspendedAt: value.At,
+		})
+	}
+
+	return workloads, nil
+}
+
+func (a *adapter) SaveTightenedThresholdCommand(
+	ctx context.Context,
+	threshold controller.TightenedThreshold,
+) error {
+	ctx, cancel := a.callContext(ctx)
+	defer cancel()
+
+	if a.restartRecordName == "" {
+		return errRestartRecordNotConfigured
+	}
+
+	value, err := json.Marshal(tightenedValue{
+		Namespace:  threshold.Namespace,
+		APIVersion: threshold.Owner.APIVersion,
+		Kind:       threshold.Owner.Kind,
+		Name:       threshold.Owner.Name,
+		Threshold:  threshold.Threshold.String(),
+		At:         threshold.At.UTC(),
+	})
+	if err != nil {
+		return fmt.Errorf("marshal tightened threshold: %w", err)
+	}
+
+	data := map[string]string{tightenedKeyPrefix + threshold.Owner.UID: string(value)}
+
+	if err := a.patchConfigMapData(ctx, a.restartRecordNamespace, a.restartRecordName, data); err != nil {
+		return fmt.Errorf("save tightened threshold: %w", err)
+	}
+
+	return nil
+}
+
+func (a *adapter) ListTightenedThresholdsQuery(ctx context.Context) ([]controller.TightenedThreshold, error) {
+	ctx, cancel := a.callContext(ctx)
+	defer cancel()
+
+	if a.restartRecordName == "" {
+		return nil, errRestartRecordNotConfigured
+	}
+
+	data, err := a.restartRecordData(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var thresholds []controller.TightenedThreshold
+
+	for key, entry := range data {
+		uid, ok := strings.CutPrefix(key, tightenedKeyPrefix)
+		if !ok || strings.Contains(uid, ".") {
+			continue
+		}
+
+		var value tightenedValue
+		if err := json.Unmarshal([]byte(entry), &value); err != nil {
+			a.logger.WarnContext(ctx, "invalid tightened threshold", "key", key, "reason", err)
+
+			continue
+		}
+
+		threshold, err := resource.ParseQuantity(value.Threshold)
+		if err != nil {
+			a.logger.WarnContext(ctx, "invalid tightened threshold", "key", key, "reason", err)
+
+			continue
+		}
+
+		thresholds = append(thresholds, controller.TightenedThreshold{
+			Namespace: value.Namespace,
+			Owner: controller.Owner{
+				APIVersion: value.APIVersion,
+				Kind:       value.Kind,
+				Name:       value.Name,
+				UID:        uid,
+			},
+			Threshold: threshold,
+			At:        value.At,
+		})
+	}
+
+	return thresholds, nil
+}
+
+// restartRecordData returns the data of the restart record ConfigMap, empty when it does not exist yet.
+func (a *adapter) restartRecordData(ctx context.Context) (map[string]string, error) {
+	configMap, err := a.clientset.CoreV1().ConfigMaps(a.restartRecordNamespace).Get(
+		ctx,
+		a.restartRecordName,
+		metav1.GetOptions{},
+	)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("get restart record configmap: %w", err)
+	}
+
+	return configMap.Data, nil
+}
+
+// recordOwner identifies the workload of a restart record ConfigMap entry; uid is empty for restart records.
+type recordOwner struct {
+	Namespace string `json:"namespace"`
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	uid       string
+}
+
+// parseRecordOwner returns the workload of a restart record ConfigMap entry: from the key of a restart
+// record, "<namespace>.<kind>.<name>", or from the value of a workload suspension or tightened threshold.
+func parseRecordOwner(key, value string) (recordOwner, bool) {
+	for _, prefix := range []string{suspendedKeyPrefix, tightenedKeyPrefix} {
+		uid, ok := strings.CutPrefix(key, prefix)
+		if !ok || strings.Contains(uid, ".") {
+			continue
+		}
+
+		var owner recordOwner
+		if err := json.Unmarshal([]byte(value), &owner); err != nil {
+			return recordOwner{}, false
+		}
+
+		owner.uid = uid
+
+		return owner, owner.Namespace != "" && owner.Kind != "" && owner.Name != ""
+	}
+
+	// Namespaces and kinds have no dots; names may.
+	parts := strings.SplitN(key, ".", 3)
+	if len(parts) != 3 {
+		return recordOwner{}, false
+	}
+
+	return recordOwner{Namespace: parts[0], Kind: parts[1], Name: parts[2]}, true
+}
+
+func (a *adapter) PruneRestartRecordsCommand(ctx context.Context) (int, error) {
+	if a.restartRecordName == "" {
+		return 0, errRestartRecordNotConfigured
+	}
+
+	listCtx, cancel := a.callContext(ctx)
+	data, err := a.restartRecordData(listCtx)
+
+	cancel()
+
+	if err != nil {
+		return 0, err
+	}
+
+	var gone []string
+
+	for key, value := range data {
+		owner, ok := parseRecordOwner(key, value)
+		if !ok {
+			continue
+		}
+
+		uid, exists, err := a.getOwnerUID(ctx, owner)
+		if err != nil {
+			a.logger.WarnContext(ctx, "check restart record owner failed, keeping it", "key", key, "reason", err)
+
+			continue
+		}
+
+		// A workload recreated under the same name does not inherit the state of its predecessor.
+		if !exists || (owner.uid != "" && owner.uid != uid) {
+			gone = append(gone, key)
+		}
+	}
+
+	if len(gone) == 0 {
+		return 0, nil
+	}
+
+	patchCtx, cancel := a.callContext(ctx)
+	defer cancel()
+
+	if err := a.deleteConfigMapKeys(patchCtx, a.restartRecordNamespace, a.restartRecordName, gone...); err != nil {
+		return 0, fmt.Errorf("prune restart records: %w", err)
+	}
+
+	return len(gone), nil
+}
+
+// getOwnerUID returns the UID of the workload and whether it exists. Kinds the controller cannot
+// look up, e.g. those of custom resources, are reported as existing.
+func (a *adapter) getOwnerUID(ctx context.Context, owner recordOwner) (string, bool, error) {
+	ctx, cancel := a.callContext(ctx)
+	defer cancel()
+
+	var (
+		object metav1.Object
+		err    error
+	)
+
+	options := metav1.GetOptions{}
+
+	switch strings.ToLower(owner.Kind) {
+	case "pod":
+		object, err = a.clientset.CoreV1().Pods(owner.Namespace).Get(ctx, owner.Name, options)
+	case "replicaset":
+		object, err = a.clientset.AppsV1().ReplicaSets(owner.Namespace).Get(ctx, owner.Name, options)
+	case "statefulset":
+		object, err = a.clientset.AppsV1().StatefulSets(owner.Namespace).Get(ctx, owner.Name, options)
+	case "daemonset":
+		object, err = a.clientset.AppsV1().DaemonSets(owner.Namespace).Get(ctx, owner.Name, options)
+	case "job":
+		object, err = a.clientset.BatchV1().Jobs(owner.Namespace).Get(ctx, owner.Name, options)
+	default:
+		return owner.uid, true, nil
+	}
+
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", false, nil
+		}
+
+		return "", false, fmt.Errorf("get %s: %w", owner.Kind, err)
+	}
+
+	return string(object.GetUID()), true, nil
+}
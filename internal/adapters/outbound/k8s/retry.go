@@ -0,0 +1,90 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/skillcoder/preoomkiller-controller/internal/infra/metrics"
+)
+
+// _retryBackoff bounds retries of transient API errors: up to 3 retries, 200ms, 400ms and 800ms apart.
+var _retryBackoff = wait.Backoff{
+	Duration: 200 * time.Millisecond,
+	Factor:   2,
+	Jitter:   0.1,
+	Steps:    3,
+}
+
+// withRetry calls fn and retries it with exponential backoff while it fails with a transient error.
+// Each attempt gets its own call timeout. The last error is returned when the retries are exhausted or
+// ctx is done.
+func (a *adapter) withRetry(ctx context.Context, operation string, fn func(ctx context.Context) error) error {
+	backoff := _retryBackoff
+
+	for {
+		err := a.attempt(ctx, fn)
+		if err == nil || !isTransient(err) {
+			return err
+		}
+
+		if backoff.Steps == 0 {
+			metrics.RecordK8sAPIRetriesExhausted(a.cluster, operation)
+
+			return err
+		}
+
+		delay := backoff.Step()
+
+		metrics.RecordK8sAPIRetry(a.cluster, operation)
+		a.logger.DebugContext(ctx, "transient kubernetes api error, retrying",
+			"operation", operation,
+			"delay", delay.String(),
+			"reason", err,
+		)
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+	}
+}
+
+// isTransient reports whether a failed request may succeed when retried: timeouts, 5xx responses,
+// conflicts and dropped connections.
+func isTransient(err error) bool {
+	if apierrors.IsServerTimeout(err) ||
+		apierrors.IsTimeout(err) ||
+		apierrors.IsConflict(err) ||
+		apierrors.IsInternalError(err) ||
+		apierrors.IsServiceUnavailable(err) ||
+		apierrors.IsUnexpectedServerError(err) {
+		return true
+	}
+
+	var status apierrors.APIStatus
+	if errors.As(err, &status) && status.Status().Code >= http.StatusInternalServerError {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	return utilnet.IsConnectionReset(err) || utilnet.IsProbableEOF(err)
+}
+
+func (a *adapter) attempt(ctx context.Context, fn func(ctx context.Context) error) error {
+	ctx, cancel := a.callContext(ctx)
+	defer cancel()
+
+	return fn(ctx)
+}
@@ -0,0 +1,134 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/skillcoder/preoomkiller-controller/internal/logic/controller"
+)
+
+var vpaResource = schema.GroupVersionResource{
+	Group:    "autoscaling.k8s.io",
+	Version:  "v1",
+	Resource: "verticalpodautoscalers",
+}
+
+const (
+	replicaSetKind = "ReplicaSet"
+	deploymentKind = "Deployment"
+	// vpaDefaultUpdateMode applies when a VPA does not set spec.updatePolicy.updateMode.
+	vpaDefaultUpdateMode = "Auto"
+)
+
+// ListVPARecommendationsQuery lists the VerticalPodAutoscalers of the namespace and keys them by the
+// "kind/name" of their targetRef. VPAs target Deployments while pods are owned by ReplicaSets, so the
+// ReplicaSets of target Deployments are listed too and keyed to their Deployment's VPA.
+func (a *adapter) ListVPARecommendationsQuery(
+	ctx context.Context,
+	namespace string,
+) (map[string]*controller.VPARecommendation, error) {
+	ctx, cancel := a.callContext(ctx)
+	defer cancel()
+
+	if a.dynamicClient == nil {
+		return nil, errVPANotConfigured
+	}
+
+	list, err := a.dynamicClient.Resource(vpaResource).Namespace(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("list vertical pod autoscalers: %w", err)
+	}
+
+	vpas := make(map[string]*controller.VPARecommendation, len(list.Items))
+	deployments := make(map[string]*controller.VPARecommendation)
+
+	for i := range list.Items {
+		item := &list.Items[i]
+
+		targetKind, _, _ := unstructured.NestedString(item.Object, "spec", "targetRef", "kind")
+		targetName, _, _ := unstructured.NestedString(item.Object, "spec", "targetRef", "name")
+
+		vpa, err := toDomainVPARecommendation(item)
+		if err != nil {
+			a.logger.WarnContext(ctx, "invalid vertical pod autoscaler, ignoring",
+				"namespace", namespace,
+				"reason", err,
+			)
+
+			continue
+		}
+
+		vpas[targetKind+"/"+targetName] = vpa
+
+		if targetKind == deploymentKind {
+			deployments[targetName] = vpa
+		}
+	}
+
+	if len(deployments) == 0 {
+		return vpas, nil
+	}
+
+	replicaSets, err := a.clientset.AppsV1().ReplicaSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("list replicasets: %w", err)
+	}
+
+	for i := range replicaSets.Items {
+		rs := &replicaSets.Items[i]
+
+		ref := metav1.GetControllerOf(rs)
+		if ref == nil || ref.Kind != deploymentKind {
+			continue
+		}
+
+		if vpa, ok := deployments[ref.Name]; ok {
+			vpas[replicaSetKind+"/"+rs.Name] = vpa
+		}
+	}
+
+	return vpas, nil
+}
+
+func toDomainVPARecommendation(vpa *unstructured.Unstructured) (*controller.VPARecommendation, error) {
+	out := &controller.VPARecommendation{
+		Name:       vpa.GetName(),
+		UpdateMode: vpaDefaultUpdateMode,
+	}
+
+	if mode, ok, _ := unstructured.NestedString(vpa.Object, "spec", "updatePolicy", "updateMode"); ok && mode != "" {
+		out.UpdateMode = mode
+	}
+
+	containers, _, _ := unstructured.NestedSlice(vpa.Object, "status", "recommendation", "containerRecommendations")
+
+	for _, item := range containers {
+		container, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		memory, ok, _ := unstructured.NestedString(container, "upperBound", "memory")
+		if !ok {
+			continue
+		}
+
+		quantity, err := resource.ParseQuantity(memory)
+		if err != nil {
+			return nil, fmt.Errorf("parse vpa %s memory upper bound: %w", vpa.GetName(), err)
+		}
+
+		if out.MemoryUpperBound == nil {
+			out.MemoryUpperBound = resource.NewQuantity(0, resource.BinarySI)
+		}
+
+		out.MemoryUpperBound.Add(quantity)
+	}
+
+	return out, nil
+}
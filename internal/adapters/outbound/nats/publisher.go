@@ -0,0 +1,163 @@
+// Package nats streams eviction decisions and missed OOMs to a NATS server for data pipelines and
+// long-term analytics.
+package nats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	natsgo "github.com/nats-io/nats.go"
+
+	"github.com/skillcoder/preoomkiller-controller/internal/adapters/outbound/webhook"
+	"github.com/skillcoder/preoomkiller-controller/internal/infra/shutdown"
+	"github.com/skillcoder/preoomkiller-controller/internal/logic/controller"
+)
+
+// connectionName identifies the controller in the NATS server monitoring.
+const connectionName = "preoomkiller-controller"
+
+// Subject suffixes of the published events.
+const (
+	subjectDecision  = "decision"
+	subjectMissedOOM = "missed_oom"
+)
+
+// Publisher publishes every eviction decision to "<subject>.decision.<outcome>" and every missed OOM
+// to "<subject>.missed_oom", encoded as the JSON payload of the webhook notifier. It implements
+// shutdown.Shutdowner so that buffered messages are flushed on exit.
+type Publisher struct {
+	logger  *slog.Logger
+	conn    *natsgo.Conn
+	subject string
+	// closed is closed once the connection is closed.
+	closed chan struct{}
+	// connectOpts are the connection options set by Option, authentication in particular.
+	connectOpts []natsgo.Option
+}
+
+// Option configures optional publisher behavior.
+type Option func(*Publisher)
+
+// WithCredsFile authenticates with a user credentials file (JWT and NKey seed).
+func WithCredsFile(path string) Option {
+	return func(p *Publisher) {
+		p.connectOpts = append(p.connectOpts, natsgo.UserCredentials(path))
+	}
+}
+
+// WithToken authenticates with a token.
+func WithToken(token string) Option {
+	return func(p *Publisher) {
+		p.connectOpts = append(p.connectOpts, natsgo.Token(token))
+	}
+}
+
+// WithUserInfo authenticates with a user name and password.
+func WithUserInfo(user, password string) Option {
+	return func(p *Publisher) {
+		p.connectOpts = append(p.connectOpts, natsgo.UserInfo(user, password))
+	}
+}
+
+// New connects to the NATS servers at url (comma-separated). An unreachable server does not fail
+// startup: the connection is retried in the background and messages are buffered meanwhile.
+// timeout bounds the connection attempts.
+func New(logger *slog.Logger, url, subject string, timeout time.Duration, opts ...Option) (*Publisher, error) {
+	p := &Publisher{
+		logger:  logger,
+		subject: subject,
+		closed:  make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	connectOpts := append([]natsgo.Option{
+		natsgo.Name(connectionName),
+		natsgo.Timeout(timeout),
+		natsgo.RetryOnFailedConnect(true),
+		natsgo.MaxReconnects(-1),
+		natsgo.DisconnectErrHandler(p.disconnected),
+		natsgo.ReconnectHandler(p.reconnected),
+		natsgo.ClosedHandler(func(*natsgo.Conn) { close(p.closed) }),
+	}, p.connectOpts...)
+
+	conn, err := natsgo.Connect(url, connectOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("connect to nats: %w", err)
+	}
+
+	p.conn = conn
+
+	return p, nil
+}
+
+func (p *Publisher) Name() string {
+	return "nats"
+}
+
+func (p *Publisher) NotifyCommand(_ context.Context, decision controller.EvictionDecision) error {
+	body, err := webhook.MarshalDecision(decision)
+	if err != nil {
+		return fmt.Errorf("encode decision: %w", err)
+	}
+
+	return p.publish(p.subject+"."+subjectDecision+"."+string(decision.Outcome), body)
+}
+
+func (p *Publisher) NotifyMissedOOMCommand(_ context.Context, missed controller.MissedOOM) error {
+	body, err := webhook.MarshalMissedOOM(missed)
+	if err != nil {
+		return fmt.Errorf("encode missed oom: %w", err)
+	}
+
+	return p.publish(p.subject+"."+subjectMissedOOM, body)
+}
+
+// publish hands the message to the connection, which sends it asynchronously.
+func (p *Publisher) publish(subject string, body []byte) error {
+	if err := p.conn.Publish(subject, body); err != nil {
+		return fmt.Errorf("publish to %s: %w", subject, err)
+	}
+
+	return nil
+}
+
+// ShutdownGroup returns shutdown.GroupSinks: the connection is drained after the controllers
+// published their last decisions.
+func (p *Publisher) ShutdownGroup() shutdown.Group {
+	return shutdown.GroupSinks
+}
+
+// Shutdown flushes the buffered messages and closes the connection, or closes it right away when ctx
+// is done first. It is safe to call more than once.
+func (p *Publisher) Shutdown(ctx context.Context) error {
+	if err := p.conn.Drain(); err != nil && !errors.Is(err, natsgo.ErrConnectionClosed) {
+		p.conn.Close()
+
+		return fmt.Errorf("drain nats connection: %w", err)
+	}
+
+	select {
+	case <-p.closed:
+		return nil
+	case <-ctx.Done():
+		p.conn.Close()
+
+		return fmt.Errorf("drain nats connection: %w", ctx.Err())
+	}
+}
+
+func (p *Publisher) disconnected(_ *natsgo.Conn, err error) {
+	if err != nil {
+		p.logger.Warn("nats connection lost", "reason", err)
+	}
+}
+
+func (p *Publisher) reconnected(conn *natsgo.Conn) {
+	p.logger.Info("nats connection restored", "server", conn.ConnectedUrlRedacted())
+}
@@ -0,0 +1,294 @@
+// Package prometheus evaluates PromQL conditions and optionally provides pod memory usage from a
+// Prometheus server instead of metrics.k8s.io.
+package prometheus
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"text/template"
+	"time"
+
+	promapi "github.com/prometheus/client_golang/api"
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/skillcoder/preoomkiller-controller/internal/logic/controller"
+)
+
+// DefaultQuery is the default query template. It must return samples with "namespace" and "pod"
+// labels, optionally split by a "container" label; {{.Namespace}} and {{.Pod}} expand to regular
+// expressions and {{.Metric}} to the cAdvisor series of the selected memory metric.
+const DefaultQuery = `sum by (namespace, pod, container) ({{.Metric}}{` +
+	`container!="", container!="POD", namespace=~"{{.Namespace}}", pod=~"{{.Pod}}"})`
+
+// Memory metrics selectable with WithMemoryMetric.
+const (
+	MemoryMetricWorkingSet = "working_set"
+	MemoryMetricRSS        = "rss"
+	MemoryMetricUsage      = "usage"
+)
+
+// memoryMetricSeries maps memory metrics to their cAdvisor series.
+var memoryMetricSeries = map[string]string{
+	MemoryMetricWorkingSet: "container_memory_working_set_bytes",
+	MemoryMetricRSS:        "container_memory_rss",
+	MemoryMetricUsage:      "container_memory_usage_bytes",
+}
+
+// _anyLabelValue matches every non-empty label value.
+const _anyLabelValue = ".+"
+
+// queryParams are the template parameters of the memory usage query.
+type queryParams struct {
+	Namespace string
+	Pod       string
+	Metric    string
+	// Cluster is the cluster name in multi-cluster mode, for Prometheus servers shared by several clusters.
+	Cluster string
+}
+
+// adapter serves PromQL conditions (and pod metrics when enabled) from Prometheus and delegates
+// everything else to the wrapped Repository.
+type adapter struct {
+	controller.Repository
+
+	logger *slog.Logger
+	api    promv1.API
+	// query is the memory usage query; nil keeps pod metrics on the wrapped Repository.
+	query         *template.Template
+	memoryUsage   bool
+	queryTemplate string
+	memoryMetric  string
+	// metricSeries is the cAdvisor series {{.Metric}} expands to.
+	metricSeries string
+	queryTimeout time.Duration
+	cluster      string
+	// roundTripper sends the HTTP requests of the Prometheus client.
+	roundTripper http.RoundTripper
+}
+
+// Option configures optional adapter behavior.
+type Option func(*adapter)
+
+// WithMemoryUsage reads pod memory usage from Prometheus using queryTemplate (DefaultQuery when empty).
+func WithMemoryUsage(queryTemplate string) Option {
+	return func(a *adapter) {
+		a.memoryUsage = true
+		a.queryTemplate = queryTemplate
+	}
+}
+
+// WithMemoryMetric selects the memory metric of the usage query: working_set (default), rss or usage.
+func WithMemoryMetric(metric string) Option {
+	return func(a *adapter) {
+		a.memoryMetric = metric
+	}
+}
+
+// WithQueryTimeout bounds each Prometheus query by timeout.
+func WithQueryTimeout(timeout time.Duration) Option {
+	return func(a *adapter) {
+		a.queryTimeout = timeout
+	}
+}
+
+// WithCluster sets the cluster name {{.Cluster}} expands to in the memory usage query.
+func WithCluster(name string) Option {
+	return func(a *adapter) {
+		a.cluster = name
+		a.logger = a.logger.With("cluster", name)
+	}
+}
+
+// WithRoundTripper sends the Prometheus requests through rt instead of promapi.DefaultRoundTripper.
+func WithRoundTripper(rt http.RoundTripper) Option {
+	return func(a *adapter) {
+		a.roundTripper = rt
+	}
+}
+
+// New wraps repo so that PromQL conditions are evaluated by the Prometheus server at address.
+func New(
+	logger *slog.Logger,
+	repo controller.Repository,
+	address string,
+	opts ...Option,
+) (controller.Repository, error) {
+	a := &adapter{
+		Repository:   repo,
+		logger:       logger,
+		memoryMetric: MemoryMetricWorkingSet,
+		roundTripper: promapi.DefaultRoundTripper,
+	}
+
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	client, err := promapi.NewClient(promapi.Config{Address: address, RoundTripper: a.roundTripper})
+	if err != nil {
+		return nil, fmt.Errorf("create prometheus client: %w", err)
+	}
+
+	a.api = promv1.NewAPI(client)
+
+	if a.memoryUsage {
+		var ok bool
+		if a.metricSeries, ok = memoryMetricSeries[a.memoryMetric]; !ok {
+			return nil, fmt.Errorf("%w: %q", errUnknownMemoryMetric, a.memoryMetric)
+		}
+
+		if a.queryTemplate == "" {
+			a.queryTemplate = DefaultQuery
+		}
+
+		a.query, err = template.New("query").Option("missingkey=error").Parse(a.queryTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("parse prometheus query template: %w", err)
+		}
+	}
+
+	return a, nil
+}
+
+func (a *adapter) GetPodMetricsQuery(
+	ctx context.Context,
+	namespace,
+	name string,
+) (*controller.PodMetrics, error) {
+	if a.query == nil {
+		return a.Repository.GetPodMetricsQuery(ctx, namespace, name)
+	}
+
+	usage, err := a.queryMemoryUsage(ctx, queryParams{
+		Namespace: regexp.QuoteMeta(namespace),
+		Pod:       regexp.QuoteMeta(name),
+		Metric:    a.metricSeries,
+		Cluster:   a.cluster,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get pod metrics: %w", err)
+	}
+
+	podMetrics, ok := usage[namespace+"/"+name]
+	if !ok {
+		return nil, fmt.Errorf("get pod metrics: %w", errMetricsNotFound)
+	}
+
+	return podMetrics, nil
+}
+
+// ListPodMetricsQuery returns the usage of all pods in the namespace (all namespaces when empty).
+// cAdvisor series carry no pod labels, so labelSelector is not applied; callers look pods up by key.
+func (a *adapter) ListPodMetricsQuery(
+	ctx context.Context,
+	namespace,
+	labelSelector string,
+) (map[string]*controller.PodMetrics, error) {
+	if a.query == nil {
+		return a.Repository.ListPodMetricsQuery(ctx, namespace, labelSelector)
+	}
+
+	params := queryParams{Namespace: _anyLabelValue, Pod: _anyLabelValue, Metric: a.metricSeries, Cluster: a.cluster}
+	if namespace != "" {
+		params.Namespace = regexp.QuoteMeta(namespace)
+	}
+
+	usage, err := a.queryMemoryUsage(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("list pod metrics: %w", err)
+	}
+
+	return usage, nil
+}
+
+// queryMemoryUsage runs the query and returns the memory usage keyed by "namespace/pod". Samples of
+// the same pod are summed; samples with a "container" label are also reported per container.
+func (a *adapter) queryMemoryUsage(ctx context.Context, params queryParams) (map[string]*controller.PodMetrics, error) {
+	var query bytes.Buffer
+	if err := a.query.Execute(&query, params); err != nil {
+		return nil, fmt.Errorf("render query: %w", err)
+	}
+
+	value, warnings, err := a.instantQuery(ctx, query.String())
+	if err != nil {
+		return nil, fmt.Errorf("query prometheus: %w", err)
+	}
+
+	for _, warning := range warnings {
+		a.logger.WarnContext(ctx, "prometheus query warning", "warning", warning)
+	}
+
+	vector, ok := value.(model.Vector)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errUnexpectedResultType, value.Type())
+	}
+
+	usage := make(map[string]*controller.PodMetrics, len(vector))
+
+	for _, sample := range vector {
+		namespace := string(sample.Metric["namespace"])
+		pod := string(sample.Metric["pod"])
+
+		if namespace == "" || pod == "" {
+			return nil, fmt.Errorf("%w: %s", errMissingPodLabels, sample.Metric)
+		}
+
+		key := namespace + "/" + pod
+
+		podMetrics, ok := usage[key]
+		if !ok {
+			podMetrics = &controller.PodMetrics{MemoryUsage: resource.NewQuantity(0, resource.BinarySI)}
+			usage[key] = podMetrics
+		}
+
+		memoryUsage := resource.NewQuantity(int64(sample.Value), resource.BinarySI)
+		podMetrics.MemoryUsage.Add(*memoryUsage)
+
+		if container := string(sample.Metric["container"]); container != "" {
+			podMetrics.Containers = append(podMetrics.Containers, controller.ContainerMetrics{
+				Name:        container,
+				MemoryUsage: memoryUsage,
+			})
+		}
+	}
+
+	return usage, nil
+}
+
+func (a *adapter) EvaluatePromQLQuery(ctx context.Context, expr string) (bool, error) {
+	value, warnings, err := a.instantQuery(ctx, expr)
+	if err != nil {
+		return false, fmt.Errorf("query prometheus: %w", err)
+	}
+
+	for _, warning := range warnings {
+		a.logger.WarnContext(ctx, "prometheus query warning", "warning", warning)
+	}
+
+	switch v := value.(type) {
+	case model.Vector:
+		return len(v) > 0, nil
+	case *model.Scalar:
+		return v.Value != 0, nil
+	default:
+		return false, fmt.Errorf("%w: %s", errUnexpectedResultType, value.Type())
+	}
+}
+
+// instantQuery runs an instant query, bounded by the query timeout when set.
+func (a *adapter) instantQuery(ctx context.Context, query string) (model.Value, promv1.Warnings, error) {
+	if a.queryTimeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, a.queryTimeout)
+		defer cancel()
+	}
+
+	return a.api.Query(ctx, query, time.Now())
+}
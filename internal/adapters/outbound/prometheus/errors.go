@@ -0,0 +1,20 @@
+package prometheus
+
+import "errors"
+
+var (
+	errUnexpectedResultType = errors.New("unexpected prometheus result type")
+	errMissingPodLabels     = errors.New("prometheus sample without namespace and pod labels")
+	errUnknownMemoryMetric  = errors.New("unknown memory metric")
+)
+
+// MetricsNotFoundError represents a pod without memory usage samples; it is not an error.
+type MetricsNotFoundError struct{}
+
+func (e *MetricsNotFoundError) Error() string {
+	return "pod metrics not found"
+}
+
+func (e *MetricsNotFoundError) IsNotFound() {}
+
+var errMetricsNotFound = &MetricsNotFoundError{}
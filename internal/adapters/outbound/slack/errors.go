@@ -0,0 +1,5 @@
+package slack
+
+import "errors"
+
+var errUnexpectedStatus = errors.New("unexpected slack response status")
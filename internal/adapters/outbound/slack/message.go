@@ -0,0 +1,82 @@
+package slack
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/skillcoder/preoomkiller-controller/internal/logic/controller"
+)
+
+// Message kinds.
+const (
+	KindEvicted   = "evicted"
+	KindMissedOOM = "missed_oom"
+)
+
+// DefaultTemplate is the default text/template of Slack messages, executed with a Message.
+const DefaultTemplate = `{{if eq .Kind "missed_oom" -}}
+:boom: Pod *{{.Namespace}}/{{.Pod}}* was OOMKilled at {{.Time}} before it was evicted
+{{- if .MemoryThreshold}} (memory threshold {{.MemoryThreshold}}){{end}}.
+{{- else -}}
+:recycle: Evicted pod *{{.Namespace}}/{{.Pod}}* ({{.Trigger}}){{if .Detail}}: {{.Detail}}{{end}}.
+{{- end}}
+{{- if .Owner}} Owner: {{.Owner}}.{{end}}
+{{- if .Cluster}} Cluster: {{.Cluster}}.{{end}}`
+
+// Message is the data of the message template.
+type Message struct {
+	// Kind is KindEvicted or KindMissedOOM.
+	Kind string
+	// Cluster is empty in single-cluster mode.
+	Cluster   string
+	Namespace string
+	Pod       string
+	// Owner is "Kind/Name" of the controlling owner; empty without one.
+	Owner string
+	// Time is the eviction or OOMKilled time in RFC 3339.
+	Time string
+	// Trigger and Detail describe an eviction: "threshold", "promql" or "schedule", and what triggered it.
+	Trigger string
+	Detail  string
+	// MemoryThreshold is the pod's memory threshold annotation; empty without one.
+	MemoryThreshold string
+}
+
+func newEvictedMessage(decision controller.EvictionDecision) Message {
+	detail := decision.Detail
+	if decision.Trigger == controller.TriggerSchedule {
+		detail = fmt.Sprintf("restart schedule %q (tz %s)", decision.RestartSchedule, decision.TZ)
+	}
+
+	return Message{
+		Kind:            KindEvicted,
+		Cluster:         decision.Cluster,
+		Namespace:       decision.Namespace,
+		Pod:             decision.Pod,
+		Owner:           ownerName(decision.Owner),
+		Time:            decision.Time.UTC().Format(time.RFC3339),
+		Trigger:         string(decision.Trigger),
+		Detail:          detail,
+		MemoryThreshold: decision.MemoryThresholdAnnotation,
+	}
+}
+
+func newMissedOOMMessage(missed controller.MissedOOM) Message {
+	return Message{
+		Kind:            KindMissedOOM,
+		Cluster:         missed.Cluster,
+		Namespace:       missed.Namespace,
+		Pod:             missed.Pod,
+		Owner:           ownerName(missed.Owner),
+		Time:            missed.OOMKilledAt.UTC().Format(time.RFC3339),
+		MemoryThreshold: missed.MemoryThresholdAnnotation,
+	}
+}
+
+func ownerName(owner *controller.Owner) string {
+	if owner == nil {
+		return ""
+	}
+
+	return owner.Kind + "/" + owner.Name
+}
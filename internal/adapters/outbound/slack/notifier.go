@@ -0,0 +1,143 @@
+// Package slack announces evictions and missed OOMs in Slack through incoming webhooks.
+package slack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"text/template"
+	"time"
+
+	"github.com/skillcoder/preoomkiller-controller/internal/logic/controller"
+)
+
+// notifier posts evictions and missed OOMs to the incoming webhook of the pod's namespace.
+// Skipped and deferred decisions are not announced.
+type notifier struct {
+	client     *http.Client
+	webhookURL string
+	// namespaceWebhooks maps namespaces to the webhooks that receive their messages instead of webhookURL.
+	namespaceWebhooks map[string]string
+	channel           string
+	templateText      string
+	template          *template.Template
+}
+
+// webhookPayload is the JSON body of an incoming webhook request.
+type webhookPayload struct {
+	Text    string `json:"text"`
+	Channel string `json:"channel,omitempty"`
+}
+
+// Option configures optional notifier behavior.
+type Option func(*notifier)
+
+// WithChannel overrides the channel of the webhooks; only honored by legacy incoming webhooks.
+func WithChannel(channel string) Option {
+	return func(n *notifier) {
+		n.channel = channel
+	}
+}
+
+// WithNamespaceWebhooks routes the messages about pods of a namespace to its webhook.
+func WithNamespaceWebhooks(webhooks map[string]string) Option {
+	return func(n *notifier) {
+		n.namespaceWebhooks = webhooks
+	}
+}
+
+// WithTemplate renders messages with the text/template text instead of DefaultTemplate.
+func WithTemplate(text string) Option {
+	return func(n *notifier) {
+		n.templateText = text
+	}
+}
+
+// WithTransport sends the requests through rt instead of http.DefaultTransport.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(n *notifier) {
+		n.client.Transport = rt
+	}
+}
+
+// New returns a notifier posting to webhookURL, or to the namespace's webhook when routed. Without
+// webhookURL, only routed namespaces are announced. A request is aborted after timeout.
+func New(webhookURL string, timeout time.Duration, opts ...Option) (controller.Notifier, error) {
+	n := &notifier{
+		client:       &http.Client{Timeout: timeout},
+		webhookURL:   webhookURL,
+		templateText: DefaultTemplate,
+	}
+
+	for _, opt := range opts {
+		opt(n)
+	}
+
+	var err error
+
+	n.template, err = template.New("slack").Option("missingkey=error").Parse(n.templateText)
+	if err != nil {
+		return nil, fmt.Errorf("parse slack message template: %w", err)
+	}
+
+	return n, nil
+}
+
+func (n *notifier) Name() string {
+	return "slack"
+}
+
+func (n *notifier) NotifyCommand(ctx context.Context, decision controller.EvictionDecision) error {
+	if decision.Outcome != controller.OutcomeEvicted {
+		return nil
+	}
+
+	return n.post(ctx, newEvictedMessage(decision))
+}
+
+func (n *notifier) NotifyMissedOOMCommand(ctx context.Context, missed controller.MissedOOM) error {
+	return n.post(ctx, newMissedOOMMessage(missed))
+}
+
+// post renders the message and sends it to the webhook of its namespace.
+func (n *notifier) post(ctx context.Context, message Message) error {
+	url, ok := n.namespaceWebhooks[message.Namespace]
+	if !ok {
+		url = n.webhookURL
+	}
+
+	if url == "" {
+		return nil
+	}
+
+	var text bytes.Buffer
+	if err := n.template.Execute(&text, message); err != nil {
+		return fmt.Errorf("render slack message: %w", err)
+	}
+
+	body, err := json.Marshal(webhookPayload{Text: text.String(), Channel: n.channel})
+	if err != nil {
+		return fmt.Errorf("marshal slack payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create slack request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("send slack request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: %s", errUnexpectedStatus, resp.Status)
+	}
+
+	return nil
+}
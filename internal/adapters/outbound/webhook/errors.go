@@ -0,0 +1,5 @@
+package webhook
+
+import "errors"
+
+var errUnexpectedStatus = errors.New("unexpected webhook response status")
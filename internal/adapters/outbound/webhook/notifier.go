@@ -0,0 +1,82 @@
+// Package webhook sends eviction decisions to an HTTP endpoint as JSON.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/skillcoder/preoomkiller-controller/internal/logic/controller"
+)
+
+// notifier POSTs every eviction decision and missed OOM as a JSON payload to a URL.
+type notifier struct {
+	client *http.Client
+	url    string
+}
+
+// Option configures optional notifier behavior.
+type Option func(*notifier)
+
+// WithTransport sends the requests through rt instead of http.DefaultTransport.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(n *notifier) {
+		n.client.Transport = rt
+	}
+}
+
+// New returns a notifier POSTing each decision to url; a request is aborted after timeout.
+func New(url string, timeout time.Duration, opts ...Option) controller.Notifier {
+	n := &notifier{
+		client: &http.Client{Timeout: timeout},
+		url:    url,
+	}
+
+	for _, opt := range opts {
+		opt(n)
+	}
+
+	return n
+}
+
+func (n *notifier) Name() string {
+	return "webhook"
+}
+
+func (n *notifier) NotifyCommand(ctx context.Context, decision controller.EvictionDecision) error {
+	return n.post(ctx, newDecisionPayload(decision))
+}
+
+func (n *notifier) NotifyMissedOOMCommand(ctx context.Context, missed controller.MissedOOM) error {
+	return n.post(ctx, newMissedOOMPayload(missed))
+}
+
+// post sends the payload and fails on a non-2xx response.
+func (n *notifier) post(ctx context.Context, p payload) error {
+	body, err := json.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("marshal webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create webhook request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("send webhook request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("%w: %s", errUnexpectedStatus, resp.Status)
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/skillcoder/preoomkiller-controller/internal/logic/controller"
+)
+
+// Payload events.
+const (
+	eventDecision  = "decision"
+	eventMissedOOM = "missed_oom"
+)
+
+// payload is the JSON body of a webhook request.
+type payload struct {
+	// Event is eventDecision or eventMissedOOM.
+	Event     string    `json:"event"`
+	Time      time.Time `json:"time"`
+	Cluster   string    `json:"cluster,omitempty"`
+	Decision  string    `json:"decision,omitempty"`
+	Trigger   string    `json:"trigger,omitempty"`
+	Namespace string    `json:"namespace"`
+	Pod       string    `json:"pod"`
+	PodUID    string    `json:"podUid"`
+	Owner     *owner    `json:"owner,omitempty"`
+	// Reason explains a skipped or deferred decision.
+	Reason string `json:"reason,omitempty"`
+	// Detail describes what triggered a non-scheduled eviction.
+	Detail                    string     `json:"detail,omitempty"`
+	MemoryUsageBytes          *int64     `json:"memoryUsageBytes,omitempty"`
+	MemoryThresholdBytes      *int64     `json:"memoryThresholdBytes,omitempty"`
+	MemoryThresholdAnnotation string     `json:"memoryThresholdAnnotation,omitempty"`
+	PromQL                    string     `json:"promql,omitempty"`
+	RestartSchedule           string     `json:"restartSchedule,omitempty"`
+	TZ                        string     `json:"tz,omitempty"`
+	DueAt                     *time.Time `json:"dueAt,omitempty"`
+}
+
+// owner identifies the controlling owner of the pod.
+type owner struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+}
+
+// MarshalDecision encodes the decision as the JSON document POSTed by the webhook notifier, so that
+// other event sinks publish the same schema.
+func MarshalDecision(decision controller.EvictionDecision) ([]byte, error) {
+	body, err := json.Marshal(newDecisionPayload(decision))
+	if err != nil {
+		return nil, fmt.Errorf("marshal decision payload: %w", err)
+	}
+
+	return body, nil
+}
+
+// MarshalMissedOOM encodes the missed OOM as the JSON document POSTed by the webhook notifier.
+func MarshalMissedOOM(missed controller.MissedOOM) ([]byte, error) {
+	body, err := json.Marshal(newMissedOOMPayload(missed))
+	if err != nil {
+		return nil, fmt.Errorf("marshal missed oom payload: %w", err)
+	}
+
+	return body, nil
+}
+
+func newDecisionPayload(decision controller.EvictionDecision) payload {
+	p := payload{
+		Event:                     eventDecision,
+		Time:                      decision.Time,
+		Cluster:                   decision.Cluster,
+		Decision:                  string(decision.Outcome),
+		Trigger:                   string(decision.Trigger),
+		Namespace:                 decision.Namespace,
+		Pod:                       decision.Pod,
+		PodUID:                    decision.PodUID,
+		Reason:                    decision.Reason,
+		Detail:                    decision.Detail,
+		MemoryThresholdAnnotation: decision.MemoryThresholdAnnotation,
+		PromQL:                    decision.PromQL,
+		RestartSchedule:           decision.RestartSchedule,
+		TZ:                        decision.TZ,
+		DueAt:                     decision.DueAt,
+	}
+
+	p.Owner = newOwner(decision.Owner)
+
+	if decision.MemoryUsage != nil {
+		usage := decision.MemoryUsage.Value()
+		p.MemoryUsageBytes = &usage
+	}
+
+	if decision.MemoryThreshold != nil {
+		threshold := decision.MemoryThreshold.Value()
+		p.MemoryThresholdBytes = &threshold
+	}
+
+	return p
+}
+
+func newMissedOOMPayload(missed controller.MissedOOM) payload {
+	return payload{
+		Event:                     eventMissedOOM,
+		Time:                      missed.OOMKilledAt,
+		Cluster:                   missed.Cluster,
+		Namespace:                 missed.Namespace,
+		Pod:                       missed.Pod,
+		PodUID:                    missed.PodUID,
+		Owner:                     newOwner(missed.Owner),
+		MemoryThresholdAnnotation: missed.MemoryThresholdAnnotation,
+	}
+}
+
+func newOwner(o *controller.Owner) *owner {
+	if o == nil {
+		return nil
+	}
+
+	return &owner{Kind: o.Kind, Name: o.Name}
+}
@@ -2,30 +2,70 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"math"
+	"net/http"
+	"sync"
 	"sync/atomic"
+	"time"
 
+	promapi "github.com/prometheus/client_golang/api"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	metricsv "k8s.io/metrics/pkg/client/clientset/versioned"
 
+	"github.com/skillcoder/preoomkiller-controller/internal/adapters/outbound/alertmanager"
+	"github.com/skillcoder/preoomkiller-controller/internal/adapters/outbound/evictionhistory"
 	"github.com/skillcoder/preoomkiller-controller/internal/adapters/outbound/k8s"
+	"github.com/skillcoder/preoomkiller-controller/internal/adapters/outbound/nats"
+	"github.com/skillcoder/preoomkiller-controller/internal/adapters/outbound/prometheus"
+	"github.com/skillcoder/preoomkiller-controller/internal/adapters/outbound/slack"
+	"github.com/skillcoder/preoomkiller-controller/internal/adapters/outbound/webhook"
 	"github.com/skillcoder/preoomkiller-controller/internal/config"
 	"github.com/skillcoder/preoomkiller-controller/internal/httpserver"
+	"github.com/skillcoder/preoomkiller-controller/internal/infra/blackout"
 	"github.com/skillcoder/preoomkiller-controller/internal/infra/cronparser"
+	"github.com/skillcoder/preoomkiller-controller/internal/infra/logging"
+	"github.com/skillcoder/preoomkiller-controller/internal/infra/memguard"
+	"github.com/skillcoder/preoomkiller-controller/internal/infra/metrics"
 	"github.com/skillcoder/preoomkiller-controller/internal/infra/shutdown"
 	"github.com/skillcoder/preoomkiller-controller/internal/logic/controller"
 )
 
+const pushJobName = "preoomkiller-controller"
+
+// eventFlushTimeout bounds publishing the buffered events at the end of a single run.
+const eventFlushTimeout = 5 * time.Second
+
 type App struct {
 	logger        *slog.Logger
 	signalHandler signalHandler
 	appState      appstater
-	controller    appServer
+	controllers   []controllerServer
 	httpServer    appServer
 	metricsServer appServer
+	// grpcServer serves the gRPC admin API; nil when disabled.
+	grpcServer     appServer
+	pushgatewayURL string
+	// eventPublisher streams decisions to NATS; nil when disabled.
+	eventPublisher *nats.Publisher
+	// memoryGuard watches the memory of the controller itself; nil when disabled.
+	memoryGuard *memguard.Guard
+	// reloadMu serializes reloads and guards cfg.
+	reloadMu sync.Mutex
+	// cfg is the running configuration, updated by reloads.
+	cfg *config.Config
+	// rateLimiters limit the Kubernetes API requests of each cluster.
+	rateLimiters []*k8s.RateLimiter
+	// metricsAPIPingers check the metrics API of each cluster; empty with the Prometheus metrics source.
+	metricsAPIPingers []*k8s.MetricsAPIPinger
 }
 
 // New creates a new application instance with all dependencies wired.
@@ -34,37 +74,187 @@ func New(
 	cfg *config.Config,
 	appState appstater,
 ) (*App, error) {
-	// Create K8s config
-	kubeConfig, err := clientcmd.BuildConfigFromFlags(
-		cfg.KubeMaster,
-		cfg.KubeConfig,
-	)
+	// One controller per cluster; the empty name is the single cluster of the default context.
+	clusters := cfg.Contexts
+	if len(clusters) == 0 {
+		clusters = []string{""}
+	}
+
+	metrics.RecordConfigInfo(configInfo(cfg, len(clusters)))
+
+	sharedOpts, err := sharedControllerOptions(cfg)
 	if err != nil {
-		return nil, fmt.Errorf("build k8s config: %w", err)
+		return nil, err
 	}
 
-	// Create K8s clientset
-	clientset, err := kubernetes.NewForConfig(kubeConfig)
+	var eventPublisher *nats.Publisher
+
+	if cfg.NATSURL != "" {
+		eventPublisher, err = nats.New(logger, cfg.NATSURL, cfg.NATSSubject, cfg.WebhookTimeout, natsOptions(cfg)...)
+		if err != nil {
+			return nil, fmt.Errorf("create nats publisher: %w", err)
+		}
+
+		// Shut down with the sinks, after the controllers published their last decisions.
+		if err := appState.RegisterShutdowner(eventPublisher); err != nil {
+			return nil, fmt.Errorf("register nats shutdowner: %w", err)
+		}
+
+		sharedOpts = append(sharedOpts, controller.WithNotifier(eventPublisher))
+	}
+
+	history, err := evictionhistory.New(cfg.EvictionHistorySize, cfg.EvictionHistoryFile)
 	if err != nil {
-		return nil, fmt.Errorf("create clientset: %w", err)
+		return nil, fmt.Errorf("create eviction history: %w", err)
 	}
 
-	// Create metrics clientset
-	metricsClientset, err := metricsv.NewForConfig(kubeConfig)
+	sharedOpts = append(sharedOpts, controller.WithNotifier(history))
+
+	controllers := make([]controllerServer, 0, len(clusters))
+	rateLimiters := make([]*k8s.RateLimiter, 0, len(clusters))
+
+	var metricsAPIPingers []*k8s.MetricsAPIPinger
+
+	for _, cluster := range clusters {
+		rateLimiter := k8s.NewRateLimiter(cfg.KubeQPS, cfg.KubeBurst)
+
+		controllerService, metricsAPIPinger, err := newController(logger, cfg, cluster, rateLimiter, sharedOpts...)
+		if err != nil {
+			return nil, err
+		}
+
+		controllers = append(controllers, controllerService)
+		rateLimiters = append(rateLimiters, rateLimiter)
+
+		if metricsAPIPinger != nil {
+			metricsAPIPingers = append(metricsAPIPingers, metricsAPIPinger)
+		}
+	}
+
+	a := &App{
+		controllers:       controllers,
+		appState:          appState,
+		logger:            logger,
+		pushgatewayURL:    cfg.PushgatewayURL,
+		eventPublisher:    eventPublisher,
+		cfg:               cfg,
+		rateLimiters:      rateLimiters,
+		metricsAPIPingers: metricsAPIPingers,
+	}
+
+	// Create HTTP server
+	servedControllers := make([]httpserver.Controller, 0, len(controllers))
+	for _, c := range controllers {
+		servedControllers = append(servedControllers, c)
+	}
+
+	httpOpts := []httpserver.Option{
+		httpserver.WithConfig(a.effectiveConfig),
+		httpserver.WithEvictionHistory(history),
+		httpserver.WithControllers(servedControllers...),
+		httpserver.WithAdminToken(cfg.AdminToken),
+		httpserver.WithReloader(a),
+	}
+
+	if cfg.DashboardEnabled {
+		httpOpts = append(httpOpts, httpserver.WithDashboard())
+	}
+
+	if cfg.AdminTokenReview {
+		reviewer, err := newAccessReviewer(cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		httpOpts = append(httpOpts, httpserver.WithAccessReviewer(reviewer))
+	}
+
+	var metricsOpts []httpserver.MetricsOption
+
+	if cfg.PprofEnabled {
+		metricsOpts = append(metricsOpts, httpserver.WithPprof())
+	}
+
+	if cfg.TLSCertFile != "" {
+		tlsFiles, err := httpserver.NewTLS(logger, cfg.TLSCertFile, cfg.TLSKeyFile, cfg.TLSClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("load tls files: %w", err)
+		}
+
+		httpOpts = append(httpOpts, httpserver.WithTLS(tlsFiles))
+		metricsOpts = append(metricsOpts, httpserver.WithMetricsTLS(tlsFiles))
+	}
+
+	httpServer := httpserver.New(logger, appState, cfg.HTTPPort, httpOpts...)
+
+	// Create metrics server (separate port for Prometheus scraping)
+	metricsServer := httpserver.NewMetricsServer(logger, cfg.MetricsPort, metricsOpts...)
+
+	if cfg.GRPCPort != "" {
+		a.grpcServer = httpserver.NewGRPCServer(logger, appState, cfg.GRPCPort, httpOpts...)
+	}
+
+	if cfg.SelfMemoryWatermark != "" {
+		if a.memoryGuard, err = newMemoryGuard(logger, cfg, controllers); err != nil {
+			return nil, err
+		}
+	}
+
+	// Create signal handler
+	signalHandler := shutdown.New(logger, appState, cfg.TerminationFile)
+
+	a.httpServer = httpServer
+	a.metricsServer = metricsServer
+	a.signalHandler = signalHandler
+
+	return a, nil
+}
+
+// cronParserOptions builds the accepted restart-schedule syntax from config.
+func cronParserOptions(cfg *config.Config) []cronparser.Option {
+	var opts []cronparser.Option
+
+	if cfg.CronSeconds {
+		opts = append(opts, cronparser.WithSeconds())
+	}
+
+	if cfg.CronDescriptors {
+		opts = append(opts, cronparser.WithDescriptors())
+	}
+
+	return opts
+}
+
+// newController builds the controller service of one cluster, and the pinger of its metrics API when
+// it is the metrics source; an empty cluster uses the default kubeconfig context.
+func newController(
+	logger *slog.Logger,
+	cfg *config.Config,
+	cluster string,
+	rateLimiter *k8s.RateLimiter,
+	sharedOpts ...controller.Option,
+) (*controller.Service, *k8s.MetricsAPIPinger, error) {
+	repo, metricsAPIPinger, err := newRepository(logger, cfg, cluster, rateLimiter)
 	if err != nil {
-		return nil, fmt.Errorf("create metrics clientset: %w", err)
+		return nil, nil, err
 	}
 
-	// Create secondary adapter (K8s adapter)
-	k8sRepo := k8s.New(logger, clientset, metricsClientset)
+	controllerOpts, err := controllerOptions(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	controllerOpts = append(controllerOpts, sharedOpts...)
 
-	cronParser := cronparser.New()
+	if cluster != "" {
+		controllerOpts = append(controllerOpts, controller.WithCluster(cluster))
+	}
 
 	// Create logic service (inject repository adapter)
-	controllerService := controller.New(
+	return controller.New(
 		logger,
-		k8sRepo,
-		cronParser,
+		repo,
+		cronparser.New(cronParserOptions(cfg)...),
 		cfg.Interval,
 		cfg.PodLabelSelector,
 		cfg.AnnotationMemoryThresholdKey,
@@ -73,25 +263,428 @@ func New(
 		controller.PreoomkillerAnnotationRestartAtKey,
 		cfg.RestartScheduleJitterMax,
 		cfg.MinPodAgeBeforeEviction,
-	)
+		controllerOpts...,
+	), metricsAPIPinger, nil
+}
 
-	// Create HTTP server
-	httpServer := httpserver.New(logger, appState, cfg.HTTPPort)
+// newAccessReviewer builds the reviewer of the admin endpoint tokens. Tokens are reviewed by the
+// cluster of the default context, which is the cluster the controller runs in.
+func newAccessReviewer(cfg *config.Config) (*k8s.AccessReviewer, error) {
+	kubeConfig, err := buildKubeConfig(cfg, "", nil)
+	if err != nil {
+		return nil, err
+	}
 
-	// Create metrics server (separate port for Prometheus scraping)
-	metricsServer := httpserver.NewMetricsServer(logger, cfg.MetricsPort)
+	clientset, err := kubernetes.NewForConfig(coreKubeConfig(cfg, kubeConfig))
+	if err != nil {
+		return nil, fmt.Errorf("create access review clientset: %w", err)
+	}
 
-	// Create signal handler
-	signalHandler := shutdown.New(logger, appState)
-
-	return &App{
-		controller:    controllerService,
-		signalHandler: signalHandler,
-		appState:      appState,
-		httpServer:    httpServer,
-		metricsServer: metricsServer,
-		logger:        logger,
-	}, nil
+	return k8s.NewAccessReviewer(clientset, cfg.APICallTimeout), nil
+}
+
+// newRepository builds the Kubernetes adapter of a cluster, wrapped by the Prometheus adapter when
+// configured, and the pinger of its metrics API when it is the metrics source.
+func newRepository(
+	logger *slog.Logger,
+	cfg *config.Config,
+	cluster string,
+	rateLimiter *k8s.RateLimiter,
+) (controller.Repository, *k8s.MetricsAPIPinger, error) {
+	kubeConfig, err := buildKubeConfig(cfg, cluster, rateLimiter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Create K8s clientset
+	clientset, err := kubernetes.NewForConfig(coreKubeConfig(cfg, kubeConfig))
+	if err != nil {
+		return nil, nil, fmt.Errorf("create clientset: %w", err)
+	}
+
+	// Create metrics clientset
+	metricsClientset, err := metricsv.NewForConfig(kubeConfig)
+	if err != nil {
+		return nil, nil, fmt.Errorf("create metrics clientset: %w", err)
+	}
+
+	var metricsAPIPinger *k8s.MetricsAPIPinger
+	if cfg.MetricsSource == config.MetricsSourceMetricsServer {
+		metricsAPIPinger = k8s.NewMetricsAPIPinger(metricsClientset, cluster, cfg.APICallTimeout)
+	}
+
+	// Create secondary adapter (K8s adapter)
+	k8sOpts, err := k8sOptions(cfg, kubeConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if cluster != "" {
+		k8sOpts = append(k8sOpts, k8s.WithCluster(cluster))
+	}
+
+	repo := k8s.New(logger, clientset, metricsClientset, k8sOpts...)
+
+	if cfg.PrometheusURL != "" {
+		promOpts := prometheusOptions(cfg)
+		if cluster != "" {
+			promOpts = append(promOpts, prometheus.WithCluster(cluster))
+		}
+
+		repo, err = prometheus.New(logger, repo, cfg.PrometheusURL, promOpts...)
+		if err != nil {
+			return nil, nil, fmt.Errorf("create prometheus adapter: %w", err)
+		}
+	}
+
+	return repo, metricsAPIPinger, nil
+}
+
+// k8sOptions builds the Kubernetes adapter options from config.
+func k8sOptions(cfg *config.Config, kubeConfig *rest.Config) ([]k8s.Option, error) {
+	var k8sOpts []k8s.Option
+	if cfg.RestartRecordConfigMap != "" {
+		k8sOpts = append(k8sOpts, k8s.WithRestartRecordConfigMap(cfg.Namespace, cfg.RestartRecordConfigMap))
+	}
+
+	if cfg.PendingEvictionsConfigMap != "" {
+		k8sOpts = append(k8sOpts, k8s.WithPendingEvictionsConfigMap(cfg.Namespace, cfg.PendingEvictionsConfigMap))
+	}
+
+	if cfg.APICallTimeout > 0 {
+		k8sOpts = append(k8sOpts, k8s.WithCallTimeout(cfg.APICallTimeout))
+	}
+
+	if cfg.NodeName != "" {
+		k8sOpts = append(k8sOpts, k8s.WithNodeName(cfg.NodeName))
+	}
+
+	if cfg.PodDiscovery == config.PodDiscoveryAnnotation {
+		k8sOpts = append(k8sOpts, k8s.WithAnnotationDiscovery(
+			cfg.AnnotationMemoryThresholdKey,
+			cfg.AnnotationRestartScheduleKey,
+			controller.PreoomkillerAnnotationRestartWindowKey,
+			controller.PreoomkillerAnnotationPromQLKey,
+		))
+	}
+
+	if cfg.VPAMode != config.VPAModeOff {
+		dynamicClient, err := dynamic.NewForConfig(kubeConfig)
+		if err != nil {
+			return nil, fmt.Errorf("create dynamic client: %w", err)
+		}
+
+		k8sOpts = append(k8sOpts, k8s.WithVPA(dynamicClient))
+	}
+
+	return k8sOpts, nil
+}
+
+// sharedControllerOptions builds the controller options shared by all clusters: the audit log and
+// the notifiers.
+func sharedControllerOptions(cfg *config.Config) ([]controller.Option, error) {
+	var sharedOpts []controller.Option
+
+	if cfg.AuditLog != "" {
+		auditLogger, err := logging.NewAudit(cfg.AuditLog)
+		if err != nil {
+			return nil, fmt.Errorf("create audit logger: %w", err)
+		}
+
+		sharedOpts = append(sharedOpts, controller.WithAuditLogger(auditLogger))
+	}
+
+	if cfg.WebhookURL != "" {
+		var webhookOpts []webhook.Option
+		if cfg.TracingEnabled {
+			webhookOpts = append(webhookOpts, webhook.WithTransport(otelhttp.NewTransport(http.DefaultTransport)))
+		}
+
+		sharedOpts = append(sharedOpts, controller.WithNotifier(
+			webhook.New(cfg.WebhookURL, cfg.WebhookTimeout, webhookOpts...),
+		))
+	}
+
+	if cfg.SlackWebhookURL != "" || len(cfg.SlackNamespaceWebhooks) > 0 {
+		slackNotifier, err := slack.New(cfg.SlackWebhookURL, cfg.WebhookTimeout, slackOptions(cfg)...)
+		if err != nil {
+			return nil, fmt.Errorf("create slack notifier: %w", err)
+		}
+
+		sharedOpts = append(sharedOpts, controller.WithNotifier(slackNotifier))
+	}
+
+	if cfg.AlertmanagerURL != "" {
+		var alertmanagerOpts []alertmanager.Option
+		if cfg.TracingEnabled {
+			alertmanagerOpts = append(alertmanagerOpts,
+				alertmanager.WithTransport(otelhttp.NewTransport(http.DefaultTransport)))
+		}
+
+		sharedOpts = append(sharedOpts, controller.WithNotifier(alertmanager.New(
+			cfg.AlertmanagerURL,
+			cfg.AlertmanagerEvictions,
+			cfg.AlertmanagerFailures,
+			cfg.AlertmanagerWindow,
+			cfg.WebhookTimeout,
+			alertmanagerOpts...,
+		)))
+	}
+
+	return sharedOpts, nil
+}
+
+// natsOptions builds the NATS publisher authentication options from config.
+func natsOptions(cfg *config.Config) []nats.Option {
+	var natsOpts []nats.Option
+	if cfg.NATSCredsFile != "" {
+		natsOpts = append(natsOpts, nats.WithCredsFile(cfg.NATSCredsFile))
+	}
+
+	if cfg.NATSToken != "" {
+		natsOpts = append(natsOpts, nats.WithToken(cfg.NATSToken))
+	}
+
+	if cfg.NATSUser != "" {
+		natsOpts = append(natsOpts, nats.WithUserInfo(cfg.NATSUser, cfg.NATSPassword))
+	}
+
+	return natsOpts
+}
+
+// slackOptions builds the Slack notifier options from config.
+func slackOptions(cfg *config.Config) []slack.Option {
+	slackOpts := []slack.Option{slack.WithNamespaceWebhooks(cfg.SlackNamespaceWebhooks)}
+	if cfg.SlackChannel != "" {
+		slackOpts = append(slackOpts, slack.WithChannel(cfg.SlackChannel))
+	}
+
+	if cfg.SlackTemplate != "" {
+		slackOpts = append(slackOpts, slack.WithTemplate(cfg.SlackTemplate))
+	}
+
+	if cfg.TracingEnabled {
+		slackOpts = append(slackOpts, slack.WithTransport(otelhttp.NewTransport(http.DefaultTransport)))
+	}
+
+	return slackOpts
+}
+
+// prometheusOptions builds the Prometheus adapter options from config.
+func prometheusOptions(cfg *config.Config) []prometheus.Option {
+	var promOpts []prometheus.Option
+	if cfg.APICallTimeout > 0 {
+		promOpts = append(promOpts, prometheus.WithQueryTimeout(cfg.APICallTimeout))
+	}
+
+	if cfg.TracingEnabled {
+		promOpts = append(promOpts, prometheus.WithRoundTripper(otelhttp.NewTransport(promapi.DefaultRoundTripper)))
+	}
+
+	if cfg.MetricsSource == config.MetricsSourcePrometheus {
+		promOpts = append(promOpts,
+			prometheus.WithMemoryUsage(cfg.PrometheusQuery),
+			prometheus.WithMemoryMetric(cfg.MemoryMetric),
+		)
+	}
+
+	return promOpts
+}
+
+// buildKubeConfig builds the rest.Config shared by all Kubernetes clients of a cluster. A non-empty
+// kubeContext selects that context of the kubeconfig instead of the current one. A non-nil
+// rateLimiter replaces the rate limiter built from the QPS and burst, and is shared by the clients.
+func buildKubeConfig(cfg *config.Config, kubeContext string, rateLimiter *k8s.RateLimiter) (*rest.Config, error) {
+	kubeConfig, err := loadKubeConfig(cfg, kubeContext)
+	if err != nil {
+		return nil, fmt.Errorf("build k8s config: %w", err)
+	}
+
+	// Zero keeps the client-go defaults; a negative QPS disables client-side rate limiting.
+	if cfg.KubeQPS != 0 {
+		kubeConfig.QPS = cfg.KubeQPS
+	}
+
+	kubeConfig.Burst = cfg.KubeBurst
+	if kubeConfig.Burst == 0 && kubeConfig.QPS > 0 {
+		// The clientset rejects a positive QPS without a burst.
+		kubeConfig.Burst = rest.DefaultBurst
+	}
+
+	if rateLimiter != nil {
+		kubeConfig.RateLimiter = rateLimiter
+	}
+
+	if cfg.TracingEnabled {
+		// Every client built from this config (core, metrics, dynamic) reports a span per API request.
+		kubeConfig.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+			return otelhttp.NewTransport(rt)
+		})
+	}
+
+	return kubeConfig, nil
+}
+
+// loadKubeConfig loads the kubeconfig (in-cluster config when none is set) or one of its contexts.
+func loadKubeConfig(cfg *config.Config, kubeContext string) (*rest.Config, error) {
+	if kubeContext == "" {
+		return clientcmd.BuildConfigFromFlags(cfg.KubeMaster, cfg.KubeConfig)
+	}
+
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = cfg.KubeConfig
+
+	overrides := &clientcmd.ConfigOverrides{CurrentContext: kubeContext}
+	overrides.ClusterInfo.Server = cfg.KubeMaster
+
+	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, overrides).ClientConfig()
+}
+
+// coreKubeConfig returns the rest.Config of the core clientset. With protobuf enabled, built-in
+// resources are exchanged as protobuf, falling back to JSON for types the server cannot encode.
+// The metrics and dynamic clients keep JSON: aggregated APIs and CRDs may not serve protobuf.
+func coreKubeConfig(cfg *config.Config, kubeConfig *rest.Config) *rest.Config {
+	if !cfg.KubeProtobuf {
+		return kubeConfig
+	}
+
+	coreConfig := rest.CopyConfig(kubeConfig)
+	coreConfig.ContentType = runtime.ContentTypeProtobuf
+	coreConfig.AcceptContentTypes = runtime.ContentTypeProtobuf + "," + runtime.ContentTypeJSON
+
+	return coreConfig
+}
+
+// newMemoryGuard creates the memory guard dropping the caches of the controllers.
+func newMemoryGuard(logger *slog.Logger, cfg *config.Config, controllers []controllerServer) (*memguard.Guard, error) {
+	watermark, err := memguard.ParseWatermark(cfg.SelfMemoryWatermark)
+	if err != nil {
+		return nil, fmt.Errorf("parse self memory watermark: %w", err)
+	}
+
+	droppers := make([]memguard.CacheDropper, 0, len(controllers))
+	for _, c := range controllers {
+		droppers = append(droppers, c)
+	}
+
+	opts := []memguard.Option{memguard.WithCacheDroppers(droppers...)}
+	if cfg.SelfMemoryExit {
+		opts = append(opts, memguard.WithExit())
+	}
+
+	return memguard.New(logger, watermark, opts...), nil
+}
+
+// controllerOptions builds optional controller features from config.
+func controllerOptions(cfg *config.Config) ([]controller.Option, error) {
+	var opts []controller.Option
+
+	if cfg.RunOnce {
+		opts = append(opts, controller.WithOneShot())
+	}
+
+	if cfg.MetricsBlindReconciles > 0 {
+		opts = append(opts, controller.WithMetricsBlindLimit(cfg.MetricsBlindReconciles))
+	}
+
+	if cfg.ReadyAfterReconcile {
+		opts = append(opts, controller.WithReadinessGate())
+	}
+
+	if cfg.ReconcileConcurrency > 1 {
+		opts = append(opts, controller.WithReconcileConcurrency(cfg.ReconcileConcurrency))
+	}
+
+	opts = append(opts, controller.WithPodPacing(cfg.ReconcilePodPacing))
+
+	if cfg.ReconcileTimeout > 0 {
+		opts = append(opts, controller.WithReconcileTimeout(cfg.ReconcileTimeout))
+	}
+
+	if cfg.OOMThresholdTightenPercent > 0 {
+		opts = append(opts, controller.WithOOMThresholdTightening(cfg.OOMThresholdTightenPercent))
+	}
+
+	if cfg.DeterministicJitter {
+		opts = append(opts, controller.WithDeterministicJitter())
+	}
+
+	if cfg.RestartScheduleSpread > 0 {
+		opts = append(opts, controller.WithRestartSpread(cfg.RestartScheduleSpread))
+	}
+
+	if cfg.RestartMinInterval > 0 {
+		opts = append(opts, controller.WithRestartMinInterval(cfg.RestartMinInterval))
+	}
+
+	if cfg.NamespaceDefaults {
+		opts = append(opts, controller.WithNamespaceDefaults())
+	}
+
+	if cfg.DefaultMemoryThreshold != "" {
+		opts = append(opts, controller.WithDefaultMemoryThreshold(cfg.DefaultMemoryThreshold))
+	}
+
+	if cfg.WatchAnnotations {
+		opts = append(opts, controller.WithAnnotationWatch())
+	}
+
+	if cfg.SerialRestart {
+		opts = append(opts, controller.WithSerialRestart(cfg.SerialRestartReadyTimeout))
+	}
+
+	if cfg.CanarySoak > 0 {
+		opts = append(opts, controller.WithCanary(cfg.CanarySoak))
+	}
+
+	if cfg.EvictionVerifyTimeout > 0 {
+		opts = append(opts, controller.WithEvictionVerification(cfg.EvictionVerifyTimeout))
+	}
+
+	if cfg.RetryBaseDelay > 0 {
+		opts = append(opts, controller.WithRetry(cfg.RetryBaseDelay, cfg.RetryMaxDelay))
+	}
+
+	if cfg.RestartRecordConfigMap != "" {
+		opts = append(opts, controller.WithRestartRecording())
+	}
+
+	if cfg.PendingEvictionsConfigMap != "" {
+		opts = append(opts, controller.WithPendingEvictionPersistence())
+	}
+
+	if cfg.MinReadyReplicas > 0 {
+		opts = append(opts, controller.WithMinReadyReplicas(cfg.MinReadyReplicas))
+	}
+
+	opts = append(opts, controller.WithUsageHistorySize(cfg.UsageHistorySize))
+
+	if cfg.ObservedAnnotationsInterval > 0 {
+		opts = append(opts, controller.WithObservedAnnotations(cfg.ObservedAnnotationsInterval))
+	}
+
+	if cfg.PodMemoryGaugesMaxPods > 0 {
+		opts = append(opts, controller.WithPodMemoryGauges(cfg.PodMemoryGaugesMaxPods))
+	}
+
+	if cfg.VPAMode != config.VPAModeOff {
+		opts = append(opts, controller.WithVPAMode(controller.VPAMode(cfg.VPAMode)))
+	}
+
+	if cfg.ContainerAggregation != controller.ContainerAggregationSum {
+		opts = append(opts, controller.WithContainerAggregation(cfg.ContainerAggregation))
+	}
+
+	if cfg.BlackoutWindows != "" {
+		schedule, err := blackout.Parse(cfg.BlackoutWindows, cfg.BlackoutTZ)
+		if err != nil {
+			return nil, fmt.Errorf("parse blackout windows: %w", err)
+		}
+
+		opts = append(opts, controller.WithBlackout(schedule))
+	}
+
+	return opts, nil
 }
 
 // Run starts the application and blocks until context is cancelled.
@@ -114,6 +707,43 @@ func (a *App) Run(originCtx context.Context) error {
 	return a.runUntilShutdown(ctx)
 }
 
+// RunOnce performs a single reconcile and returns; servers and pingers are not started.
+// When a Pushgateway URL is configured, metrics are pushed before returning, and streamed events are
+// flushed likewise.
+func (a *App) RunOnce(originCtx context.Context) error {
+	ctx, cancel := context.WithCancel(originCtx)
+	defer cancel()
+
+	go a.signalHandler.HandleSignals(ctx, cancel)
+
+	a.logger.InfoContext(ctx, "running single reconcile")
+
+	var err error
+
+	for _, c := range a.controllers {
+		if reconcileErr := c.ReconcileCommand(ctx); reconcileErr != nil {
+			err = errors.Join(err, fmt.Errorf("reconcile %s: %w", c.Name(), reconcileErr))
+		}
+	}
+
+	if a.pushgatewayURL != "" {
+		if pushErr := metrics.Push(context.WithoutCancel(ctx), a.pushgatewayURL, pushJobName); pushErr != nil {
+			err = errors.Join(err, pushErr)
+		}
+	}
+
+	if a.eventPublisher != nil {
+		flushCtx, flushCancel := context.WithTimeout(context.WithoutCancel(ctx), eventFlushTimeout)
+		defer flushCancel()
+
+		if flushErr := a.eventPublisher.Shutdown(flushCtx); flushErr != nil {
+			err = errors.Join(err, flushErr)
+		}
+	}
+
+	return err
+}
+
 // initialize checks termination file and sets starting state
 func (a *App) initialize(ctx context.Context) error {
 	if err := a.signalHandler.CheckTermination(ctx); err != nil {
@@ -139,10 +769,18 @@ func (a *App) startServices(ctx context.Context, cancel func()) error {
 		return fmt.Errorf("start metrics server: %w", err)
 	}
 
+	if err := a.startGRPCServer(ctx); err != nil {
+		return fmt.Errorf("start grpc server: %w", err)
+	}
+
 	if err := a.startController(ctx); err != nil {
 		return fmt.Errorf("start controller: %w", err)
 	}
 
+	if err := a.startMemoryGuard(ctx); err != nil {
+		return fmt.Errorf("start memory guard: %w", err)
+	}
+
 	return nil
 }
 
@@ -180,18 +818,64 @@ func (a *App) startMetricsServer(ctx context.Context) error {
 	return nil
 }
 
-// startController starts the controller and registers it
-func (a *App) startController(ctx context.Context) error {
-	if err := a.controller.Start(ctx); err != nil {
-		return fmt.Errorf("start controller: %w", err)
+// startGRPCServer starts the gRPC server, when enabled, and registers it
+func (a *App) startGRPCServer(ctx context.Context) error {
+	if a.grpcServer == nil {
+		return nil
 	}
 
-	if err := a.appState.RegisterShutdowner(a.controller); err != nil {
-		return fmt.Errorf("register shutdowner: %w", err)
+	if err := a.grpcServer.Start(ctx); err != nil {
+		return fmt.Errorf("start grpc server: %w", err)
 	}
 
-	if err := a.appState.RegisterPinger(a.controller); err != nil {
-		return fmt.Errorf("register pinger: %w", err)
+	if err := a.appState.RegisterShutdowner(a.grpcServer); err != nil {
+		return fmt.Errorf("register grpc shutdowner: %w", err)
+	}
+
+	if err := a.appState.RegisterPinger(a.grpcServer); err != nil {
+		return fmt.Errorf("register grpc pinger: %w", err)
+	}
+
+	return nil
+}
+
+// startMemoryGuard starts the memory guard, when enabled, and registers it
+func (a *App) startMemoryGuard(ctx context.Context) error {
+	if a.memoryGuard == nil {
+		return nil
+	}
+
+	if err := a.memoryGuard.Start(ctx); err != nil {
+		return fmt.Errorf("start memory guard: %w", err)
+	}
+
+	if err := a.appState.RegisterShutdowner(a.memoryGuard); err != nil {
+		return fmt.Errorf("register memory guard shutdowner: %w", err)
+	}
+
+	return nil
+}
+
+// startController starts the controllers and registers them
+func (a *App) startController(ctx context.Context) error {
+	for _, c := range a.controllers {
+		if err := c.Start(ctx); err != nil {
+			return fmt.Errorf("start controller: %w", err)
+		}
+
+		if err := a.appState.RegisterShutdowner(c); err != nil {
+			return fmt.Errorf("register shutdowner: %w", err)
+		}
+
+		if err := a.appState.RegisterPinger(c); err != nil {
+			return fmt.Errorf("register pinger: %w", err)
+		}
+	}
+
+	for _, p := range a.metricsAPIPingers {
+		if err := a.appState.RegisterPinger(p); err != nil {
+			return fmt.Errorf("register metrics api pinger: %w", err)
+		}
 	}
 
 	return nil
@@ -202,7 +886,7 @@ func (a *App) waitForReady(ctx context.Context) error {
 	select {
 	case <-ctx.Done():
 		return fmt.Errorf("context done")
-	case <-allChannelsClose(ctx, a.logger, a.httpServer.Ready(), a.metricsServer.Ready(), a.controller.Ready()):
+	case <-allChannelsClose(ctx, a.logger, a.readyChannels()...):
 		// All are ready
 	}
 
@@ -215,6 +899,20 @@ func (a *App) waitForReady(ctx context.Context) error {
 	return nil
 }
 
+// readyChannels returns the Ready channels of the servers and all controllers.
+func (a *App) readyChannels() []<-chan struct{} {
+	channels := []<-chan struct{}{a.httpServer.Ready(), a.metricsServer.Ready()}
+	if a.grpcServer != nil {
+		channels = append(channels, a.grpcServer.Ready())
+	}
+
+	for _, c := range a.controllers {
+		channels = append(channels, c.Ready())
+	}
+
+	return channels
+}
+
 // runUntilShutdown waits for shutdown signal and performs shutdown
 func (a *App) runUntilShutdown(ctx context.Context) error {
 	<-ctx.Done()
@@ -268,3 +966,18 @@ func allChannelsClose(ctx context.Context, logger *slog.Logger, cs ...<-chan str
 func (a *App) Shutdown(ctx context.Context) error {
 	return a.appState.Shutdown(ctx)
 }
+
+// configInfo returns the settings exported by preoomkiller_config_info.
+func configInfo(cfg *config.Config, clusters int) metrics.ConfigInfo {
+	return metrics.ConfigInfo{
+		Interval:                 cfg.Interval.String(),
+		MetricsSource:            cfg.MetricsSource,
+		MemoryMetric:             cfg.MemoryMetric,
+		ContainerAggregation:     cfg.ContainerAggregation,
+		VPAMode:                  cfg.VPAMode,
+		MinPodAgeBeforeEviction:  cfg.MinPodAgeBeforeEviction.String(),
+		RestartScheduleJitterMax: cfg.RestartScheduleJitterMax.String(),
+		RunOnce:                  cfg.RunOnce,
+		Clusters:                 clusters,
+	}
+}
@@ -1,10 +1,22 @@
 package app
 
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"io"
 	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/skillcoder/preoomkiller-controller/internal/config"
+	"github.com/skillcoder/preoomkiller-controller/internal/logic/controller"
 )
 
 type allChannelsCloseCase struct {
@@ -86,3 +98,137 @@ func TestAllChannelsClose(t *testing.T) {
 		})
 	}
 }
+
+func TestWriteDecisionsTable(t *testing.T) {
+	threshold := resource.MustParse("256Mi")
+	usage := resource.MustParse("512Mi")
+
+	var buf bytes.Buffer
+
+	err := writeDecisionsTable(&buf, []controller.Decision{
+		{
+			Namespace:       "default",
+			Name:            "test-pod",
+			Trigger:         controller.TriggerThreshold,
+			Action:          controller.ActionEvict,
+			MemoryUsage:     &usage,
+			MemoryThreshold: &threshold,
+		},
+	})
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	require.Equal(t, []string{"default", "test-pod", "threshold", "evict", "-", "512Mi", "256Mi", "-"}, strings.Fields(lines[1]))
+}
+
+func TestWriteDecisionsTable_multiCluster(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := writeDecisionsTable(&buf, []controller.Decision{
+		{
+			Cluster:   "prod-eu",
+			Namespace: "default",
+			Name:      "test-pod",
+			Trigger:   controller.TriggerThreshold,
+			Action:    controller.ActionNone,
+		},
+	})
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	require.Equal(t, "CLUSTER", strings.Fields(lines[0])[0])
+	require.Equal(t, []string{"prod-eu", "default", "test-pod", "threshold", "none", "-", "-", "-", "-"}, strings.Fields(lines[1]))
+}
+
+func TestWriteCheckReport(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := writeCheckReport(&buf, []checkResult{
+		checkPorts(&config.Config{HTTPPort: "8080", MetricsPort: "9090"}),
+		checkPorts(&config.Config{HTTPPort: "8080", MetricsPort: "8080"}),
+		checkPorts(&config.Config{HTTPPort: "80a", MetricsPort: "9090"}),
+	})
+	require.ErrorIs(t, err, ErrCheckFailed)
+	require.ErrorContains(t, err, "2 of 3 checks")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 4)
+	require.Contains(t, lines[1], "ok")
+	require.Contains(t, lines[1], "http 8080, metrics 9090")
+	require.Contains(t, lines[2], "FAIL")
+	require.Contains(t, lines[2], "share port 8080")
+	require.Contains(t, lines[3], `invalid port "80a"`)
+}
+
+func TestRequiredPermissions(t *testing.T) {
+	cfg := &config.Config{
+		MetricsSource:             config.MetricsSourcePrometheus,
+		VPAMode:                   config.VPAModeOff,
+		PendingEvictionsConfigMap: "preoomkiller-pending",
+		Namespace:                 "kube-system",
+		AdminTokenReview:          true,
+	}
+
+	required := requiredPermissions(cfg, false)
+
+	perms := make([]string, 0, len(required))
+	for _, perm := range required {
+		perms = append(perms, perm.String())
+	}
+
+	require.Equal(t, []string{
+		"list pods",
+		"get pods",
+		"patch pods",
+		"create pods/eviction",
+		"create events",
+		"get configmaps in kube-system",
+		"create configmaps in kube-system",
+		"patch configmaps in kube-system",
+	}, perms)
+
+	require.Len(t, requiredPermissions(cfg, true), len(perms)+2, "the default cluster reviews admin tokens")
+}
+
+func TestStatus(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/-/status", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"state":"running","uptime":"1h0m0s","suspendedWorkloads":[{"namespace":"default",`+
+			`"ownerKind":"ReplicaSet","owner":"app-7d9c","pod":"app-3","suspendedAt":"2026-02-15T06:00:00Z"}]}`)
+	})
+	mux.HandleFunc("/-/pods", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"pods":[{"namespace":"default","pod":"app-1","memoryThreshold":"1Gi","memoryUsage":"600Mi",`+
+			`"checkedAt":"2026-02-15T07:00:00Z"}]}`)
+	})
+	mux.HandleFunc("/-/pending", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"pending":[{"namespace":"default","pod":"app-2","fireAt":"2026-02-15T08:00:30Z"}]}`)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	var buf bytes.Buffer
+
+	require.NoError(t, Status(t.Context(), &buf, server.URL+"/"))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 11, "the evictions table is omitted without eviction history")
+	require.Equal(t, []string{"STATE", "running"}, strings.Fields(lines[0]))
+	require.Equal(t, []string{"default", "replicaset/app-7d9c", "app-3", "2026-02-15T06:00:00Z"}, strings.Fields(lines[4]))
+	require.Equal(t, []string{"default", "app-1", "1Gi", "600Mi", "2026-02-15T07:00:00Z", "-"}, strings.Fields(lines[7]))
+	require.Equal(t, []string{"default", "app-2", "-", "2026-02-15T08:00:30Z"}, strings.Fields(lines[10]))
+}
+
+func TestStatus_notAController(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.NotFoundHandler())
+	t.Cleanup(server.Close)
+
+	err := Status(t.Context(), io.Discard, server.URL)
+	require.ErrorIs(t, err, ErrStatusEndpoint)
+}
@@ -0,0 +1,364 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/skillcoder/preoomkiller-controller/internal/adapters/outbound/k8s"
+	"github.com/skillcoder/preoomkiller-controller/internal/adapters/outbound/slack"
+	"github.com/skillcoder/preoomkiller-controller/internal/config"
+	"github.com/skillcoder/preoomkiller-controller/internal/httpserver"
+	"github.com/skillcoder/preoomkiller-controller/internal/infra/blackout"
+	"github.com/skillcoder/preoomkiller-controller/internal/infra/cronparser"
+	"github.com/skillcoder/preoomkiller-controller/internal/logic/controller"
+)
+
+// ErrCheckFailed is returned by Check when at least one check failed.
+var ErrCheckFailed = errors.New("check failed")
+
+// maxPort is the highest TCP port.
+const maxPort = 65535
+
+// checkResult is a line of the check report; a nil err means the check passed.
+type checkResult struct {
+	name   string
+	detail string
+	err    error
+}
+
+// Check loads the configuration with flags and validates it without starting the controller: the
+// settings, then for each cluster that its API server is reachable, that the controller is granted
+// the permissions it needs and that the restart schedules of the selected pods parse. It writes a
+// report to w and returns ErrCheckFailed when a check failed, e.g. to check deployment manifests in CI.
+func Check(ctx context.Context, w io.Writer, flags config.Flags) error {
+	cfg, err := config.Load(flags)
+	if err != nil {
+		return writeCheckReport(w, []checkResult{{name: "config", err: err}})
+	}
+
+	results := []checkResult{
+		{name: "config", detail: configSources(cfg)},
+		checkLabelSelector(cfg),
+		checkAnnotationKeys(cfg),
+		checkPorts(cfg),
+		checkBlackoutWindows(cfg),
+		checkSlack(cfg),
+		checkTLS(cfg),
+	}
+
+	clusters := cfg.Contexts
+	if len(clusters) == 0 {
+		clusters = []string{""}
+	}
+
+	for _, cluster := range clusters {
+		results = append(results, checkCluster(ctx, cfg, cluster)...)
+	}
+
+	return writeCheckReport(w, results)
+}
+
+func writeCheckReport(w io.Writer, results []checkResult) error {
+	tw := tabwriter.NewWriter(w, tableMinWidth, tableTabWidth, tablePadding, ' ', 0)
+
+	fmt.Fprintln(tw, "RESULT\tCHECK\tDETAIL")
+
+	failed := 0
+
+	for _, r := range results {
+		result, detail := "ok", r.detail
+		if r.err != nil {
+			result, detail = "FAIL", r.err.Error()
+			failed++
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", result, r.name, detail)
+	}
+
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("write check report: %w", err)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%w: %d of %d checks", ErrCheckFailed, failed, len(results))
+	}
+
+	return nil
+}
+
+// configSources describes where the configuration was loaded from.
+func configSources(cfg *config.Config) string {
+	sources := []string{"environment"}
+	if cfg.ConfigFile != "" {
+		sources = append(sources, "file "+cfg.ConfigFile)
+	}
+
+	if cfg.ConfigDir != "" {
+		sources = append(sources, "directory "+cfg.ConfigDir)
+	}
+
+	if len(cfg.Flags) > 0 {
+		sources = append(sources, "flags")
+	}
+
+	return "loaded from " + strings.Join(sources, ", ")
+}
+
+func checkLabelSelector(cfg *config.Config) checkResult {
+	if cfg.PodDiscovery == config.PodDiscoveryAnnotation {
+		return checkResult{name: "label selector", detail: "unused, pods are discovered by annotation"}
+	}
+
+	for _, selector := range controller.SplitLabelSelectors(cfg.PodLabelSelector) {
+		if _, err := labels.Parse(selector); err != nil {
+			return checkResult{name: "label selector", err: fmt.Errorf("parse %q: %w", selector, err)}
+		}
+	}
+
+	return checkResult{name: "label selector", detail: cfg.PodLabelSelector}
+}
+
+func checkAnnotationKeys(cfg *config.Config) checkResult {
+	for _, key := range []string{cfg.AnnotationMemoryThresholdKey, cfg.AnnotationRestartScheduleKey, cfg.AnnotationTZKey} {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return checkResult{name: "annotation keys", err: fmt.Errorf("invalid key %q: %s", key, strings.Join(errs, "; "))}
+		}
+	}
+
+	return checkResult{name: "annotation keys", detail: "valid"}
+}
+
+func checkPorts(cfg *config.Config) checkResult {
+	ports := []string{cfg.HTTPPort, cfg.MetricsPort}
+	if cfg.GRPCPort != "" {
+		ports = append(ports, cfg.GRPCPort)
+	}
+
+	for _, port := range ports {
+		if n, err := strconv.Atoi(port); err != nil || n < 1 || n > maxPort {
+			return checkResult{name: "ports", err: fmt.Errorf("invalid port %q", port)}
+		}
+	}
+
+	if cfg.HTTPPort == cfg.MetricsPort {
+		return checkResult{name: "ports", err: fmt.Errorf("http and metrics servers share port %s", cfg.HTTPPort)}
+	}
+
+	if cfg.GRPCPort == cfg.HTTPPort || cfg.GRPCPort == cfg.MetricsPort {
+		return checkResult{name: "ports", err: fmt.Errorf("grpc server shares port %s", cfg.GRPCPort)}
+	}
+
+	detail := "http " + cfg.HTTPPort + ", metrics " + cfg.MetricsPort
+	if cfg.GRPCPort != "" {
+		detail += ", grpc " + cfg.GRPCPort
+	}
+
+	return checkResult{name: "ports", detail: detail}
+}
+
+func checkBlackoutWindows(cfg *config.Config) checkResult {
+	if cfg.BlackoutWindows == "" {
+		return checkResult{name: "blackout windows", detail: "none"}
+	}
+
+	if _, err := blackout.Parse(cfg.BlackoutWindows, cfg.BlackoutTZ); err != nil {
+		return checkResult{name: "blackout windows", err: err}
+	}
+
+	return checkResult{name: "blackout windows", detail: cfg.BlackoutWindows}
+}
+
+func checkSlack(cfg *config.Config) checkResult {
+	if cfg.SlackWebhookURL == "" && len(cfg.SlackNamespaceWebhooks) == 0 {
+		return checkResult{name: "slack", detail: "disabled"}
+	}
+
+	if _, err := slack.New(cfg.SlackWebhookURL, cfg.WebhookTimeout, slackOptions(cfg)...); err != nil {
+		return checkResult{name: "slack", err: err}
+	}
+
+	return checkResult{name: "slack", detail: "template valid"}
+}
+
+func checkTLS(cfg *config.Config) checkResult {
+	if cfg.TLSCertFile == "" {
+		return checkResult{name: "tls", detail: "disabled"}
+	}
+
+	if _, err := httpserver.NewTLS(slog.Default(), cfg.TLSCertFile, cfg.TLSKeyFile, cfg.TLSClientCAFile); err != nil {
+		return checkResult{name: "tls", err: err}
+	}
+
+	return checkResult{name: "tls", detail: "certificate loaded"}
+}
+
+// checkCluster checks the API server of a cluster, the permissions of the controller and the restart
+// schedules of the selected pods. Later checks are skipped when the API server is unreachable.
+func checkCluster(ctx context.Context, cfg *config.Config, cluster string) []checkResult {
+	prefix := "cluster"
+	if cluster != "" {
+		prefix += " " + cluster
+	}
+
+	kubeConfig, err := buildKubeConfig(cfg, cluster, nil)
+	if err != nil {
+		return []checkResult{{name: prefix, err: err}}
+	}
+
+	// The version request takes no context.
+	kubeConfig.Timeout = cfg.APICallTimeout
+
+	clientset, err := kubernetes.NewForConfig(coreKubeConfig(cfg, kubeConfig))
+	if err != nil {
+		return []checkResult{{name: prefix, err: fmt.Errorf("create clientset: %w", err)}}
+	}
+
+	preflight := k8s.NewPreflight(clientset, cfg.APICallTimeout)
+
+	version, err := preflight.ServerVersion()
+	if err != nil {
+		return []checkResult{{name: prefix + " api server", err: err}}
+	}
+
+	return []checkResult{
+		{name: prefix + " api server", detail: version},
+		checkPermissions(ctx, preflight, requiredPermissions(cfg, cluster == ""), prefix),
+		checkRestartSchedules(ctx, cfg, preflight, prefix),
+	}
+}
+
+func checkPermissions(ctx context.Context, preflight *k8s.Preflight, perms []k8s.Permission, prefix string) checkResult {
+	name := prefix + " permissions"
+
+	missing, err := preflight.MissingPermissions(ctx, perms)
+	if err != nil {
+		return checkResult{name: name, err: err}
+	}
+
+	if len(missing) > 0 {
+		denied := make([]string, 0, len(missing))
+		for _, perm := range missing {
+			denied = append(denied, perm.String())
+		}
+
+		return checkResult{name: name, err: fmt.Errorf("missing: %s", strings.Join(denied, ", "))}
+	}
+
+	return checkResult{name: name, detail: fmt.Sprintf("%d granted", len(perms))}
+}
+
+// requiredPermissions returns the API permissions the configured features need. Admin tokens are
+// only reviewed by the default cluster.
+func requiredPermissions(cfg *config.Config, defaultCluster bool) []k8s.Permission {
+	perms := []k8s.Permission{
+		{Verb: "list", Resource: "pods"},
+		{Verb: "get", Resource: "pods"},
+		{Verb: "patch", Resource: "pods"},
+		{Verb: "create", Resource: "pods", Subresource: "eviction"},
+		{Verb: "create", Resource: "events"},
+	}
+
+	if cfg.MetricsSource == config.MetricsSourceMetricsServer {
+		perms = append(perms, k8s.Permission{Verb: "list", Group: "metrics.k8s.io", Resource: "pods"})
+	}
+
+	if cfg.RestartRecordConfigMap != "" || cfg.PendingEvictionsConfigMap != "" {
+		for _, verb := range []string{"get", "create", "patch"} {
+			perms = append(perms, k8s.Permission{Verb: verb, Resource: "configmaps", Namespace: cfg.Namespace})
+		}
+	}
+
+	if cfg.RestartRecordConfigMap != "" {
+		perms = append(perms,
+			k8s.Permission{Verb: "get", Group: "apps", Resource: "replicasets"},
+			k8s.Permission{Verb: "get", Group: "apps", Resource: "statefulsets"},
+			k8s.Permission{Verb: "get", Group: "apps", Resource: "daemonsets"},
+			k8s.Permission{Verb: "get", Group: "batch", Resource: "jobs"},
+		)
+	}
+
+	if cfg.NamespaceDefaults {
+		perms = append(perms, k8s.Permission{Verb: "list", Resource: "namespaces"})
+	}
+
+	if cfg.PodDiscovery == config.PodDiscoveryAnnotation || cfg.WatchAnnotations {
+		perms = append(perms, k8s.Permission{Verb: "watch", Resource: "pods"})
+	}
+
+	if cfg.VPAMode != config.VPAModeOff {
+		perms = append(perms,
+			k8s.Permission{Verb: "list", Group: "apps", Resource: "replicasets"},
+			k8s.Permission{Verb: "list", Group: "autoscaling.k8s.io", Resource: "verticalpodautoscalers"},
+		)
+	}
+
+	if cfg.AdminTokenReview && defaultCluster {
+		perms = append(perms,
+			k8s.Permission{Verb: "create", Group: "authentication.k8s.io", Resource: "tokenreviews"},
+			k8s.Permission{Verb: "create", Group: "authorization.k8s.io", Resource: "subjectaccessreviews"},
+		)
+	}
+
+	return perms
+}
+
+// checkRestartSchedules parses the restart schedules of the selected pods like the controller does.
+func checkRestartSchedules(ctx context.Context, cfg *config.Config, preflight *k8s.Preflight, prefix string) checkResult {
+	name := prefix + " restart schedules"
+
+	selectors := controller.SplitLabelSelectors(cfg.PodLabelSelector)
+	if cfg.PodDiscovery == config.PodDiscoveryAnnotation {
+		selectors = []string{""}
+	}
+
+	pods := make(map[string]map[string]string)
+
+	for _, selector := range selectors {
+		selected, err := preflight.PodAnnotations(ctx, selector)
+		if err != nil {
+			return checkResult{name: name, err: err}
+		}
+
+		maps.Copy(pods, selected)
+	}
+
+	parser := cronparser.New(cronParserOptions(cfg)...)
+	now := time.Now()
+	scheduled := 0
+
+	var invalid []string
+
+	for _, pod := range slices.Sorted(maps.Keys(pods)) {
+		annotations := pods[pod]
+
+		spec, ok := annotations[cfg.AnnotationRestartScheduleKey]
+		if !ok {
+			continue
+		}
+
+		scheduled++
+
+		if _, err := parser.NextAfter(spec, annotations[cfg.AnnotationTZKey], now); err != nil {
+			invalid = append(invalid, pod+": "+err.Error())
+		}
+	}
+
+	if len(invalid) > 0 {
+		return checkResult{name: name, err: fmt.Errorf("%d of %d invalid: %s", len(invalid), scheduled, strings.Join(invalid, "; "))}
+	}
+
+	return checkResult{name: name, detail: fmt.Sprintf("%d of %d selected pods scheduled", scheduled, len(pods))}
+}
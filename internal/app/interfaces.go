@@ -8,6 +8,7 @@ import (
 	"github.com/skillcoder/preoomkiller-controller/internal/infra/appstate"
 	"github.com/skillcoder/preoomkiller-controller/internal/infra/pinger"
 	"github.com/skillcoder/preoomkiller-controller/internal/infra/shutdown"
+	"github.com/skillcoder/preoomkiller-controller/internal/logic/controller"
 )
 
 // appstater defines the interface for application state management
@@ -58,3 +59,19 @@ type appServer interface {
 	Ready() <-chan struct{}
 	shutdown.Shutdowner
 }
+
+type controllerServer interface {
+	appServer
+	ReconcileCommand(ctx context.Context) error
+	SimulateQuery(ctx context.Context) ([]controller.Decision, error)
+	Cluster() string
+	PendingEvictionsQuery() []controller.ScheduledEviction
+	EnrolledPodsQuery() []controller.EnrolledPod
+	TriggerReconcileCommand()
+	RequestEvictionCommand(ctx context.Context, namespace, name string) (controller.EvictionRequestResult, error)
+	CancelPendingEvictionCommand(ctx context.Context, namespace, name string) (time.Time, error)
+	SuspendedWorkloadsQuery() []controller.SuspendedWorkload
+	ResumeWorkloadCommand(ctx context.Context, namespace, kind, name string) (controller.SuspendedWorkload, error)
+	ReloadCommand(interval time.Duration, labelSelector string)
+	DropCachesCommand(ctx context.Context)
+}
@@ -0,0 +1,80 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/skillcoder/preoomkiller-controller/internal/config"
+	"github.com/skillcoder/preoomkiller-controller/internal/infra/metrics"
+)
+
+// ReloadCommand loads the configuration again and applies the reloadable settings to the running
+// controllers: the reconcile interval, the pod label selector and the Kubernetes API rate limits.
+// Pending scheduled evictions are kept. The other changed settings are reported only, as they need a
+// restart. When the configuration cannot be loaded, the running one is kept.
+func (a *App) ReloadCommand(ctx context.Context) (config.Changes, error) {
+	a.reloadMu.Lock()
+	defer a.reloadMu.Unlock()
+
+	next, err := config.Load(a.cfg.Flags)
+	if err != nil {
+		return config.Changes{}, fmt.Errorf("load config: %w", err)
+	}
+
+	changes := a.cfg.Changes(next)
+
+	a.cfg.Interval = next.Interval
+	a.cfg.PodLabelSelector = next.PodLabelSelector
+	a.cfg.KubeQPS = next.KubeQPS
+	a.cfg.KubeBurst = next.KubeBurst
+
+	for _, c := range a.controllers {
+		c.ReloadCommand(a.cfg.Interval, a.cfg.PodLabelSelector)
+	}
+
+	for _, rateLimiter := range a.rateLimiters {
+		rateLimiter.Set(a.cfg.KubeQPS, a.cfg.KubeBurst)
+	}
+
+	metrics.RecordConfigInfo(configInfo(a.cfg, len(a.controllers)))
+
+	a.logger.InfoContext(ctx, "configuration reloaded",
+		"reloaded", changes.Reloadable,
+		"restartRequired", changes.RestartRequired,
+	)
+
+	if len(changes.RestartRequired) > 0 {
+		a.logger.WarnContext(ctx, "changed settings need a restart to apply",
+			"settings", changes.RestartRequired,
+		)
+	}
+
+	return changes, nil
+}
+
+// HandleReloadSignals reloads the configuration on each signal (SIGHUP) until ctx is done.
+func (a *App) HandleReloadSignals(ctx context.Context, signals <-chan os.Signal) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-signals:
+			a.logger.InfoContext(ctx, "received reload signal, reloading configuration")
+
+			if _, err := a.ReloadCommand(ctx); err != nil {
+				a.logger.ErrorContext(ctx, "reload configuration failed, keeping the running one",
+					"reason", err,
+				)
+			}
+		}
+	}
+}
+
+// effectiveConfig returns the redacted running configuration.
+func (a *App) effectiveConfig() map[string]string {
+	a.reloadMu.Lock()
+	defer a.reloadMu.Unlock()
+
+	return a.cfg.Effective()
+}
@@ -0,0 +1,92 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/skillcoder/preoomkiller-controller/internal/logic/controller"
+)
+
+const (
+	tableMinWidth = 0
+	tableTabWidth = 8
+	tablePadding  = 2
+	tableEmpty    = "-"
+)
+
+// Simulate evaluates all matching pods without modifying anything and prints
+// the would-be decisions as a table to w.
+func (a *App) Simulate(ctx context.Context, w io.Writer) error {
+	var decisions []controller.Decision
+
+	for _, c := range a.controllers {
+		clusterDecisions, err := c.SimulateQuery(ctx)
+		if err != nil {
+			return fmt.Errorf("simulate %s: %w", c.Name(), err)
+		}
+
+		decisions = append(decisions, clusterDecisions...)
+	}
+
+	return writeDecisionsTable(w, decisions)
+}
+
+func writeDecisionsTable(w io.Writer, decisions []controller.Decision) error {
+	tw := tabwriter.NewWriter(w, tableMinWidth, tableTabWidth, tablePadding, ' ', 0)
+
+	// The cluster column is only shown when watching several clusters.
+	multiCluster := len(decisions) > 0 && decisions[0].Cluster != ""
+	if multiCluster {
+		fmt.Fprint(tw, "CLUSTER\t")
+	}
+
+	fmt.Fprintln(tw, "NAMESPACE\tPOD\tTRIGGER\tACTION\tSKIP REASON\tUSAGE\tTHRESHOLD\tRESTART AT")
+
+	for i := range decisions {
+		d := &decisions[i]
+
+		if multiCluster {
+			fmt.Fprintf(tw, "%s\t", d.Cluster)
+		}
+
+		skipReason := tableEmpty
+		if d.SkipReason != "" {
+			skipReason = string(d.SkipReason)
+		}
+
+		restartAt := tableEmpty
+		if d.RestartAt != nil {
+			restartAt = d.RestartAt.Format(time.RFC3339)
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			d.Namespace,
+			d.Name,
+			d.Trigger,
+			d.Action,
+			skipReason,
+			formatQuantity(d.MemoryUsage),
+			formatQuantity(d.MemoryThreshold),
+			restartAt,
+		)
+	}
+
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("write decisions table: %w", err)
+	}
+
+	return nil
+}
+
+func formatQuantity(q *resource.Quantity) string {
+	if q == nil {
+		return tableEmpty
+	}
+
+	return q.String()
+}
@@ -0,0 +1,254 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/skillcoder/preoomkiller-controller/internal/adapters/outbound/evictionhistory"
+)
+
+// ErrStatusEndpoint is returned by Status when an admin endpoint of the controller answers with an
+// unexpected status code.
+var ErrStatusEndpoint = errors.New("unexpected admin endpoint response")
+
+const (
+	// DefaultStatusURL is the base URL queried by Status when none is given, e.g. through
+	// "kubectl port-forward deploy/preoomkiller-controller 8080".
+	DefaultStatusURL = "http://localhost:8080"
+
+	// statusTimeout bounds each request of Status.
+	statusTimeout = 10 * time.Second
+	// statusMaxEvictions is the number of recent evictions printed by Status.
+	statusMaxEvictions = 10
+)
+
+// statusReport is what the admin endpoints of a running controller report. Optional endpoints that
+// are not served leave their fields nil.
+type statusReport struct {
+	Status struct {
+		State  string `json:"state"`
+		Uptime string `json:"uptime"`
+		// SuspendedWorkloads is only reported by controllers.
+		SuspendedWorkloads []struct {
+			Cluster     string    `json:"cluster"`
+			Namespace   string    `json:"namespace"`
+			OwnerKind   string    `json:"ownerKind"`
+			Owner       string    `json:"owner"`
+			Pod         string    `json:"pod"`
+			SuspendedAt time.Time `json:"suspendedAt"`
+		} `json:"suspendedWorkloads"`
+	}
+	Pods *struct {
+		Pods []struct {
+			Cluster         string     `json:"cluster"`
+			Namespace       string     `json:"namespace"`
+			Pod             string     `json:"pod"`
+			MemoryThreshold string     `json:"memoryThreshold"`
+			RestartSchedule string     `json:"restartSchedule"`
+			MemoryUsage     string     `json:"memoryUsage"`
+			CheckedAt       *time.Time `json:"checkedAt"`
+		} `json:"pods"`
+	}
+	Pending *struct {
+		Pending []struct {
+			Cluster   string     `json:"cluster"`
+			Namespace string     `json:"namespace"`
+			Pod       string     `json:"pod"`
+			RestartAt *time.Time `json:"restartAt"`
+			FireAt    time.Time  `json:"fireAt"`
+		} `json:"pending"`
+	}
+	Evictions *struct {
+		Evictions []evictionhistory.Eviction `json:"evictions"`
+	}
+}
+
+// Status queries the admin endpoints of the controller served at baseURL and writes its state,
+// suspended workloads, enrolled pods, pending scheduled restarts and recent evictions as tables to w.
+func Status(ctx context.Context, w io.Writer, baseURL string) error {
+	client := &http.Client{Timeout: statusTimeout}
+	baseURL = strings.TrimSuffix(baseURL, "/")
+
+	var report statusReport
+
+	served, err := getJSON(ctx, client, baseURL+"/-/status", &report.Status)
+	if err != nil {
+		return err
+	}
+
+	if !served {
+		return fmt.Errorf("%w: %s is not a preoomkiller-controller", ErrStatusEndpoint, baseURL)
+	}
+
+	optional := []struct {
+		path   string
+		target any
+	}{
+		{"/-/pods", &report.Pods},
+		{"/-/pending", &report.Pending},
+		{"/-/evictions", &report.Evictions},
+	}
+
+	for _, endpoint := range optional {
+		if _, err := getJSON(ctx, client, baseURL+endpoint.path, endpoint.target); err != nil {
+			return err
+		}
+	}
+
+	return writeStatusReport(w, &report)
+}
+
+// getJSON decodes the JSON body of a GET of url into target. Returns false without error when the
+// endpoint is not served.
+func getJSON(ctx context.Context, client *http.Client, url string, target any) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+	if err != nil {
+		return false, fmt.Errorf("new request: %w", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("get %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, fmt.Errorf("%w: get %s: %s", ErrStatusEndpoint, url, resp.Status)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(target); err != nil {
+		return false, fmt.Errorf("decode %s: %w", url, err)
+	}
+
+	return true, nil
+}
+
+func writeStatusReport(w io.Writer, report *statusReport) error {
+	tw := tabwriter.NewWriter(w, tableMinWidth, tableTabWidth, tablePadding, ' ', 0)
+
+	fmt.Fprintf(tw, "STATE\t%s\nUPTIME\t%s\n", report.Status.State, report.Status.Uptime)
+
+	// The cluster column is only shown when watching several clusters.
+	cluster := func(string) string { return "" }
+	if report.multiCluster() {
+		cluster = func(name string) string { return name + "\t" }
+	}
+
+	if len(report.Status.SuspendedWorkloads) > 0 {
+		fmt.Fprintln(tw, "\n"+cluster("CLUSTER")+"NAMESPACE\tSUSPENDED WORKLOAD\tEVICTED POD\tSUSPENDED AT")
+
+		for _, s := range report.Status.SuspendedWorkloads {
+			fmt.Fprintf(tw, "%s%s\t%s\t%s\t%s\n",
+				cluster(s.Cluster),
+				s.Namespace,
+				strings.ToLower(s.OwnerKind)+"/"+s.Owner,
+				s.Pod,
+				formatTime(&s.SuspendedAt),
+			)
+		}
+	}
+
+	if report.Pods != nil {
+		fmt.Fprintln(tw, "\n"+cluster("CLUSTER")+"NAMESPACE\tPOD\tTHRESHOLD\tUSAGE\tCHECKED AT\tRESTART SCHEDULE")
+
+		for _, p := range report.Pods.Pods {
+			fmt.Fprintf(tw, "%s%s\t%s\t%s\t%s\t%s\t%s\n",
+				cluster(p.Cluster),
+				p.Namespace,
+				p.Pod,
+				orEmpty(p.MemoryThreshold),
+				orEmpty(p.MemoryUsage),
+				formatTime(p.CheckedAt),
+				orEmpty(p.RestartSchedule),
+			)
+		}
+	}
+
+	if report.Pending != nil {
+		fmt.Fprintln(tw, "\n"+cluster("CLUSTER")+"NAMESPACE\tPOD\tRESTART AT\tFIRE AT")
+
+		for _, p := range report.Pending.Pending {
+			fmt.Fprintf(tw, "%s%s\t%s\t%s\t%s\n",
+				cluster(p.Cluster),
+				p.Namespace,
+				p.Pod,
+				formatTime(p.RestartAt),
+				formatTime(&p.FireAt),
+			)
+		}
+	}
+
+	if report.Evictions != nil {
+		fmt.Fprintln(tw, "\n"+cluster("CLUSTER")+"NAMESPACE\tPOD\tEVICTED AT\tTRIGGER\tREASON")
+
+		evictions := report.Evictions.Evictions
+		if len(evictions) > statusMaxEvictions {
+			evictions = evictions[:statusMaxEvictions]
+		}
+
+		for i := range evictions {
+			e := &evictions[i]
+
+			fmt.Fprintf(tw, "%s%s\t%s\t%s\t%s\t%s\n",
+				cluster(e.Cluster),
+				e.Namespace,
+				e.Pod,
+				formatTime(&e.Time),
+				e.Trigger,
+				orEmpty(e.Reason),
+			)
+		}
+	}
+
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("flush status report: %w", err)
+	}
+
+	return nil
+}
+
+// multiCluster reports whether the controller watches several clusters, its entries then naming
+// their cluster.
+func (r *statusReport) multiCluster() bool {
+	switch {
+	case len(r.Status.SuspendedWorkloads) > 0:
+		return r.Status.SuspendedWorkloads[0].Cluster != ""
+	case r.Pods != nil && len(r.Pods.Pods) > 0:
+		return r.Pods.Pods[0].Cluster != ""
+	case r.Pending != nil && len(r.Pending.Pending) > 0:
+		return r.Pending.Pending[0].Cluster != ""
+	case r.Evictions != nil && len(r.Evictions.Evictions) > 0:
+		return r.Evictions.Evictions[0].Cluster != ""
+	default:
+		return false
+	}
+}
+
+// orEmpty returns s, or tableEmpty when s is empty.
+func orEmpty(s string) string {
+	if s == "" {
+		return tableEmpty
+	}
+
+	return s
+}
+
+// formatTime formats t as RFC 3339, or tableEmpty when t is nil.
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return tableEmpty
+	}
+
+	return t.Format(time.RFC3339)
+}
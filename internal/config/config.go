@@ -1,95 +1,875 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"maps"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/skillcoder/preoomkiller-controller/internal/infra/memguard"
 	"github.com/skillcoder/preoomkiller-controller/internal/logic/controller"
 )
 
+// ErrNamespaceRequired is returned when a setting needs the controller namespace but it is unknown.
+var ErrNamespaceRequired = errors.New("controller namespace required")
+
+// ErrInvalidMetricsSource is returned for an unknown PREOOMKILLER_METRICS_SOURCE.
+var ErrInvalidMetricsSource = errors.New("invalid metrics source")
+
+// ErrPrometheusURLRequired is returned when the Prometheus metrics source is selected without a URL.
+var ErrPrometheusURLRequired = errors.New("prometheus url required")
+
+// ErrInvalidMemoryMetric is returned for an unknown PREOOMKILLER_MEMORY_METRIC.
+var ErrInvalidMemoryMetric = errors.New("invalid memory metric")
+
+// ErrMemoryMetricUnsupported is returned when the memory metric is not available from the metrics source.
+var ErrMemoryMetricUnsupported = errors.New("memory metric not supported by metrics source")
+
+// ErrInvalidVPAMode is returned for an unknown PREOOMKILLER_VPA_MODE.
+var ErrInvalidVPAMode = errors.New("invalid vpa mode")
+
+// ErrTLSKeyPairRequired is returned when only part of the TLS certificate and key is configured.
+var ErrTLSKeyPairRequired = errors.New("tls certificate and key required")
+
+// ErrInvalidKeyValue is returned for an entry of a key=value list without a key or value.
+var ErrInvalidKeyValue = errors.New("invalid key=value entry")
+
+// ErrBelowMinimum is returned for a setting below its minimum, e.g. an interval that would hammer the
+// API server.
+var ErrBelowMinimum = errors.New("value below minimum")
+
+// ErrUnknownSetting is returned for a setting of the configuration file that does not exist.
+var ErrUnknownSetting = errors.New("unknown setting")
+
+// ErrInvalidSettingValue is returned for a value of the configuration file that is neither a scalar
+// nor a list or map of scalars.
+var ErrInvalidSettingValue = errors.New("invalid setting value")
+
+// ErrInvalidBuckets is returned for histogram buckets that are not positive and increasing.
+var ErrInvalidBuckets = errors.New("invalid histogram buckets")
+
+// defaultPingerLatencyBuckets are the default buckets of the pinger latency histogram.
+const defaultPingerLatencyBuckets = "5ms,10ms,25ms,50ms,100ms,250ms,500ms,1s,2.5s,5s"
+
+// defaultTerminationFile is the termination file of the signal-file convention, written by a preStop
+// hook to a shared volume.
+const defaultTerminationFile = "/mnt/signal/terminating"
+
+// VPAModeOff disables VerticalPodAutoscaler integration.
+const VPAModeOff = "off"
+
+// ErrInvalidPodDiscovery is returned for an unknown PREOOMKILLER_POD_DISCOVERY.
+var ErrInvalidPodDiscovery = errors.New("invalid pod discovery")
+
+// How the controller finds the pods to act on.
+const (
+	// PodDiscoveryLabel selects pods with PREOOMKILLER_POD_LABEL_SELECTOR.
+	PodDiscoveryLabel = "label"
+	// PodDiscoveryAnnotation selects pods having a memory threshold, restart schedule or window, or
+	// PromQL annotation, watched with an informer.
+	PodDiscoveryAnnotation = "annotation"
+)
+
+// Memory metrics compared against thresholds.
+const (
+	MemoryMetricWorkingSet = "working_set"
+	MemoryMetricRSS        = "rss"
+	MemoryMetricUsage      = "usage"
+)
+
+// Sources of pod memory usage.
+const (
+	MetricsSourceMetricsServer = "metrics-server"
+	MetricsSourcePrometheus    = "prometheus"
+)
+
+// maxPercent is the exclusive upper bound for percentage settings.
+const maxPercent = 100
+
+// Default alert conditions of the Alertmanager notifier.
+const (
+	defaultAlertmanagerEvictions = 3
+	defaultAlertmanagerFailures  = 3
+)
+
+// defaultEvictionHistorySize is the default number of evictions served on /-/evictions.
+const defaultEvictionHistorySize = 100
+
+// defaultUsageHistorySize is the default number of memory usage samples kept per pod.
+const defaultUsageHistorySize = 10
+
 type Config struct {
-	KubeConfig                   string
-	KubeMaster                   string
+	KubeConfig                string
+	KubeMaster                string
+	Namespace                 string
+	RestartRecordConfigMap    string
+	PendingEvictionsConfigMap string
+	MetricsSource             string
+	PrometheusURL             string
+	PrometheusQuery           string
+	MemoryMetric              string
+	ContainerAggregation      string
+	VPAMode                   string
+	KubeQPS                   float32
+	KubeBurst                 int
+	KubeProtobuf              bool
+	NodeName                  string
+	APICallTimeout            time.Duration
+	// Contexts are the kubeconfig contexts of the clusters to watch; empty watches a single cluster.
+	Contexts                     []string
 	Interval                     time.Duration
+	ReconcileConcurrency         int
+	ReconcilePodPacing           time.Duration
+	ReconcileTimeout             time.Duration
 	PingerInterval               time.Duration
 	LogLevel                     string
 	LogFormat                    string
+	AuditLog                     string
+	WebhookURL                   string
+	WebhookTimeout               time.Duration
 	HTTPPort                     string
 	MetricsPort                  string
+	GRPCPort                     string
 	PodLabelSelector             string
 	AnnotationMemoryThresholdKey string
 	AnnotationRestartScheduleKey string
 	AnnotationTZKey              string
 	RestartScheduleJitterMax     time.Duration
+	DeterministicJitter          bool
+	RestartScheduleSpread        time.Duration
+	RestartMinInterval           time.Duration
+	CronSeconds                  bool
+	CronDescriptors              bool
+	SerialRestart                bool
+	SerialRestartReadyTimeout    time.Duration
+	CanarySoak                   time.Duration
 	MinPodAgeBeforeEviction      time.Duration
+	EvictionVerifyTimeout        time.Duration
+	RetryBaseDelay               time.Duration
+	RetryMaxDelay                time.Duration
+	BlackoutWindows              string
+	BlackoutTZ                   string
+	PushgatewayURL               string
+	RunOnce                      bool
+	OOMThresholdTightenPercent   float64
+	MinReadyReplicas             int
+	MetricsBlindReconciles       int
+	PodMemoryGaugesMaxPods       int
+	UsageHistorySize             int
+	ObservedAnnotationsInterval  time.Duration
+	TracingEnabled               bool
+	SlackWebhookURL              string
+	// SlackNamespaceWebhooks maps namespaces to the Slack webhooks receiving their messages.
+	SlackNamespaceWebhooks map[string]string
+	SlackChannel           string
+	SlackTemplate          string
+	AlertmanagerURL        string
+	AlertmanagerEvictions  int
+	AlertmanagerFailures   int
+	AlertmanagerWindow     time.Duration
+	NATSURL                string
+	NATSSubject            string
+	NATSCredsFile          string
+	NATSToken              string
+	NATSUser               string
+	NATSPassword           string
+	EvictionHistorySize    int
+	EvictionHistoryFile    string
+	AdminToken             string
+	AdminTokenReview       bool
+	TLSCertFile            string
+	TLSKeyFile             string
+	TLSClientCAFile        string
+	PprofEnabled           bool
+	DashboardEnabled       bool
+	SelfMemoryWatermark    string
+	SelfMemoryExit         bool
+	ConfigDir              string
+	ConfigFile             string
+	NamespaceDefaults      bool
+	DefaultMemoryThreshold string
+	PodDiscovery           string
+	WatchAnnotations       bool
+	PingerJitter           time.Duration
+	PingerFlapTransitions  int
+	PingerFlapWindow       time.Duration
+	PingerFlapHoldReady    bool
+	PingerFailureThreshold int
+	PingerSuccessThreshold int
+	// PingerLatencyBuckets are the upper bounds of the buckets of the pinger latency histogram.
+	PingerLatencyBuckets []time.Duration
+	PingerStartupGrace   time.Duration
+	ReadyAfterReconcile  bool
+	ShutdownDrainDelay   time.Duration
+	// TerminationFile is the path of the termination file; empty when the check is disabled.
+	TerminationFile        string
+	TerminationFileEnabled bool
+	// Flags are the settings given on the command line, kept for reloads.
+	Flags Flags
+}
+
+// Load reads the configuration from the PREOOMKILLER_CONFIG_FILE, overridden by the environment,
+// overridden by the files of PREOOMKILLER_CONFIG_DIR, overridden by flags. It is called again to
+// reload the configuration.
+func Load(flags Flags) (*Config, error) {
+	configFile := env(flags).get(envKeyConfigFile)
+
+	e, err := readConfigFile(configFile)
+	if err != nil {
+		return nil, fmt.Errorf("read config file: %s: %w", envKeyConfigFile, err)
+	}
+
+	// The environment overrides the file.
+	for key := range e {
+		if os.Getenv(key) != "" {
+			delete(e, key)
+		}
+	}
+
+	maps.Copy(e, flags)
+	configDir := e.get(envKeyConfigDir)
+
+	dir, err := readConfigDir(configDir)
+	if err != nil {
+		return nil, fmt.Errorf("read config dir: %s: %w", envKeyConfigDir, err)
+	}
+
+	maps.Copy(e, dir)
+	maps.Copy(e, flags)
+
+	cfg, err := e.load()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.ConfigDir = configDir
+	cfg.ConfigFile = configFile
+	cfg.Flags = flags
+
+	return cfg, nil
 }
 
-func Load() (*Config, error) {
+// load parses the settings looked up in e.
+func (e env) load() (*Config, error) {
 	cfg := &Config{
-		KubeConfig:       getEnvWithFallback(envKeyKubeConfig, envKeyKubeConfigFallback),
-		KubeMaster:       getEnvWithFallback(envKeyKubeMaster, envKeyKubeMasterFallback),
-		LogLevel:         getEnvOrDefault(envKeyLogLevel, "info"),
-		LogFormat:        getEnvOrDefault(envKeyLogFormat, "json"),
-		HTTPPort:         getEnvOrDefault(envKeyHTTPPort, "8080"),
-		MetricsPort:      getEnvOrDefault(envKeyMetricsPort, "9090"),
-		PodLabelSelector: getEnvOrDefault(envKeyPodLabelSelector, controller.PreoomkillerPodLabelSelector),
-		AnnotationMemoryThresholdKey: getEnvOrDefault(
+		KubeConfig:       e.getEnvWithFallback(envKeyKubeConfig, envKeyKubeConfigFallback),
+		KubeMaster:       e.getEnvWithFallback(envKeyKubeMaster, envKeyKubeMasterFallback),
+		Namespace:        e.getEnvWithFallback(envKeyNamespace, envKeyNamespaceFallback),
+		LogLevel:         e.getEnvOrDefault(envKeyLogLevel, "info"),
+		LogFormat:        e.getEnvOrDefault(envKeyLogFormat, "json"),
+		AuditLog:         e.get(envKeyAuditLog),
+		WebhookURL:       e.get(envKeyWebhookURL),
+		SlackWebhookURL:  e.get(envKeySlackWebhookURL),
+		SlackChannel:     e.get(envKeySlackChannel),
+		SlackTemplate:    e.get(envKeySlackTemplate),
+		AlertmanagerURL:  e.get(envKeyAlertmanagerURL),
+		NATSURL:          e.get(envKeyNATSURL),
+		NATSSubject:      e.getEnvOrDefault(envKeyNATSSubject, "preoomkiller.events"),
+		NATSCredsFile:    e.get(envKeyNATSCredsFile),
+		NATSToken:        e.get(envKeyNATSToken),
+		NATSUser:         e.get(envKeyNATSUser),
+		NATSPassword:     e.get(envKeyNATSPassword),
+		HTTPPort:         e.getEnvOrDefault(envKeyHTTPPort, "8080"),
+		MetricsPort:      e.getEnvOrDefault(envKeyMetricsPort, "9090"),
+		GRPCPort:         e.get(envKeyGRPCPort),
+		PodLabelSelector: e.getEnvOrDefault(envKeyPodLabelSelector, controller.PreoomkillerPodLabelSelector),
+		AnnotationMemoryThresholdKey: e.getEnvOrDefault(
 			envKeyAnnotationMemoryThreshold,
 			controller.PreoomkillerAnnotationMemoryThresholdKey,
 		),
-		AnnotationRestartScheduleKey: getEnvOrDefault(
+		AnnotationRestartScheduleKey: e.getEnvOrDefault(
 			envKeyAnnotationRestartSchedule,
 			controller.PreoomkillerAnnotationRestartScheduleKey,
 		),
-		AnnotationTZKey: getEnvOrDefault(
+		AnnotationTZKey: e.getEnvOrDefault(
 			envKeyAnnotationTZ,
 			controller.PreoomkillerAnnotationTZKey,
 		),
+		BlackoutWindows:           e.get(envKeyBlackoutWindows),
+		BlackoutTZ:                e.get(envKeyBlackoutTZ),
+		PushgatewayURL:            e.get(envKeyPushgatewayURL),
+		RestartRecordConfigMap:    e.get(envKeyRestartRecordConfigMap),
+		PendingEvictionsConfigMap: e.get(envKeyPendingEvictionsConfigMap),
+		MetricsSource:             e.getEnvOrDefault(envKeyMetricsSource, MetricsSourceMetricsServer),
+		PrometheusURL:             e.get(envKeyPrometheusURL),
+		PrometheusQuery:           e.get(envKeyPrometheusQuery),
+		MemoryMetric:              e.getEnvOrDefault(envKeyMemoryMetric, MemoryMetricWorkingSet),
+		ContainerAggregation:      e.getEnvOrDefault(envKeyContainerAggregation, controller.ContainerAggregationSum),
+		VPAMode:                   e.getEnvOrDefault(envKeyVPAMode, VPAModeOff),
+		NodeName:                  e.get(envKeyNodeName),
+		Contexts:                  e.parseListEnv(envKeyContexts),
+		PodDiscovery:              e.getEnvOrDefault(envKeyPodDiscovery, PodDiscoveryLabel),
 	}
 
 	var err error
 
-	cfg.PingerInterval, err = parseDurationEnv(envKeyPingerInterval, "10s", envMinPingerInterval)
+	cfg.PingerInterval, err = e.parseDurationEnv(envKeyPingerInterval, "10s", envMinPingerInterval)
 	if err != nil {
 		return nil, fmt.Errorf("parse duration env: %s: %w", envKeyPingerInterval, err)
 	}
 
-	cfg.Interval, err = parseDurationEnv(envKeyInterval, "300s", envMinInterval)
+	cfg.PingerJitter, err = e.parseDurationEnv(envKeyPingerJitter, "0s", 0)
+	if err != nil {
+		return nil, fmt.Errorf("parse duration env: %s: %w", envKeyPingerJitter, err)
+	}
+
+	cfg.PingerFlapTransitions, err = e.parseNonNegativeIntEnv(envKeyPingerFlapTransitions)
+	if err != nil {
+		return nil, fmt.Errorf("parse int env: %s: %w", envKeyPingerFlapTransitions, err)
+	}
+
+	cfg.PingerFlapWindow, err = e.parseDurationEnv(envKeyPingerFlapWindow, "5m", 0)
+	if err != nil {
+		return nil, fmt.Errorf("parse duration env: %s: %w", envKeyPingerFlapWindow, err)
+	}
+
+	cfg.PingerFlapHoldReady, err = e.parseBoolEnv(envKeyPingerFlapHoldReady, false)
+	if err != nil {
+		return nil, fmt.Errorf("parse bool env: %s: %w", envKeyPingerFlapHoldReady, err)
+	}
+
+	cfg.PingerFailureThreshold, err = e.parsePositiveIntEnv(envKeyPingerFailureThreshold, 1)
+	if err != nil {
+		return nil, fmt.Errorf("parse int env: %s: %w", envKeyPingerFailureThreshold, err)
+	}
+
+	cfg.PingerSuccessThreshold, err = e.parsePositiveIntEnv(envKeyPingerSuccessThreshold, 1)
+	if err != nil {
+		return nil, fmt.Errorf("parse int env: %s: %w", envKeyPingerSuccessThreshold, err)
+	}
+
+	cfg.PingerLatencyBuckets, err = e.parseBucketsEnv(envKeyPingerLatencyBuckets, defaultPingerLatencyBuckets)
+	if err != nil {
+		return nil, fmt.Errorf("parse buckets env: %s: %w", envKeyPingerLatencyBuckets, err)
+	}
+
+	cfg.PingerStartupGrace, err = e.parseDurationEnv(envKeyPingerStartupGrace, "0s", 0)
+	if err != nil {
+		return nil, fmt.Errorf("parse duration env: %s: %w", envKeyPingerStartupGrace, err)
+	}
+
+	cfg.MetricsBlindReconciles, err = e.parseNonNegativeIntEnv(envKeyMetricsBlindReconciles)
+	if err != nil {
+		return nil, fmt.Errorf("parse int env: %s: %w", envKeyMetricsBlindReconciles, err)
+	}
+
+	cfg.ReadyAfterReconcile, err = e.parseBoolEnv(envKeyReadyAfterReconcile, false)
+	if err != nil {
+		return nil, fmt.Errorf("parse bool env: %s: %w", envKeyReadyAfterReconcile, err)
+	}
+
+	cfg.ShutdownDrainDelay, err = e.parseDurationEnv(envKeyShutdownDrainDelay, "0s", 0)
+	if err != nil {
+		return nil, fmt.Errorf("parse duration env: %s: %w", envKeyShutdownDrainDelay, err)
+	}
+
+	cfg.TerminationFileEnabled, err = e.parseBoolEnv(envKeyTerminationFileEnabled, true)
+	if err != nil {
+		return nil, fmt.Errorf("parse bool env: %s: %w", envKeyTerminationFileEnabled, err)
+	}
+
+	if cfg.TerminationFileEnabled {
+		cfg.TerminationFile = e.getEnvOrDefault(envKeyTerminationFile, defaultTerminationFile)
+	}
+
+	cfg.Interval, err = e.parseDurationEnv(envKeyInterval, "300s", envMinInterval)
 	if err != nil {
 		return nil, fmt.Errorf("parse duration env: %s: %w", envKeyInterval, err)
 	}
 
-	cfg.RestartScheduleJitterMax, err = parseDurationEnv(envKeyRestartScheduleJitterMax, "30s", envMinRestartScheduleJitterMax)
+	cfg.ReconcileConcurrency, err = e.parsePositiveIntEnv(envKeyReconcileConcurrency, 1)
+	if err != nil {
+		return nil, fmt.Errorf("parse int env: %s: %w", envKeyReconcileConcurrency, err)
+	}
+
+	cfg.ReconcilePodPacing, err = e.parseDurationEnv(envKeyReconcilePodPacing, "1s", 0)
+	if err != nil {
+		return nil, fmt.Errorf("parse duration env: %s: %w", envKeyReconcilePodPacing, err)
+	}
+
+	cfg.ReconcileTimeout, err = e.parseDurationEnv(envKeyReconcileTimeout, "0s", 0)
+	if err != nil {
+		return nil, fmt.Errorf("parse duration env: %s: %w", envKeyReconcileTimeout, err)
+	}
+
+	cfg.RestartScheduleJitterMax, err = e.parseDurationEnv(envKeyRestartScheduleJitterMax, "30s", envMinRestartScheduleJitterMax)
 	if err != nil {
 		return nil, fmt.Errorf("parse duration env: %s: %w", envKeyRestartScheduleJitterMax, err)
 	}
 
-	cfg.MinPodAgeBeforeEviction, err = parseDurationEnv(envKeyMinPodAgeBeforeEviction, "30m", envMinMinPodAgeBeforeEviction)
+	cfg.DeterministicJitter, err = e.parseBoolEnv(envKeyRestartScheduleJitterDeterministic, false)
+	if err != nil {
+		return nil, fmt.Errorf("parse bool env: %s: %w", envKeyRestartScheduleJitterDeterministic, err)
+	}
+
+	cfg.RestartScheduleSpread, err = e.parseDurationEnv(envKeyRestartScheduleSpread, "0s", 0)
+	if err != nil {
+		return nil, fmt.Errorf("parse duration env: %s: %w", envKeyRestartScheduleSpread, err)
+	}
+
+	cfg.RestartMinInterval, err = e.parseDurationEnv(envKeyRestartMinInterval, "0s", 0)
+	if err != nil {
+		return nil, fmt.Errorf("parse duration env: %s: %w", envKeyRestartMinInterval, err)
+	}
+
+	cfg.APICallTimeout, err = e.parseDurationEnv(envKeyAPICallTimeout, "30s", 0)
+	if err != nil {
+		return nil, fmt.Errorf("parse duration env: %s: %w", envKeyAPICallTimeout, err)
+	}
+
+	cfg.WebhookTimeout, err = e.parseDurationEnv(envKeyWebhookTimeout, "5s", time.Second)
+	if err != nil {
+		return nil, fmt.Errorf("parse duration env: %s: %w", envKeyWebhookTimeout, err)
+	}
+
+	cfg.SlackNamespaceWebhooks, err = e.parseMapEnv(envKeySlackNamespaceWebhooks)
+	if err != nil {
+		return nil, fmt.Errorf("parse map env: %s: %w", envKeySlackNamespaceWebhooks, err)
+	}
+
+	cfg.EvictionHistoryFile = e.get(envKeyEvictionHistoryFile)
+	cfg.AdminToken = e.get(envKeyAdminToken)
+	cfg.TLSCertFile = e.get(envKeyTLSCertFile)
+	cfg.TLSKeyFile = e.get(envKeyTLSKeyFile)
+	cfg.TLSClientCAFile = e.get(envKeyTLSClientCAFile)
+
+	if err := validateTLS(cfg); err != nil {
+		return nil, err
+	}
+
+	cfg.EvictionHistorySize, err = e.parsePositiveIntEnv(envKeyEvictionHistorySize, defaultEvictionHistorySize)
+	if err != nil {
+		return nil, fmt.Errorf("parse int env: %s: %w", envKeyEvictionHistorySize, err)
+	}
+
+	cfg.AdminTokenReview, err = e.parseBoolEnv(envKeyAdminTokenReview, false)
+	if err != nil {
+		return nil, fmt.Errorf("parse bool env: %s: %w", envKeyAdminTokenReview, err)
+	}
+
+	if err := e.parseAlertmanagerEnv(cfg); err != nil {
+		return nil, err
+	}
+
+	cfg.CronSeconds, err = e.parseBoolEnv(envKeyCronSeconds, false)
+	if err != nil {
+		return nil, fmt.Errorf("parse bool env: %s: %w", envKeyCronSeconds, err)
+	}
+
+	cfg.CronDescriptors, err = e.parseBoolEnv(envKeyCronDescriptors, false)
+	if err != nil {
+		return nil, fmt.Errorf("parse bool env: %s: %w", envKeyCronDescriptors, err)
+	}
+
+	cfg.DefaultMemoryThreshold = strings.TrimSpace(e.get(envKeyDefaultMemoryThreshold))
+
+	cfg.NamespaceDefaults, err = e.parseBoolEnv(envKeyNamespaceDefaults, false)
+	if err != nil {
+		return nil, fmt.Errorf("parse bool env: %s: %w", envKeyNamespaceDefaults, err)
+	}
+
+	cfg.WatchAnnotations, err = e.parseBoolEnv(envKeyWatchAnnotations, false)
+	if err != nil {
+		return nil, fmt.Errorf("parse bool env: %s: %w", envKeyWatchAnnotations, err)
+	}
+
+	cfg.SerialRestart, err = e.parseBoolEnv(envKeySerialRestart, false)
+	if err != nil {
+		return nil, fmt.Errorf("parse bool env: %s: %w", envKeySerialRestart, err)
+	}
+
+	cfg.SerialRestartReadyTimeout, err = e.parseDurationEnv(
+		envKeySerialRestartReadyTimeout,
+		"5m",
+		envMinSerialRestartReadyTimeout,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("parse duration env: %s: %w", envKeySerialRestartReadyTimeout, err)
+	}
+
+	cfg.CanarySoak, err = e.parseDurationEnv(envKeyCanarySoak, "0s", 0)
+	if err != nil {
+		return nil, fmt.Errorf("parse duration env: %s: %w", envKeyCanarySoak, err)
+	}
+
+	cfg.MinPodAgeBeforeEviction, err = e.parseOptionalDurationEnv(envKeyMinPodAgeBeforeEviction, "30m", envMinMinPodAgeBeforeEviction)
 	if err != nil {
 		return nil, fmt.Errorf("parse duration env: %s: %w", envKeyMinPodAgeBeforeEviction, err)
 	}
 
+	cfg.EvictionVerifyTimeout, err = e.parseDurationEnv(envKeyEvictionVerifyTimeout, "0s", 0)
+	if err != nil {
+		return nil, fmt.Errorf("parse duration env: %s: %w", envKeyEvictionVerifyTimeout, err)
+	}
+
+	cfg.RetryBaseDelay, err = e.parseDurationEnv(envKeyRetryBaseDelay, "0s", 0)
+	if err != nil {
+		return nil, fmt.Errorf("parse duration env: %s: %w", envKeyRetryBaseDelay, err)
+	}
+
+	cfg.RetryMaxDelay, err = e.parseDurationEnv(envKeyRetryMaxDelay, "5m", 0)
+	if err != nil {
+		return nil, fmt.Errorf("parse duration env: %s: %w", envKeyRetryMaxDelay, err)
+	}
+
+	cfg.RunOnce, err = e.parseBoolEnv(envKeyRunOnce, false)
+	if err != nil {
+		return nil, fmt.Errorf("parse bool env: %s: %w", envKeyRunOnce, err)
+	}
+
+	cfg.OOMThresholdTightenPercent, err = e.parsePercentEnv(envKeyOOMThresholdTightenPercent)
+	if err != nil {
+		return nil, fmt.Errorf("parse percent env: %s: %w", envKeyOOMThresholdTightenPercent, err)
+	}
+
+	if cfg.RestartRecordConfigMap != "" && cfg.Namespace == "" {
+		return nil, fmt.Errorf("%w: %s is set but %s is empty", ErrNamespaceRequired, envKeyRestartRecordConfigMap, envKeyNamespace)
+	}
+
+	if cfg.PendingEvictionsConfigMap != "" && cfg.Namespace == "" {
+		return nil, fmt.Errorf("%w: %s is set but %s is empty", ErrNamespaceRequired, envKeyPendingEvictionsConfigMap, envKeyNamespace)
+	}
+
+	cfg.MinReadyReplicas, err = e.parseNonNegativeIntEnv(envKeyMinReadyReplicas)
+	if err != nil {
+		return nil, fmt.Errorf("parse int env: %s: %w", envKeyMinReadyReplicas, err)
+	}
+
+	cfg.PodMemoryGaugesMaxPods, err = e.parseNonNegativeIntEnv(envKeyPodMemoryGaugesMaxPods)
+	if err != nil {
+		return nil, fmt.Errorf("parse int env: %s: %w", envKeyPodMemoryGaugesMaxPods, err)
+	}
+
+	cfg.UsageHistorySize, err = e.parsePositiveIntEnv(envKeyUsageHistorySize, defaultUsageHistorySize)
+	if err != nil {
+		return nil, fmt.Errorf("parse int env: %s: %w", envKeyUsageHistorySize, err)
+	}
+
+	cfg.ObservedAnnotationsInterval, err = e.parseDurationEnv(envKeyObservedAnnotationsInterval, "0s", 0)
+	if err != nil {
+		return nil, fmt.Errorf("parse duration env: %s: %w", envKeyObservedAnnotationsInterval, err)
+	}
+
+	cfg.KubeQPS, err = e.parseFloat32Env(envKeyKubeQPS)
+	if err != nil {
+		return nil, fmt.Errorf("parse float env: %s: %w", envKeyKubeQPS, err)
+	}
+
+	cfg.KubeBurst, err = e.parseNonNegativeIntEnv(envKeyKubeBurst)
+	if err != nil {
+		return nil, fmt.Errorf("parse int env: %s: %w", envKeyKubeBurst, err)
+	}
+
+	cfg.KubeProtobuf, err = e.parseBoolEnv(envKeyKubeProtobuf, true)
+	if err != nil {
+		return nil, fmt.Errorf("parse bool env: %s: %w", envKeyKubeProtobuf, err)
+	}
+
+	cfg.TracingEnabled, err = e.parseBoolEnv(envKeyTracingEnabled, false)
+	if err != nil {
+		return nil, fmt.Errorf("parse bool env: %s: %w", envKeyTracingEnabled, err)
+	}
+
+	cfg.PprofEnabled, err = e.parseBoolEnv(envKeyPprofEnabled, false)
+	if err != nil {
+		return nil, fmt.Errorf("parse bool env: %s: %w", envKeyPprofEnabled, err)
+	}
+
+	cfg.DashboardEnabled, err = e.parseBoolEnv(envKeyDashboardEnabled, false)
+	if err != nil {
+		return nil, fmt.Errorf("parse bool env: %s: %w", envKeyDashboardEnabled, err)
+	}
+
+	cfg.SelfMemoryWatermark = strings.TrimSpace(e.get(envKeySelfMemoryWatermark))
+	if cfg.SelfMemoryWatermark != "" {
+		if _, err := memguard.ParseWatermark(cfg.SelfMemoryWatermark); err != nil {
+			return nil, fmt.Errorf("%s: %w", envKeySelfMemoryWatermark, err)
+		}
+	}
+
+	cfg.SelfMemoryExit, err = e.parseBoolEnv(envKeySelfMemoryExit, false)
+	if err != nil {
+		return nil, fmt.Errorf("parse bool env: %s: %w", envKeySelfMemoryExit, err)
+	}
+
+	if err := validateMetricsSource(cfg); err != nil {
+		return nil, err
+	}
+
+	if err := controller.ValidateContainerAggregation(cfg.ContainerAggregation); err != nil {
+		return nil, fmt.Errorf("%s: %w", envKeyContainerAggregation, err)
+	}
+
+	if cfg.DefaultMemoryThreshold != "" {
+		if err := controller.ValidateMemoryThreshold(cfg.DefaultMemoryThreshold); err != nil {
+			return nil, fmt.Errorf("%s: %w", envKeyDefaultMemoryThreshold, err)
+		}
+	}
+
+	switch controller.VPAMode(cfg.VPAMode) {
+	case VPAModeOff, controller.VPAModeUpperBound, controller.VPAModeDefer:
+	default:
+		return nil, fmt.Errorf("%w: %s: %q", ErrInvalidVPAMode, envKeyVPAMode, cfg.VPAMode)
+	}
+
+	if cfg.PodDiscovery != PodDiscoveryLabel && cfg.PodDiscovery != PodDiscoveryAnnotation {
+		return nil, fmt.Errorf("%w: %s: %q", ErrInvalidPodDiscovery, envKeyPodDiscovery, cfg.PodDiscovery)
+	}
+
 	return cfg, nil
 }
 
-func parseDurationEnv(key, defaultVal string, minDuration time.Duration) (time.Duration, error) {
-	s := getEnvOrDefault(key, defaultVal)
+func validateTLS(cfg *Config) error {
+	if (cfg.TLSCertFile == "") != (cfg.TLSKeyFile == "") {
+		return fmt.Errorf("%w: %s and %s must be set together", ErrTLSKeyPairRequired, envKeyTLSCertFile, envKeyTLSKeyFile)
+	}
+
+	if cfg.TLSClientCAFile != "" && cfg.TLSCertFile == "" {
+		return fmt.Errorf("%w: %s is set but %s is empty", ErrTLSKeyPairRequired, envKeyTLSClientCAFile, envKeyTLSCertFile)
+	}
+
+	return nil
+}
+
+func validateMetricsSource(cfg *Config) error {
+	switch cfg.MemoryMetric {
+	case MemoryMetricWorkingSet, MemoryMetricRSS, MemoryMetricUsage:
+	default:
+		return fmt.Errorf("%w: %s: %q", ErrInvalidMemoryMetric, envKeyMemoryMetric, cfg.MemoryMetric)
+	}
+
+	switch cfg.MetricsSource {
+	case MetricsSourceMetricsServer:
+		// metrics.k8s.io reports the working set only.
+		if cfg.MemoryMetric != MemoryMetricWorkingSet {
+			return fmt.Errorf("%w: %s %q with %s %q",
+				ErrMemoryMetricUnsupported, envKeyMemoryMetric, cfg.MemoryMetric, envKeyMetricsSource, cfg.MetricsSource)
+		}
+
+		return nil
+	case MetricsSourcePrometheus:
+		if cfg.PrometheusURL == "" {
+			return fmt.Errorf("%w: %s is %q but %s is empty",
+				ErrPrometheusURLRequired, envKeyMetricsSource, cfg.MetricsSource, envKeyPrometheusURL)
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("%w: %s: %q", ErrInvalidMetricsSource, envKeyMetricsSource, cfg.MetricsSource)
+	}
+}
+
+// parseNonNegativeIntEnv parses a non-negative integer; unset means 0.
+func (e env) parseNonNegativeIntEnv(key string) (int, error) {
+	s := e.get(key)
+	if s == "" {
+		return 0, nil
+	}
+
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("parse int: %w", err)
+	}
+
+	if n < 0 {
+		return 0, fmt.Errorf("%w: got %s, must be at least 0", ErrBelowMinimum, s)
+	}
+
+	return n, nil
+}
+
+// parsePositiveIntEnv parses an integer of at least 1; unset means defaultVal.
+func (e env) parsePositiveIntEnv(key string, defaultVal int) (int, error) {
+	s := e.get(key)
+	if s == "" {
+		return defaultVal, nil
+	}
+
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("parse int: %w", err)
+	}
+
+	if n < 1 {
+		return 0, fmt.Errorf("%w: got %s, must be at least 1", ErrBelowMinimum, s)
+	}
+
+	return n, nil
+}
+
+// parseAlertmanagerEnv parses the alert conditions of the Alertmanager notifier.
+func (e env) parseAlertmanagerEnv(cfg *Config) error {
+	var err error
+
+	cfg.AlertmanagerEvictions, err = e.parsePositiveIntEnv(envKeyAlertmanagerEvictions, defaultAlertmanagerEvictions)
+	if err != nil {
+		return fmt.Errorf("parse int env: %s: %w", envKeyAlertmanagerEvictions, err)
+	}
+
+	cfg.AlertmanagerFailures, err = e.parsePositiveIntEnv(envKeyAlertmanagerFailures, defaultAlertmanagerFailures)
+	if err != nil {
+		return fmt.Errorf("parse int env: %s: %w", envKeyAlertmanagerFailures, err)
+	}
+
+	cfg.AlertmanagerWindow, err = e.parseDurationEnv(envKeyAlertmanagerWindow, "1h", time.Minute)
+	if err != nil {
+		return fmt.Errorf("parse duration env: %s: %w", envKeyAlertmanagerWindow, err)
+	}
+
+	return nil
+}
+
+// parseListEnv parses a comma-separated list, dropping empty items; unset means nil.
+func (e env) parseListEnv(key string) []string {
+	var items []string
+
+	for item := range strings.SplitSeq(e.get(key), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+// parseMapEnv parses comma-separated key=value pairs; unset means empty.
+func (e env) parseMapEnv(key string) (map[string]string, error) {
+	items := e.parseListEnv(key)
+	pairs := make(map[string]string, len(items))
+
+	for _, item := range items {
+		k, v, ok := strings.Cut(item, "=")
+		if k, v = strings.TrimSpace(k), strings.TrimSpace(v); !ok || k == "" || v == "" {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidKeyValue, item)
+		}
+
+		pairs[k] = v
+	}
+
+	return pairs, nil
+}
+
+// parseFloat32Env parses a float; unset means 0.
+func (e env) parseFloat32Env(key string) (float32, error) {
+	s := e.get(key)
+	if s == "" {
+		return 0, nil
+	}
+
+	f, err := strconv.ParseFloat(s, 32)
+	if err != nil {
+		return 0, fmt.Errorf("parse float: %w", err)
+	}
+
+	return float32(f), nil
+}
+
+// parsePercentEnv parses a percentage in [0, 100); unset means 0.
+func (e env) parsePercentEnv(key string) (float64, error) {
+	s := e.get(key)
+	if s == "" {
+		return 0, nil
+	}
+
+	percent, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("parse float: %w", err)
+	}
+
+	if percent < 0 || percent >= maxPercent {
+		return 0, fmt.Errorf("value must be in [0, %d), got %s", maxPercent, s)
+	}
+
+	return percent, nil
+}
+
+func (e env) parseBoolEnv(key string, defaultVal bool) (bool, error) {
+	s := e.get(key)
+	if s == "" {
+		return defaultVal, nil
+	}
+
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return false, fmt.Errorf("parse bool: %w", err)
+	}
+
+	return b, nil
+}
+
+func (e env) parseDurationEnv(key, defaultVal string, minDuration time.Duration) (time.Duration, error) {
+	s := e.getEnvOrDefault(key, defaultVal)
+
+	d, err := parseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+
+	if d < minDuration {
+		return 0, fmt.Errorf("%w: got %s, must be at least %s", ErrBelowMinimum, d.String(), minDuration.String())
+	}
+
+	return d, nil
+}
+
+// parseDuration parses a Go duration (e.g. 5m, 1h30m) or, as earlier releases did, bare seconds
+// (e.g. 300).
+func parseDuration(s string) (time.Duration, error) {
+	if seconds, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.Duration(seconds) * time.Second, nil
+	}
 
 	d, err := time.ParseDuration(s)
 	if err != nil {
 		return 0, fmt.Errorf("parse duration: %w", err)
 	}
 
-	if d < minDuration {
-		return 0, fmt.Errorf("value must be at least %s, got %s", minDuration.String(), d.String())
+	return d, nil
+}
+
+// parseBucketsEnv parses comma-separated durations, the increasing upper bounds of histogram
+// buckets.
+func (e env) parseBucketsEnv(key, defaultVal string) ([]time.Duration, error) {
+	s := e.getEnvOrDefault(key, defaultVal)
+
+	var buckets []time.Duration
+
+	for item := range strings.SplitSeq(s, ",") {
+		d, err := parseDuration(strings.TrimSpace(item))
+		if err != nil {
+			return nil, err
+		}
+
+		if d <= 0 || (len(buckets) > 0 && d <= buckets[len(buckets)-1]) {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidBuckets, s)
+		}
+
+		buckets = append(buckets, d)
+	}
+
+	return buckets, nil
+}
+
+// parseOptionalDurationEnv parses a duration that is either 0, disabling the feature, or at least
+// minDuration.
+func (e env) parseOptionalDurationEnv(key, defaultVal string, minDuration time.Duration) (time.Duration, error) {
+	d, err := e.parseDurationEnv(key, defaultVal, 0)
+	if err != nil {
+		return 0, err
+	}
+
+	if d != 0 && d < minDuration {
+		return 0, fmt.Errorf("%w: got %s, must be 0 or at least %s", ErrBelowMinimum, d.String(), minDuration.String())
 	}
 
 	return d, nil
 }
 
-func getEnvOrDefault(key, defaultValue string) string {
-	value := os.Getenv(key)
+func (e env) getEnvOrDefault(key, defaultValue string) string {
+	value := e.get(key)
 	if value == "" {
 		return defaultValue
 	}
@@ -97,10 +877,10 @@ func getEnvOrDefault(key, defaultValue string) string {
 	return value
 }
 
-func getEnvWithFallback(primaryKey, fallbackKey string) string {
-	if v := os.Getenv(primaryKey); v != "" {
+func (e env) getEnvWithFallback(primaryKey, fallbackKey string) string {
+	if v := e.get(primaryKey); v != "" {
 		return v
 	}
 
-	return os.Getenv(fallbackKey)
+	return e.get(fallbackKey)
 }
@@ -0,0 +1,63 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// env looks up settings by environment variable name: the files of the configuration directory
+// override the environment.
+type env map[string]string
+
+func (e env) get(key string) string {
+	if value, ok := e[key]; ok {
+		return value
+	}
+
+	return os.Getenv(key)
+}
+
+// readConfigDir reads the files of dir, named after the environment variables they set, like the
+// volume of a ConfigMap. Hidden entries (such as the "..data" link of ConfigMap volumes) and
+// directories are skipped, and a trailing newline is trimmed. An empty dir reads nothing.
+func readConfigDir(dir string) (env, error) {
+	e := env{}
+
+	if dir == "" {
+		return e, nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read dir: %w", err)
+	}
+
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+
+		// Stat follows the symlinks of ConfigMap volumes to the files.
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("stat: %w", err)
+		}
+
+		if info.IsDir() {
+			continue
+		}
+
+		value, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read: %w", err)
+		}
+
+		e[entry.Name()] = strings.TrimSuffix(strings.TrimSuffix(string(value), "\n"), "\r")
+	}
+
+	return e, nil
+}
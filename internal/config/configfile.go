@@ -0,0 +1,118 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// readConfigFile reads a YAML file of settings keyed by their flag names, e.g.
+//
+//	interval: 2m
+//	contexts: [prod-eu, prod-us]
+//	slack-namespace-webhooks:
+//	  payments: https://hooks.slack.com/services/...
+//
+// Lists are joined like the list environment variables and maps into key=value pairs. Unknown
+// settings are rejected, to catch typos. An empty path reads nothing.
+func readConfigFile(path string) (env, error) {
+	e := env{}
+
+	if path == "" {
+		return e, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read: %w", err)
+	}
+
+	var settings map[string]any
+	if err := yaml.Unmarshal(data, &settings); err != nil {
+		return nil, fmt.Errorf("parse yaml: %w", err)
+	}
+
+	keys := make(map[string]string)
+	for _, spec := range flagSpecs() {
+		keys[spec.name] = spec.key
+	}
+
+	for name, value := range settings {
+		key, ok := keys[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownSetting, name)
+		}
+
+		s, err := formatSetting(value, listSeparator(key))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+
+		e[key] = s
+	}
+
+	return e, nil
+}
+
+// listSeparator returns the separator of the items of a list setting.
+func listSeparator(key string) string {
+	if key == envKeyBlackoutWindows || key == envKeyPodLabelSelector {
+		return ";"
+	}
+
+	return ","
+}
+
+// formatSetting formats a YAML value like the environment variable of the setting.
+func formatSetting(value any, sep string) (string, error) {
+	switch v := value.(type) {
+	case []any:
+		items := make([]string, 0, len(v))
+
+		for _, item := range v {
+			s, err := formatScalar(item)
+			if err != nil {
+				return "", err
+			}
+
+			items = append(items, s)
+		}
+
+		return strings.Join(items, sep), nil
+	case map[string]any:
+		pairs := make([]string, 0, len(v))
+
+		for _, k := range slices.Sorted(maps.Keys(v)) {
+			s, err := formatScalar(v[k])
+			if err != nil {
+				return "", err
+			}
+
+			pairs = append(pairs, k+"="+s)
+		}
+
+		return strings.Join(pairs, sep), nil
+	default:
+		return formatScalar(value)
+	}
+}
+
+func formatScalar(value any) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	default:
+		return "", fmt.Errorf("%w: %T", ErrInvalidSettingValue, value)
+	}
+}
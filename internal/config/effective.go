@@ -0,0 +1,208 @@
+package config
+
+import (
+	"maps"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// redacted replaces secrets in the effective configuration.
+const redacted = "REDACTED"
+
+// Effective returns the effective configuration keyed by environment variable, with defaults applied.
+// Tokens, passwords and webhook URLs are redacted, and so are the passwords of other URLs, so that
+// the result can be shared with support.
+func (c *Config) Effective() map[string]string {
+	return map[string]string{
+		envKeyKubeConfig:                         c.KubeConfig,
+		envKeyKubeMaster:                         redactURL(c.KubeMaster),
+		envKeyNamespace:                          c.Namespace,
+		envKeyRestartRecordConfigMap:             c.RestartRecordConfigMap,
+		envKeyPendingEvictionsConfigMap:          c.PendingEvictionsConfigMap,
+		envKeyMetricsSource:                      c.MetricsSource,
+		envKeyPrometheusURL:                      redactURL(c.PrometheusURL),
+		envKeyPrometheusQuery:                    c.PrometheusQuery,
+		envKeyMemoryMetric:                       c.MemoryMetric,
+		envKeyContainerAggregation:               c.ContainerAggregation,
+		envKeyVPAMode:                            c.VPAMode,
+		envKeyKubeQPS:                            strconv.FormatFloat(float64(c.KubeQPS), 'g', -1, 32),
+		envKeyKubeBurst:                          strconv.Itoa(c.KubeBurst),
+		envKeyKubeProtobuf:                       strconv.FormatBool(c.KubeProtobuf),
+		envKeyNodeName:                           c.NodeName,
+		envKeyAPICallTimeout:                     c.APICallTimeout.String(),
+		envKeyContexts:                           strings.Join(c.Contexts, ","),
+		envKeyInterval:                           c.Interval.String(),
+		envKeyReconcileConcurrency:               strconv.Itoa(c.ReconcileConcurrency),
+		envKeyReconcilePodPacing:                 c.ReconcilePodPacing.String(),
+		envKeyReconcileTimeout:                   c.ReconcileTimeout.String(),
+		envKeyPingerInterval:                     c.PingerInterval.String(),
+		envKeyPingerJitter:                       c.PingerJitter.String(),
+		envKeyPingerFlapTransitions:              strconv.Itoa(c.PingerFlapTransitions),
+		envKeyPingerFlapWindow:                   c.PingerFlapWindow.String(),
+		envKeyPingerFlapHoldReady:                strconv.FormatBool(c.PingerFlapHoldReady),
+		envKeyPingerFailureThreshold:             strconv.Itoa(c.PingerFailureThreshold),
+		envKeyPingerSuccessThreshold:             strconv.Itoa(c.PingerSuccessThreshold),
+		envKeyPingerLatencyBuckets:               joinDurations(c.PingerLatencyBuckets),
+		envKeyPingerStartupGrace:                 c.PingerStartupGrace.String(),
+		envKeyMetricsBlindReconciles:             strconv.Itoa(c.MetricsBlindReconciles),
+		envKeyReadyAfterReconcile:                strconv.FormatBool(c.ReadyAfterReconcile),
+		envKeyShutdownDrainDelay:                 c.ShutdownDrainDelay.String(),
+		envKeyTerminationFile:                    c.TerminationFile,
+		envKeyTerminationFileEnabled:             strconv.FormatBool(c.TerminationFileEnabled),
+		envKeyLogLevel:                           c.LogLevel,
+		envKeyLogFormat:                          c.LogFormat,
+		envKeyAuditLog:                           c.AuditLog,
+		envKeyWebhookURL:                         redactWebhookURL(c.WebhookURL),
+		envKeyWebhookTimeout:                     c.WebhookTimeout.String(),
+		envKeyHTTPPort:                           c.HTTPPort,
+		envKeyMetricsPort:                        c.MetricsPort,
+		envKeyGRPCPort:                           c.GRPCPort,
+		envKeyPodLabelSelector:                   c.PodLabelSelector,
+		envKeyAnnotationMemoryThreshold:          c.AnnotationMemoryThresholdKey,
+		envKeyAnnotationRestartSchedule:          c.AnnotationRestartScheduleKey,
+		envKeyAnnotationTZ:                       c.AnnotationTZKey,
+		envKeyRestartScheduleJitterMax:           c.RestartScheduleJitterMax.String(),
+		envKeyRestartScheduleJitterDeterministic: strconv.FormatBool(c.DeterministicJitter),
+		envKeyRestartScheduleSpread:              c.RestartScheduleSpread.String(),
+		envKeyRestartMinInterval:                 c.RestartMinInterval.String(),
+		envKeyCronSeconds:                        strconv.FormatBool(c.CronSeconds),
+		envKeyCronDescriptors:                    strconv.FormatBool(c.CronDescriptors),
+		envKeySerialRestart:                      strconv.FormatBool(c.SerialRestart),
+		envKeySerialRestartReadyTimeout:          c.SerialRestartReadyTimeout.String(),
+		envKeyCanarySoak:                         c.CanarySoak.String(),
+		envKeyMinPodAgeBeforeEviction:            c.MinPodAgeBeforeEviction.String(),
+		envKeyEvictionVerifyTimeout:              c.EvictionVerifyTimeout.String(),
+		envKeyRetryBaseDelay:                     c.RetryBaseDelay.String(),
+		envKeyRetryMaxDelay:                      c.RetryMaxDelay.String(),
+		envKeyBlackoutWindows:                    c.BlackoutWindows,
+		envKeyBlackoutTZ:                         c.BlackoutTZ,
+		envKeyPushgatewayURL:                     redactURL(c.PushgatewayURL),
+		envKeyRunOnce:                            strconv.FormatBool(c.RunOnce),
+		envKeyOOMThresholdTightenPercent:         strconv.FormatFloat(c.OOMThresholdTightenPercent, 'g', -1, 64),
+		envKeyMinReadyReplicas:                   strconv.Itoa(c.MinReadyReplicas),
+		envKeyPodMemoryGaugesMaxPods:             strconv.Itoa(c.PodMemoryGaugesMaxPods),
+		envKeyUsageHistorySize:                   strconv.Itoa(c.UsageHistorySize),
+		envKeyObservedAnnotationsInterval:        c.ObservedAnnotationsInterval.String(),
+		envKeyTracingEnabled:                     strconv.FormatBool(c.TracingEnabled),
+		envKeySlackWebhookURL:                    redactWebhookURL(c.SlackWebhookURL),
+		envKeySlackNamespaceWebhooks:             joinNamespaceWebhooks(c.SlackNamespaceWebhooks, redactWebhookURL),
+		envKeySlackChannel:                       c.SlackChannel,
+		envKeySlackTemplate:                      c.SlackTemplate,
+		envKeyAlertmanagerURL:                    redactURL(c.AlertmanagerURL),
+		envKeyAlertmanagerEvictions:              strconv.Itoa(c.AlertmanagerEvictions),
+		envKeyAlertmanagerFailures:               strconv.Itoa(c.AlertmanagerFailures),
+		envKeyAlertmanagerWindow:                 c.AlertmanagerWindow.String(),
+		envKeyNATSURL:                            redactURLList(c.NATSURL),
+		envKeyNATSSubject:                        c.NATSSubject,
+		envKeyNATSCredsFile:                      c.NATSCredsFile,
+		envKeyNATSToken:                          redactSecret(c.NATSToken),
+		envKeyNATSUser:                           c.NATSUser,
+		envKeyNATSPassword:                       redactSecret(c.NATSPassword),
+		envKeyEvictionHistorySize:                strconv.Itoa(c.EvictionHistorySize),
+		envKeyEvictionHistoryFile:                c.EvictionHistoryFile,
+		envKeyAdminToken:                         redactSecret(c.AdminToken),
+		envKeyAdminTokenReview:                   strconv.FormatBool(c.AdminTokenReview),
+		envKeyTLSCertFile:                        c.TLSCertFile,
+		envKeyTLSKeyFile:                         c.TLSKeyFile,
+		envKeyTLSClientCAFile:                    c.TLSClientCAFile,
+		envKeyPprofEnabled:                       strconv.FormatBool(c.PprofEnabled),
+		envKeyDashboardEnabled:                   strconv.FormatBool(c.DashboardEnabled),
+		envKeySelfMemoryWatermark:                c.SelfMemoryWatermark,
+		envKeySelfMemoryExit:                     strconv.FormatBool(c.SelfMemoryExit),
+		envKeyConfigDir:                          c.ConfigDir,
+		envKeyConfigFile:                         c.ConfigFile,
+		envKeyNamespaceDefaults:                  strconv.FormatBool(c.NamespaceDefaults),
+		envKeyDefaultMemoryThreshold:             c.DefaultMemoryThreshold,
+		envKeyPodDiscovery:                       c.PodDiscovery,
+		envKeyWatchAnnotations:                   strconv.FormatBool(c.WatchAnnotations),
+	}
+}
+
+// secrets returns the unredacted values of the settings Effective redacts.
+func (c *Config) secrets() map[string]string {
+	return map[string]string{
+		envKeyKubeMaster:             c.KubeMaster,
+		envKeyPrometheusURL:          c.PrometheusURL,
+		envKeyWebhookURL:             c.WebhookURL,
+		envKeyPushgatewayURL:         c.PushgatewayURL,
+		envKeySlackWebhookURL:        c.SlackWebhookURL,
+		envKeySlackNamespaceWebhooks: joinNamespaceWebhooks(c.SlackNamespaceWebhooks, func(s string) string { return s }),
+		envKeyAlertmanagerURL:        c.AlertmanagerURL,
+		envKeyNATSURL:                c.NATSURL,
+		envKeyNATSToken:              c.NATSToken,
+		envKeyNATSPassword:           c.NATSPassword,
+		envKeyAdminToken:             c.AdminToken,
+	}
+}
+
+// joinDurations formats durations as a comma-separated list.
+func joinDurations(durations []time.Duration) string {
+	items := make([]string, 0, len(durations))
+	for _, d := range durations {
+		items = append(items, d.String())
+	}
+
+	return strings.Join(items, ",")
+}
+
+// redactSecret hides a set secret, keeping whether it is set.
+func redactSecret(s string) string {
+	if s == "" {
+		return ""
+	}
+
+	return redacted
+}
+
+// redactURL hides the password of a URL; unparsable values are redacted as a whole.
+func redactURL(s string) string {
+	if s == "" {
+		return ""
+	}
+
+	u, err := url.Parse(s)
+	if err != nil {
+		return redacted
+	}
+
+	return u.Redacted()
+}
+
+// redactURLList redacts each URL of a comma-separated list.
+func redactURLList(s string) string {
+	urls := strings.Split(s, ",")
+	for i := range urls {
+		urls[i] = redactURL(strings.TrimSpace(urls[i]))
+	}
+
+	return strings.Join(urls, ",")
+}
+
+// redactWebhookURL keeps the scheme and host of a webhook URL only: its path and query usually
+// hold the secret (e.g. Slack incoming webhooks).
+func redactWebhookURL(s string) string {
+	if s == "" {
+		return ""
+	}
+
+	u, err := url.Parse(s)
+	if err != nil || u.Host == "" {
+		return redacted
+	}
+
+	return u.Scheme + "://" + u.Host + "/" + redacted
+}
+
+// joinNamespaceWebhooks formats namespace=webhook pairs sorted by namespace, with webhooks formatted
+// by format.
+func joinNamespaceWebhooks(webhooks map[string]string, format func(string) string) string {
+	pairs := make([]string, 0, len(webhooks))
+	for _, namespace := range slices.Sorted(maps.Keys(webhooks)) {
+		pairs = append(pairs, namespace+"="+format(webhooks[namespace]))
+	}
+
+	return strings.Join(pairs, ",")
+}
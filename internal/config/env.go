@@ -3,7 +3,7 @@ package config
 import "time"
 
 // Env key constants. All controller configuration env vars use PREOOMKILLER_ prefix;
-// duration values support explicit units (e.g. 5m, 40s, 2h).
+// duration values support explicit units (e.g. 5m, 40s, 1h30m) and bare seconds (e.g. 300).
 
 // Path to kubeconfig file. If unset, KUBECONFIG is used as fallback.
 const envKeyKubeConfig = "PREOOMKILLER_KUBECONFIG"
@@ -11,19 +11,141 @@ const envKeyKubeConfig = "PREOOMKILLER_KUBECONFIG"
 // Kubernetes API server URL. If unset, KUBERNETES_MASTER is used as fallback.
 const envKeyKubeMaster = "PREOOMKILLER_KUBE_MASTER"
 
+// Namespace the controller runs in. If unset, POD_NAMESPACE (set via the downward API) is used as fallback.
+const (
+	envKeyNamespace         = "PREOOMKILLER_NAMESPACE"
+	envKeyNamespaceFallback = "POD_NAMESPACE"
+)
+
+// Name of a ConfigMap in the controller namespace where the last restart of each workload is recorded; empty disables.
+const envKeyRestartRecordConfigMap = "PREOOMKILLER_RESTART_RECORD_CONFIGMAP"
+
+// Name of a ConfigMap in the controller namespace where pending scheduled evictions are persisted; empty disables.
+const envKeyPendingEvictionsConfigMap = "PREOOMKILLER_PENDING_EVICTIONS_CONFIGMAP"
+
+// Source of pod memory usage: metrics-server (metrics.k8s.io) or prometheus.
+const envKeyMetricsSource = "PREOOMKILLER_METRICS_SOURCE"
+
+// Memory metric compared against thresholds: working_set, rss or usage. metrics-server provides working_set only.
+const envKeyMemoryMetric = "PREOOMKILLER_MEMORY_METRIC"
+
+// How container memory usages combine into the pod value: sum, max or named:<container>.
+const envKeyContainerAggregation = "PREOOMKILLER_CONTAINER_AGGREGATION"
+
+// VerticalPodAutoscaler integration: off, upper-bound (compare usage against the VPA memory upper bound)
+// or defer (leave pods of workloads that VPA restarts to VPA).
+const envKeyVPAMode = "PREOOMKILLER_VPA_MODE"
+
+// Prometheus server URL for the prometheus metrics source and promql conditions (e.g. http://prometheus.monitoring:9090).
+const envKeyPrometheusURL = "PREOOMKILLER_PROMETHEUS_URL"
+
+// Go template of the Prometheus memory usage query; {{.Namespace}} and {{.Pod}} expand to regular expressions.
+const envKeyPrometheusQuery = "PREOOMKILLER_PROMETHEUS_QUERY"
+
 // Log level: debug, info, warn, error.
 const envKeyLogLevel = "PREOOMKILLER_LOG_LEVEL"
 
 // Log format: json or text.
 const envKeyLogFormat = "PREOOMKILLER_LOG_FORMAT"
 
+// Audit log of eviction decisions: stdout, stderr or a file path to append to; empty disables.
+const envKeyAuditLog = "PREOOMKILLER_AUDIT_LOG"
+
+// URL that every eviction decision is POSTed to as JSON; empty disables the webhook.
+const envKeyWebhookURL = "PREOOMKILLER_WEBHOOK_URL"
+
+// Timeout of a notification request, to the webhook or Slack (default 5s).
+const envKeyWebhookTimeout = "PREOOMKILLER_WEBHOOK_TIMEOUT"
+
+// Slack incoming webhook URL announcing evictions and missed OOMs; empty disables unless namespaces are routed.
+const envKeySlackWebhookURL = "PREOOMKILLER_SLACK_WEBHOOK_URL"
+
+// Comma-separated namespace=URL pairs routing a namespace's Slack messages to another incoming webhook.
+const envKeySlackNamespaceWebhooks = "PREOOMKILLER_SLACK_NAMESPACE_WEBHOOKS"
+
+// Slack channel override, honored by legacy incoming webhooks only.
+const envKeySlackChannel = "PREOOMKILLER_SLACK_CHANNEL"
+
+// Go template of Slack messages; empty uses the built-in template.
+const envKeySlackTemplate = "PREOOMKILLER_SLACK_TEMPLATE"
+
+// Alertmanager base URL to raise alerts on repeated evictions and persistent eviction failures; empty disables.
+const envKeyAlertmanagerURL = "PREOOMKILLER_ALERTMANAGER_URL"
+
+// Evictions of a workload within PREOOMKILLER_ALERTMANAGER_WINDOW that raise an alert (default 3).
+const envKeyAlertmanagerEvictions = "PREOOMKILLER_ALERTMANAGER_EVICTIONS"
+
+// Consecutive failed evictions of a pod that raise an alert (default 3).
+const envKeyAlertmanagerFailures = "PREOOMKILLER_ALERTMANAGER_FAILURES"
+
+// Window of counted evictions and lifetime of sent alerts (default 1h).
+const envKeyAlertmanagerWindow = "PREOOMKILLER_ALERTMANAGER_WINDOW"
+
+// NATS server URL(s) to stream eviction decisions and missed OOMs to; empty disables.
+const envKeyNATSURL = "PREOOMKILLER_NATS_URL"
+
+// Subject prefix of the streamed events (default preoomkiller.events).
+const envKeyNATSSubject = "PREOOMKILLER_NATS_SUBJECT"
+
+// NATS user credentials (JWT and NKey seed) file.
+const envKeyNATSCredsFile = "PREOOMKILLER_NATS_CREDS_FILE"
+
+// NATS authentication token.
+const envKeyNATSToken = "PREOOMKILLER_NATS_TOKEN"
+
+// NATS user name, with PREOOMKILLER_NATS_PASSWORD.
+const envKeyNATSUser = "PREOOMKILLER_NATS_USER"
+
+// NATS password.
+const envKeyNATSPassword = "PREOOMKILLER_NATS_PASSWORD"
+
+// Evictions kept for GET /-/evictions (default 100).
+const envKeyEvictionHistorySize = "PREOOMKILLER_EVICTION_HISTORY_SIZE"
+
+// File the eviction history is persisted to, to survive restarts; empty keeps it in memory only.
+const envKeyEvictionHistoryFile = "PREOOMKILLER_EVICTION_HISTORY_FILE"
+
+// Bearer token of the admin endpoints of the HTTP server (e.g. POST /-/reconcile); empty disables them.
+const envKeyAdminToken = "PREOOMKILLER_ADMIN_TOKEN"
+
+// Also accept Kubernetes bearer tokens on the admin endpoints, authorized with TokenReview and
+// SubjectAccessReview (default false).
+const envKeyAdminTokenReview = "PREOOMKILLER_ADMIN_TOKEN_REVIEW"
+
+// TLS certificate and key files of the HTTP and metrics servers; both unset serves plain HTTP.
+// The files are reloaded when they change.
+const (
+	envKeyTLSCertFile = "PREOOMKILLER_TLS_CERT_FILE"
+	envKeyTLSKeyFile  = "PREOOMKILLER_TLS_KEY_FILE"
+)
+
+// CA file of the client certificates required by the HTTP and metrics servers (mTLS); needs the TLS
+// certificate and key.
+const envKeyTLSClientCAFile = "PREOOMKILLER_TLS_CLIENT_CA_FILE"
+
+// YAML file of settings keyed by their flag names (e.g. interval: 5m), overridden by the environment.
+// It is read again on reload (SIGHUP or POST /-/reload).
+const envKeyConfigFile = "PREOOMKILLER_CONFIG_FILE"
+
+// Directory of files named after the environment variables they override, e.g. a mounted ConfigMap.
+// It is read again on reload (SIGHUP or POST /-/reload).
+const envKeyConfigDir = "PREOOMKILLER_CONFIG_DIR"
+
 // Port for health/readiness HTTP server.
 const envKeyHTTPPort = "PREOOMKILLER_HTTP_PORT"
 
 // Port for Prometheus metrics (GET /metrics).
 const envKeyMetricsPort = "PREOOMKILLER_METRICS_PORT"
 
-// Label selector to list pods (e.g. preoomkiller.beta.k8s.skillcoder.com/enabled=true).
+// Port of the gRPC admin API (AdminService of api/preoomkiller/v1); empty disables.
+const envKeyGRPCPort = "PREOOMKILLER_GRPC_PORT"
+
+// How pods are discovered: label (with PREOOMKILLER_POD_LABEL_SELECTOR) or annotation (pods having
+// a controller annotation, whatever their labels).
+const envKeyPodDiscovery = "PREOOMKILLER_POD_DISCOVERY"
+
+// Label selector to list pods (e.g. preoomkiller.beta.k8s.skillcoder.com/enabled=true); ';' separates
+// alternative selectors, selecting the pods matching any of them.
 const envKeyPodLabelSelector = "PREOOMKILLER_POD_LABEL_SELECTOR"
 
 // Annotation key for memory threshold on pod metadata.
@@ -35,25 +157,200 @@ const envKeyAnnotationRestartSchedule = "PREOOMKILLER_ANNOTATION_RESTART_SCHEDUL
 // Annotation key for schedule timezone (IANA, e.g. America/New_York).
 const envKeyAnnotationTZ = "PREOOMKILLER_ANNOTATION_TZ"
 
+// Run a single reconcile and exit (for CronJob usage): true or false. Same as the --once flag.
+const envKeyRunOnce = "PREOOMKILLER_RUN_ONCE"
+
+// Prometheus Pushgateway URL; in run-once mode metrics are pushed there before exit.
+const envKeyPushgatewayURL = "PREOOMKILLER_PUSHGATEWAY_URL"
+
+// Controller-wide eviction blackout windows, separated by ';' (e.g. "Mon-Fri 09:00-18:00;Sat 10:00-12:00").
+const envKeyBlackoutWindows = "PREOOMKILLER_BLACKOUT_WINDOWS"
+
+// Timezone for blackout windows (IANA, e.g. Europe/Berlin). Defaults to UTC.
+const envKeyBlackoutTZ = "PREOOMKILLER_BLACKOUT_TZ"
+
+// Percent by which a pod's memory threshold is lowered after each observed OOMKilled termination; 0 disables.
+const envKeyOOMThresholdTightenPercent = "PREOOMKILLER_OOM_THRESHOLD_TIGHTEN_PERCENT"
+
+// Comma-separated kubeconfig contexts to watch from one process (e.g. prod-eu,prod-us); empty watches the
+// cluster of the current context (or the in-cluster config).
+const envKeyContexts = "PREOOMKILLER_CONTEXTS"
+
+// Only reconcile pods scheduled on this node (e.g. spec.nodeName via the downward API when running per node).
+const envKeyNodeName = "PREOOMKILLER_NODE_NAME"
+
+// Timeout of each Kubernetes API request and Prometheus query; 0 disables. Units: s, m, h (e.g. 30s).
+const envKeyAPICallTimeout = "PREOOMKILLER_API_CALL_TIMEOUT"
+
+// Client-side rate limit of Kubernetes API requests (queries per second); 0 keeps the client-go default,
+// a negative value disables client-side limiting and leaves throttling to API Priority and Fairness.
+const envKeyKubeQPS = "PREOOMKILLER_KUBE_QPS"
+
+// Use protobuf instead of JSON for built-in resources of the Kubernetes API (default true).
+const envKeyKubeProtobuf = "PREOOMKILLER_KUBE_PROTOBUF"
+
+// Burst of Kubernetes API requests allowed above the QPS limit; 0 keeps the client-go default.
+const envKeyKubeBurst = "PREOOMKILLER_KUBE_BURST"
+
+// Number of memory usage samples kept per pod between reconciles (default 10).
+const envKeyUsageHistorySize = "PREOOMKILLER_USAGE_HISTORY_SIZE"
+
+// Minimum time between two writes of the last-observed-usage annotations of a pod; 0 disables them.
+// Units: s, m, h (e.g. 15m).
+const envKeyObservedAnnotationsInterval = "PREOOMKILLER_OBSERVED_ANNOTATIONS_INTERVAL"
+
+// Export memory usage and threshold gauges for up to this many pods; 0 disables the per-pod gauges.
+const envKeyPodMemoryGaugesMaxPods = "PREOOMKILLER_POD_MEMORY_GAUGES_MAX_PODS"
+
+// Export OpenTelemetry traces over OTLP/HTTP (default false); the exporter reads the standard OTEL_* variables.
+const envKeyTracingEnabled = "PREOOMKILLER_TRACING_ENABLED"
+
+// Resident memory of the controller above which it drops its per-pod caches: a quantity (e.g. 400Mi)
+// or a percentage of GOMEMLIMIT (e.g. 90%); empty disables.
+const envKeySelfMemoryWatermark = "PREOOMKILLER_SELF_MEMORY_WATERMARK"
+
+// Shut the controller down gracefully when its resident memory is still above the watermark after
+// dropping its caches (default false).
+const envKeySelfMemoryExit = "PREOOMKILLER_SELF_MEMORY_EXIT"
+
+// Serve the runtime profiles of the controller on /debug/pprof/ of the metrics port (default false).
+const envKeyPprofEnabled = "PREOOMKILLER_PPROF_ENABLED"
+
+// Serve a read-only web dashboard on /-/dashboard/ of the HTTP server (default false).
+const envKeyDashboardEnabled = "PREOOMKILLER_DASHBOARD_ENABLED"
+
+// Minimum number of other Ready replicas the owning workload must have before a pod is evicted; 0 disables.
+const envKeyMinReadyReplicas = "PREOOMKILLER_MIN_READY_REPLICAS"
+
 // Reconciliation interval. Units: s, m, h (e.g. 300s, 5m).
 const (
 	envKeyInterval = "PREOOMKILLER_INTERVAL"
 	envMinInterval = 30 * time.Second
 )
 
+// Number of workers processing the pods of a reconcile; pods of the same owner share a worker (default 1).
+const envKeyReconcileConcurrency = "PREOOMKILLER_RECONCILE_CONCURRENCY"
+
+// Pause of a reconcile worker between two pods; 0 disables. Units: ms, s, m (e.g. 1s, 200ms).
+const envKeyReconcilePodPacing = "PREOOMKILLER_RECONCILE_POD_PACING"
+
+// Deadline of each reconcile; 0 disables. Units: s, m, h (e.g. 10m).
+const envKeyReconcileTimeout = "PREOOMKILLER_RECONCILE_TIMEOUT"
+
 // Pinger check interval. Units: s, m, h (e.g. 10s, 1m).
 const (
 	envKeyPingerInterval = "PREOOMKILLER_PINGER_INTERVAL"
 	envMinPingerInterval = time.Second
 )
 
+// Max offset by which each pinger is delayed after a tick, so that pingers do not all run at once;
+// capped at half the pinger interval, 0 disables. Units: s, m, h (e.g. 2s).
+const envKeyPingerJitter = "PREOOMKILLER_PINGER_JITTER"
+
+// Number of changes between success and failure of a pinger within PREOOMKILLER_PINGER_FLAP_WINDOW
+// marking it flapping; 0 disables flap detection.
+const envKeyPingerFlapTransitions = "PREOOMKILLER_PINGER_FLAP_TRANSITIONS"
+
+// Window in which the transitions of a pinger are counted. Units: s, m, h (e.g. 5m).
+const envKeyPingerFlapWindow = "PREOOMKILLER_PINGER_FLAP_WINDOW"
+
+// Keep the readiness of a flapping pinger at what it was before it started flapping (true/false).
+const envKeyPingerFlapHoldReady = "PREOOMKILLER_PINGER_FLAP_HOLD_READY"
+
+// Consecutive failures of a pinger marking it not ready or healthy, and consecutive successes marking
+// it recovered, like the thresholds of kubelet probes (e.g. 3 and 1).
+const (
+	envKeyPingerFailureThreshold = "PREOOMKILLER_PINGER_FAILURE_THRESHOLD"
+	envKeyPingerSuccessThreshold = "PREOOMKILLER_PINGER_SUCCESS_THRESHOLD"
+)
+
+// Comma-separated upper bounds of the buckets of the pinger latency histogram, increasing
+// (e.g. 10ms,100ms,1s).
+const envKeyPingerLatencyBuckets = "PREOOMKILLER_PINGER_LATENCY_BUCKETS"
+
+// Period after start during which failures of a pinger do not make the controller not ready or
+// unhealthy, until the pinger's first success; 0 disables. Units: s, m, h (e.g. 1m).
+const envKeyPingerStartupGrace = "PREOOMKILLER_PINGER_STARTUP_GRACE"
+
+// Consecutive reconciles that needed pod metrics but could fetch none before the controller reports
+// itself unhealthy, e.g. when metrics-server is down; 0 disables.
+const envKeyMetricsBlindReconciles = "PREOOMKILLER_METRICS_BLIND_RECONCILES"
+
+// Keep the controller not ready until a reconcile listed the pods and the metrics of those with a
+// memory threshold (default false), so a rollout lacking RBAC permissions or a metrics API fails.
+const envKeyReadyAfterReconcile = "PREOOMKILLER_READY_AFTER_RECONCILE"
+
+// Delay between SIGTERM and the shutdown of the servers, during which /-/readyz fails; 0 disables.
+// Units: s, m, h (e.g. 5s).
+const envKeyShutdownDrainDelay = "PREOOMKILLER_SHUTDOWN_DRAIN_DELAY"
+
+// Path of the file whose presence means the pod is being terminated, e.g. written by a preStop hook.
+const envKeyTerminationFile = "PREOOMKILLER_TERMINATION_FILE"
+
+// Whether the termination file is checked at startup (default true).
+const envKeyTerminationFileEnabled = "PREOOMKILLER_TERMINATION_FILE_ENABLED"
+
 // Max jitter added to scheduled eviction time. Units: s, m, h (e.g. 30s).
 const (
 	envKeyRestartScheduleJitterMax = "PREOOMKILLER_RESTART_SCHEDULE_JITTER_MAX"
 	envMinRestartScheduleJitterMax = time.Second
 )
 
-// Minimum pod age before eviction is allowed; 0 disables the check. Units: s, m, h (e.g. 30m).
+// Derive scheduled eviction jitter from a hash of the pod UID instead of picking it at random.
+const envKeyRestartScheduleJitterDeterministic = "PREOOMKILLER_RESTART_SCHEDULE_JITTER_DETERMINISTIC"
+
+// Window across which scheduled restarts of replicas sharing an owner and schedule are spread; 0 disables.
+// Units: s, m, h (e.g. 10m).
+const envKeyRestartScheduleSpread = "PREOOMKILLER_RESTART_SCHEDULE_SPREAD"
+
+// Minimum time between a pod's creation and its next scheduled restart; earlier runs are deferred. 0 disables.
+// Units: s, m, h (e.g. 1h).
+const envKeyRestartMinInterval = "PREOOMKILLER_RESTART_MIN_INTERVAL"
+
+// Evict scheduled replicas of the same owner one at a time, waiting for a replacement to become Ready.
+const envKeySerialRestart = "PREOOMKILLER_SERIAL_RESTART"
+
+// Max wait for a replacement pod to become Ready before the next serialized restart. Units: s, m, h (e.g. 5m).
+const (
+	envKeySerialRestartReadyTimeout = "PREOOMKILLER_SERIAL_RESTART_READY_TIMEOUT"
+	envMinSerialRestartReadyTimeout = 10 * time.Second
+)
+
+// Time within which a replacement pod must become Ready after an eviction before evictions of the
+// workload are suspended; 0 disables verification. Units: s, m, h (e.g. 10m).
+const envKeyEvictionVerifyTimeout = "PREOOMKILLER_EVICTION_VERIFY_TIMEOUT"
+
+// Delay before the first retry of a pod whose eviction triggers failed, e.g. on a failed eviction or
+// metrics fetch, doubling with each consecutive failure; 0 disables retries. Units: s, m, h (e.g. 5s).
+const envKeyRetryBaseDelay = "PREOOMKILLER_RETRY_BASE_DELAY"
+
+// Max delay between retries of a failing pod. Units: s, m, h (e.g. 5m).
+const envKeyRetryMaxDelay = "PREOOMKILLER_RETRY_MAX_DELAY"
+
+// Soak period for the canary replica of a scheduled restart before the remaining replicas are evicted;
+// 0 disables canary mode. Units: s, m, h (e.g. 10m).
+const envKeyCanarySoak = "PREOOMKILLER_CANARY_SOAK"
+
+// Memory threshold of selected pods with a memory limit but no memory-threshold annotation, in the
+// syntax of the annotation (e.g. 90%); empty disables.
+const envKeyDefaultMemoryThreshold = "PREOOMKILLER_DEFAULT_MEMORY_THRESHOLD"
+
+// Apply the default-memory-threshold and default-restart-schedule annotations of Namespaces to
+// their pods without their own (default false). Needs permission to list namespaces.
+const envKeyNamespaceDefaults = "PREOOMKILLER_NAMESPACE_DEFAULTS"
+
+// Reconcile a pod as soon as one of its preoomkiller annotations changes instead of at the next
+// reconcile (default false). Watches all running pods.
+const envKeyWatchAnnotations = "PREOOMKILLER_WATCH_ANNOTATIONS"
+
+// Accept 6-field restart-schedule specs with a leading seconds field.
+const envKeyCronSeconds = "PREOOMKILLER_CRON_SECONDS"
+
+// Accept restart-schedule descriptors such as @daily and @every 12h.
+const envKeyCronDescriptors = "PREOOMKILLER_CRON_DESCRIPTORS"
+
+// Minimum pod age before eviction is allowed; 0 disables the check, other values must be at least
+// envMinMinPodAgeBeforeEviction. Units: s, m, h (e.g. 30m).
 const (
 	envKeyMinPodAgeBeforeEviction = "PREOOMKILLER_MIN_POD_AGE_BEFORE_EVICTION"
 	envMinMinPodAgeBeforeEviction = time.Minute
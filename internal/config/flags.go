@@ -0,0 +1,168 @@
+package config
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/pflag"
+)
+
+// flagSpec is a command line flag mirroring an environment variable.
+type flagSpec struct {
+	name  string
+	key   string
+	usage string
+	// isBool flags may be given without a value, meaning true.
+	isBool bool
+}
+
+// flagSpecs returns the flags of all settings, in the order of --help.
+func flagSpecs() []flagSpec {
+	return []flagSpec{
+		{"config", envKeyConfigFile, "YAML file of settings keyed by flag name, overridden by the environment", false},
+		{"config-dir", envKeyConfigDir, "directory of files named after the environment variables they override", false},
+		{"kubeconfig", envKeyKubeConfig, "path to the kubeconfig file (default $KUBECONFIG)", false},
+		{"contexts", envKeyContexts, "comma-separated kubeconfig contexts to watch from one process", false},
+		{"kube-master", envKeyKubeMaster, "Kubernetes API server URL (default $KUBERNETES_MASTER)", false},
+		{"log-level", envKeyLogLevel, "log level: debug, info, warn or error (default info)", false},
+		{"log-format", envKeyLogFormat, "log format: json or text (default json)", false},
+		{"audit-log", envKeyAuditLog, "audit log of eviction decisions: stdout, stderr or a file path", false},
+		{"webhook-url", envKeyWebhookURL, "URL every eviction decision is POSTed to as JSON", false},
+		{"webhook-timeout", envKeyWebhookTimeout, "timeout of a notification request (default 5s)", false},
+		{"slack-webhook-url", envKeySlackWebhookURL, "Slack incoming webhook announcing evictions and missed OOMs", false},
+		{"slack-namespace-webhooks", envKeySlackNamespaceWebhooks, "comma-separated namespace=URL pairs routing Slack messages", false},
+		{"slack-channel", envKeySlackChannel, "Slack channel override, honored by legacy incoming webhooks only", false},
+		{"slack-template", envKeySlackTemplate, "Go template of Slack messages", false},
+		{"alertmanager-url", envKeyAlertmanagerURL, "Alertmanager base URL to raise alerts on", false},
+		{"alertmanager-evictions", envKeyAlertmanagerEvictions, "evictions of a workload per window raising an alert (default 3)", false},
+		{"alertmanager-failures", envKeyAlertmanagerFailures, "consecutive failed evictions of a pod raising an alert (default 3)", false},
+		{"alertmanager-window", envKeyAlertmanagerWindow, "window of counted evictions and lifetime of sent alerts (default 1h)", false},
+		{"nats-url", envKeyNATSURL, "NATS server URL(s) to stream eviction decisions and missed OOMs to", false},
+		{"nats-subject", envKeyNATSSubject, "subject prefix of the streamed events (default preoomkiller.events)", false},
+		{"nats-creds-file", envKeyNATSCredsFile, "NATS user credentials file", false},
+		{"nats-token", envKeyNATSToken, "NATS authentication token", false},
+		{"nats-user", envKeyNATSUser, "NATS user name", false},
+		{"nats-password", envKeyNATSPassword, "NATS password", false},
+		{"http-port", envKeyHTTPPort, "port of the health, readiness and admin HTTP server (default 8080)", false},
+		{"admin-token", envKeyAdminToken, "bearer token of the admin endpoints", false},
+		{"admin-token-review", envKeyAdminTokenReview, "also accept Kubernetes bearer tokens on the admin endpoints", true},
+		{"eviction-history-size", envKeyEvictionHistorySize, "evictions kept for GET /-/evictions (default 100)", false},
+		{"eviction-history-file", envKeyEvictionHistoryFile, "file the eviction history is persisted to", false},
+		{"metrics-port", envKeyMetricsPort, "port of the Prometheus metrics server (default 9090)", false},
+		{"grpc-port", envKeyGRPCPort, "port of the gRPC admin API; empty disables", false},
+		{"tls-cert-file", envKeyTLSCertFile, "TLS certificate of the HTTP and metrics servers", false},
+		{"tls-key-file", envKeyTLSKeyFile, "TLS private key of the HTTP and metrics servers", false},
+		{"tls-client-ca-file", envKeyTLSClientCAFile, "CA of the client certificates required by the HTTP and metrics servers", false},
+		{"interval", envKeyInterval, "reconciliation interval (default 5m)", false},
+		{"reconcile-concurrency", envKeyReconcileConcurrency, "number of workers processing the pods of a reconcile (default 1)", false},
+		{"reconcile-pod-pacing", envKeyReconcilePodPacing, "pause of a reconcile worker between two pods (default 1s)", false},
+		{"reconcile-timeout", envKeyReconcileTimeout, "deadline of each reconcile", false},
+		{"pinger-interval", envKeyPingerInterval, "pinger check interval (default 10s)", false},
+		{"pinger-jitter", envKeyPingerJitter, "max offset spreading the pingers across the interval", false},
+		{"pinger-flap-transitions", envKeyPingerFlapTransitions, "result changes within the flap window marking a pinger flapping", false},
+		{"pinger-flap-window", envKeyPingerFlapWindow, "window of counted pinger result changes (default 5m)", false},
+		{"pinger-flap-hold-ready", envKeyPingerFlapHoldReady, "keep the readiness of flapping pingers steady", true},
+		{"pinger-failure-threshold", envKeyPingerFailureThreshold, "consecutive failures marking a pinger failing (default 1)", false},
+		{"pinger-success-threshold", envKeyPingerSuccessThreshold, "consecutive successes marking a pinger recovered (default 1)", false},
+		{"pinger-latency-buckets", envKeyPingerLatencyBuckets, "comma-separated buckets of the pinger latency histogram", false},
+		{"pinger-startup-grace", envKeyPingerStartupGrace, "period after start in which pinger failures do not count", false},
+		{"metrics-blind-reconciles", envKeyMetricsBlindReconciles, "consecutive reconciles without pod metrics before the controller is unhealthy", false},
+		{"ready-after-reconcile", envKeyReadyAfterReconcile, "stay not ready until a reconcile listed the pods and their metrics", true},
+		{"shutdown-drain-delay", envKeyShutdownDrainDelay, "delay between SIGTERM and shutdown, with /-/readyz failing", false},
+		{"termination-file", envKeyTerminationFile, "file whose presence means the pod is terminating (default /mnt/signal/terminating)", false},
+		{"termination-file-enabled", envKeyTerminationFileEnabled, "check the termination file at startup (default true)", true},
+		{"pod-discovery", envKeyPodDiscovery, "how pods are discovered: label or annotation (default label)", false},
+		{"label-selector", envKeyPodLabelSelector, "label selector of the pods to watch; ';' separates alternatives", false},
+		{"annotation-memory-threshold", envKeyAnnotationMemoryThreshold, "annotation key of the memory threshold", false},
+		{"annotation-restart-schedule", envKeyAnnotationRestartSchedule, "annotation key of the restart schedule", false},
+		{"annotation-tz", envKeyAnnotationTZ, "annotation key of the schedule timezone", false},
+		{"restart-schedule-jitter-max", envKeyRestartScheduleJitterMax, "max jitter added to scheduled evictions (default 30s)", false},
+		{"restart-min-interval", envKeyRestartMinInterval, "minimum time between a pod's creation and its next scheduled restart", false},
+		{"restart-schedule-jitter-deterministic", envKeyRestartScheduleJitterDeterministic, "derive jitter from the pod UID", true},
+		{"serial-restart", envKeySerialRestart, "evict scheduled replicas of the same owner one at a time", true},
+		{"serial-restart-ready-timeout", envKeySerialRestartReadyTimeout, "max wait for a Ready replacement (default 5m)", false},
+		{"canary-soak", envKeyCanarySoak, "soak period of the canary replica of a scheduled restart", false},
+		{"default-memory-threshold", envKeyDefaultMemoryThreshold, "memory threshold of pods with a memory limit but no threshold annotation", false},
+		{"namespace-defaults", envKeyNamespaceDefaults, "apply the default annotations of Namespaces to pods without their own", true},
+		{"watch-annotations", envKeyWatchAnnotations, "reconcile a pod as soon as its preoomkiller annotations change", true},
+		{"cron-seconds", envKeyCronSeconds, "accept restart schedules with a leading seconds field", true},
+		{"cron-descriptors", envKeyCronDescriptors, "accept restart schedule descriptors such as @daily", true},
+		{"restart-schedule-spread", envKeyRestartScheduleSpread, "window across which scheduled restarts of replicas are spread", false},
+		{"once", envKeyRunOnce, "run a single reconcile and exit (for CronJob usage)", true},
+		{"pushgateway-url", envKeyPushgatewayURL, "Prometheus Pushgateway URL metrics are pushed to in run-once mode", false},
+		{"namespace", envKeyNamespace, "namespace the controller runs in (default $POD_NAMESPACE)", false},
+		{"restart-record-configmap", envKeyRestartRecordConfigMap, "ConfigMap recording the last restart of each workload", false},
+		{"pending-evictions-configmap", envKeyPendingEvictionsConfigMap, "ConfigMap persisting pending scheduled evictions", false},
+		{"metrics-source", envKeyMetricsSource, "source of pod memory usage: metrics-server or prometheus (default metrics-server)", false},
+		{"prometheus-url", envKeyPrometheusURL, "Prometheus server URL", false},
+		{"prometheus-query", envKeyPrometheusQuery, "Go template of the Prometheus memory usage query", false},
+		{"memory-metric", envKeyMemoryMetric, "memory metric: working_set, rss or usage (default working_set)", false},
+		{"container-aggregation", envKeyContainerAggregation, "aggregation: sum, max or named:<container> (default sum)", false},
+		{"node-name", envKeyNodeName, "only reconcile pods scheduled on this node", false},
+		{"api-call-timeout", envKeyAPICallTimeout, "timeout of each Kubernetes API request and Prometheus query (default 30s)", false},
+		{"kube-qps", envKeyKubeQPS, "client-side rate limit of Kubernetes API requests per second", false},
+		{"kube-burst", envKeyKubeBurst, "burst of Kubernetes API requests above the QPS limit", false},
+		{"kube-protobuf", envKeyKubeProtobuf, "use protobuf for built-in resources of the Kubernetes API (default true)", true},
+		{"vpa-mode", envKeyVPAMode, "VerticalPodAutoscaler integration: off, upper-bound or defer (default off)", false},
+		{"min-ready-replicas", envKeyMinReadyReplicas, "minimum number of other Ready replicas before a pod is evicted", false},
+		{"pod-memory-gauges-max-pods", envKeyPodMemoryGaugesMaxPods, "export per-pod memory gauges for up to this many pods", false},
+		{"usage-history-size", envKeyUsageHistorySize, "number of memory usage samples kept per pod (default 10)", false},
+		{"observed-annotations-interval", envKeyObservedAnnotationsInterval, "minimum time between two writes of the last-observed-usage annotations", false},
+		{"tracing-enabled", envKeyTracingEnabled, "export OpenTelemetry traces over OTLP/HTTP", true},
+		{"pprof-enabled", envKeyPprofEnabled, "serve runtime profiles on /debug/pprof/ of the metrics port", true},
+		{"self-memory-watermark", envKeySelfMemoryWatermark, "resident memory (e.g. 400Mi or 90% of GOMEMLIMIT) above which the controller drops its caches", false},
+		{"self-memory-exit", envKeySelfMemoryExit, "shut down gracefully when still above the self memory watermark after dropping caches", true},
+		{"dashboard-enabled", envKeyDashboardEnabled, "serve a read-only web dashboard on /-/dashboard/ of the HTTP server", true},
+		{"eviction-verify-timeout", envKeyEvictionVerifyTimeout, "time within which an evicted pod must have a Ready replacement", false},
+		{"retry-base-delay", envKeyRetryBaseDelay, "delay before retrying a pod whose processing failed, doubling per failure", false},
+		{"retry-max-delay", envKeyRetryMaxDelay, "max delay between retries of a failing pod (default 5m)", false},
+		{"oom-threshold-tighten-percent", envKeyOOMThresholdTightenPercent, "threshold percent lowered per OOMKilled", false},
+		{"blackout-windows", envKeyBlackoutWindows, "eviction blackout windows separated by ';', e.g. \"Mon-Fri 09:00-18:00\"", false},
+		{"blackout-tz", envKeyBlackoutTZ, "IANA timezone of the blackout windows (default UTC)", false},
+		{"min-pod-age-before-eviction", envKeyMinPodAgeBeforeEviction, "minimum pod age before eviction is allowed (default 30m)", false},
+	}
+}
+
+// Flags are the settings given on the command line, keyed by environment variable. They take
+// precedence over the configuration directory and the environment.
+type Flags map[string]string
+
+// ParseFlags parses the command line arguments (without the program name) and returns the set
+// flags and the remaining arguments. Each setting has a flag, e.g. --interval for
+// PREOOMKILLER_INTERVAL. On -h or --help, the usage is written to output and pflag.ErrHelp
+// returned.
+func ParseFlags(name string, args []string, output io.Writer) (Flags, []string, error) {
+	fs := pflag.NewFlagSet(name, pflag.ContinueOnError)
+	fs.SetOutput(output)
+	fs.SortFlags = false
+	fs.Usage = func() {
+		fmt.Fprintf(output, "Usage: %s [flags] [once|simulate|check|status [URL]]\n\n", name)
+		fmt.Fprint(output, "Flags override the environment variables named in brackets.\n\n")
+		fmt.Fprint(output, fs.FlagUsages())
+	}
+
+	specs := flagSpecs()
+	for _, spec := range specs {
+		usage := spec.usage + " [" + spec.key + "]"
+
+		if spec.isBool {
+			fs.Bool(spec.name, false, usage)
+		} else {
+			fs.String(spec.name, "", usage)
+		}
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return nil, nil, fmt.Errorf("parse flags: %w", err)
+	}
+
+	flags := Flags{}
+
+	for _, spec := range specs {
+		if fs.Changed(spec.name) {
+			flags[spec.key] = fs.Lookup(spec.name).Value.String()
+		}
+	}
+
+	return flags, fs.Args(), nil
+}
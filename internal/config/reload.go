@@ -0,0 +1,46 @@
+package config
+
+import (
+	"maps"
+	"slices"
+)
+
+// reloadableKeys are the settings applied to the running controller on reload; the others need a
+// restart.
+var reloadableKeys = map[string]struct{}{
+	envKeyInterval:         {},
+	envKeyPodLabelSelector: {},
+	envKeyKubeQPS:          {},
+	envKeyKubeBurst:        {},
+}
+
+// Changes lists the environment variables whose effective value changed, sorted.
+type Changes struct {
+	// Reloadable settings are applied on reload.
+	Reloadable []string
+	// RestartRequired settings only apply after a restart.
+	RestartRequired []string
+}
+
+// Changes compares c with the reloaded configuration next. Secrets are compared unredacted, so
+// that a rotated token or webhook is reported; only the keys are.
+func (c *Config) Changes(next *Config) Changes {
+	previous, current := c.Effective(), next.Effective()
+	maps.Copy(previous, c.secrets())
+	maps.Copy(current, next.secrets())
+	changes := Changes{Reloadable: []string{}, RestartRequired: []string{}}
+
+	for _, key := range slices.Sorted(maps.Keys(current)) {
+		if previous[key] == current[key] {
+			continue
+		}
+
+		if _, ok := reloadableKeys[key]; ok {
+			changes.Reloadable = append(changes.Reloadable, key)
+		} else {
+			changes.RestartRequired = append(changes.RestartRequired, key)
+		}
+	}
+
+	return changes
+}
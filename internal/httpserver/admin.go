@@ -0,0 +1,162 @@
+package httpserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+
+	"github.com/skillcoder/preoomkiller-controller/internal/logic/controller"
+)
+
+// evictResponse is the body of the POST /-/evict endpoint.
+type evictResponse struct {
+	Outcome string `json:"outcome,omitempty"`
+	Reason  string `json:"reason,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// cancelPendingResponse is the body of the DELETE /-/pending endpoint.
+type cancelPendingResponse struct {
+	// RestartAt is the next scheduled restart of the pod.
+	RestartAt *time.Time `json:"restartAt,omitempty"`
+	Error     string     `json:"error,omitempty"`
+}
+
+// handleReconcile returns an http.HandlerFunc for the POST /-/reconcile endpoint, making every
+// controller reconcile now instead of at its next tick.
+func handleReconcile(logger *slog.Logger, controllers []Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		for _, c := range controllers {
+			c.TriggerReconcileCommand()
+		}
+
+		logger.InfoContext(ctx, "reconcile requested through admin api",
+			"traceID", middleware.GetReqID(ctx),
+		)
+
+		w.WriteHeader(http.StatusAccepted)
+	}
+}
+
+// handleEvict returns an http.HandlerFunc for the POST /-/evict/{namespace}/{pod} endpoint, evicting
+// the pod through the eviction guards of the controller. It answers 200 when the pod was evicted, 403
+// when the user may not evict the pod or the controller does not select it, and 409 when a guard
+// skipped it or the eviction API refused it.
+func handleEvict(logger *slog.Logger, controllers []Controller, reviewer AccessReviewer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		logger := logger.With("traceID", middleware.GetReqID(ctx))
+		namespace, name := chi.URLParam(r, "namespace"), chi.URLParam(r, "pod")
+
+		c, err := selectController(r.URL.Query().Get("cluster"), controllers)
+		if err != nil {
+			writeJSON(ctx, logger, w, http.StatusBadRequest, evictResponse{Error: err.Error()})
+
+			return
+		}
+
+		if err := reviewEviction(ctx, reviewer, namespace, name); err != nil {
+			denyReviewed(ctx, logger, w, err)
+
+			return
+		}
+
+		result, err := c.RequestEvictionCommand(ctx, namespace, name)
+
+		response := evictResponse{Outcome: string(result.Outcome), Reason: result.Reason}
+
+		switch {
+		case errors.Is(err, controller.ErrPodNotFound):
+			response.Error = err.Error()
+			writeJSON(ctx, logger, w, http.StatusNotFound, response)
+		case errors.Is(err, controller.ErrPodNotSelected):
+			response.Error = err.Error()
+			writeJSON(ctx, logger, w, http.StatusForbidden, response)
+		case err != nil:
+			response.Error = err.Error()
+			writeJSON(ctx, logger, w, http.StatusInternalServerError, response)
+		case result.Outcome != controller.OutcomeEvicted:
+			writeJSON(ctx, logger, w, http.StatusConflict, response)
+		default:
+			writeJSON(ctx, logger, w, http.StatusOK, response)
+		}
+	}
+}
+
+// handleCancelPending returns an http.HandlerFunc for the DELETE /-/pending/{namespace}/{pod}
+// endpoint, cancelling the pending scheduled eviction of the pod; the pod is rescheduled for the
+// next occurrence of its schedule. The user needs the same permissions as for evicting the pod.
+func handleCancelPending(logger *slog.Logger, controllers []Controller, reviewer AccessReviewer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		logger := logger.With("traceID", middleware.GetReqID(ctx))
+		namespace, name := chi.URLParam(r, "namespace"), chi.URLParam(r, "pod")
+
+		c, err := selectController(r.URL.Query().Get("cluster"), controllers)
+		if err != nil {
+			writeJSON(ctx, logger, w, http.StatusBadRequest, cancelPendingResponse{Error: err.Error()})
+
+			return
+		}
+
+		if err := reviewEviction(ctx, reviewer, namespace, name); err != nil {
+			denyReviewed(ctx, logger, w, err)
+
+			return
+		}
+
+		restartAt, err := c.CancelPendingEvictionCommand(ctx, namespace, name)
+
+		switch {
+		case errors.Is(err, controller.ErrNoPendingEviction), errors.Is(err, controller.ErrPodNotFound):
+			writeJSON(ctx, logger, w, http.StatusNotFound, cancelPendingResponse{Error: err.Error()})
+		case errors.Is(err, controller.ErrPodNotSelected):
+			writeJSON(ctx, logger, w, http.StatusForbidden, cancelPendingResponse{Error: err.Error()})
+		case err != nil:
+			writeJSON(ctx, logger, w, http.StatusInternalServerError, cancelPendingResponse{Error: err.Error()})
+		default:
+			writeJSON(ctx, logger, w, http.StatusOK, cancelPendingResponse{RestartAt: &restartAt})
+		}
+	}
+}
+
+// selectController returns the controller of cluster, e.g. given by the "cluster" query parameter,
+// which may be empty when there is a single controller.
+func selectController(cluster string, controllers []Controller) (Controller, error) {
+	if cluster == "" {
+		if len(controllers) > 1 {
+			return nil, errClusterRequired
+		}
+
+		return controllers[0], nil
+	}
+
+	for _, c := range controllers {
+		if c.Cluster() == cluster {
+			return c, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %q", errUnknownCluster, cluster)
+}
+
+// writeJSON writes response as the JSON body of a code response.
+func writeJSON(ctx context.Context, logger *slog.Logger, w http.ResponseWriter, code int, response any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		logger.ErrorContext(ctx, "failed to encode response",
+			"error", err,
+		)
+	}
+}
@@ -0,0 +1,544 @@
+package httpserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+
+	"github.com/skillcoder/preoomkiller-controller/internal/config"
+	"github.com/skillcoder/preoomkiller-controller/internal/logic/controller"
+)
+
+// fakeController records the admin requests it receives.
+type fakeController struct {
+	cluster   string
+	triggered int
+	// evicted and cancelled hold the "namespace/pod" keys of the requests.
+	evicted   []string
+	cancelled []string
+	// resumed holds the "namespace/kind/name" keys of the resumed workloads.
+	resumed   []string
+	suspended controller.SuspendedWorkload
+	result    controller.EvictionRequestResult
+	restartAt time.Time
+	err       error
+}
+
+func (c *fakeController) Cluster() string {
+	return c.cluster
+}
+
+func (c *fakeController) PendingEvictionsQuery() []controller.ScheduledEviction {
+	return nil
+}
+
+func (c *fakeController) EnrolledPodsQuery() []controller.EnrolledPod {
+	return nil
+}
+
+func (c *fakeController) TriggerReconcileCommand() {
+	c.triggered++
+}
+
+func (c *fakeController) RequestEvictionCommand(
+	_ context.Context,
+	namespace,
+	name string,
+) (controller.EvictionRequestResult, error) {
+	c.evicted = append(c.evicted, namespace+"/"+name)
+
+	return c.result, c.err
+}
+
+func (c *fakeController) CancelPendingEvictionCommand(_ context.Context, namespace, name string) (time.Time, error) {
+	c.cancelled = append(c.cancelled, namespace+"/"+name)
+
+	return c.restartAt, c.err
+}
+
+func (c *fakeController) SuspendedWorkloadsQuery() []controller.SuspendedWorkload {
+	return nil
+}
+
+func (c *fakeController) ResumeWorkloadCommand(
+	_ context.Context,
+	namespace,
+	kind,
+	name string,
+) (controller.SuspendedWorkload, error) {
+	c.resumed = append(c.resumed, namespace+"/"+kind+"/"+name)
+
+	return c.suspended, c.err
+}
+
+// serveAdmin serves the request through the admin routes, authenticated with the "s3cr3t" token or
+// reviewed by a fakeReviewer.
+func serveAdmin(t *testing.T, controllers []Controller, method, path, token string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	reviewer := &fakeReviewer{err: unauthenticatedError{}}
+
+	router := chi.NewRouter()
+	router.Use(adminAuth(slog.Default(), "s3cr3t", reviewer))
+	router.Post("/-/reconcile", handleReconcile(slog.Default(), controllers))
+	router.Post("/-/evict/{namespace}/{pod}", handleEvict(slog.Default(), controllers, reviewer))
+	router.Delete("/-/pending/{namespace}/{pod}", handleCancelPending(slog.Default(), controllers, reviewer))
+	router.Delete("/-/suspended/{namespace}/{kind}/{name}", handleResumeWorkload(slog.Default(), controllers, reviewer))
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(method, path, http.NoBody)
+
+	if token != "" {
+		req.Header.Set("Authorization", token)
+	}
+
+	router.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestHandleReconcile(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		authorization string
+		wantCode      int
+		wantTriggered int
+	}{
+		{
+			name:          "valid token triggers every controller",
+			authorization: "Bearer s3cr3t",
+			wantCode:      http.StatusAccepted,
+			wantTriggered: 1,
+		},
+		{
+			name:     "missing token is rejected",
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:          "wrong token is rejected",
+			authorization: "Bearer guess",
+			wantCode:      http.StatusUnauthorized,
+		},
+		{
+			name:          "non-bearer scheme is rejected",
+			authorization: "Basic czNjcjN0",
+			wantCode:      http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			first, second := &fakeController{}, &fakeController{}
+
+			rec := serveAdmin(t, []Controller{first, second}, http.MethodPost, "/-/reconcile", tt.authorization)
+
+			require.Equal(t, tt.wantCode, rec.Code)
+			require.Equal(t, tt.wantTriggered, first.triggered)
+			require.Equal(t, tt.wantTriggered, second.triggered)
+		})
+	}
+}
+
+func TestHandleEvict(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		path        string
+		giveResult  controller.EvictionRequestResult
+		giveErr     error
+		wantCode    int
+		wantOutcome string
+		wantReason  string
+		wantEvicted []string
+		// authorization defaults to the static admin token.
+		authorization string
+	}{
+		{
+			name:        "evicted pod",
+			path:        "/-/evict/default/api-1",
+			giveResult:  controller.EvictionRequestResult{Outcome: controller.OutcomeEvicted},
+			wantCode:    http.StatusOK,
+			wantOutcome: "evicted",
+			wantEvicted: []string{"default/api-1"},
+		},
+		{
+			name: "skipped eviction conflicts",
+			path: "/-/evict/default/api-1",
+			giveResult: controller.EvictionRequestResult{
+				Outcome: controller.OutcomeSkipped,
+				Reason:  string(controller.SkipReasonPodTooYoung),
+			},
+			wantCode:    http.StatusConflict,
+			wantOutcome: "skipped",
+			wantReason:  "pod_too_young",
+			wantEvicted: []string{"default/api-1"},
+		},
+		{
+			name:        "missing pod",
+			path:        "/-/evict/default/gone",
+			giveErr:     controller.ErrPodNotFound,
+			wantCode:    http.StatusNotFound,
+			wantEvicted: []string{"default/gone"},
+		},
+		{
+			name:        "failed eviction",
+			path:        "/-/evict/default/api-1",
+			giveResult:  controller.EvictionRequestResult{Outcome: controller.OutcomeFailed, Reason: "api_error"},
+			giveErr:     fmt.Errorf("%w: boom", controller.ErrEvictPod),
+			wantCode:    http.StatusInternalServerError,
+			wantOutcome: "failed",
+			wantReason:  "api_error",
+			wantEvicted: []string{"default/api-1"},
+		},
+		{
+			name:     "cluster of a single controller is optional but must match",
+			path:     "/-/evict/default/api-1?cluster=staging",
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:        "pod not selected by the controller",
+			path:        "/-/evict/kube-system/coredns-1",
+			giveErr:     controller.ErrPodNotSelected,
+			wantCode:    http.StatusForbidden,
+			wantEvicted: []string{"kube-system/coredns-1"},
+		},
+		{
+			name:          "reviewed user may evict the pod",
+			path:          "/-/evict/default/api-1",
+			authorization: "Bearer sa-token",
+			giveResult:    controller.EvictionRequestResult{Outcome: controller.OutcomeEvicted},
+			wantCode:      http.StatusOK,
+			wantOutcome:   "evicted",
+			wantEvicted:   []string{"default/api-1"},
+		},
+		{
+			name:          "reviewed user may not evict pods of the namespace",
+			path:          "/-/evict/kube-system/coredns-1",
+			authorization: "Bearer sa-token",
+			wantCode:      http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := &fakeController{cluster: "prod", result: tt.giveResult, err: tt.giveErr}
+
+			authorization := tt.authorization
+			if authorization == "" {
+				authorization = "Bearer s3cr3t"
+			}
+
+			rec := serveAdmin(t, []Controller{c}, http.MethodPost, tt.path, authorization)
+
+			require.Equal(t, tt.wantCode, rec.Code)
+			require.Equal(t, tt.wantEvicted, c.evicted)
+
+			if tt.wantEvicted == nil && tt.wantCode == http.StatusForbidden {
+				return
+			}
+
+			var body evictResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			require.Equal(t, tt.wantOutcome, body.Outcome)
+			require.Equal(t, tt.wantReason, body.Reason)
+		})
+	}
+}
+
+func TestHandleCancelPending(t *testing.T) {
+	t.Parallel()
+
+	restartAt := time.Date(2026, 1, 14, 3, 0, 0, 0, time.UTC)
+
+	t.Run("cancelled eviction returns the next restart", func(t *testing.T) {
+		t.Parallel()
+
+		prod := &fakeController{cluster: "prod", restartAt: restartAt}
+		staging := &fakeController{cluster: "staging"}
+
+		rec := serveAdmin(t, []Controller{staging, prod}, http.MethodDelete,
+			"/-/pending/default/api-1?cluster=prod", "Bearer s3cr3t")
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.JSONEq(t, `{"restartAt":"2026-01-14T03:00:00Z"}`, rec.Body.String())
+		require.Equal(t, []string{"default/api-1"}, prod.cancelled)
+		require.Empty(t, staging.cancelled)
+	})
+
+	t.Run("no pending eviction", func(t *testing.T) {
+		t.Parallel()
+
+		c := &fakeController{err: controller.ErrNoPendingEviction}
+
+		rec := serveAdmin(t, []Controller{c}, http.MethodDelete, "/-/pending/default/api-1", "Bearer s3cr3t")
+
+		require.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("multiple controllers require a cluster", func(t *testing.T) {
+		t.Parallel()
+
+		prod, staging := &fakeController{cluster: "prod"}, &fakeController{cluster: "staging"}
+
+		rec := serveAdmin(t, []Controller{prod, staging}, http.MethodDelete, "/-/pending/default/api-1", "Bearer s3cr3t")
+
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		require.Empty(t, prod.cancelled)
+		require.Empty(t, staging.cancelled)
+	})
+}
+
+func TestHandleResumeWorkload(t *testing.T) {
+	t.Parallel()
+
+	t.Run("resumed workload is returned", func(t *testing.T) {
+		t.Parallel()
+
+		c := &fakeController{suspended: controller.SuspendedWorkload{
+			Namespace:   "default",
+			Owner:       controller.Owner{Kind: "ReplicaSet", Name: "api-7d9c", UID: "uid-1"},
+			Pod:         "api-7d9c-x2kq",
+			SuspendedAt: time.Date(2026, 1, 14, 3, 0, 0, 0, time.UTC),
+		}}
+
+		rec := serveAdmin(t, []Controller{c}, http.MethodDelete, "/-/suspended/default/replicaset/api-7d9c", "Bearer s3cr3t")
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.JSONEq(t, `{"resumed":{"namespace":"default","ownerKind":"ReplicaSet","owner":"api-7d9c",`+
+			`"pod":"api-7d9c-x2kq","suspendedAt":"2026-01-14T03:00:00Z"}}`, rec.Body.String())
+		require.Equal(t, []string{"default/replicaset/api-7d9c"}, c.resumed)
+	})
+
+	t.Run("workload not suspended", func(t *testing.T) {
+		t.Parallel()
+
+		c := &fakeController{err: controller.ErrWorkloadNotSuspended}
+
+		rec := serveAdmin(t, []Controller{c}, http.MethodDelete, "/-/suspended/default/replicaset/api-7d9c", "Bearer s3cr3t")
+
+		require.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("missing token is rejected", func(t *testing.T) {
+		t.Parallel()
+
+		c := &fakeController{}
+
+		rec := serveAdmin(t, []Controller{c}, http.MethodDelete, "/-/suspended/default/replicaset/api-7d9c", "")
+
+		require.Equal(t, http.StatusUnauthorized, rec.Code)
+		require.Empty(t, c.resumed)
+	})
+}
+
+type unauthenticatedError struct{}
+
+func (unauthenticatedError) Error() string      { return "token is not authenticated" }
+func (unauthenticatedError) IsUnauthenticated() {}
+
+type forbiddenError struct{}
+
+func (forbiddenError) Error() string { return "user is not allowed" }
+func (forbiddenError) IsForbidden()  {}
+
+// fakeReviewer allows the "sa-token" token to post and to evict pods outside kube-system, answering
+// err to the other tokens.
+type fakeReviewer struct {
+	err error
+	// reviews holds the "verb path" of the reviews.
+	reviews []string
+}
+
+func (r *fakeReviewer) ReviewEvictionAccess(_ context.Context, token, namespace, name string) (string, error) {
+	r.reviews = append(r.reviews, "evict "+namespace+"/"+name)
+
+	if token != "sa-token" {
+		return "", r.err
+	}
+
+	if namespace == "kube-system" {
+		return "", forbiddenError{}
+	}
+
+	return "system:serviceaccount:ops:oncall", nil
+}
+
+func (r *fakeReviewer) ReviewAccess(_ context.Context, token, verb, path string) (string, error) {
+	r.reviews = append(r.reviews, verb+" "+path)
+
+	if token == "sa-token" && verb == "post" {
+		return "system:serviceaccount:ops:oncall", nil
+	}
+
+	return "", r.err
+}
+
+func TestAdminAuth(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		token         string
+		reviewErr     error
+		method        string
+		authorization string
+		wantStatus    int
+		wantReviews   []string
+	}{
+		{
+			name:          "static token skips the review",
+			token:         "s3cr3t",
+			method:        http.MethodPost,
+			authorization: "Bearer s3cr3t",
+			wantStatus:    http.StatusOK,
+		},
+		{
+			name:          "reviewed token is allowed",
+			token:         "s3cr3t",
+			method:        http.MethodPost,
+			authorization: "Bearer sa-token",
+			wantStatus:    http.StatusOK,
+			wantReviews:   []string{"post /-/admin"},
+		},
+		{
+			name:          "reviewed token without static token",
+			method:        http.MethodPost,
+			authorization: "Bearer sa-token",
+			wantStatus:    http.StatusOK,
+			wantReviews:   []string{"post /-/admin"},
+		},
+		{
+			name:          "missing token",
+			method:        http.MethodPost,
+			authorization: "",
+			wantStatus:    http.StatusUnauthorized,
+		},
+		{
+			name:          "forbidden user",
+			method:        http.MethodDelete,
+			authorization: "Bearer sa-token",
+			reviewErr:     forbiddenError{},
+			wantStatus:    http.StatusForbidden,
+			wantReviews:   []string{"delete /-/admin"},
+		},
+		{
+			name:          "unauthenticated token",
+			method:        http.MethodPost,
+			authorization: "Bearer expired",
+			reviewErr:     fmt.Errorf("review: %w", unauthenticatedError{}),
+			wantStatus:    http.StatusUnauthorized,
+			wantReviews:   []string{"post /-/admin"},
+		},
+		{
+			name:          "review failure",
+			method:        http.MethodPost,
+			authorization: "Bearer other",
+			reviewErr:     context.DeadlineExceeded,
+			wantStatus:    http.StatusInternalServerError,
+			wantReviews:   []string{"post /-/admin"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			reviewer := &fakeReviewer{err: tt.reviewErr}
+			handler := adminAuth(slog.Default(), tt.token, reviewer)(
+				http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) }),
+			)
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, "/-/admin", http.NoBody)
+
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+
+			handler.ServeHTTP(rec, req)
+
+			require.Equal(t, tt.wantStatus, rec.Code)
+			require.Equal(t, tt.wantReviews, reviewer.reviews)
+
+			if tt.wantStatus == http.StatusUnauthorized {
+				require.Equal(t, "Bearer", rec.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
+
+// fakeReloader returns its changes or error.
+type fakeReloader struct {
+	changes config.Changes
+	err     error
+}
+
+func (r *fakeReloader) ReloadCommand(context.Context) (config.Changes, error) {
+	return r.changes, r.err
+}
+
+func TestHandleReload(t *testing.T) {
+	t.Parallel()
+
+	t.Run("reports the changed settings", func(t *testing.T) {
+		t.Parallel()
+
+		reloader := &fakeReloader{changes: config.Changes{
+			Reloadable:      []string{"PREOOMKILLER_INTERVAL"},
+			RestartRequired: []string{"PREOOMKILLER_HTTP_PORT"},
+		}}
+
+		rec := httptest.NewRecorder()
+		handleReload(slog.Default(), reloader)(rec, httptest.NewRequest(http.MethodPost, "/-/reload", http.NoBody))
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.JSONEq(t,
+			`{"reloaded":["PREOOMKILLER_INTERVAL"],"restartRequired":["PREOOMKILLER_HTTP_PORT"]}`,
+			rec.Body.String(),
+		)
+	})
+
+	t.Run("load failure keeps the running configuration", func(t *testing.T) {
+		t.Parallel()
+
+		reloader := &fakeReloader{err: fmt.Errorf("load config: %w", context.DeadlineExceeded)}
+
+		rec := httptest.NewRecorder()
+		handleReload(slog.Default(), reloader)(rec, httptest.NewRequest(http.MethodPost, "/-/reload", http.NoBody))
+
+		require.Equal(t, http.StatusInternalServerError, rec.Code)
+		require.Contains(t, rec.Body.String(), "load config")
+	})
+}
+
+func TestHandleDashboard(t *testing.T) {
+	t.Parallel()
+
+	router := chi.NewRouter()
+	router.Handle(dashboardPath+"*", handleDashboard())
+
+	for _, path := range []string{"/-/dashboard/", "/-/dashboard/dashboard.js", "/-/dashboard/style.css"} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, http.NoBody))
+		require.Equal(t, http.StatusOK, rec.Code, path)
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/-/dashboard/", http.NoBody))
+	require.Contains(t, rec.Body.String(), `<script src="dashboard.js">`)
+}
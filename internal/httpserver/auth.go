@@ -0,0 +1,126 @@
+package httpserver
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// staticTokenUser is the user logged for requests authenticated with the static admin token.
+const staticTokenUser = "admin-token"
+
+// AccessReviewer authenticates bearer tokens and authorizes their user, e.g. with Kubernetes
+// TokenReviews and SubjectAccessReviews.
+type AccessReviewer interface {
+	// ReviewAccess returns the user name of token when that user may send verb requests to path.
+	ReviewAccess(ctx context.Context, token, verb, path string) (string, error)
+	// ReviewEvictionAccess returns the user name of token when that user may evict the pod.
+	ReviewEvictionAccess(ctx context.Context, token, namespace, name string) (string, error)
+}
+
+// reviewedTokenKey is the context key of the bearer token of a request authorized by an access review.
+type reviewedTokenKey struct{}
+
+// unauthenticated is implemented by ReviewAccess errors of invalid tokens.
+type unauthenticated interface {
+	IsUnauthenticated()
+}
+
+// forbidden is implemented by ReviewAccess errors of users without permission.
+type forbidden interface {
+	IsForbidden()
+}
+
+// adminAuth authenticates requests with an "Authorization: Bearer <token>" header: token, when set,
+// is accepted as is, and other tokens are reviewed by reviewer, when set. It answers 401 to
+// unauthenticated and 403 to unauthorized requests.
+func adminAuth(logger *slog.Logger, token string, reviewer AccessReviewer) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			logger := logger.With(
+				"traceID", middleware.GetReqID(ctx),
+				"method", r.Method,
+				"path", r.URL.Path,
+			)
+
+			given, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || given == "" {
+				logger.WarnContext(ctx, "unauthenticated admin request")
+				unauthorized(w)
+
+				return
+			}
+
+			if token != "" && subtle.ConstantTimeCompare([]byte(given), []byte(token)) == 1 {
+				logger.InfoContext(ctx, "admin request authorized", "user", staticTokenUser)
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			if reviewer == nil {
+				logger.WarnContext(ctx, "unauthenticated admin request")
+				unauthorized(w)
+
+				return
+			}
+
+			user, err := reviewer.ReviewAccess(ctx, given, strings.ToLower(r.Method), r.URL.Path)
+			if err != nil {
+				denyReviewed(ctx, logger, w, err)
+
+				return
+			}
+
+			logger.InfoContext(ctx, "admin request authorized", "user", user)
+			next.ServeHTTP(w, r.WithContext(context.WithValue(ctx, reviewedTokenKey{}, given)))
+		})
+	}
+}
+
+// reviewEviction authorizes the eviction of the pod, or the cancellation of its pending eviction, for a
+// request authorized by an access review: its user must also be allowed to evict the pod with their own
+// RBAC, so that the admin API grants no more than kubectl would. Requests authenticated with the static
+// admin token are not reviewed; the controller only acts on the pods it selects.
+func reviewEviction(ctx context.Context, reviewer AccessReviewer, namespace, name string) error {
+	token, ok := ctx.Value(reviewedTokenKey{}).(string)
+	if !ok || reviewer == nil {
+		return nil
+	}
+
+	_, err := reviewer.ReviewEvictionAccess(ctx, token, namespace, name)
+
+	return err
+}
+
+// denyReviewed answers a request whose access review failed.
+func denyReviewed(ctx context.Context, logger *slog.Logger, w http.ResponseWriter, err error) {
+	var (
+		unauthenticatedTarget unauthenticated
+		forbiddenTarget       forbidden
+	)
+
+	switch {
+	case errors.As(err, &unauthenticatedTarget):
+		logger.WarnContext(ctx, "unauthenticated admin request", "reason", err)
+		unauthorized(w)
+	case errors.As(err, &forbiddenTarget):
+		logger.WarnContext(ctx, "forbidden admin request", "reason", err)
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+	default:
+		logger.ErrorContext(ctx, "admin request access review failed", "reason", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+	}
+}
+
+// unauthorized answers 401 with a bearer challenge.
+func unauthorized(w http.ResponseWriter) {
+	w.Header().Set("WWW-Authenticate", "Bearer")
+	http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+}
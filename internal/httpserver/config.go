@@ -0,0 +1,33 @@
+package httpserver
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// configResponse is the body of the /-/config endpoint.
+type configResponse struct {
+	// Config maps environment variables to their effective values.
+	Config map[string]string `json:"config"`
+}
+
+// handleConfig returns an http.HandlerFunc for the /-/config endpoint, serving the effective
+// configuration returned by effective, which must already be redacted.
+func handleConfig(logger *slog.Logger, effective func() map[string]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		logger := logger.With("traceID", middleware.GetReqID(ctx))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		if err := json.NewEncoder(w).Encode(configResponse{Config: effective()}); err != nil {
+			logger.ErrorContext(ctx, "failed to encode config response",
+				"error", err,
+			)
+		}
+	}
+}
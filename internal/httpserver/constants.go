@@ -10,4 +10,11 @@ const (
 	writeTimeout      = 5 * time.Second
 	idleTimeout       = 60 * time.Second
 	maxHeaderBytes    = 1 << 12 // 4kb
+
+	// pprofWriteTimeout replaces writeTimeout on the metrics server with pprof enabled, so that CPU
+	// profiles and traces of up to a minute can be captured.
+	pprofWriteTimeout = 65 * time.Second
+
+	// certReloadInterval bounds how often the TLS files are checked for changes.
+	certReloadInterval = 10 * time.Second
 )
@@ -0,0 +1,26 @@
+package httpserver
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+)
+
+// dashboardAssets are the static files of the read-only web dashboard.
+//
+//go:embed dashboard
+var dashboardAssets embed.FS
+
+// dashboardPath is where the dashboard is served; its scripts query the endpoints next to it.
+const dashboardPath = "/-/dashboard/"
+
+// handleDashboard returns an http.Handler serving the dashboard assets under dashboardPath.
+func handleDashboard() http.Handler {
+	assets, err := fs.Sub(dashboardAssets, "dashboard")
+	if err != nil {
+		// The directory is embedded at build time.
+		panic(err)
+	}
+
+	return http.StripPrefix(dashboardPath, http.FileServerFS(assets))
+}
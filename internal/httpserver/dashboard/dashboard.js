@@ -0,0 +1,113 @@
+"use strict";
+
+// Read-only view of the /-/status, /-/pods, /-/pending and /-/evictions endpoints, refreshed periodically.
+const refreshInterval = 30000;
+const maxEvictions = 50;
+
+// getJSON returns the decoded body of the endpoint, or null when it is not served.
+async function getJSON(path) {
+  const response = await fetch(path, { cache: "no-store" });
+  if (response.status === 404) {
+    return null;
+  }
+
+  if (!response.ok) {
+    throw new Error(`${path}: ${response.status} ${response.statusText}`);
+  }
+
+  return response.json();
+}
+
+function formatTime(value) {
+  return value ? new Date(value).toLocaleString() : "-";
+}
+
+function cell(content) {
+  const td = document.createElement("td");
+  if (content instanceof Node) {
+    td.appendChild(content);
+  } else {
+    td.textContent = content || "-";
+  }
+
+  return td;
+}
+
+// fillTable replaces the rows of the table body with one row of cells per item.
+function fillTable(id, items, columns) {
+  const body = document.getElementById(id);
+  body.replaceChildren();
+
+  if (items.length === 0) {
+    const td = cell("none");
+    td.colSpan = body.closest("table").tHead.rows[0].cells.length;
+    td.className = "empty";
+    body.appendChild(document.createElement("tr")).appendChild(td);
+
+    return;
+  }
+
+  for (const item of items) {
+    const tr = document.createElement("tr");
+    for (const column of columns(item)) {
+      tr.appendChild(cell(column));
+    }
+
+    body.appendChild(tr);
+  }
+}
+
+// usageBar shows the last checked usage against the effective threshold.
+function usageBar(pod) {
+  if (!pod.memoryUsage) {
+    return pod.memoryThreshold ? `- / ${pod.memoryThreshold}` : "-";
+  }
+
+  const span = document.createElement("span");
+  span.className = "usage";
+
+  if (pod.memoryThresholdBytes) {
+    const ratio = pod.memoryUsageBytes / pod.memoryThresholdBytes;
+    const bar = span.appendChild(document.createElement("span"));
+    bar.className = "bar";
+    const fill = bar.appendChild(document.createElement("div"));
+    fill.style.width = `${Math.min(ratio, 1) * 100}%`;
+    fill.className = ratio > 1 ? "over" : ratio > 0.8 ? "warn" : "";
+  }
+
+  const text = span.appendChild(document.createElement("span"));
+  text.textContent = `${pod.memoryUsage} / ${pod.effectiveThreshold || pod.memoryThreshold || "-"}`;
+
+  return span;
+}
+
+async function refresh() {
+  try {
+    const [status, pods, pending, evictions] = await Promise.all([
+      getJSON("../status"),
+      getJSON("../pods"),
+      getJSON("../pending"),
+      getJSON("../evictions"),
+    ]);
+
+    document.getElementById("status").textContent =
+      `${status.state}, up ${status.uptime}, updated ${new Date().toLocaleTimeString()}`;
+
+    fillTable("pods", pods ? pods.pods : [], (p) => [
+      p.cluster, p.namespace, p.pod, usageBar(p), formatTime(p.checkedAt), p.restartSchedule, formatTime(p.restartAt),
+    ]);
+    fillTable("pending", pending ? pending.pending : [], (p) => [
+      p.cluster, p.namespace, p.pod, formatTime(p.restartAt), formatTime(p.fireAt),
+    ]);
+
+    document.getElementById("evictions-section").hidden = evictions === null;
+    fillTable("evictions", evictions ? evictions.evictions.slice(0, maxEvictions) : [], (e) => [
+      e.cluster, e.namespace, e.pod, formatTime(e.time), e.trigger, e.reason,
+    ]);
+  } catch (err) {
+    document.getElementById("status").textContent = `refresh failed: ${err.message}`;
+  }
+}
+
+refresh();
+setInterval(refresh, refreshInterval);
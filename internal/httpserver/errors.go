@@ -0,0 +1,9 @@
+package httpserver
+
+import "errors"
+
+var (
+	errClusterRequired = errors.New("cluster query parameter is required in multi-cluster mode")
+	errUnknownCluster  = errors.New("unknown cluster")
+	errNoClientCA      = errors.New("no certificate found in tls client ca file")
+)
@@ -0,0 +1,545 @@
+package httpserver
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"strings"
+	"sync/atomic"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	preoomkillerv1 "github.com/skillcoder/preoomkiller-controller/api/preoomkiller/v1"
+	"github.com/skillcoder/preoomkiller-controller/internal/infra/shutdown"
+	"github.com/skillcoder/preoomkiller-controller/internal/logic/controller"
+)
+
+// grpcReviewVerb is the verb of the access reviews of gRPC admin methods, whose path is the full
+// method name, e.g. "/preoomkiller.v1.AdminService/Evict".
+const grpcReviewVerb = "post"
+
+// grpcAdminMethods are the AdminService methods that need authentication, like the HTTP admin
+// endpoints; the others are read-only.
+var grpcAdminMethods = map[string]struct{}{
+	preoomkillerv1.AdminService_Reconcile_FullMethodName:             {},
+	preoomkillerv1.AdminService_Evict_FullMethodName:                 {},
+	preoomkillerv1.AdminService_CancelPendingEviction_FullMethodName: {},
+	preoomkillerv1.AdminService_Reload_FullMethodName:                {},
+	preoomkillerv1.AdminService_ResumeWorkload_FullMethodName:        {},
+}
+
+// GRPCServer serves the introspection and admin operations of the HTTP server over gRPC, as the
+// AdminService of api/preoomkiller/v1.
+type GRPCServer struct {
+	preoomkillerv1.UnimplementedAdminServiceServer
+
+	logger     *slog.Logger
+	appState   appstater
+	port       string
+	server     *grpc.Server
+	ready      chan struct{}
+	inShutdown atomic.Bool
+	// endpoints holds the served controllers, history, admin authentication, TLS and reloader, set
+	// by the options of the HTTP server.
+	endpoints Server
+}
+
+// NewGRPCServer creates a gRPC server on port serving what the same options serve on the HTTP
+// server; the options of HTTP-only endpoints are ignored.
+func NewGRPCServer(logger *slog.Logger, appState appstater, port string, opts ...Option) *GRPCServer {
+	s := &GRPCServer{
+		logger:   logger,
+		appState: appState,
+		port:     port,
+		ready:    make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(&s.endpoints)
+	}
+
+	return s
+}
+
+var _ shutdown.Shutdowner = (*GRPCServer)(nil)
+
+// Name returns the name of the gRPC server component.
+func (s *GRPCServer) Name() string {
+	return "grpc-server"
+}
+
+// Ping returns nil when the server is ready to serve.
+func (s *GRPCServer) Ping(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-s.ready:
+		return nil
+	default:
+		return fmt.Errorf("grpc server is not ready")
+	}
+}
+
+// Start starts the gRPC server in a goroutine.
+func (s *GRPCServer) Start(ctx context.Context) error {
+	if s.inShutdown.Load() {
+		s.logger.InfoContext(ctx, "grpc server is shutting down, skipping start")
+
+		return nil
+	}
+
+	serverOpts := []grpc.ServerOption{grpc.UnaryInterceptor(s.authorize)}
+	if s.endpoints.tls != nil {
+		serverOpts = append(serverOpts, grpc.Creds(credentials.NewTLS(s.endpoints.tls.serverConfig())))
+	}
+
+	s.server = grpc.NewServer(serverOpts...)
+	preoomkillerv1.RegisterAdminServiceServer(s.server, s)
+
+	lc := &net.ListenConfig{
+		KeepAliveConfig: net.KeepAliveConfig{
+			Enable: true,
+		},
+	}
+
+	listener, err := lc.Listen(ctx, "tcp", ":"+s.port)
+	if err != nil {
+		return fmt.Errorf("listen grpc tcp: %w", err)
+	}
+
+	s.logger.InfoContext(ctx, "grpc server listening", "addr", listener.Addr().String(), "tls", s.endpoints.tls != nil)
+
+	go func() {
+		close(s.ready)
+
+		if err := s.server.Serve(listener); err != nil {
+			s.logger.ErrorContext(ctx, "grpc server error", "error", err)
+		}
+	}()
+
+	return nil
+}
+
+// Ready returns a channel that is closed when the gRPC server is ready.
+func (s *GRPCServer) Ready() <-chan struct{} {
+	return s.ready
+}
+
+// ShutdownGroup returns shutdown.GroupServers: the server stops before the components it serves.
+func (s *GRPCServer) ShutdownGroup() shutdown.Group {
+	return shutdown.GroupServers
+}
+
+// Shutdown gracefully stops the gRPC server, waiting for the pending calls until ctx is done.
+func (s *GRPCServer) Shutdown(ctx context.Context) error {
+	if !s.inShutdown.CompareAndSwap(false, true) {
+		s.logger.ErrorContext(ctx, "grpc server is already shutting down, skipping shutdown")
+
+		return nil
+	}
+
+	s.logger.InfoContext(ctx, "shutting down grpc server")
+
+	if s.server == nil {
+		return nil
+	}
+
+	stopped := make(chan struct{})
+
+	go func() {
+		s.server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		s.logger.InfoContext(ctx, "grpc server closed properly")
+
+		return nil
+	case <-ctx.Done():
+		s.server.Stop()
+
+		return fmt.Errorf("grpc server shutdown: %w", ctx.Err())
+	}
+}
+
+// authorize is the unary interceptor authenticating the admin methods with a "Bearer <token>"
+// authorization metadata, like adminAuth: the static admin token is accepted as is, and other tokens
+// are reviewed by the access reviewer. Admin methods are unimplemented when neither is set.
+func (s *GRPCServer) authorize(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	if s.endpoints.tls.clientCertRequired() && !verifiedPeer(ctx) {
+		s.logger.WarnContext(ctx, "request without client certificate", "method", info.FullMethod)
+
+		return nil, status.Error(codes.Unauthenticated, "client certificate required")
+	}
+
+	if _, ok := grpcAdminMethods[info.FullMethod]; !ok {
+		return handler(ctx, req)
+	}
+
+	token, reviewer := s.endpoints.adminToken, s.endpoints.accessReviewer
+	if token == "" && reviewer == nil {
+		return nil, status.Error(codes.Unimplemented, "admin methods are disabled")
+	}
+
+	logger := s.logger.With("method", info.FullMethod)
+
+	var given string
+	if values := metadata.ValueFromIncomingContext(ctx, "authorization"); len(values) > 0 {
+		given, _ = strings.CutPrefix(values[0], "Bearer ")
+	}
+
+	if given == "" {
+		logger.WarnContext(ctx, "unauthenticated admin request")
+
+		return nil, status.Error(codes.Unauthenticated, "bearer token required")
+	}
+
+	if token != "" && subtle.ConstantTimeCompare([]byte(given), []byte(token)) == 1 {
+		logger.InfoContext(ctx, "admin request authorized", "user", staticTokenUser)
+
+		return handler(ctx, req)
+	}
+
+	if reviewer == nil {
+		logger.WarnContext(ctx, "unauthenticated admin request")
+
+		return nil, status.Error(codes.Unauthenticated, "invalid bearer token")
+	}
+
+	user, err := reviewer.ReviewAccess(ctx, given, grpcReviewVerb, info.FullMethod)
+	if err != nil {
+		return nil, reviewStatus(ctx, logger, err)
+	}
+
+	logger.InfoContext(ctx, "admin request authorized", "user", user)
+
+	return handler(context.WithValue(ctx, reviewedTokenKey{}, given), req)
+}
+
+// verifiedPeer reports whether the client of the call presented a verified certificate.
+func verifiedPeer(ctx context.Context) bool {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return false
+	}
+
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+
+	return ok && len(info.State.VerifiedChains) > 0
+}
+
+// reviewStatus returns the status of a call whose access review failed.
+func reviewStatus(ctx context.Context, logger *slog.Logger, err error) error {
+	var (
+		unauthenticatedTarget unauthenticated
+		forbiddenTarget       forbidden
+	)
+
+	switch {
+	case errors.As(err, &unauthenticatedTarget):
+		logger.WarnContext(ctx, "unauthenticated admin request", "reason", err)
+
+		return status.Error(codes.Unauthenticated, "invalid bearer token")
+	case errors.As(err, &forbiddenTarget):
+		logger.WarnContext(ctx, "forbidden admin request", "reason", err)
+
+		return status.Error(codes.PermissionDenied, "forbidden")
+	default:
+		logger.ErrorContext(ctx, "admin request access review failed", "reason", err)
+
+		return status.Error(codes.Internal, "access review failed")
+	}
+}
+
+// GetStatus returns the state of the application and the workloads whose evictions are suspended.
+func (s *GRPCServer) GetStatus(
+	context.Context,
+	*preoomkillerv1.GetStatusRequest,
+) (*preoomkillerv1.GetStatusResponse, error) {
+	response := &preoomkillerv1.GetStatusResponse{
+		State:     string(s.appState.GetState()),
+		StartTime: timestamppb.New(s.appState.GetStartTime()),
+		Uptime:    durationpb.New(s.appState.GetUptime()),
+	}
+
+	for _, workload := range collectSuspendedWorkloads(s.endpoints.controllers) {
+		response.SuspendedWorkloads = append(response.SuspendedWorkloads, toProtoSuspendedWorkload(workload))
+	}
+
+	return response, nil
+}
+
+// ListPods returns the pods selected by the last reconcile of all controllers.
+func (s *GRPCServer) ListPods(context.Context, *preoomkillerv1.ListPodsRequest) (*preoomkillerv1.ListPodsResponse, error) {
+	enrolled := collectEnrolledPods(s.endpoints.controllers)
+	response := &preoomkillerv1.ListPodsResponse{Pods: make([]*preoomkillerv1.Pod, 0, len(enrolled))}
+
+	for i := range enrolled {
+		e := &enrolled[i]
+		pod := &preoomkillerv1.Pod{
+			Cluster:         e.Cluster,
+			Namespace:       e.Namespace,
+			Name:            e.Name,
+			MemoryThreshold: e.MemoryThreshold,
+			RestartSchedule: e.RestartSchedule,
+		}
+
+		if !e.RestartAt.IsZero() {
+			pod.RestartAt = timestamppb.New(e.RestartAt)
+		}
+
+		if e.MemoryUsage != nil {
+			pod.MemoryUsageBytes, pod.CheckedAt = e.MemoryUsage.Value(), timestamppb.New(e.CheckedAt)
+		}
+
+		if e.EffectiveThreshold != nil {
+			pod.MemoryThresholdBytes = e.EffectiveThreshold.Value()
+		}
+
+		if !e.SuspendedAt.IsZero() {
+			pod.SuspendedAt = timestamppb.New(e.SuspendedAt)
+		}
+
+		response.Pods = append(response.Pods, pod)
+	}
+
+	return response, nil
+}
+
+// ListPendingEvictions returns the pending scheduled evictions of all controllers, soonest first.
+func (s *GRPCServer) ListPendingEvictions(
+	context.Context,
+	*preoomkillerv1.ListPendingEvictionsRequest,
+) (*preoomkillerv1.ListPendingEvictionsResponse, error) {
+	pending := collectPendingEvictions(s.endpoints.controllers)
+	response := &preoomkillerv1.ListPendingEvictionsResponse{
+		PendingEvictions: make([]*preoomkillerv1.PendingEviction, 0, len(pending)),
+	}
+
+	for i := range pending {
+		p := &pending[i]
+		eviction := &preoomkillerv1.PendingEviction{
+			Cluster:   p.Cluster,
+			Namespace: p.Namespace,
+			Name:      p.Name,
+			FireAt:    timestamppb.New(p.FireAt),
+		}
+
+		if !p.RestartAt.IsZero() {
+			eviction.RestartAt = timestamppb.New(p.RestartAt)
+		}
+
+		response.PendingEvictions = append(response.PendingEvictions, eviction)
+	}
+
+	return response, nil
+}
+
+// ListEvictions returns the recorded evictions, newest first.
+func (s *GRPCServer) ListEvictions(
+	_ context.Context,
+	req *preoomkillerv1.ListEvictionsRequest,
+) (*preoomkillerv1.ListEvictionsResponse, error) {
+	if s.endpoints.evictionHistory == nil {
+		return nil, status.Error(codes.Unimplemented, "eviction history is disabled")
+	}
+
+	evictions := s.endpoints.evictionHistory.List(req.GetNamespace())
+	response := &preoomkillerv1.ListEvictionsResponse{Evictions: make([]*preoomkillerv1.Eviction, 0, len(evictions))}
+
+	for i := range evictions {
+		e := &evictions[i]
+		eviction := &preoomkillerv1.Eviction{
+			Time:                      timestamppb.New(e.Time),
+			Cluster:                   e.Cluster,
+			Namespace:                 e.Namespace,
+			Name:                      e.Pod,
+			Uid:                       e.PodUID,
+			Trigger:                   e.Trigger,
+			Reason:                    e.Reason,
+			MemoryThresholdAnnotation: e.MemoryThresholdAnnotation,
+		}
+
+		if e.Owner != nil {
+			eviction.OwnerKind, eviction.OwnerName = e.Owner.Kind, e.Owner.Name
+		}
+
+		if e.MemoryUsageBytes != nil {
+			eviction.MemoryUsageBytes = *e.MemoryUsageBytes
+		}
+
+		if e.MemoryThresholdBytes != nil {
+			eviction.MemoryThresholdBytes = *e.MemoryThresholdBytes
+		}
+
+		response.Evictions = append(response.Evictions, eviction)
+	}
+
+	return response, nil
+}
+
+// Reconcile makes every controller reconcile now instead of at its next tick.
+func (s *GRPCServer) Reconcile(
+	ctx context.Context,
+	_ *preoomkillerv1.ReconcileRequest,
+) (*preoomkillerv1.ReconcileResponse, error) {
+	for _, c := range s.endpoints.controllers {
+		c.TriggerReconcileCommand()
+	}
+
+	s.logger.InfoContext(ctx, "reconcile requested through grpc admin api")
+
+	return &preoomkillerv1.ReconcileResponse{}, nil
+}
+
+// Evict evicts the pod through the eviction guards of its controller. Like the HTTP endpoint, it is
+// denied when the user may not evict the pod or the controller does not select it.
+func (s *GRPCServer) Evict(ctx context.Context, req *preoomkillerv1.EvictRequest) (*preoomkillerv1.EvictResponse, error) {
+	c, err := s.selectController(req.GetCluster())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.reviewEviction(ctx, req.GetNamespace(), req.GetName()); err != nil {
+		return nil, err
+	}
+
+	result, err := c.RequestEvictionCommand(ctx, req.GetNamespace(), req.GetName())
+
+	switch {
+	case errors.Is(err, controller.ErrPodNotFound):
+		return nil, status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, controller.ErrPodNotSelected):
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	case err != nil:
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &preoomkillerv1.EvictResponse{Outcome: string(result.Outcome), Reason: result.Reason}, nil
+}
+
+// CancelPendingEviction cancels the pending scheduled eviction of the pod; the pod is rescheduled for
+// the next occurrence of its schedule.
+func (s *GRPCServer) CancelPendingEviction(
+	ctx context.Context,
+	req *preoomkillerv1.CancelPendingEvictionRequest,
+) (*preoomkillerv1.CancelPendingEvictionResponse, error) {
+	c, err := s.selectController(req.GetCluster())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.reviewEviction(ctx, req.GetNamespace(), req.GetName()); err != nil {
+		return nil, err
+	}
+
+	restartAt, err := c.CancelPendingEvictionCommand(ctx, req.GetNamespace(), req.GetName())
+
+	switch {
+	case errors.Is(err, controller.ErrNoPendingEviction), errors.Is(err, controller.ErrPodNotFound):
+		return nil, status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, controller.ErrPodNotSelected):
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	case err != nil:
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &preoomkillerv1.CancelPendingEvictionResponse{RestartAt: timestamppb.New(restartAt)}, nil
+}
+
+// Reload reloads the configuration; a configuration that cannot be loaded keeps the running one.
+func (s *GRPCServer) Reload(ctx context.Context, _ *preoomkillerv1.ReloadRequest) (*preoomkillerv1.ReloadResponse, error) {
+	if s.endpoints.reloader == nil {
+		return nil, status.Error(codes.Unimplemented, "reload is disabled")
+	}
+
+	changes, err := s.endpoints.reloader.ReloadCommand(ctx)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "reload requested through grpc admin api failed", "reason", err)
+
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &preoomkillerv1.ReloadResponse{
+		Reloaded:        changes.Reloadable,
+		RestartRequired: changes.RestartRequired,
+	}, nil
+}
+
+// ResumeWorkload resumes the suspended evictions of the workload. Like the HTTP endpoint, the user
+// needs the permission to evict pods in the namespace.
+func (s *GRPCServer) ResumeWorkload(
+	ctx context.Context,
+	req *preoomkillerv1.ResumeWorkloadRequest,
+) (*preoomkillerv1.ResumeWorkloadResponse, error) {
+	c, err := s.selectController(req.GetCluster())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.reviewEviction(ctx, req.GetNamespace(), ""); err != nil {
+		return nil, err
+	}
+
+	workload, err := c.ResumeWorkloadCommand(ctx, req.GetNamespace(), req.GetOwnerKind(), req.GetOwnerName())
+
+	switch {
+	case errors.Is(err, controller.ErrWorkloadNotSuspended):
+		return nil, status.Error(codes.NotFound, err.Error())
+	case err != nil:
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &preoomkillerv1.ResumeWorkloadResponse{Resumed: toProtoSuspendedWorkload(workload)}, nil
+}
+
+func toProtoSuspendedWorkload(workload controller.SuspendedWorkload) *preoomkillerv1.SuspendedWorkload {
+	return &preoomkillerv1.SuspendedWorkload{
+		Cluster:     workload.Cluster,
+		Namespace:   workload.Namespace,
+		OwnerKind:   workload.Owner.Kind,
+		OwnerName:   workload.Owner.Name,
+		Pod:         workload.Pod,
+		SuspendedAt: timestamppb.New(workload.SuspendedAt),
+	}
+}
+
+// reviewEviction authorizes the eviction of the pod for calls authorized by an access review, as the
+// status of the failed review.
+func (s *GRPCServer) reviewEviction(ctx context.Context, namespace, name string) error {
+	if err := reviewEviction(ctx, s.endpoints.accessReviewer, namespace, name); err != nil {
+		return reviewStatus(ctx, s.logger.With("pod", name, "namespace", namespace), err)
+	}
+
+	return nil
+}
+
+// selectController returns the controller of cluster, as an InvalidArgument status when there is none.
+func (s *GRPCServer) selectController(cluster string) (Controller, error) {
+	if len(s.endpoints.controllers) == 0 {
+		return nil, status.Error(codes.Unimplemented, "no controllers are served")
+	}
+
+	c, err := selectController(cluster, s.endpoints.controllers)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return c, nil
+}
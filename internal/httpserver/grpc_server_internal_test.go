@@ -0,0 +1,201 @@
+package httpserver
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	preoomkillerv1 "github.com/skillcoder/preoomkiller-controller/api/preoomkiller/v1"
+	"github.com/skillcoder/preoomkiller-controller/internal/logic/controller"
+)
+
+func TestGRPCServer_authorize(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		token         string
+		reviewErr     error
+		method        string
+		authorization string
+		wantCode      codes.Code
+		wantReviews   []string
+	}{
+		{
+			name:     "read-only method needs no token",
+			token:    "s3cr3t",
+			method:   preoomkillerv1.AdminService_ListPods_FullMethodName,
+			wantCode: codes.OK,
+		},
+		{
+			name:          "static token skips the review",
+			token:         "s3cr3t",
+			method:        preoomkillerv1.AdminService_Evict_FullMethodName,
+			authorization: "Bearer s3cr3t",
+			wantCode:      codes.OK,
+		},
+		{
+			name:          "reviewed token is allowed",
+			token:         "s3cr3t",
+			method:        preoomkillerv1.AdminService_Reconcile_FullMethodName,
+			authorization: "Bearer sa-token",
+			wantCode:      codes.OK,
+			wantReviews:   []string{"post /preoomkiller.v1.AdminService/Reconcile"},
+		},
+		{
+			name:     "missing token",
+			token:    "s3cr3t",
+			method:   preoomkillerv1.AdminService_Reload_FullMethodName,
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:          "unauthenticated token",
+			token:         "s3cr3t",
+			reviewErr:     unauthenticatedError{},
+			method:        preoomkillerv1.AdminService_Evict_FullMethodName,
+			authorization: "Bearer other",
+			wantCode:      codes.Unauthenticated,
+			wantReviews:   []string{"post /preoomkiller.v1.AdminService/Evict"},
+		},
+		{
+			name:          "forbidden user",
+			reviewErr:     forbiddenError{},
+			method:        preoomkillerv1.AdminService_Evict_FullMethodName,
+			authorization: "Bearer other",
+			wantCode:      codes.PermissionDenied,
+			wantReviews:   []string{"post /preoomkiller.v1.AdminService/Evict"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			reviewer := &fakeReviewer{err: tt.reviewErr}
+			s := NewGRPCServer(slog.Default(), nil, "0", WithAdminToken(tt.token), WithAccessReviewer(reviewer))
+
+			ctx := context.Background()
+			if tt.authorization != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", tt.authorization))
+			}
+
+			handled := false
+			handler := func(context.Context, any) (any, error) {
+				handled = true
+
+				return nil, nil
+			}
+
+			_, err := s.authorize(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			require.Equal(t, tt.wantCode, status.Code(err))
+			require.Equal(t, tt.wantCode == codes.OK, handled)
+			require.Equal(t, tt.wantReviews, reviewer.reviews)
+		})
+	}
+}
+
+func TestGRPCServer_authorizeDisabled(t *testing.T) {
+	t.Parallel()
+
+	s := NewGRPCServer(slog.Default(), nil, "0")
+	info := &grpc.UnaryServerInfo{FullMethod: preoomkillerv1.AdminService_Evict_FullMethodName}
+
+	_, err := s.authorize(context.Background(), nil, info, func(context.Context, any) (any, error) {
+		return nil, nil
+	})
+	require.Equal(t, codes.Unimplemented, status.Code(err))
+}
+
+func TestGRPCServer_authorizeClientCert(t *testing.T) {
+	t.Parallel()
+
+	s := NewGRPCServer(slog.Default(), nil, "0", WithTLS(&TLS{clientCAFile: "ca.crt"}))
+	info := &grpc.UnaryServerInfo{FullMethod: preoomkillerv1.AdminService_ListPods_FullMethodName}
+	handler := func(context.Context, any) (any, error) {
+		return nil, nil
+	}
+
+	_, err := s.authorize(context.Background(), nil, info, handler)
+	require.Equal(t, codes.Unauthenticated, status.Code(err), "calls without a client certificate are rejected")
+
+	verified := peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{
+		State: tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{{}}}},
+	}})
+
+	_, err = s.authorize(verified, nil, info, handler)
+	require.NoError(t, err)
+}
+
+func TestGRPCServer_Evict(t *testing.T) {
+	t.Parallel()
+
+	t.Run("skipped eviction", func(t *testing.T) {
+		t.Parallel()
+
+		c := &fakeController{
+			cluster: "prod",
+			result:  controller.EvictionRequestResult{Outcome: controller.OutcomeSkipped, Reason: "pdb"},
+		}
+		s := NewGRPCServer(slog.Default(), nil, "0", WithControllers(c))
+
+		resp, err := s.Evict(context.Background(), &preoomkillerv1.EvictRequest{Namespace: "default", Name: "test-pod"})
+		require.NoError(t, err)
+		require.Equal(t, string(controller.OutcomeSkipped), resp.GetOutcome())
+		require.Equal(t, "pdb", resp.GetReason())
+		require.Equal(t, []string{"default/test-pod"}, c.evicted)
+	})
+
+	t.Run("pod not found", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewGRPCServer(slog.Default(), nil, "0", WithControllers(&fakeController{err: controller.ErrPodNotFound}))
+
+		_, err := s.Evict(context.Background(), &preoomkillerv1.EvictRequest{Namespace: "default", Name: "test-pod"})
+		require.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("pod not selected", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewGRPCServer(slog.Default(), nil, "0", WithControllers(&fakeController{err: controller.ErrPodNotSelected}))
+
+		_, err := s.Evict(context.Background(), &preoomkillerv1.EvictRequest{Namespace: "kube-system", Name: "coredns-1"})
+		require.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("reviewed user may not evict the pod", func(t *testing.T) {
+		t.Parallel()
+
+		c := &fakeController{}
+		reviewer := &fakeReviewer{}
+		s := NewGRPCServer(slog.Default(), nil, "0", WithControllers(c), WithAccessReviewer(reviewer))
+		ctx := context.WithValue(context.Background(), reviewedTokenKey{}, "sa-token")
+
+		_, err := s.Evict(ctx, &preoomkillerv1.EvictRequest{Namespace: "kube-system", Name: "coredns-1"})
+		require.Equal(t, codes.PermissionDenied, status.Code(err))
+		require.Equal(t, []string{"evict kube-system/coredns-1"}, reviewer.reviews)
+		require.Empty(t, c.evicted)
+	})
+
+	t.Run("cluster required", func(t *testing.T) {
+		t.Parallel()
+
+		prod, staging := &fakeController{cluster: "prod"}, &fakeController{cluster: "staging"}
+		s := NewGRPCServer(slog.Default(), nil, "0", WithControllers(prod, staging))
+
+		_, err := s.Evict(context.Background(), &preoomkillerv1.EvictRequest{Namespace: "default", Name: "test-pod"})
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+		require.Empty(t, prod.evicted)
+		require.Empty(t, staging.evicted)
+	})
+}
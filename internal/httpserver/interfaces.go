@@ -1,10 +1,13 @@
 package httpserver
 
 import (
+	"context"
 	"time"
 
+	"github.com/skillcoder/preoomkiller-controller/internal/config"
 	"github.com/skillcoder/preoomkiller-controller/internal/infra/appstate"
 	"github.com/skillcoder/preoomkiller-controller/internal/infra/pinger"
+	"github.com/skillcoder/preoomkiller-controller/internal/logic/controller"
 )
 
 // appstater is an internal interface for application state management
@@ -16,3 +19,25 @@ type appstater interface {
 	GetStartTime() time.Time
 	GetAllStats() map[string]*pinger.Statistics
 }
+
+// statsGetter returns the statistics of the pingers, keyed by name.
+type statsGetter interface {
+	GetAllStats() map[string]*pinger.Statistics
+}
+
+// Controller is a controller served by the admin and introspection endpoints.
+type Controller interface {
+	Cluster() string
+	PendingEvictionsQuery() []controller.ScheduledEviction
+	EnrolledPodsQuery() []controller.EnrolledPod
+	TriggerReconcileCommand()
+	RequestEvictionCommand(ctx context.Context, namespace, name string) (controller.EvictionRequestResult, error)
+	CancelPendingEvictionCommand(ctx context.Context, namespace, name string) (time.Time, error)
+	SuspendedWorkloadsQuery() []controller.SuspendedWorkload
+	ResumeWorkloadCommand(ctx context.Context, namespace, kind, name string) (controller.SuspendedWorkload, error)
+}
+
+// Reloader reloads the configuration of the application.
+type Reloader interface {
+	ReloadCommand(ctx context.Context) (config.Changes, error)
+}
@@ -6,6 +6,7 @@ import (
 	"log/slog"
 	"net"
 	"net/http"
+	"net/http/pprof"
 	"sync/atomic"
 
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -22,19 +23,46 @@ type MetricsServer struct {
 	server     *http.Server
 	ready      chan struct{}
 	inShutdown atomic.Bool
+	// tls serves over TLS when set.
+	tls *TLS
+	// pprof serves the /debug/pprof/ profiles when set.
+	pprof bool
+}
+
+// MetricsOption configures optional metrics server behavior.
+type MetricsOption func(*MetricsServer)
+
+// WithMetricsTLS serves over TLS with the files of tlsFiles.
+func WithMetricsTLS(tlsFiles *TLS) MetricsOption {
+	return func(s *MetricsServer) {
+		s.tls = tlsFiles
+	}
+}
+
+// WithPprof serves the runtime profiles of the controller on /debug/pprof/.
+func WithPprof() MetricsOption {
+	return func(s *MetricsServer) {
+		s.pprof = true
+	}
 }
 
 // NewMetricsServer creates a new metrics server that serves GET /metrics on the given port.
-func NewMetricsServer(logger *slog.Logger, port string) *MetricsServer {
+func NewMetricsServer(logger *slog.Logger, port string, opts ...MetricsOption) *MetricsServer {
 	if port == "" {
 		port = defaultMetricsPort
 	}
 
-	return &MetricsServer{
+	s := &MetricsServer{
 		logger: logger,
 		port:   port,
 		ready:  make(chan struct{}),
 	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
 }
 
 var _ shutdown.Shutdowner = (*MetricsServer)(nil)
@@ -67,13 +95,25 @@ func (s *MetricsServer) Start(ctx context.Context) error {
 	mux := http.NewServeMux()
 	mux.Handle("/metrics", promhttp.Handler())
 
+	serverWriteTimeout := writeTimeout
+
+	if s.pprof {
+		mux.HandleFunc("/debug/pprof/", pprof.Index)
+		mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+		mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+		mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+		mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+
+		serverWriteTimeout = pprofWriteTimeout
+	}
+
 	addr := ":" + s.port
 	s.server = &http.Server{
 		Addr:              addr,
-		Handler:           mux,
+		Handler:           s.tls.requireClientCert(s.logger)(mux),
 		ReadTimeout:       readTimeout,
 		ReadHeaderTimeout: readHeaderTimeout,
-		WriteTimeout:      writeTimeout,
+		WriteTimeout:      serverWriteTimeout,
 		IdleTimeout:       idleTimeout,
 		MaxHeaderBytes:    maxHeaderBytes,
 	}
@@ -89,12 +129,12 @@ func (s *MetricsServer) Start(ctx context.Context) error {
 		return fmt.Errorf("listen metrics tcp: %w", err)
 	}
 
-	s.logger.InfoContext(ctx, "metrics server listening", "addr", listener.Addr().String())
+	s.logger.InfoContext(ctx, "metrics server listening", "addr", listener.Addr().String(), "tls", s.tls != nil, "pprof", s.pprof)
 
 	go func() {
 		close(s.ready)
 
-		if err := s.server.Serve(listener); err != nil && err != http.ErrServerClosed {
+		if err := serve(s.server, listener, s.tls); err != nil && err != http.ErrServerClosed {
 			s.logger.ErrorContext(ctx, "metrics server error", "error", err)
 		}
 	}()
@@ -107,6 +147,11 @@ func (s *MetricsServer) Ready() <-chan struct{} {
 	return s.ready
 }
 
+// ShutdownGroup returns shutdown.GroupServers: the server stops before the components it serves.
+func (s *MetricsServer) ShutdownGroup() shutdown.Group {
+	return shutdown.GroupServers
+}
+
 // Shutdown gracefully shuts down the metrics server.
 //
 //nolint:dupl // mirrors Server.Shutdown for same lifecycle; dedup would abstract over *http.Server
@@ -0,0 +1,79 @@
+package httpserver
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+
+	"github.com/skillcoder/preoomkiller-controller/internal/logic/controller"
+)
+
+// pendingResponse is the body of the /-/pending endpoint.
+type pendingResponse struct {
+	Pending []pendingEviction `json:"pending"`
+}
+
+// pendingEviction is a pending scheduled eviction.
+type pendingEviction struct {
+	Cluster   string `json:"cluster,omitempty"`
+	Namespace string `json:"namespace"`
+	Pod       string `json:"pod"`
+	// RestartAt is the restart-at annotation; omitted when unknown.
+	RestartAt *time.Time `json:"restartAt,omitempty"`
+	// FireAt is when the eviction runs, after jitter and blackout deferral.
+	FireAt time.Time `json:"fireAt"`
+}
+
+// handlePending returns an http.HandlerFunc for the /-/pending endpoint, listing the pending
+// scheduled evictions of all controllers, soonest first.
+func handlePending(logger *slog.Logger, controllers []Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		logger := logger.With("traceID", middleware.GetReqID(ctx))
+
+		response := pendingResponse{Pending: []pendingEviction{}}
+
+		for _, scheduled := range collectPendingEvictions(controllers) {
+			pending := pendingEviction{
+				Cluster:   scheduled.Cluster,
+				Namespace: scheduled.Namespace,
+				Pod:       scheduled.Name,
+				FireAt:    scheduled.FireAt,
+			}
+
+			if !scheduled.RestartAt.IsZero() {
+				pending.RestartAt = &scheduled.RestartAt
+			}
+
+			response.Pending = append(response.Pending, pending)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			logger.ErrorContext(ctx, "failed to encode pending response",
+				"error", err,
+			)
+		}
+	}
+}
+
+// collectPendingEvictions returns the pending scheduled evictions of all controllers, soonest first.
+func collectPendingEvictions(controllers []Controller) []controller.ScheduledEviction {
+	var pending []controller.ScheduledEviction
+
+	for _, c := range controllers {
+		pending = append(pending, c.PendingEvictionsQuery()...)
+	}
+
+	slices.SortFunc(pending, func(a, b controller.ScheduledEviction) int {
+		return a.FireAt.Compare(b.FireAt)
+	})
+
+	return pending
+}
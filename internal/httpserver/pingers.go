@@ -0,0 +1,150 @@
+package httpserver
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+
+	"github.com/skillcoder/preoomkiller-controller/internal/infra/pinger"
+)
+
+// pingersResponse is the body of the /-/pingers endpoint.
+type pingersResponse struct {
+	Pingers []pingerStats `json:"pingers"`
+}
+
+// pingerStats are the statistics of a pinger. Durations are formatted like 1.5ms.
+type pingerStats struct {
+	Name    string `json:"name"`
+	Ready   bool   `json:"ready"`
+	Healthy bool   `json:"healthy"`
+	// LastRun is omitted until the first check.
+	LastRun      *time.Time `json:"lastRun,omitempty"`
+	SuccessCount int        `json:"successCount"`
+	ErrorCount   int        `json:"errorCount"`
+	// ConsecutiveFailures and ConsecutiveSuccesses count the last checks of the same result.
+	ConsecutiveFailures  int `json:"consecutiveFailures"`
+	ConsecutiveSuccesses int `json:"consecutiveSuccesses"`
+	// LastError is the error of the last check; omitted when it succeeded.
+	LastError string `json:"lastError,omitempty"`
+	// LastFailure is the last failed check, kept after later successes.
+	LastFailure *pingerFailure `json:"lastFailure,omitempty"`
+	// RecentFailures are the last failed checks, newest first.
+	RecentFailures   []pingerFailure `json:"recentFailures"`
+	SuccessLatencies latencyStats    `json:"successLatencies"`
+	ErrorLatencies   latencyStats    `json:"errorLatencies"`
+	// Flapping is set when the result changed too often within the flap window; Transitions is the
+	// number of changes within the window.
+	Flapping    bool `json:"flapping"`
+	Transitions int  `json:"transitions"`
+	// StartupGrace is set during the startup grace period, in which failures do not count.
+	StartupGrace bool `json:"startupGrace"`
+}
+
+// pingerFailure is a failed check of a pinger.
+type pingerFailure struct {
+	Time    time.Time `json:"time"`
+	Latency string    `json:"latency"`
+	Error   string    `json:"error"`
+}
+
+// latencyStats are the latency percentiles of the recent checks of a pinger.
+type latencyStats struct {
+	Count   int    `json:"count"`
+	Median  string `json:"median"`
+	Average string `json:"average"`
+	P80     string `json:"p80"`
+	P90     string `json:"p90"`
+	P99     string `json:"p99"`
+}
+
+func toLatencyStats(m pinger.LatencyMetrics) latencyStats {
+	return latencyStats{
+		Count:   m.Count,
+		Median:  m.Median.String(),
+		Average: m.Average.String(),
+		P80:     m.P80.String(),
+		P90:     m.P90.String(),
+		P99:     m.P99.String(),
+	}
+}
+
+func toPingerFailure(snapshot pinger.ErrorSnapshot) pingerFailure {
+	return pingerFailure{
+		Time:    snapshot.Timestamp,
+		Latency: snapshot.Latency.String(),
+		Error:   snapshot.Error.Error(),
+	}
+}
+
+func toPingerStats(name string, stats *pinger.Statistics) pingerStats {
+	ps := pingerStats{
+		Name:                 name,
+		Ready:                stats.IsReady,
+		Healthy:              stats.IsHealthy,
+		SuccessCount:         stats.SuccessCount,
+		ErrorCount:           stats.ErrorCount,
+		ConsecutiveFailures:  stats.ConsecutiveFailures,
+		ConsecutiveSuccesses: stats.ConsecutiveSuccesses,
+		SuccessLatencies:     toLatencyStats(stats.SuccessLatencies),
+		ErrorLatencies:       toLatencyStats(stats.ErrorLatencies),
+		Flapping:             stats.IsFlapping,
+		Transitions:          stats.Transitions,
+		StartupGrace:         stats.InStartupGrace,
+		RecentFailures:       make([]pingerFailure, 0, len(stats.ErrorSnapshots)),
+	}
+
+	if !stats.LastRun.IsZero() {
+		ps.LastRun = &stats.LastRun
+	}
+
+	if stats.LastError != nil {
+		ps.LastError = stats.LastError.Error()
+	}
+
+	if snapshot := stats.LastErrorSnapshot; snapshot != nil && snapshot.Error != nil {
+		failure := toPingerFailure(*snapshot)
+		ps.LastFailure = &failure
+	}
+
+	for _, snapshot := range stats.ErrorSnapshots {
+		if snapshot.Error != nil {
+			ps.RecentFailures = append(ps.RecentFailures, toPingerFailure(snapshot))
+		}
+	}
+
+	return ps
+}
+
+// handlePingers returns an http.HandlerFunc for the /-/pingers endpoint, detailing the statistics
+// behind /-/readyz and /-/healthz for each pinger, sorted by name.
+func handlePingers(logger *slog.Logger, appState statsGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		logger := logger.With("traceID", middleware.GetReqID(ctx))
+
+		response := pingersResponse{Pingers: []pingerStats{}}
+
+		for name, stats := range appState.GetAllStats() {
+			response.Pingers = append(response.Pingers, toPingerStats(name, stats))
+		}
+
+		slices.SortFunc(response.Pingers, func(a, b pingerStats) int {
+			return strings.Compare(a.Name, b.Name)
+		})
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			logger.ErrorContext(ctx, "failed to encode pingers response",
+				"error", err,
+			)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package httpserver
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skillcoder/preoomkiller-controller/internal/infra/pinger"
+)
+
+// fakeStats returns fixed pinger statistics.
+type fakeStats map[string]*pinger.Statistics
+
+func (f fakeStats) GetAllStats() map[string]*pinger.Statistics {
+	return f
+}
+
+func TestHandlePingers(t *testing.T) {
+	t.Parallel()
+
+	lastRun := time.Date(2026, 1, 12, 3, 0, 0, 0, time.UTC)
+	errTimeout := errors.New("timeout")
+
+	stats := fakeStats{
+		"http-server": {
+			IsReady:      true,
+			IsHealthy:    true,
+			LastRun:      lastRun,
+			SuccessCount: 3,
+			LastErrorSnapshot: &pinger.ErrorSnapshot{
+				Timestamp: lastRun.Add(-time.Minute),
+				Latency:   2 * time.Second,
+				Error:     errTimeout,
+			},
+			ErrorSnapshots: []pinger.ErrorSnapshot{
+				{Timestamp: lastRun.Add(-time.Minute), Latency: 2 * time.Second, Error: errTimeout},
+				{Timestamp: lastRun.Add(-time.Hour), Latency: time.Second, Error: errors.New("refused")},
+			},
+			SuccessLatencies: pinger.LatencyMetrics{Count: 3, Median: time.Millisecond, P99: 3 * time.Millisecond},
+		},
+		"controller": {
+			LastRun:    lastRun,
+			LastError:  errTimeout,
+			ErrorCount: 1,
+		},
+		"new": {},
+	}
+
+	rec := httptest.NewRecorder()
+	handlePingers(slog.Default(), stats)(rec, httptest.NewRequest(http.MethodGet, "/-/pingers", nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var got pingersResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&got))
+	require.Len(t, got.Pingers, 3)
+
+	require.Equal(t, "controller", got.Pingers[0].Name)
+	require.Equal(t, "timeout", got.Pingers[0].LastError)
+	require.Equal(t, 1, got.Pingers[0].ErrorCount)
+	require.Nil(t, got.Pingers[0].LastFailure)
+
+	require.Equal(t, "http-server", got.Pingers[1].Name)
+	require.True(t, got.Pingers[1].Ready)
+	require.Empty(t, got.Pingers[1].LastError)
+	require.NotNil(t, got.Pingers[1].LastRun)
+	require.True(t, lastRun.Equal(*got.Pingers[1].LastRun))
+	require.Equal(t, &pingerFailure{Time: lastRun.Add(-time.Minute), Latency: "2s", Error: "timeout"}, got.Pingers[1].LastFailure)
+	require.Len(t, got.Pingers[1].RecentFailures, 2)
+	require.Equal(t, "refused", got.Pingers[1].RecentFailures[1].Error)
+	require.Equal(t, "1ms", got.Pingers[1].SuccessLatencies.Median)
+	require.Equal(t, "3ms", got.Pingers[1].SuccessLatencies.P99)
+
+	require.Equal(t, "new", got.Pingers[2].Name)
+	require.Nil(t, got.Pingers[2].LastRun)
+	require.Empty(t, got.Pingers[2].RecentFailures)
+}
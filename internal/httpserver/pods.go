@@ -0,0 +1,109 @@
+package httpserver
+
+import (
+	"cmp"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+
+	"github.com/skillcoder/preoomkiller-controller/internal/logic/controller"
+)
+
+// podsResponse is the body of the /-/pods endpoint.
+type podsResponse struct {
+	Pods []enrolledPod `json:"pods"`
+}
+
+// enrolledPod is a pod selected by a controller.
+type enrolledPod struct {
+	Cluster         string `json:"cluster,omitempty"`
+	Namespace       string `json:"namespace"`
+	Pod             string `json:"pod"`
+	MemoryThreshold string `json:"memoryThreshold,omitempty"`
+	RestartSchedule string `json:"restartSchedule,omitempty"`
+	// RestartAt is the restart-at annotation; omitted when unset.
+	RestartAt *time.Time `json:"restartAt,omitempty"`
+	// MemoryUsage is the usage of the last threshold check and EffectiveThreshold the threshold it
+	// was compared against; omitted before the first check.
+	MemoryUsage          string     `json:"memoryUsage,omitempty"`
+	MemoryUsageBytes     *int64     `json:"memoryUsageBytes,omitempty"`
+	EffectiveThreshold   string     `json:"effectiveThreshold,omitempty"`
+	MemoryThresholdBytes *int64     `json:"memoryThresholdBytes,omitempty"`
+	CheckedAt            *time.Time `json:"checkedAt,omitempty"`
+	// SuspendedAt is when evictions of the pod's workload were suspended; omitted when they are not.
+	SuspendedAt *time.Time `json:"suspendedAt,omitempty"`
+}
+
+// handlePods returns an http.HandlerFunc for the /-/pods endpoint, listing the pods selected by the
+// last reconcile of all controllers, sorted by cluster, namespace and name.
+func handlePods(logger *slog.Logger, controllers []Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		logger := logger.With("traceID", middleware.GetReqID(ctx))
+
+		response := podsResponse{Pods: []enrolledPod{}}
+
+		for _, enrolled := range collectEnrolledPods(controllers) {
+			pod := enrolledPod{
+				Cluster:         enrolled.Cluster,
+				Namespace:       enrolled.Namespace,
+				Pod:             enrolled.Name,
+				MemoryThreshold: enrolled.MemoryThreshold,
+				RestartSchedule: enrolled.RestartSchedule,
+			}
+
+			if !enrolled.RestartAt.IsZero() {
+				pod.RestartAt = &enrolled.RestartAt
+			}
+
+			if enrolled.MemoryUsage != nil {
+				usageBytes := enrolled.MemoryUsage.Value()
+				pod.MemoryUsage, pod.MemoryUsageBytes = enrolled.MemoryUsage.String(), &usageBytes
+				pod.CheckedAt = &enrolled.CheckedAt
+			}
+
+			if enrolled.EffectiveThreshold != nil {
+				thresholdBytes := enrolled.EffectiveThreshold.Value()
+				pod.EffectiveThreshold, pod.MemoryThresholdBytes = enrolled.EffectiveThreshold.String(), &thresholdBytes
+			}
+
+			if !enrolled.SuspendedAt.IsZero() {
+				pod.SuspendedAt = &enrolled.SuspendedAt
+			}
+
+			response.Pods = append(response.Pods, pod)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			logger.ErrorContext(ctx, "failed to encode pods response",
+				"error", err,
+			)
+		}
+	}
+}
+
+// collectEnrolledPods returns the enrolled pods of all controllers, sorted by cluster, namespace and name.
+func collectEnrolledPods(controllers []Controller) []controller.EnrolledPod {
+	var pods []controller.EnrolledPod
+
+	for _, c := range controllers {
+		pods = append(pods, c.EnrolledPodsQuery()...)
+	}
+
+	slices.SortFunc(pods, func(a, b controller.EnrolledPod) int {
+		return cmp.Or(
+			cmp.Compare(a.Cluster, b.Cluster),
+			cmp.Compare(a.Namespace, b.Namespace),
+			cmp.Compare(a.Name, b.Name),
+		)
+	})
+
+	return pods
+}
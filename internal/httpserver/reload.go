@@ -0,0 +1,39 @@
+package httpserver
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// reloadResponse is the body of the POST /-/reload endpoint.
+type reloadResponse struct {
+	// Reloaded lists the changed settings that were applied.
+	Reloaded []string `json:"reloaded,omitempty"`
+	// RestartRequired lists the changed settings that only apply after a restart.
+	RestartRequired []string `json:"restartRequired,omitempty"`
+	Error           string   `json:"error,omitempty"`
+}
+
+// handleReload returns an http.HandlerFunc for the POST /-/reload endpoint, reloading the
+// configuration. It answers 500 when the configuration cannot be loaded, which keeps the running one.
+func handleReload(logger *slog.Logger, reloader Reloader) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		logger := logger.With("traceID", middleware.GetReqID(ctx))
+
+		changes, err := reloader.ReloadCommand(ctx)
+		if err != nil {
+			logger.ErrorContext(ctx, "reload requested through admin api failed", "reason", err)
+			writeJSON(ctx, logger, w, http.StatusInternalServerError, reloadResponse{Error: err.Error()})
+
+			return
+		}
+
+		writeJSON(ctx, logger, w, http.StatusOK, reloadResponse{
+			Reloaded:        changes.Reloadable,
+			RestartRequired: changes.RestartRequired,
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 
+	"github.com/skillcoder/preoomkiller-controller/internal/adapters/outbound/evictionhistory"
 	"github.com/skillcoder/preoomkiller-controller/internal/infra/appstate"
 	"github.com/skillcoder/preoomkiller-controller/internal/infra/shutdown"
 )
@@ -22,20 +23,104 @@ type Server struct {
 	server     *http.Server
 	ready      chan struct{}
 	inShutdown atomic.Bool
+	// evictionHistory serves /-/evictions when set.
+	evictionHistory *evictionhistory.History
+	// controllers serve /-/pending, /-/pods and, with adminToken, the admin endpoints.
+	controllers []Controller
+	// adminToken is a static bearer token of the admin endpoints.
+	adminToken string
+	// accessReviewer reviews the other bearer tokens of the admin endpoints.
+	accessReviewer AccessReviewer
+	// tls serves over TLS when set.
+	tls *TLS
+	// config serves /-/config when set.
+	config func() map[string]string
+	// reloader serves the POST /-/reload admin endpoint when set.
+	reloader Reloader
+	// dashboard serves the web dashboard on /-/dashboard/ when set.
+	dashboard bool
+}
+
+// Option configures optional server endpoints.
+type Option func(*Server)
+
+// WithEvictionHistory serves the recent evictions of history on /-/evictions.
+func WithEvictionHistory(history *evictionhistory.History) Option {
+	return func(s *Server) {
+		s.evictionHistory = history
+	}
+}
+
+// WithControllers serves the pending scheduled evictions of the controllers on /-/pending and their
+// enrolled pods on /-/pods, and controls them through the admin endpoints when enabled with WithAdminToken.
+func WithControllers(controllers ...Controller) Option {
+	return func(s *Server) {
+		s.controllers = controllers
+	}
+}
+
+// WithAdminToken enables the admin endpoints, authenticated with token as a bearer token.
+func WithAdminToken(token string) Option {
+	return func(s *Server) {
+		s.adminToken = token
+	}
+}
+
+// WithAccessReviewer enables the admin endpoints, authenticating and authorizing bearer tokens with
+// reviewer.
+func WithAccessReviewer(reviewer AccessReviewer) Option {
+	return func(s *Server) {
+		s.accessReviewer = reviewer
+	}
+}
+
+// WithTLS serves over TLS with the files of tlsFiles.
+func WithTLS(tlsFiles *TLS) Option {
+	return func(s *Server) {
+		s.tls = tlsFiles
+	}
+}
+
+// WithConfig serves the effective configuration returned by effective on /-/config; secrets must
+// already be redacted.
+func WithConfig(effective func() map[string]string) Option {
+	return func(s *Server) {
+		s.config = effective
+	}
+}
+
+// WithReloader serves the POST /-/reload admin endpoint, reloading the configuration with reloader.
+func WithReloader(reloader Reloader) Option {
+	return func(s *Server) {
+		s.reloader = reloader
+	}
+}
+
+// WithDashboard serves a read-only web dashboard of the controllers on /-/dashboard/.
+func WithDashboard() Option {
+	return func(s *Server) {
+		s.dashboard = true
+	}
 }
 
 // New creates a new HTTP server instance
-func New(logger *slog.Logger, appState appstater, port string) *Server {
+func New(logger *slog.Logger, appState appstater, port string, opts ...Option) *Server {
 	if port == "" {
 		port = defaultPort
 	}
 
-	return &Server{
+	s := &Server{
 		logger:   logger,
 		appState: appState,
 		port:     port,
 		ready:    make(chan struct{}),
 	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
 }
 
 var _ shutdown.Shutdowner = (*Server)(nil)
@@ -72,10 +157,14 @@ func (s *Server) Start(ctx context.Context) error {
 	router.Use(middleware.Logger)
 	router.Use(middleware.Recoverer)
 
-	// Register health endpoints
+	// Register health endpoints; with mTLS, they stay open to the kubelet, which has no client certificate.
 	router.Get("/-/healthz", appstate.HandleHealthz(s.logger, s.appState))
 	router.Get("/-/readyz", appstate.HandleReadyz(s.logger, s.appState))
-	router.Get("/-/status", appstate.HandleStatus(s.logger, s.appState))
+
+	router.Group(func(protected chi.Router) {
+		protected.Use(s.tls.requireClientCert(s.logger))
+		s.route(protected)
+	})
 
 	addr := ":" + s.port
 	s.server = &http.Server{
@@ -99,12 +188,12 @@ func (s *Server) Start(ctx context.Context) error {
 		return fmt.Errorf("listen tcp: %w", err)
 	}
 
-	s.logger.InfoContext(ctx, "http server listening", "addr", listener.Addr().String())
+	s.logger.InfoContext(ctx, "http server listening", "addr", listener.Addr().String(), "tls", s.tls != nil)
 
 	go func() {
 		close(s.ready)
 
-		if err := s.server.Serve(listener); err != nil && err != http.ErrServerClosed {
+		if err := serve(s.server, listener, s.tls); err != nil && err != http.ErrServerClosed {
 			s.logger.ErrorContext(ctx, "http server error", "error", err)
 		}
 	}()
@@ -112,11 +201,61 @@ func (s *Server) Start(ctx context.Context) error {
 	return nil
 }
 
+// route registers the endpoints other than the probes.
+func (s *Server) route(router chi.Router) {
+	if len(s.controllers) > 0 {
+		router.Get("/-/status", handleStatus(s.logger, s.appState, s.controllers))
+	} else {
+		router.Get("/-/status", appstate.HandleStatus(s.logger, s.appState))
+	}
+
+	router.Get("/-/pingers", handlePingers(s.logger, s.appState))
+
+	if s.config != nil {
+		router.Get("/-/config", handleConfig(s.logger, s.config))
+	}
+
+	if s.evictionHistory != nil {
+		router.Get("/-/evictions", evictionhistory.HandleEvictions(s.logger, s.evictionHistory))
+	}
+
+	if len(s.controllers) > 0 {
+		router.Get("/-/pending", handlePending(s.logger, s.controllers))
+		router.Get("/-/pods", handlePods(s.logger, s.controllers))
+
+		if s.dashboard {
+			router.Get("/-/dashboard", http.RedirectHandler(dashboardPath, http.StatusMovedPermanently).ServeHTTP)
+			router.Handle(dashboardPath+"*", handleDashboard())
+		}
+	}
+
+	// Read-only endpoints need no token; the mutating admin endpoints need authentication.
+	if (s.adminToken != "" || s.accessReviewer != nil) && len(s.controllers) > 0 {
+		router.Group(func(admin chi.Router) {
+			admin.Use(adminAuth(s.logger, s.adminToken, s.accessReviewer))
+			admin.Post("/-/reconcile", handleReconcile(s.logger, s.controllers))
+			admin.Post("/-/evict/{namespace}/{pod}", handleEvict(s.logger, s.controllers, s.accessReviewer))
+			admin.Delete("/-/pending/{namespace}/{pod}", handleCancelPending(s.logger, s.controllers, s.accessReviewer))
+			admin.Delete("/-/suspended/{namespace}/{kind}/{name}",
+				handleResumeWorkload(s.logger, s.controllers, s.accessReviewer))
+
+			if s.reloader != nil {
+				admin.Post("/-/reload", handleReload(s.logger, s.reloader))
+			}
+		})
+	}
+}
+
 // Ready returns a channel that is closed when the HTTP server is ready to serve requests
 func (s *Server) Ready() <-chan struct{} {
 	return s.ready
 }
 
+// ShutdownGroup returns shutdown.GroupServers: the server stops before the components it serves.
+func (s *Server) ShutdownGroup() shutdown.Group {
+	return shutdown.GroupServers
+}
+
 // Shutdown gracefully shuts down the HTTP server
 //
 //nolint:dupl // mirrors MetricsServer.Shutdown for same lifecycle
@@ -0,0 +1,135 @@
+package httpserver
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+
+	"github.com/skillcoder/preoomkiller-controller/internal/infra/appstate"
+	"github.com/skillcoder/preoomkiller-controller/internal/logic/controller"
+)
+
+// statusResponse is the body of the /-/status endpoint when controllers are served.
+type statusResponse struct {
+	appstate.StatusResponse
+	SuspendedWorkloads []suspendedWorkload `json:"suspendedWorkloads"`
+}
+
+// suspendedWorkload is a workload whose evictions are suspended.
+type suspendedWorkload struct {
+	Cluster   string `json:"cluster,omitempty"`
+	Namespace string `json:"namespace"`
+	OwnerKind string `json:"ownerKind"`
+	Owner     string `json:"owner"`
+	// Pod is the evicted pod whose replacement did not become Ready.
+	Pod         string    `json:"pod"`
+	SuspendedAt time.Time `json:"suspendedAt"`
+}
+
+// resumeWorkloadResponse is the body of the DELETE /-/suspended endpoint.
+type resumeWorkloadResponse struct {
+	Resumed *suspendedWorkload `json:"resumed,omitempty"`
+	Error   string             `json:"error,omitempty"`
+}
+
+// handleStatus returns an http.HandlerFunc for the /-/status endpoint, with the state of the
+// application and the workloads whose evictions are suspended by the controllers.
+func handleStatus(logger *slog.Logger, appState appstater, controllers []Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		logger := logger.With("traceID", middleware.GetReqID(ctx))
+
+		response := statusResponse{
+			StatusResponse:     appstate.NewStatusResponse(appState),
+			SuspendedWorkloads: []suspendedWorkload{},
+		}
+
+		for _, workload := range collectSuspendedWorkloads(controllers) {
+			response.SuspendedWorkloads = append(response.SuspendedWorkloads, toSuspendedWorkload(workload))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			logger.ErrorContext(ctx, "failed to encode status response",
+				"error", err,
+			)
+		}
+	}
+}
+
+// handleResumeWorkload returns an http.HandlerFunc for the DELETE /-/suspended/{namespace}/{kind}/{name}
+// endpoint, resuming the suspended evictions of the workload. The user needs the permission to evict
+// pods in the namespace.
+func handleResumeWorkload(logger *slog.Logger, controllers []Controller, reviewer AccessReviewer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		logger := logger.With("traceID", middleware.GetReqID(ctx))
+		namespace, kind, name := chi.URLParam(r, "namespace"), chi.URLParam(r, "kind"), chi.URLParam(r, "name")
+
+		c, err := selectController(r.URL.Query().Get("cluster"), controllers)
+		if err != nil {
+			writeJSON(ctx, logger, w, http.StatusBadRequest, resumeWorkloadResponse{Error: err.Error()})
+
+			return
+		}
+
+		if err := reviewEviction(ctx, reviewer, namespace, ""); err != nil {
+			denyReviewed(ctx, logger, w, err)
+
+			return
+		}
+
+		workload, err := c.ResumeWorkloadCommand(ctx, namespace, kind, name)
+
+		switch {
+		case errors.Is(err, controller.ErrWorkloadNotSuspended):
+			writeJSON(ctx, logger, w, http.StatusNotFound, resumeWorkloadResponse{Error: err.Error()})
+		case err != nil:
+			writeJSON(ctx, logger, w, http.StatusInternalServerError, resumeWorkloadResponse{Error: err.Error()})
+		default:
+			resumed := toSuspendedWorkload(workload)
+			writeJSON(ctx, logger, w, http.StatusOK, resumeWorkloadResponse{Resumed: &resumed})
+		}
+	}
+}
+
+func toSuspendedWorkload(workload controller.SuspendedWorkload) suspendedWorkload {
+	return suspendedWorkload{
+		Cluster:     workload.Cluster,
+		Namespace:   workload.Namespace,
+		OwnerKind:   workload.Owner.Kind,
+		Owner:       workload.Owner.Name,
+		Pod:         workload.Pod,
+		SuspendedAt: workload.SuspendedAt,
+	}
+}
+
+// collectSuspendedWorkloads returns the suspended workloads of all controllers, sorted by cluster,
+// namespace, owner kind and owner name.
+func collectSuspendedWorkloads(controllers []Controller) []controller.SuspendedWorkload {
+	var workloads []controller.SuspendedWorkload
+
+	for _, c := range controllers {
+		workloads = append(workloads, c.SuspendedWorkloadsQuery()...)
+	}
+
+	slices.SortFunc(workloads, func(a, b controller.SuspendedWorkload) int {
+		return cmp.Or(
+			cmp.Compare(a.Cluster, b.Cluster),
+			cmp.Compare(a.Namespace, b.Namespace),
+			cmp.Compare(a.Owner.Kind, b.Owner.Kind),
+			cmp.Compare(a.Owner.Name, b.Owner.Name),
+		)
+	})
+
+	return workloads
+}
@@ -0,0 +1,196 @@
+package httpserver
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"slices"
+	"sync"
+	"time"
+)
+
+// TLS serves the HTTP servers over TLS. The certificate, key and client CA files are checked for
+// changes at most every certReloadInterval and reloaded when they changed, e.g. when cert-manager
+// renews a mounted Secret, so that renewals need no restart.
+type TLS struct {
+	logger       *slog.Logger
+	certFile     string
+	keyFile      string
+	clientCAFile string
+
+	mu sync.Mutex
+	// config is the configuration of the last loaded files.
+	config *tls.Config
+	// modTimes are the modification times of the loaded files.
+	modTimes []time.Time
+	// checkedAt is the last time the files were checked for changes.
+	checkedAt time.Time
+}
+
+// NewTLS loads the certificate and key, and the client CA when set: clients then must present a
+// certificate it signed (mTLS), except on the probe endpoints, see requireClientCert.
+func NewTLS(logger *slog.Logger, certFile, keyFile, clientCAFile string) (*TLS, error) {
+	t := &TLS{
+		logger:       logger,
+		certFile:     certFile,
+		keyFile:      keyFile,
+		clientCAFile: clientCAFile,
+	}
+
+	if err := t.load(time.Now()); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// serverConfig returns the configuration of an http.Server; each handshake uses the current files.
+func (t *TLS) serverConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		GetConfigForClient: t.configForClient,
+	}
+}
+
+func (t *TLS) configForClient(*tls.ClientHelloInfo) (*tls.Config, error) {
+	now := time.Now()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if now.Sub(t.checkedAt) < certReloadInterval {
+		return t.config, nil
+	}
+
+	t.checkedAt = now
+
+	modTimes, err := t.fileModTimes()
+	if err != nil {
+		t.logger.Warn("failed to check tls files, keeping loaded certificate", "reason", err)
+
+		return t.config, nil
+	}
+
+	if slices.EqualFunc(modTimes, t.modTimes, time.Time.Equal) {
+		return t.config, nil
+	}
+
+	if err := t.load(now); err != nil {
+		t.logger.Warn("failed to reload tls files, keeping loaded certificate", "reason", err)
+
+		return t.config, nil
+	}
+
+	t.logger.Info("tls certificate reloaded", "certFile", t.certFile)
+
+	return t.config, nil
+}
+
+// load reads the files into config. The caller holds mu, unless t is not shared yet.
+func (t *TLS) load(now time.Time) error {
+	// Modification times are read first, so that a change during the load is picked up next time.
+	modTimes, err := t.fileModTimes()
+	if err != nil {
+		return err
+	}
+
+	cert, err := tls.LoadX509KeyPair(t.certFile, t.keyFile)
+	if err != nil {
+		return fmt.Errorf("load tls key pair: %w", err)
+	}
+
+	config := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+		NextProtos:   []string{"h2", "http/1.1"},
+	}
+
+	if t.clientCAFile != "" {
+		pem, err := os.ReadFile(t.clientCAFile)
+		if err != nil {
+			return fmt.Errorf("read tls client ca: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("%w: %s", errNoClientCA, t.clientCAFile)
+		}
+
+		config.ClientCAs = pool
+		// The kubelet presents no certificate, so probes can only succeed when the handshake does
+		// without one; requireClientCert rejects the other requests.
+		config.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+
+	t.config = config
+	t.modTimes = modTimes
+	t.checkedAt = now
+
+	return nil
+}
+
+func (t *TLS) fileModTimes() ([]time.Time, error) {
+	files := []string{t.certFile, t.keyFile}
+	if t.clientCAFile != "" {
+		files = append(files, t.clientCAFile)
+	}
+
+	modTimes := make([]time.Time, 0, len(files))
+
+	for _, file := range files {
+		// Stat follows the symlinks of mounted Secrets, which are swapped on update.
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, fmt.Errorf("stat tls file: %w", err)
+		}
+
+		modTimes = append(modTimes, info.ModTime())
+	}
+
+	return modTimes, nil
+}
+
+// clientCertRequired reports whether clients must present a certificate signed by the client CA.
+func (t *TLS) clientCertRequired() bool {
+	return t != nil && t.clientCAFile != ""
+}
+
+// requireClientCert answers 401 to requests without a verified client certificate when a client CA is
+// set. The handshake accepts clients without a certificate, so routes outside this middleware, the
+// probes, stay open to the kubelet.
+func (t *TLS) requireClientCert(logger *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if !t.clientCertRequired() {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 {
+				logger.WarnContext(r.Context(), "request without client certificate",
+					"method", r.Method,
+					"path", r.URL.Path,
+				)
+				http.Error(w, "client certificate required", http.StatusUnauthorized)
+
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// serve serves srv on listener, over TLS when tlsFiles is set.
+func serve(srv *http.Server, listener net.Listener, tlsFiles *TLS) error {
+	if tlsFiles == nil {
+		return srv.Serve(listener)
+	}
+
+	srv.TLSConfig = tlsFiles.serverConfig()
+
+	return srv.ServeTLS(listener, "", "")
+}
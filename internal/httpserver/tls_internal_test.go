@@ -0,0 +1,172 @@
+package httpserver
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"log/slog"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// writeSelfSigned writes a self-signed certificate for 127.0.0.1, usable by servers, clients and as
+// their CA, and its key to certFile and keyFile. It returns the DER certificate.
+func writeSelfSigned(t *testing.T, certFile, keyFile string, serial int64) []byte {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(serial),
+		Subject:               pkix.Name{CommonName: "preoomkiller-controller"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IPAddresses:           []net.IP{net.IPv4(127, 0, 0, 1)},
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+
+	return der
+}
+
+func TestTLS_Reload(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+
+	first := writeSelfSigned(t, certFile, keyFile, 1)
+
+	tlsFiles, err := NewTLS(slog.Default(), certFile, keyFile, "")
+	require.NoError(t, err)
+
+	config, err := tlsFiles.configForClient(nil)
+	require.NoError(t, err)
+	require.True(t, bytes.Equal(first, config.Certificates[0].Certificate[0]))
+
+	renewed := writeSelfSigned(t, certFile, keyFile, 2)
+	future := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(certFile, future, future))
+
+	// Within the reload interval, the loaded certificate is kept.
+	config, err = tlsFiles.configForClient(nil)
+	require.NoError(t, err)
+	require.True(t, bytes.Equal(first, config.Certificates[0].Certificate[0]))
+
+	tlsFiles.mu.Lock()
+	tlsFiles.checkedAt = time.Time{}
+	tlsFiles.mu.Unlock()
+
+	config, err = tlsFiles.configForClient(nil)
+	require.NoError(t, err)
+	require.True(t, bytes.Equal(renewed, config.Certificates[0].Certificate[0]))
+
+	// A broken renewal keeps the last good certificate.
+	require.NoError(t, os.WriteFile(keyFile, []byte("broken"), 0o600))
+	require.NoError(t, os.Chtimes(keyFile, future.Add(time.Minute), future.Add(time.Minute)))
+
+	tlsFiles.mu.Lock()
+	tlsFiles.checkedAt = time.Time{}
+	tlsFiles.mu.Unlock()
+
+	config, err = tlsFiles.configForClient(nil)
+	require.NoError(t, err)
+	require.True(t, bytes.Equal(renewed, config.Certificates[0].Certificate[0]))
+}
+
+func TestTLS_ClientCA(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+
+	der := writeSelfSigned(t, certFile, keyFile, 1)
+
+	tlsFiles, err := NewTLS(slog.Default(), certFile, keyFile, certFile)
+	require.NoError(t, err)
+
+	ok := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux := http.NewServeMux()
+	mux.Handle("/-/readyz", ok)
+	mux.Handle("/", tlsFiles.requireClientCert(slog.Default())(ok))
+
+	srv := httptest.NewUnstartedServer(mux)
+	srv.TLS = tlsFiles.serverConfig()
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+
+	ca, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca)
+
+	clientCert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	require.NoError(t, err)
+
+	get := func(path string, certificates []tls.Certificate) int {
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+			MinVersion:   tls.VersionTLS12,
+			RootCAs:      roots,
+			Certificates: certificates,
+		}}}
+		defer client.CloseIdleConnections()
+
+		req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, srv.URL+path, http.NoBody)
+		require.NoError(t, err)
+
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+
+		return resp.StatusCode
+	}
+
+	require.Equal(t, http.StatusUnauthorized, get("/-/status", nil), "clients without a certificate are rejected")
+	require.Equal(t, http.StatusOK, get("/-/readyz", nil), "probes need no certificate")
+	require.Equal(t, http.StatusOK, get("/-/status", []tls.Certificate{clientCert}))
+}
+
+func TestNewTLS_InvalidClientCA(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+	caFile := filepath.Join(dir, "ca.crt")
+
+	writeSelfSigned(t, certFile, keyFile, 1)
+	require.NoError(t, os.WriteFile(caFile, []byte("not a certificate"), 0o600))
+
+	_, err := NewTLS(slog.Default(), certFile, keyFile, caFile)
+	require.ErrorIs(t, err, errNoClientCA)
+}
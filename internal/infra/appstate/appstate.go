@@ -47,6 +47,22 @@ type AppState struct {
 	terminationFilePath string
 	pinger              pingerServer
 	shutdowners         []shutdown.Shutdowner
+	// drainDelay is the time between entering Terminating and shutting the components down.
+	drainDelay time.Duration
+	// draining is set during the drain delay, in which the application stays healthy.
+	draining bool
+}
+
+// Option configures optional AppState behavior.
+type Option func(*AppState)
+
+// WithDrainDelay delays the shutdown of the components by delay after termination is requested. In
+// the meantime /-/readyz fails while the servers keep serving, so that load balancers and the kubelet
+// stop routing to the pod before its connections are cut. A second signal skips the rest of the delay.
+func WithDrainDelay(delay time.Duration) Option {
+	return func(s *AppState) {
+		s.drainDelay = delay
+	}
 }
 
 // New creates a new AppState with the given start time
@@ -56,8 +72,9 @@ func New(
 	terminationFilePath string,
 	quit <-chan os.Signal,
 	pingSvc pingerServer,
+	opts ...Option,
 ) *AppState {
-	return &AppState{
+	s := &AppState{
 		logger:              logger,
 		startedAt:           appStart,
 		state:               StateInit,
@@ -66,12 +83,28 @@ func New(
 		pinger:              pingSvc,
 		shutdowners:         make([]shutdown.Shutdowner, 0, defaultShutdownersCount),
 	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
 }
 
 func (s *AppState) RegisterPinger(ping pinger.Pinger) error {
 	return s.pinger.Register(ping)
 }
 
+// DeregisterPinger removes the named pinger from the readiness and health checks.
+func (s *AppState) DeregisterPinger(name string) error {
+	return s.pinger.Deregister(name)
+}
+
+// ReplacePinger registers the pinger in place of the registered pinger of the same name, if any.
+func (s *AppState) ReplacePinger(ping pinger.Pinger) error {
+	return s.pinger.Replace(ping)
+}
+
 func (s *AppState) RegisterShutdowner(shutdowner shutdown.Shutdowner) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -196,12 +229,13 @@ func (s *AppState) GetUptime() time.Duration {
 	return time.Since(s.startedAt)
 }
 
-// IsHealthy returns true if the application is in a healthy state (running)
+// IsHealthy returns true if the application is in a healthy state (running, or draining before
+// shutdown)
 func (s *AppState) IsHealthy() bool {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	return s.state == StateRunning
+	return s.state == StateRunning || (s.state == StateTerminating && s.draining)
 }
 
 // IsReady returns true if the application is ready to serve requests (running and readyAt is set)
@@ -217,12 +251,42 @@ func (s *AppState) Quit() <-chan os.Signal {
 	return s.quit
 }
 
+// drain waits for the drain delay, or a second termination signal, before the components shut down.
+func (s *AppState) drain(ctx context.Context) {
+	if s.drainDelay <= 0 {
+		return
+	}
+
+	s.logger.InfoContext(ctx, "draining before shutdown", "delay", s.drainDelay)
+
+	s.setDraining(true)
+	defer s.setDraining(false)
+
+	timer := time.NewTimer(s.drainDelay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+	case <-s.quit:
+		s.logger.InfoContext(ctx, "received second termination signal, skipping drain")
+	}
+}
+
+func (s *AppState) setDraining(draining bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.draining = draining
+}
+
 // Shutdown transitions the application to the terminated state
 func (s *AppState) Shutdown(ctx context.Context) error {
 	if err := s.SetTerminating(ctx); err != nil {
 		return fmt.Errorf("set terminating application state: %w", err)
 	}
 
+	s.drain(ctx)
+
 	// Make a copy of shutdowners slice while holding the lock to avoid data race
 	s.mu.RLock()
 	shutdownersCopy := make([]shutdown.Shutdowner, len(s.shutdowners))
@@ -98,6 +98,45 @@ func TestAppState_QueryMethods(t *testing.T) {
 	require.True(t, s.IsReady())
 }
 
+func TestAppState_ShutdownDrain(t *testing.T) {
+	ctx := t.Context()
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	quit := make(chan os.Signal, 1)
+
+	pingerService := pinger.New(logger, 1*time.Second)
+	s := appstate.New(logger, time.Now(), "/mnt/signal/terminating", quit, pingerService,
+		appstate.WithDrainDelay(time.Hour),
+	)
+
+	require.NoError(t, s.SetStarting(ctx))
+	require.NoError(t, s.SetRunning(ctx))
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- s.Shutdown(ctx)
+	}()
+
+	require.Eventually(t, func() bool {
+		return s.GetState() == appstate.StateTerminating
+	}, time.Second, 10*time.Millisecond)
+	require.False(t, s.IsReady())
+	require.True(t, s.IsHealthy())
+
+	// A second signal skips the rest of the delay.
+	quit <- syscall.SIGTERM
+
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("shutdown did not end after the second signal")
+	}
+
+	require.Equal(t, appstate.StateTerminated, s.GetState())
+	require.False(t, s.IsHealthy())
+}
+
 func TestAppState_GetUptime(t *testing.T) {
 	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
 	quit := make(chan os.Signal, 1)
@@ -9,7 +9,8 @@ import (
 	"github.com/go-chi/chi/v5/middleware"
 )
 
-type statusResponse struct {
+// StatusResponse is the body of the /-/status endpoint.
+type StatusResponse struct {
 	State     string    `json:"state"`
 	Uptime    string    `json:"uptime"`
 	StartTime time.Time `json:"startTime"`
@@ -70,16 +71,7 @@ func HandleStatus(
 		requestID := middleware.GetReqID(ctx)
 		logger = logger.With("traceID", requestID)
 
-		state := appState.GetState()
-		uptime := appState.GetUptime()
-		startTime := appState.GetStartTime()
-
-		response := statusResponse{
-			State:     string(state),
-			Uptime:    uptime.String(),
-			StartTime: startTime,
-			UptimeSec: uptime.Seconds(),
-		}
+		response := NewStatusResponse(appState)
 
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
@@ -93,8 +85,21 @@ func HandleStatus(
 		}
 
 		logger.DebugContext(ctx, "status response sent",
-			"state", string(state),
-			"uptime", uptime.String(),
+			"state", response.State,
+			"uptime", response.Uptime,
 		)
 	}
 }
+
+// NewStatusResponse returns the current status of the application, e.g. to extend the /-/status
+// endpoint with the state of the controllers.
+func NewStatusResponse(appState statusGetter) StatusResponse {
+	uptime := appState.GetUptime()
+
+	return StatusResponse{
+		State:     string(appState.GetState()),
+		Uptime:    uptime.String(),
+		StartTime: appState.GetStartTime(),
+		UptimeSec: uptime.Seconds(),
+	}
+}
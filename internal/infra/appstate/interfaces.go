@@ -18,6 +18,8 @@ type pingerServer interface {
 	Ready() <-chan struct{}
 	shutdown.Shutdowner
 	Register(pinger pinger.Pinger) error
+	Deregister(name string) error
+	Replace(pinger pinger.Pinger) error
 	pingerStatsGetter
 }
 
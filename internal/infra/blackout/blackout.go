@@ -0,0 +1,229 @@
+package blackout
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// windowSeparator separates windows in a spec, e.g. "Mon-Fri 09:00-18:00;Sat 10:00-12:00".
+	windowSeparator = ";"
+
+	// clockLayout is the layout of window start/end times.
+	clockLayout = "15:04"
+
+	daysInWeek     = 7
+	minutesPerHour = 60
+)
+
+var (
+	// ErrInvalidWindow is returned when a blackout window cannot be parsed.
+	ErrInvalidWindow = errors.New("invalid blackout window")
+
+	_weekdays = map[string]time.Weekday{
+		"sun": time.Sunday,
+		"mon": time.Monday,
+		"tue": time.Tuesday,
+		"wed": time.Wednesday,
+		"thu": time.Thursday,
+		"fri": time.Friday,
+		"sat": time.Saturday,
+	}
+)
+
+// window is a daily time range active on a set of weekdays.
+// A window whose end is not after its start spans midnight (e.g. 22:00-06:00);
+// the weekday set refers to the day the window starts.
+type window struct {
+	days [daysInWeek]bool
+	// start and end are minutes since midnight.
+	start int
+	end   int
+}
+
+// Schedule is a set of blackout windows evaluated in a fixed location.
+type Schedule struct {
+	windows  []window
+	location *time.Location
+}
+
+// Parse parses a blackout spec: windows separated by ';', each "[DAYS ]HH:MM-HH:MM",
+// where DAYS is a weekday ("Sat"), a range ("Mon-Fri") or a list ("Sat,Sun"); no DAYS means every day.
+// tz is an IANA timezone name; empty means UTC.
+func Parse(spec, tz string) (*Schedule, error) {
+	location := time.UTC
+
+	if tz != "" {
+		loc, err := time.LoadLocation(tz)
+		if err != nil {
+			return nil, fmt.Errorf("load location %q: %w", tz, err)
+		}
+
+		location = loc
+	}
+
+	schedule := &Schedule{location: location}
+
+	for raw := range strings.SplitSeq(spec, windowSeparator) {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+
+		w, err := parseWindow(raw)
+		if err != nil {
+			return nil, err
+		}
+
+		schedule.windows = append(schedule.windows, w)
+	}
+
+	return schedule, nil
+}
+
+func parseWindow(raw string) (window, error) {
+	var w window
+
+	fields := strings.Fields(raw)
+
+	var daysPart, clockPart string
+
+	switch len(fields) {
+	case 1:
+		clockPart = fields[0]
+	case 2:
+		daysPart, clockPart = fields[0], fields[1]
+	default:
+		return window{}, fmt.Errorf("%w: %q", ErrInvalidWindow, raw)
+	}
+
+	if err := parseDays(daysPart, &w.days); err != nil {
+		return window{}, fmt.Errorf("%w: %q: %w", ErrInvalidWindow, raw, err)
+	}
+
+	startStr, endStr, ok := strings.Cut(clockPart, "-")
+	if !ok {
+		return window{}, fmt.Errorf("%w: %q: expected HH:MM-HH:MM", ErrInvalidWindow, raw)
+	}
+
+	start, err := parseClock(startStr)
+	if err != nil {
+		return window{}, fmt.Errorf("%w: %q: %w", ErrInvalidWindow, raw, err)
+	}
+
+	end, err := parseClock(endStr)
+	if err != nil {
+		return window{}, fmt.Errorf("%w: %q: %w", ErrInvalidWindow, raw, err)
+	}
+
+	w.start = start
+	w.end = end
+
+	return w, nil
+}
+
+func parseDays(spec string, days *[daysInWeek]bool) error {
+	if spec == "" {
+		for i := range days {
+			days[i] = true
+		}
+
+		return nil
+	}
+
+	for part := range strings.SplitSeq(spec, ",") {
+		fromStr, toStr, isRange := strings.Cut(part, "-")
+
+		from, ok := _weekdays[strings.ToLower(fromStr)]
+		if !ok {
+			return fmt.Errorf("unknown weekday %q", fromStr)
+		}
+
+		if !isRange {
+			days[from] = true
+
+			continue
+		}
+
+		to, ok := _weekdays[strings.ToLower(toStr)]
+		if !ok {
+			return fmt.Errorf("unknown weekday %q", toStr)
+		}
+
+		for d := from; ; d = (d + 1) % daysInWeek {
+			days[d] = true
+
+			if d == to {
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+func parseClock(s string) (int, error) {
+	t, err := time.Parse(clockLayout, s)
+	if err != nil {
+		return 0, fmt.Errorf("parse time of day %q: %w", s, err)
+	}
+
+	return t.Hour()*minutesPerHour + t.Minute(), nil
+}
+
+// Active reports whether t falls inside any blackout window.
+func (s *Schedule) Active(t time.Time) bool {
+	_, ok := s.windowEnd(t)
+
+	return ok
+}
+
+// NextAllowed returns t when it is outside all blackout windows, otherwise the end of the
+// (possibly chained) blackout period containing t.
+func (s *Schedule) NextAllowed(t time.Time) time.Time {
+	// Adjacent windows may chain; each iteration moves past one window occurrence.
+	for range len(s.windows)*daysInWeek + 1 {
+		end, ok := s.windowEnd(t)
+		if !ok {
+			return t
+		}
+
+		t = end
+	}
+
+	return t
+}
+
+// windowEnd returns the end of the window occurrence containing t.
+func (s *Schedule) windowEnd(t time.Time) (time.Time, bool) {
+	local := t.In(s.location)
+
+	for i := range s.windows {
+		w := &s.windows[i]
+
+		// Overnight windows that started yesterday may still be active today.
+		for _, offset := range []int{-1, 0} {
+			day := time.Date(local.Year(), local.Month(), local.Day()+offset, 0, 0, 0, 0, s.location)
+			if !w.days[day.Weekday()] {
+				continue
+			}
+
+			start := time.Date(day.Year(), day.Month(), day.Day(), 0, w.start, 0, 0, s.location)
+
+			endDay := day.Day()
+			if w.end <= w.start {
+				endDay++
+			}
+
+			end := time.Date(day.Year(), day.Month(), endDay, 0, w.end, 0, 0, s.location)
+
+			if !local.Before(start) && local.Before(end) {
+				return end, true
+			}
+		}
+	}
+
+	return time.Time{}, false
+}
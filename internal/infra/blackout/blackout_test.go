@@ -0,0 +1,116 @@
+package blackout_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/skillcoder/preoomkiller-controller/internal/infra/blackout"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		spec    string
+		tz      string
+		wantErr bool
+	}{
+		{name: "empty spec", spec: ""},
+		{name: "every day", spec: "09:00-18:00"},
+		{name: "weekday range", spec: "Mon-Fri 09:00-18:00"},
+		{name: "weekday list and several windows", spec: "Sat,Sun 10:00-12:00; Mon 22:00-02:00"},
+		{name: "with tz", spec: "09:00-18:00", tz: "Europe/Berlin"},
+		{name: "unknown tz", spec: "09:00-18:00", tz: "Mars/Olympus", wantErr: true},
+		{name: "unknown weekday", spec: "Funday 09:00-18:00", wantErr: true},
+		{name: "missing range", spec: "09:00", wantErr: true},
+		{name: "invalid clock", spec: "25:00-26:00", wantErr: true},
+		{name: "too many fields", spec: "Mon Tue 09:00-18:00", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := blackout.Parse(tt.spec, tt.tz)
+			if tt.wantErr {
+				require.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestSchedule_NextAllowed(t *testing.T) {
+	t.Parallel()
+
+	// 2026-02-16 is a Monday.
+	monday := func(hour, minute int) time.Time {
+		return time.Date(2026, 2, 16, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name       string
+		spec       string
+		giveTime   time.Time
+		wantTime   time.Time
+		wantActive bool
+	}{
+		{
+			name:     "outside window",
+			spec:     "Mon-Fri 09:00-18:00",
+			giveTime: monday(8, 0),
+			wantTime: monday(8, 0),
+		},
+		{
+			name:       "inside window",
+			spec:       "Mon-Fri 09:00-18:00",
+			giveTime:   monday(12, 0),
+			wantTime:   monday(18, 0),
+			wantActive: true,
+		},
+		{
+			name:     "window end is exclusive",
+			spec:     "Mon-Fri 09:00-18:00",
+			giveTime: monday(18, 0),
+			wantTime: monday(18, 0),
+		},
+		{
+			name:     "weekday not matched",
+			spec:     "Sat,Sun 09:00-18:00",
+			giveTime: monday(12, 0),
+			wantTime: monday(12, 0),
+		},
+		{
+			name:       "overnight window started the previous day",
+			spec:       "Sun 22:00-06:00",
+			giveTime:   monday(1, 0),
+			wantTime:   monday(6, 0),
+			wantActive: true,
+		},
+		{
+			name:       "adjacent windows chain",
+			spec:       "09:00-12:00;12:00-14:00",
+			giveTime:   monday(10, 0),
+			wantTime:   monday(14, 0),
+			wantActive: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			schedule, err := blackout.Parse(tt.spec, "")
+			require.NoError(t, err)
+
+			require.Equal(t, tt.wantActive, schedule.Active(tt.giveTime))
+			require.True(t, tt.wantTime.Equal(schedule.NextAllowed(tt.giveTime)))
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package cronparser
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -8,34 +9,80 @@ import (
 	cron "github.com/netresearch/go-cron"
 )
 
-var _parser = cron.MustNewParser(
-	cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow,
-)
+// ErrEmptySpec is returned when a schedule contains no cron spec.
+var ErrEmptySpec = errors.New("empty cron spec")
+
+// _standardFields are the fields of a standard 5-field (minute-first) cron spec.
+const _standardFields = cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow
 
 // Parser computes next cron occurrences using go-cron.
-type Parser struct{}
+type Parser struct {
+	parser cron.Parser
+}
+
+// Option extends the accepted cron syntax.
+type Option func(*cron.ParseOption)
+
+// WithSeconds additionally accepts 6-field specs with a leading seconds field; 5-field specs stay valid.
+func WithSeconds() Option {
+	return func(o *cron.ParseOption) {
+		*o |= cron.SecondOptional
+	}
+}
+
+// WithDescriptors additionally accepts descriptors such as @daily, @hourly and @every 12h.
+func WithDescriptors() Option {
+	return func(o *cron.ParseOption) {
+		*o |= cron.Descriptor
+	}
+}
+
+// New creates a new cron parser. Without options it accepts standard 5-field specs only.
+func New(opts ...Option) *Parser {
+	options := _standardFields
 
-// New creates a new cron parser.
-func New() *Parser {
-	return &Parser{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return &Parser{parser: cron.MustNewParser(options)}
 }
 
+// specSeparator separates several cron specs in one schedule, e.g. "0 3 * * 1-5; 0 6 * * 0,6".
+const specSeparator = ";"
+
 // NextAfter returns the next cron occurrence strictly after `after`.
-// If tz is non-empty and the spec has no CRON_TZ=/TZ= prefix, it prepends CRON_TZ=<tz>.
+// spec may hold several specs separated by ';'; the earliest next occurrence across them is returned.
+// If tz is non-empty and a spec has no CRON_TZ=/TZ= prefix, it prepends CRON_TZ=<tz>.
 // Defaults to UTC when no tz is given.
 func (p *Parser) NextAfter(
 	spec,
 	tz string,
 	after time.Time,
 ) (time.Time, error) {
-	fullSpec := buildSpec(spec, tz)
+	var next time.Time
+
+	for part := range strings.SplitSeq(spec, specSeparator) {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		schedule, err := p.parser.Parse(buildSpec(part, tz))
+		if err != nil {
+			return time.Time{}, fmt.Errorf("parse cron spec %q: %w", part, err)
+		}
+
+		if candidate := schedule.Next(after); next.IsZero() || candidate.Before(next) {
+			next = candidate
+		}
+	}
 
-	schedule, err := _parser.Parse(fullSpec)
-	if err != nil {
-		return time.Time{}, fmt.Errorf("parse cron spec %q: %w", spec, err)
+	if next.IsZero() {
+		return time.Time{}, fmt.Errorf("%w: %q", ErrEmptySpec, spec)
 	}
 
-	return schedule.Next(after), nil
+	return next, nil
 }
 
 func buildSpec(spec, tz string) string {
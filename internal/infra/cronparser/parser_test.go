@@ -44,6 +44,36 @@ func TestParser_NextAfter(t *testing.T) {
 		require.Equal(t, 14, next.Hour())
 	})
 
+	t.Run("multiple specs return earliest occurrence", func(t *testing.T) {
+		t.Parallel()
+
+		// 2026-02-14 is a Saturday.
+		after := time.Date(2026, 2, 14, 12, 0, 0, 0, time.UTC)
+		next, err := p.NextAfter("0 3 * * 1-5; 0 6 * * 0,6", "", after)
+		require.NoError(t, err)
+		require.Equal(t, time.Date(2026, 2, 15, 6, 0, 0, 0, time.UTC), next)
+
+		// Weekday spec wins on Sunday evening.
+		after = time.Date(2026, 2, 15, 12, 0, 0, 0, time.UTC)
+		next, err = p.NextAfter("0 3 * * 1-5; 0 6 * * 0,6", "", after)
+		require.NoError(t, err)
+		require.Equal(t, time.Date(2026, 2, 16, 3, 0, 0, 0, time.UTC), next)
+	})
+
+	t.Run("one malformed spec among several returns error", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := p.NextAfter("0 3 * * *; invalid", "", time.Now())
+		require.Error(t, err)
+	})
+
+	t.Run("empty spec returns error", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := p.NextAfter(" ; ", "", time.Now())
+		require.ErrorIs(t, err, cronparser.ErrEmptySpec)
+	})
+
 	t.Run("malformed spec returns error", func(t *testing.T) {
 		t.Parallel()
 
@@ -51,3 +81,68 @@ func TestParser_NextAfter(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+func TestParser_NextAfter_extendedSyntax(t *testing.T) {
+	t.Parallel()
+
+	after := time.Date(2026, 2, 15, 7, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		opts     []cronparser.Option
+		spec     string
+		wantNext time.Time
+		wantErr  bool
+	}{
+		{
+			name:    "seconds field rejected by default",
+			spec:    "30 40 7 * * *",
+			wantErr: true,
+		},
+		{
+			name:     "seconds field",
+			opts:     []cronparser.Option{cronparser.WithSeconds()},
+			spec:     "30 40 7 * * *",
+			wantNext: time.Date(2026, 2, 15, 7, 40, 30, 0, time.UTC),
+		},
+		{
+			name:     "five fields still accepted with seconds",
+			opts:     []cronparser.Option{cronparser.WithSeconds()},
+			spec:     "40 7 * * *",
+			wantNext: time.Date(2026, 2, 15, 7, 40, 0, 0, time.UTC),
+		},
+		{
+			name:    "descriptor rejected by default",
+			spec:    "@daily",
+			wantErr: true,
+		},
+		{
+			name:     "daily descriptor",
+			opts:     []cronparser.Option{cronparser.WithDescriptors()},
+			spec:     "@daily",
+			wantNext: time.Date(2026, 2, 16, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "every descriptor",
+			opts:     []cronparser.Option{cronparser.WithDescriptors()},
+			spec:     "@every 12h",
+			wantNext: after.Add(12 * time.Hour),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			next, err := cronparser.New(tt.opts...).NextAfter(tt.spec, "", after)
+			if tt.wantErr {
+				require.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.True(t, tt.wantNext.Equal(next), "got %s", next)
+		})
+	}
+}
@@ -1,10 +1,18 @@
 package logging
 
 import (
+	"fmt"
+	"io"
 	"log/slog"
 	"os"
 )
 
+// Audit log destinations besides a file path.
+const (
+	AuditStdout = "stdout"
+	AuditStderr = "stderr"
+)
+
 func New(logFormat, logLevel string) *slog.Logger {
 	// Setup logging
 	var level slog.Level
@@ -44,3 +52,27 @@ func New(logFormat, logLevel string) *slog.Logger {
 
 	return logger
 }
+
+// NewAudit returns the JSON logger of the audit log, writing to stdout, stderr or appending to the
+// file at destination. Records carry "log":"audit", to tell them apart on a stream shared with the
+// operational logs.
+func NewAudit(destination string) (*slog.Logger, error) {
+	var w io.Writer
+
+	switch destination {
+	case AuditStdout:
+		w = os.Stdout
+	case AuditStderr:
+		w = os.Stderr
+	default:
+		//nolint:gosec // the path is operator configuration.
+		file, err := os.OpenFile(destination, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+		if err != nil {
+			return nil, fmt.Errorf("open audit log: %w", err)
+		}
+
+		w = file
+	}
+
+	return slog.New(slog.NewJSONHandler(w, nil)).With("log", "audit"), nil
+}
@@ -0,0 +1,11 @@
+package memguard
+
+import "errors"
+
+var (
+	// ErrInvalidWatermark is returned when a watermark is neither a positive quantity nor a percentage
+	ErrInvalidWatermark = errors.New("invalid memory watermark")
+
+	// ErrNoMemoryLimit is returned when a percentage watermark is set but GOMEMLIMIT is not
+	ErrNoMemoryLimit = errors.New("percentage memory watermark needs GOMEMLIMIT")
+)
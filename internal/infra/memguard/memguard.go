@@ -0,0 +1,281 @@
+package memguard
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"os"
+	"runtime/debug"
+	"runtime/metrics"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	inframetrics "github.com/skillcoder/preoomkiller-controller/internal/infra/metrics"
+	"github.com/skillcoder/preoomkiller-controller/internal/infra/shutdown"
+)
+
+// defaultInterval is the interval between two checks of the resident memory.
+const defaultInterval = 10 * time.Second
+
+// CacheDropper drops caches that are rebuilt on demand, to relieve memory pressure.
+type CacheDropper interface {
+	DropCachesCommand(ctx context.Context)
+}
+
+// Guard watches the resident memory of the controller process, pre-OOM killer style: when it rises
+// above the watermark, the guard logs it, drops the per-pod caches and, optionally, shuts the
+// controller down gracefully before the kernel OOM kills it.
+type Guard struct {
+	logger    *slog.Logger
+	watermark uint64
+	interval  time.Duration
+	exit      bool
+	droppers  []CacheDropper
+	// readUsage returns the resident memory of the process.
+	readUsage func() (uint64, error)
+	// terminate shuts the process down gracefully.
+	terminate func() error
+	// exceeded is whether the last check was above the watermark; only used by the run goroutine.
+	exceeded   bool
+	stopCh     chan struct{}
+	doneCh     chan struct{}
+	inShutdown atomic.Bool
+}
+
+// Option configures optional Guard behavior.
+type Option func(*Guard)
+
+// WithExit shuts the controller down gracefully, with SIGTERM, when its resident memory is still
+// above the watermark after dropping the caches; Kubernetes then restarts it afresh.
+func WithExit() Option {
+	return func(g *Guard) {
+		g.exit = true
+	}
+}
+
+// WithCacheDroppers drops the caches of droppers when the resident memory rises above the watermark.
+func WithCacheDroppers(droppers ...CacheDropper) Option {
+	return func(g *Guard) {
+		g.droppers = append(g.droppers, droppers...)
+	}
+}
+
+// New creates a memory guard acting when the resident memory of the process rises above watermark
+// bytes.
+func New(logger *slog.Logger, watermark uint64, opts ...Option) *Guard {
+	g := &Guard{
+		logger:    logger,
+		watermark: watermark,
+		interval:  defaultInterval,
+		readUsage: residentMemory,
+		terminate: func() error { return syscall.Kill(os.Getpid(), syscall.SIGTERM) },
+		stopCh:    make(chan struct{}),
+		doneCh:    make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(g)
+	}
+
+	return g
+}
+
+var _ shutdown.Shutdowner = (*Guard)(nil)
+
+// Name returns the name of the memory guard component
+func (g *Guard) Name() string {
+	return "memory-guard"
+}
+
+// Start starts the memory guard in a goroutine
+func (g *Guard) Start(ctx context.Context) error {
+	if g.inShutdown.Load() {
+		g.logger.InfoContext(ctx, "memory guard is shutting down, skipping start")
+
+		return nil
+	}
+
+	g.logger.InfoContext(ctx, "memory guard started", "watermarkBytes", g.watermark, "exit", g.exit)
+
+	go g.run(ctx)
+
+	return nil
+}
+
+// Shutdown stops the memory guard
+func (g *Guard) Shutdown(ctx context.Context) error {
+	if !g.inShutdown.CompareAndSwap(false, true) {
+		g.logger.ErrorContext(ctx, "memory guard is already shutting down, skipping shutdown")
+
+		return nil
+	}
+
+	close(g.stopCh)
+
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("shutdown context done before memory guard loop exited: %w", ctx.Err())
+	case <-g.doneCh:
+		g.logger.InfoContext(ctx, "memory guard loop exited")
+	}
+
+	return nil
+}
+
+func (g *Guard) run(ctx context.Context) {
+	defer close(g.doneCh)
+
+	ticker := time.NewTicker(g.interval)
+	defer ticker.Stop()
+
+	g.check(ctx)
+
+	for {
+		select {
+		case <-ticker.C:
+			g.check(ctx)
+		case <-g.stopCh:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// check compares the resident memory with the watermark and acts when it rose above it.
+func (g *Guard) check(ctx context.Context) {
+	usage, err := g.readUsage()
+	if err != nil {
+		g.logger.WarnContext(ctx, "read controller memory usage failed", "reason", err)
+
+		return
+	}
+
+	inframetrics.SetSelfMemory(float64(usage), float64(g.watermark))
+
+	if usage < g.watermark {
+		if g.exceeded {
+			g.logger.InfoContext(ctx, "controller memory back below watermark",
+				"usageBytes", usage,
+				"watermarkBytes", g.watermark,
+			)
+		}
+
+		g.exceeded = false
+
+		return
+	}
+
+	if g.exceeded {
+		return
+	}
+
+	g.exceeded = true
+	inframetrics.RecordSelfMemoryWatermarkExceeded()
+
+	g.logger.WarnContext(ctx, "controller memory above watermark, dropping caches",
+		"usageBytes", usage,
+		"watermarkBytes", g.watermark,
+	)
+
+	for _, d := range g.droppers {
+		d.DropCachesCommand(ctx)
+	}
+
+	debug.FreeOSMemory()
+
+	g.exitIfStillAbove(ctx)
+}
+
+// exitIfStillAbove shuts the controller down, with WithExit, when dropping the caches did not bring
+// the resident memory below the watermark.
+func (g *Guard) exitIfStillAbove(ctx context.Context) {
+	if !g.exit {
+		return
+	}
+
+	usage, err := g.readUsage()
+	if err != nil {
+		g.logger.WarnContext(ctx, "read controller memory usage failed", "reason", err)
+
+		return
+	}
+
+	if usage < g.watermark {
+		g.logger.InfoContext(ctx, "controller memory below watermark after dropping caches", "usageBytes", usage)
+
+		return
+	}
+
+	g.logger.ErrorContext(ctx, "controller memory still above watermark after dropping caches, shutting down",
+		"usageBytes", usage,
+		"watermarkBytes", g.watermark,
+	)
+
+	if err := g.terminate(); err != nil {
+		g.logger.ErrorContext(ctx, "failed to send SIGTERM", "error", err)
+	}
+}
+
+// ParseWatermark parses a watermark: a quantity (e.g. 400Mi) or a percentage of GOMEMLIMIT (e.g. 90%).
+func ParseWatermark(value string) (uint64, error) {
+	if percent, ok := strings.CutSuffix(value, "%"); ok {
+		p, err := strconv.ParseFloat(percent, 64)
+		if err != nil || p <= 0 || p > 100 {
+			return 0, fmt.Errorf("%w: %q: percentage must be in (0, 100]", ErrInvalidWatermark, value)
+		}
+
+		limit := debug.SetMemoryLimit(-1)
+		if limit == math.MaxInt64 {
+			return 0, fmt.Errorf("%w: %q", ErrNoMemoryLimit, value)
+		}
+
+		return uint64(float64(limit) * p / 100), nil
+	}
+
+	q, err := resource.ParseQuantity(value)
+	if err != nil || q.Sign() <= 0 {
+		return 0, fmt.Errorf("%w: %q: must be a positive quantity or a percentage", ErrInvalidWatermark, value)
+	}
+
+	return uint64(q.Value()), nil
+}
+
+// residentMemory returns the resident set size of the process from /proc/self/statm, or the memory
+// the Go runtime holds from the OS where /proc is not available.
+func residentMemory() (uint64, error) {
+	statm, err := os.ReadFile("/proc/self/statm")
+	if err != nil {
+		return runtimeMemory(), nil
+	}
+
+	fields := bytes.Fields(statm)
+	if len(fields) < 2 {
+		return 0, fmt.Errorf("parse /proc/self/statm: %q", statm)
+	}
+
+	pages, err := strconv.ParseUint(string(fields[1]), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("parse /proc/self/statm: %w", err)
+	}
+
+	return pages * uint64(os.Getpagesize()), nil
+}
+
+// runtimeMemory returns the memory mapped by the Go runtime minus the heap released to the OS.
+func runtimeMemory() uint64 {
+	samples := []metrics.Sample{
+		{Name: "/memory/classes/total:bytes"},
+		{Name: "/memory/classes/heap/released:bytes"},
+	}
+	metrics.Read(samples)
+
+	return samples[0].Value.Uint64() - samples[1].Value.Uint64()
+}
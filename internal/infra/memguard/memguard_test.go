@@ -0,0 +1,93 @@
+package memguard
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDropper counts the dropped caches.
+type fakeDropper struct {
+	dropped int
+}
+
+func (d *fakeDropper) DropCachesCommand(context.Context) {
+	d.dropped++
+}
+
+// newTestGuard returns a guard reading its usage from usages, one per read, and counting terminations.
+func newTestGuard(usages []uint64, terminated *int, opts ...Option) *Guard {
+	g := New(slog.Default(), 100, opts...)
+	g.readUsage = func() (uint64, error) {
+		usage := usages[0]
+		usages = usages[1:]
+
+		return usage, nil
+	}
+	g.terminate = func() error {
+		*terminated++
+
+		return nil
+	}
+
+	return g
+}
+
+func TestGuard_check(t *testing.T) {
+	t.Parallel()
+
+	t.Run("drops caches once per crossing", func(t *testing.T) {
+		t.Parallel()
+
+		dropper := &fakeDropper{}
+		terminated := 0
+		g := newTestGuard([]uint64{50, 120, 130, 90, 110}, &terminated, WithCacheDroppers(dropper))
+
+		g.check(t.Context())
+		require.Zero(t, dropper.dropped, "below the watermark")
+
+		g.check(t.Context())
+		g.check(t.Context())
+		require.Equal(t, 1, dropper.dropped, "caches are dropped when crossing the watermark only")
+
+		g.check(t.Context())
+		g.check(t.Context())
+		require.Equal(t, 2, dropper.dropped, "crossing again drops them again")
+		require.Zero(t, terminated, "the guard only exits with WithExit")
+	})
+
+	t.Run("exits when still above after dropping caches", func(t *testing.T) {
+		t.Parallel()
+
+		terminated := 0
+		g := newTestGuard([]uint64{120, 110}, &terminated, WithExit())
+
+		g.check(t.Context())
+		require.Equal(t, 1, terminated)
+	})
+
+	t.Run("keeps running when dropping caches was enough", func(t *testing.T) {
+		t.Parallel()
+
+		terminated := 0
+		g := newTestGuard([]uint64{120, 80}, &terminated, WithExit())
+
+		g.check(t.Context())
+		require.Zero(t, terminated)
+	})
+}
+
+func TestParseWatermark(t *testing.T) {
+	t.Parallel()
+
+	watermark, err := ParseWatermark("400Mi")
+	require.NoError(t, err)
+	require.Equal(t, uint64(400<<20), watermark)
+
+	for _, value := range []string{"lots", "0", "-1Gi", "0%", "101%", "x%"} {
+		_, err := ParseWatermark(value)
+		require.ErrorIs(t, err, ErrInvalidWatermark, value)
+	}
+}
@@ -0,0 +1,72 @@
+package metrics
+
+import (
+	"runtime"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Info metrics describe the process and carry no "cluster" label; they are always 1.
+
+var buildInfo = promauto.With(prometheus.DefaultRegisterer).NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "preoomkiller_build_info",
+		Help: "Build information of the running controller; always 1.",
+	},
+	[]string{"version", "commit", "go_version"},
+)
+
+var configInfo = promauto.With(prometheus.DefaultRegisterer).NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "preoomkiller_config_info",
+		Help: "Effective controller settings (without URLs or other secrets); always 1.",
+	},
+	[]string{
+		"interval",
+		"metrics_source",
+		"memory_metric",
+		"container_aggregation",
+		"vpa_mode",
+		"min_pod_age_before_eviction",
+		"restart_schedule_jitter_max",
+		"run_once",
+		"clusters",
+	},
+)
+
+// ConfigInfo holds the settings exported by preoomkiller_config_info.
+type ConfigInfo struct {
+	Interval                 string
+	MetricsSource            string
+	MemoryMetric             string
+	ContainerAggregation     string
+	VPAMode                  string
+	MinPodAgeBeforeEviction  string
+	RestartScheduleJitterMax string
+	RunOnce                  bool
+	Clusters                 int
+}
+
+// RecordBuildInfo exports the version and commit of the running binary.
+func RecordBuildInfo(version, commit string) {
+	buildInfo.Reset()
+	buildInfo.WithLabelValues(version, commit, runtime.Version()).Set(1)
+}
+
+// RecordConfigInfo exports the effective settings, replacing previously recorded ones.
+func RecordConfigInfo(info ConfigInfo) {
+	configInfo.Reset()
+	configInfo.WithLabelValues(
+		info.Interval,
+		info.MetricsSource,
+		info.MemoryMetric,
+		info.ContainerAggregation,
+		info.VPAMode,
+		info.MinPodAgeBeforeEviction,
+		info.RestartScheduleJitterMax,
+		strconv.FormatBool(info.RunOnce),
+		strconv.Itoa(info.Clusters),
+	).Set(1)
+}
@@ -1,21 +1,302 @@
 package metrics
 
 import (
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 )
 
+// Every metric of a reconciled cluster carries a "cluster" label: the kubeconfig context in
+// multi-cluster mode, empty otherwise.
+
+var evictionsTotal = promauto.With(prometheus.DefaultRegisterer).NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "preoomkiller_evictions_total",
+		Help: "Total number of pods evicted, by trigger (threshold, schedule or promql).",
+	},
+	[]string{"cluster", "namespace", "reason"},
+)
+
+var evictionErrorsTotal = promauto.With(prometheus.DefaultRegisterer).NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "preoomkiller_eviction_errors_total",
+		Help: "Total number of failed eviction attempts, by error type.",
+	},
+	[]string{"cluster", "namespace", "error_type"},
+)
+
+var podMemoryUsageBytes = promauto.With(prometheus.DefaultRegisterer).NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "preoomkiller_pod_memory_usage_bytes",
+		Help: "Memory usage of an enrolled pod as compared against its threshold.",
+	},
+	[]string{"cluster", "namespace", "pod"},
+)
+
+var podMemoryThresholdBytes = promauto.With(prometheus.DefaultRegisterer).NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "preoomkiller_pod_memory_threshold_bytes",
+		Help: "Effective memory threshold of an enrolled pod above which it is evicted.",
+	},
+	[]string{"cluster", "namespace", "pod"},
+)
+
+var scheduledEvictionsPending = promauto.With(prometheus.DefaultRegisterer).NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "preoomkiller_scheduled_evictions_pending",
+		Help: "Number of scheduled evictions waiting for their fire time.",
+	},
+	[]string{"cluster"},
+)
+
+var nextScheduledEvictionTimestampSeconds = promauto.With(prometheus.DefaultRegisterer).NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "preoomkiller_next_scheduled_eviction_timestamp_seconds",
+		Help: "Unix time of the earliest pending scheduled eviction; absent when none is pending.",
+	},
+	[]string{"cluster"},
+)
+
+var metricsAPIAvailable = promauto.With(prometheus.DefaultRegisterer).NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "preoomkiller_metrics_api_available",
+		Help: "Whether the last check of the metrics.k8s.io API succeeded (1) or failed (0); " +
+			"without it, memory thresholds are not enforced.",
+	},
+	[]string{"cluster"},
+)
+
+var evictionSkippedTotal = promauto.With(prometheus.DefaultRegisterer).NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "preoomkiller_eviction_skipped_total",
+		Help: "Total number of evictions skipped or refused, by reason, e.g. pod_too_young, blackout or pdb_blocked.",
+	},
+	[]string{"cluster", "reason"},
+)
+
 var evictionSkippedPodTooYoungTotal = promauto.With(prometheus.DefaultRegisterer).NewCounterVec(
 	prometheus.CounterOpts{
 		Name: "preoomkiller_eviction_skipped_pod_too_young_total",
 		Help: "Total number of evictions skipped because pod age was below minimum " +
 			"(possible misconfiguration or too-frequent restarts).",
 	},
-	[]string{"namespace", "pod"},
+	[]string{"cluster", "namespace", "pod"},
 )
 
+var evictionSkippedUnhealthyPodTotal = promauto.With(prometheus.DefaultRegisterer).NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "preoomkiller_eviction_skipped_unhealthy_pod_total",
+		Help: "Total number of evictions skipped because the pod was in CrashLoopBackOff or not Ready.",
+	},
+	[]string{"cluster", "namespace", "pod", "reason"},
+)
+
+var evictionSkippedReadyReplicasTotal = promauto.With(prometheus.DefaultRegisterer).NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "preoomkiller_eviction_skipped_insufficient_ready_replicas_total",
+		Help: "Total number of evictions skipped because the owning workload had too few other Ready replicas.",
+	},
+	[]string{"cluster", "namespace", "pod"},
+)
+
+var replacementNotReadyTotal = promauto.With(prometheus.DefaultRegisterer).NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "preoomkiller_replacement_not_ready_total",
+		Help: "Total number of evictions after which no replacement pod became Ready in time; " +
+			"evictions of the workload are suspended.",
+	},
+	[]string{"cluster", "namespace", "owner_kind", "owner"},
+)
+
+var canaryAbortedTotal = promauto.With(prometheus.DefaultRegisterer).NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "preoomkiller_canary_aborted_total",
+		Help: "Total number of scheduled restarts aborted because the canary replacement was unhealthy.",
+	},
+	[]string{"cluster", "namespace", "owner_kind", "owner"},
+)
+
+var missedOOMTotal = promauto.With(prometheus.DefaultRegisterer).NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "preoomkiller_missed_oom_total",
+		Help: "Total number of OOMKilled container terminations observed in enrolled pods " +
+			"(the controller did not act before the OOM killer).",
+	},
+	[]string{"cluster", "namespace", "pod"},
+)
+
+var invalidTimezoneTotal = promauto.With(prometheus.DefaultRegisterer).NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "preoomkiller_invalid_timezone_total",
+		Help: "Total number of restart schedules computed in UTC because the pod's tz annotation is not a valid IANA time zone.",
+	},
+	[]string{"cluster", "namespace", "pod"},
+)
+
+var k8sAPIErrorsTotal = promauto.With(prometheus.DefaultRegisterer).NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "preoomkiller_k8s_api_errors_total",
+		Help: "Total number of failed Kubernetes and metrics API requests, by operation and error type.",
+	},
+	[]string{"cluster", "operation", "error_type"},
+)
+
+var k8sAPIRetriesTotal = promauto.With(prometheus.DefaultRegisterer).NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "preoomkiller_k8s_api_retries_total",
+		Help: "Total number of Kubernetes API requests retried after a transient error.",
+	},
+	[]string{"cluster", "operation"},
+)
+
+var k8sAPIRetriesExhaustedTotal = promauto.With(prometheus.DefaultRegisterer).NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "preoomkiller_k8s_api_retries_exhausted_total",
+		Help: "Total number of Kubernetes API requests that still failed with a transient error after all retries.",
+	},
+	[]string{"cluster", "operation"},
+)
+
+var reconcileOverrunsTotal = promauto.With(prometheus.DefaultRegisterer).NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "preoomkiller_reconcile_overruns_total",
+		Help: "Total number of reconciles cancelled because they exceeded their deadline.",
+	},
+	[]string{"cluster"},
+)
+
+var podRetriesTotal = promauto.With(prometheus.DefaultRegisterer).NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "preoomkiller_pod_retries_total",
+		Help: "Total number of retries of pods whose eviction triggers failed, by result.",
+	},
+	[]string{"cluster", "result"},
+)
+
+// Results of a pod retry.
+const (
+	RetryResultSuccess = "success"
+	RetryResultFailure = "failure"
+)
+
+// RecordEviction increments the counter when a pod is evicted; reason is the eviction trigger.
+func RecordEviction(cluster, namespace, reason string) {
+	evictionsTotal.WithLabelValues(cluster, namespace, reason).Inc()
+}
+
+// RecordEvictionError increments the counter when an eviction attempt fails (error_type: get_pod,
+// list_owner_pods, not_found, too_many_requests or api_error).
+func RecordEvictionError(cluster, namespace, errorType string) {
+	evictionErrorsTotal.WithLabelValues(cluster, namespace, errorType).Inc()
+}
+
+// SetPodMemory sets the memory usage and threshold gauges of a pod.
+func SetPodMemory(cluster, namespace, pod string, usage, threshold float64) {
+	podMemoryUsageBytes.WithLabelValues(cluster, namespace, pod).Set(usage)
+	podMemoryThresholdBytes.WithLabelValues(cluster, namespace, pod).Set(threshold)
+}
+
+// DeletePodMemory removes the memory usage and threshold gauges of a pod.
+func DeletePodMemory(cluster, namespace, pod string) {
+	podMemoryUsageBytes.DeleteLabelValues(cluster, namespace, pod)
+	podMemoryThresholdBytes.DeleteLabelValues(cluster, namespace, pod)
+}
+
+// SetScheduledEvictionsPending sets the number of pending scheduled evictions and the fire time of the
+// earliest one; a zero next removes the timestamp.
+func SetScheduledEvictionsPending(cluster string, pending int, next time.Time) {
+	scheduledEvictionsPending.WithLabelValues(cluster).Set(float64(pending))
+
+	if next.IsZero() {
+		nextScheduledEvictionTimestampSeconds.DeleteLabelValues(cluster)
+
+		return
+	}
+
+	nextScheduledEvictionTimestampSeconds.WithLabelValues(cluster).Set(float64(next.Unix()))
+}
+
+// SetMetricsAPIAvailable records whether the last check of the metrics.k8s.io API succeeded.
+func SetMetricsAPIAvailable(cluster string, available bool) {
+	value := 0.0
+	if available {
+		value = 1
+	}
+
+	metricsAPIAvailable.WithLabelValues(cluster).Set(value)
+}
+
+// RecordEvictionSkipped increments the counter of skipped evictions for the reason.
+func RecordEvictionSkipped(cluster, reason string) {
+	evictionSkippedTotal.WithLabelValues(cluster, reason).Inc()
+}
+
 // RecordEvictionSkippedPodTooYoung increments the counter when an eviction is skipped
 // because the pod was younger than the configured minimum age.
-func RecordEvictionSkippedPodTooYoung(namespace, pod string) {
-	evictionSkippedPodTooYoungTotal.WithLabelValues(namespace, pod).Inc()
+func RecordEvictionSkippedPodTooYoung(cluster, namespace, pod string) {
+	evictionSkippedPodTooYoungTotal.WithLabelValues(cluster, namespace, pod).Inc()
+}
+
+// RecordEvictionSkippedUnhealthyPod increments the counter when an eviction is skipped
+// because the pod is already unhealthy (reason: crash_loop_backoff or not_ready).
+func RecordEvictionSkippedUnhealthyPod(cluster, namespace, pod, reason string) {
+	evictionSkippedUnhealthyPodTotal.WithLabelValues(cluster, namespace, pod, reason).Inc()
+}
+
+// RecordEvictionSkippedReadyReplicas increments the counter when an eviction is skipped
+// because the owning workload did not have enough other Ready replicas.
+func RecordEvictionSkippedReadyReplicas(cluster, namespace, pod string) {
+	evictionSkippedReadyReplicasTotal.WithLabelValues(cluster, namespace, pod).Inc()
+}
+
+// RecordReplacementNotReady increments the counter when no replacement pod of an evicted pod's
+// owner became Ready within the verification timeout.
+func RecordReplacementNotReady(cluster, namespace, ownerKind, owner string) {
+	replacementNotReadyTotal.WithLabelValues(cluster, namespace, ownerKind, owner).Inc()
+}
+
+// RecordCanaryAborted increments the counter when a scheduled restart batch is aborted
+// because the canary's replacement pod was unhealthy after the soak period.
+func RecordCanaryAborted(cluster, namespace, ownerKind, owner string) {
+	canaryAbortedTotal.WithLabelValues(cluster, namespace, ownerKind, owner).Inc()
+}
+
+// RecordMissedOOM increments the counter when an OOMKilled termination is observed in an enrolled pod.
+func RecordMissedOOM(cluster, namespace, pod string) {
+	missedOOMTotal.WithLabelValues(cluster, namespace, pod).Inc()
+}
+
+// RecordInvalidTimezone increments the counter when a pod's tz annotation is not a valid IANA time zone
+// and its restart schedule falls back to UTC.
+func RecordInvalidTimezone(cluster, namespace, pod string) {
+	invalidTimezoneTotal.WithLabelValues(cluster, namespace, pod).Inc()
+}
+
+// RecordK8sAPIError increments the counter when a Kubernetes or metrics API request fails after any
+// retries (error_type: not_found, too_many_requests, timeout or other).
+func RecordK8sAPIError(cluster, operation, errorType string) {
+	k8sAPIErrorsTotal.WithLabelValues(cluster, operation, errorType).Inc()
+}
+
+// RecordK8sAPIRetry increments the counter when a Kubernetes API request is retried after a transient error.
+func RecordK8sAPIRetry(cluster, operation string) {
+	k8sAPIRetriesTotal.WithLabelValues(cluster, operation).Inc()
+}
+
+// RecordReconcileOverrun increments the counter when a reconcile is cancelled at its deadline.
+func RecordReconcileOverrun(cluster string) {
+	reconcileOverrunsTotal.WithLabelValues(cluster).Inc()
+}
+
+// RecordPodRetry increments the counter when the eviction triggers of a failed pod are retried
+// (result: success or failure).
+func RecordPodRetry(cluster, result string) {
+	podRetriesTotal.WithLabelValues(cluster, result).Inc()
+}
+
+// RecordK8sAPIRetriesExhausted increments the counter when a Kubernetes API request still fails with a
+// transient error after all retries.
+func RecordK8sAPIRetriesExhausted(cluster, operation string) {
+	k8sAPIRetriesExhaustedTotal.WithLabelValues(cluster, operation).Inc()
 }
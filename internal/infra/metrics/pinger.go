@@ -0,0 +1,99 @@
+package metrics
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Pinger metrics are labelled by pinger name, which includes the cluster in multi-cluster mode.
+
+var pingerChecksTotal = promauto.With(prometheus.DefaultRegisterer).NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "preoomkiller_pinger_checks_total",
+		Help: "Total number of health checks run by a pinger, by result (success or error).",
+	},
+	[]string{"pinger", "result"},
+)
+
+var pingerLatencySeconds = promauto.With(prometheus.DefaultRegisterer).NewSummaryVec(
+	prometheus.SummaryOpts{
+		Name:       "preoomkiller_pinger_latency_seconds",
+		Help:       "Latency of pinger health checks, by result (success or error).",
+		Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+		MaxAge:     10 * time.Minute,
+	},
+	[]string{"pinger", "result"},
+)
+
+// pingerCheckDurationSeconds is registered by RegisterPingerLatencyHistogram, as its buckets are
+// configurable.
+var pingerCheckDurationSeconds atomic.Pointer[prometheus.HistogramVec]
+
+var pingerUp = promauto.With(prometheus.DefaultRegisterer).NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "preoomkiller_pinger_up",
+		Help: "Whether the last health check of a pinger succeeded (1) or failed (0).",
+	},
+	[]string{"pinger"},
+)
+
+// RegisterPingerLatencyHistogram registers the pinger latency histogram with the upper bounds of its
+// buckets. Unlike the summary, it can be aggregated across replicas, e.g. to alert on the p99 latency
+// of the health checks with histogram_quantile.
+func RegisterPingerLatencyHistogram(buckets []time.Duration) error {
+	bucketSeconds := make([]float64, 0, len(buckets))
+	for _, bucket := range buckets {
+		bucketSeconds = append(bucketSeconds, bucket.Seconds())
+	}
+
+	histogram := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "preoomkiller_pinger_check_duration_seconds",
+			Help:    "Latency histogram of pinger health checks, by result (success or error).",
+			Buckets: bucketSeconds,
+		},
+		[]string{"pinger", "result"},
+	)
+
+	if err := prometheus.DefaultRegisterer.Register(histogram); err != nil {
+		return fmt.Errorf("register pinger latency histogram: %w", err)
+	}
+
+	pingerCheckDurationSeconds.Store(histogram)
+
+	return nil
+}
+
+// RecordPing records the result and latency of a pinger health check.
+func RecordPing(pinger string, latency time.Duration, failed bool) {
+	result, up := "success", 1.0
+	if failed {
+		result, up = "error", 0
+	}
+
+	pingerChecksTotal.WithLabelValues(pinger, result).Inc()
+	pingerLatencySeconds.WithLabelValues(pinger, result).Observe(latency.Seconds())
+
+	if histogram := pingerCheckDurationSeconds.Load(); histogram != nil {
+		histogram.WithLabelValues(pinger, result).Observe(latency.Seconds())
+	}
+
+	pingerUp.WithLabelValues(pinger).Set(up)
+}
+
+// DeletePinger removes the series of a deregistered pinger.
+func DeletePinger(pinger string) {
+	labels := prometheus.Labels{"pinger": pinger}
+
+	pingerChecksTotal.DeletePartialMatch(labels)
+	pingerLatencySeconds.DeletePartialMatch(labels)
+	pingerUp.DeletePartialMatch(labels)
+
+	if histogram := pingerCheckDurationSeconds.Load(); histogram != nil {
+		histogram.DeletePartialMatch(labels)
+	}
+}
@@ -0,0 +1,22 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/push"
+)
+
+// Push pushes all metrics from the default registry to a Prometheus Pushgateway under the given job.
+// Used in run-once mode where there is no long-lived /metrics endpoint to scrape.
+func Push(ctx context.Context, url, job string) error {
+	err := push.New(url, job).
+		Gatherer(prometheus.DefaultGatherer).
+		PushContext(ctx)
+	if err != nil {
+		return fmt.Errorf("push metrics to %s: %w", url, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Self memory metrics describe the controller process itself, watched by the memory guard.
+
+var selfMemoryBytes = promauto.With(prometheus.DefaultRegisterer).NewGauge(
+	prometheus.GaugeOpts{
+		Name: "preoomkiller_self_memory_bytes",
+		Help: "Resident memory of the controller process at the last check of the memory guard.",
+	},
+)
+
+var selfMemoryWatermarkBytes = promauto.With(prometheus.DefaultRegisterer).NewGauge(
+	prometheus.GaugeOpts{
+		Name: "preoomkiller_self_memory_watermark_bytes",
+		Help: "Resident memory of the controller process above which the memory guard acts.",
+	},
+)
+
+var selfMemoryWatermarkExceededTotal = promauto.With(prometheus.DefaultRegisterer).NewCounter(
+	prometheus.CounterOpts{
+		Name: "preoomkiller_self_memory_watermark_exceeded_total",
+		Help: "Total number of times the resident memory of the controller process rose above the watermark.",
+	},
+)
+
+// SetSelfMemory sets the resident memory of the controller process and its watermark.
+func SetSelfMemory(usage, watermark float64) {
+	selfMemoryBytes.Set(usage)
+	selfMemoryWatermarkBytes.Set(watermark)
+}
+
+// RecordSelfMemoryWatermarkExceeded increments the counter when the resident memory of the controller
+// process rises above the watermark.
+func RecordSelfMemoryWatermarkExceeded() {
+	selfMemoryWatermarkExceededTotal.Inc()
+}
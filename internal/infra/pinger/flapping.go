@@ -0,0 +1,71 @@
+package pinger
+
+import "time"
+
+// flapDetection marks a pinger flapping when its result changed between success and failure at
+// least transitions times within window.
+type flapDetection struct {
+	transitions int
+	window      time.Duration
+	// holdReady keeps the readiness of a flapping pinger at what it was before it started flapping.
+	holdReady bool
+}
+
+// WithFlapDetection marks a pinger flapping when its result changed between success and failure at
+// least transitions times within window. With holdReady, a flapping pinger keeps the readiness it had
+// before it started flapping, so that a flaky dependency does not toggle the pod in and out of its
+// Services; its health is unaffected. Zero transitions or window disables detection.
+func WithFlapDetection(transitions int, window time.Duration, holdReady bool) Option {
+	return func(s *Service) {
+		if transitions <= 0 || window <= 0 {
+			return
+		}
+
+		s.flap = flapDetection{transitions: transitions, window: window, holdReady: holdReady}
+	}
+}
+
+// enabled reports whether flap detection is configured.
+func (f flapDetection) enabled() bool {
+	return f.transitions > 0
+}
+
+// recordTransition records a transition when failed differs from the previous result, and drops the
+// transitions older than the window. The caller holds stats.mu.
+func (f flapDetection) recordTransition(stats *Stats, now time.Time, failed bool) {
+	if !f.enabled() {
+		return
+	}
+
+	if !stats.LastRun.IsZero() && failed != (stats.LastError != nil) {
+		stats.transitions = append(stats.transitions, now)
+	}
+
+	stats.transitions = f.recent(stats.transitions, now)
+}
+
+// recordSteadyState records whether the pinger is failing while it is not flapping. The caller holds
+// stats.mu.
+func (f flapDetection) recordSteadyState(stats *Stats, now time.Time) {
+	if !f.isFlapping(stats.transitions, now) {
+		stats.steadyFailed = stats.Failing
+	}
+}
+
+// recent returns the transitions within the window ending at now.
+func (f flapDetection) recent(transitions []time.Time, now time.Time) []time.Time {
+	cutoff := now.Add(-f.window)
+
+	for i, t := range transitions {
+		if t.After(cutoff) {
+			return transitions[i:]
+		}
+	}
+
+	return nil
+}
+
+// isFlapping reports whether the transitions within the window ending at now reach the threshold.
+func (f flapDetection) isFlapping(transitions []time.Time, now time.Time) bool {
+	return f.enabled() && len(f.recent(transitions, now)) >= f.transitions
+}
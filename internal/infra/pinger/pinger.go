@@ -3,18 +3,23 @@ package pinger
 import (
 	"context"
 	"fmt"
+	"hash/fnv"
 	"log/slog"
-	"maps"
 	"sync"
 	"sync/atomic"
 	"time"
 
+	"github.com/skillcoder/preoomkiller-controller/internal/infra/metrics"
 	"github.com/skillcoder/preoomkiller-controller/internal/infra/shutdown"
 )
 
 const (
 	// defaultPingTimeout is the default timeout for ping operations
 	defaultPingTimeout = 1 * time.Second
+
+	// defaultThreshold is the default number of consecutive failures, respectively successes,
+	// marking a pinger failing, respectively recovered
+	defaultThreshold = 1
 )
 
 // Optional interface types for type assertions
@@ -30,6 +35,25 @@ type timeoutPinger interface {
 	PingerTimeout() time.Duration
 }
 
+// intervalPinger runs less (or more) often than the service interval, e.g. to ping an expensive
+// dependency less often; pingers run at the first tick after their interval elapsed.
+type intervalPinger interface {
+	PingerInterval() time.Duration
+}
+
+// thresholdPinger overrides the consecutive failures marking the pinger failing and the
+// consecutive successes marking it recovered, like the thresholds of kubelet probes.
+type thresholdPinger interface {
+	PingerFailureThreshold() int
+	PingerSuccessThreshold() int
+}
+
+// startupGracePinger overrides the startup grace period of the pinger, e.g. for a dependency that
+// takes a while to come up.
+type startupGracePinger interface {
+	PingerStartupGrace() time.Duration
+}
+
 // pingerInfo holds pinger instance and its configuration
 type pingerInfo struct {
 	// FIXME: replace pinger with name and pingFunc.
@@ -37,34 +61,98 @@ type pingerInfo struct {
 	readyCritical  bool
 	healthCritical bool
 	timeout        time.Duration
+	// interval is the pinger's own interval; zero runs it on every tick.
+	interval time.Duration
+	// nextRun is when the pinger is due again, guarded by Service.mu.
+	nextRun time.Time
+	// offset delays the pinger's runs after each tick, spreading the pingers across the interval.
+	offset time.Duration
+	// flap is the flap detection of the service.
+	flap flapDetection
+	// failureThreshold and successThreshold are the consecutive failures marking the pinger failing
+	// and the consecutive successes marking it recovered.
+	failureThreshold int
+	successThreshold int
+	// startupGrace is the period after registration during which failures do not count until the
+	// first success.
+	startupGrace time.Duration
+	// registeredAt is when the pinger was registered.
+	registeredAt time.Time
 }
 
 // Service manages health check pingers and tracks their statistics
 type Service struct {
-	logger     *slog.Logger
-	interval   time.Duration
-	pingers    map[string]*pingerInfo
-	stats      map[string]*Stats
-	mu         sync.RWMutex
-	ready      chan struct{}
-	inShutdown atomic.Bool
-	doneCh     chan struct{}
-	wg         sync.WaitGroup
+	logger   *slog.Logger
+	interval time.Duration
+	jitter   time.Duration
+	flap     flapDetection
+	// failureThreshold and successThreshold are the default thresholds of the pingers.
+	failureThreshold int
+	successThreshold int
+	// startupGrace is the default startup grace period of the pingers.
+	startupGrace time.Duration
+	pingers      map[string]*pingerInfo
+	stats        map[string]*Stats
+	mu           sync.RWMutex
+	ready        chan struct{}
+	inShutdown   atomic.Bool
+	doneCh       chan struct{}
+	wg           sync.WaitGroup
+}
+
+// Option configures optional pinger service behavior.
+type Option func(*Service)
+
+// WithJitter delays each pinger by a stable offset below jitter after every tick, so that pingers do not
+// all fire at once. The offset is derived from the pinger name; the first run at start is not delayed.
+// Jitter is capped at half the interval.
+func WithJitter(jitter time.Duration) Option {
+	return func(s *Service) {
+		s.jitter = min(jitter, s.interval/2)
+	}
+}
+
+// WithThresholds sets the default number of consecutive failures marking a pinger failing, and so
+// not ready or healthy, and of consecutive successes marking it recovered, so that a single transient
+// error does not flip readiness. Pingers may override them; values below 1 mean 1.
+func WithThresholds(failureThreshold, successThreshold int) Option {
+	return func(s *Service) {
+		s.failureThreshold = max(failureThreshold, defaultThreshold)
+		s.successThreshold = max(successThreshold, defaultThreshold)
+	}
+}
+
+// WithStartupGrace sets the default period after registration during which failures of a pinger are
+// recorded but do not make it not ready or unhealthy, until its first success; e.g. so that the
+// controller is not restarted while metrics-server comes up. Pingers may override it.
+func WithStartupGrace(grace time.Duration) Option {
+	return func(s *Service) {
+		s.startupGrace = max(grace, 0)
+	}
 }
 
 // New creates a new pinger service with the specified interval
 func New(
 	logger *slog.Logger,
 	interval time.Duration,
+	opts ...Option,
 ) *Service {
-	return &Service{
-		logger:   logger,
-		interval: interval,
-		pingers:  make(map[string]*pingerInfo),
-		stats:    make(map[string]*Stats),
-		ready:    make(chan struct{}),
-		doneCh:   make(chan struct{}),
+	s := &Service{
+		logger:           logger,
+		interval:         interval,
+		failureThreshold: defaultThreshold,
+		successThreshold: defaultThreshold,
+		pingers:          make(map[string]*pingerInfo),
+		stats:            make(map[string]*Stats),
+		ready:            make(chan struct{}),
+		doneCh:           make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(s)
 	}
+
+	return s
 }
 
 var _ shutdown.Shutdowner = (*Service)(nil)
@@ -98,20 +186,93 @@ func (s *Service) Register(pinger Pinger) error {
 	return nil
 }
 
+// Deregister removes the named pinger from health checking, e.g. when its component is removed. Its
+// statistics and metrics are dropped; the result of a check in flight is discarded.
+func (s *Service) Deregister(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.pingers[name]; !exists {
+		return fmt.Errorf("deregister pinger %s: %w", name, ErrPingerNotFound)
+	}
+
+	delete(s.pingers, name)
+	delete(s.stats, name)
+	metrics.DeletePinger(name)
+
+	s.logger.Info("pinger deregistered", "name", name)
+
+	return nil
+}
+
+// Replace registers the pinger in place of the registered pinger of the same name, if any, e.g. when
+// its component is recreated on reload. Statistics start afresh; the result of a check of the
+// replaced pinger in flight is discarded.
+func (s *Service) Replace(pinger Pinger) error {
+	if pinger == nil {
+		return fmt.Errorf("replace pinger: pinger cannot be nil")
+	}
+
+	name := pinger.Name()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	info := s.createPingerInfo(pinger)
+	s.pingers[name] = info
+	s.stats[name] = NewPingerStats(name)
+
+	s.logPingerRegistration(name, info)
+
+	return nil
+}
+
+// isRegistered reports whether info is the registered pinger of its name, i.e. it was neither
+// deregistered nor replaced.
+func (s *Service) isRegistered(name string, info *pingerInfo) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.pingers[name] == info
+}
+
 // createPingerInfo creates pinger info by detecting optional interface methods
 func (s *Service) createPingerInfo(pinger Pinger) *pingerInfo {
 	readyCritical := s.detectReadyCritical(pinger)
 	healthCritical := s.detectHealthCritical(pinger)
 	timeout := s.detectTimeout(pinger)
+	interval := s.detectInterval(pinger)
+	failureThreshold, successThreshold := s.detectThresholds(pinger)
+	startupGrace := s.detectStartupGrace(pinger)
 
 	return &pingerInfo{
-		pinger:         pinger,
-		readyCritical:  readyCritical,
-		healthCritical: healthCritical,
-		timeout:        timeout,
+		pinger:           pinger,
+		readyCritical:    readyCritical,
+		healthCritical:   healthCritical,
+		timeout:          timeout,
+		interval:         interval,
+		offset:           s.pingerOffset(pinger.Name()),
+		flap:             s.flap,
+		failureThreshold: failureThreshold,
+		successThreshold: successThreshold,
+		startupGrace:     startupGrace,
+		registeredAt:     time.Now(),
 	}
 }
 
+// pingerOffset returns the stable jitter offset of the named pinger.
+func (s *Service) pingerOffset(name string) time.Duration {
+	if s.jitter <= 0 {
+		return 0
+	}
+
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(name))
+
+	//nolint:gosec // jitter is positive and the remainder is below it.
+	return time.Duration(h.Sum64() % uint64(s.jitter))
+}
+
 // detectReadyCritical detects if pinger implements readyCriticalPinger
 func (s *Service) detectReadyCritical(pinger Pinger) bool {
 	readyCritical := true
@@ -148,6 +309,43 @@ func (s *Service) detectTimeout(pinger Pinger) time.Duration {
 	return timeout
 }
 
+// detectInterval detects if pinger implements intervalPinger; zero means the service interval
+func (s *Service) detectInterval(pinger Pinger) time.Duration {
+	if ip, ok := pinger.(intervalPinger); ok && ip.PingerInterval() > 0 {
+		return ip.PingerInterval()
+	}
+
+	return 0
+}
+
+// detectThresholds detects if pinger implements thresholdPinger; values below 1 mean the service
+// thresholds
+func (s *Service) detectThresholds(pinger Pinger) (failureThreshold, successThreshold int) {
+	failureThreshold, successThreshold = s.failureThreshold, s.successThreshold
+
+	if tp, ok := pinger.(thresholdPinger); ok {
+		if tp.PingerFailureThreshold() > 0 {
+			failureThreshold = tp.PingerFailureThreshold()
+		}
+
+		if tp.PingerSuccessThreshold() > 0 {
+			successThreshold = tp.PingerSuccessThreshold()
+		}
+	}
+
+	return failureThreshold, successThreshold
+}
+
+// detectStartupGrace detects if pinger implements startupGracePinger; a negative grace means the
+// service grace
+func (s *Service) detectStartupGrace(pinger Pinger) time.Duration {
+	if sp, ok := pinger.(startupGracePinger); ok && sp.PingerStartupGrace() >= 0 {
+		return sp.PingerStartupGrace()
+	}
+
+	return s.startupGrace
+}
+
 // logPingerRegistration logs pinger registration with optional fields
 func (s *Service) logPingerRegistration(name string, info *pingerInfo) {
 	logFields := []any{"name", name}
@@ -164,9 +362,34 @@ func (s *Service) logPingerRegistration(name string, info *pingerInfo) {
 		logFields = append(logFields, "timeout", info.timeout)
 	}
 
+	if info.interval > 0 {
+		logFields = append(logFields, "interval", info.interval)
+	}
+
+	if info.offset > 0 {
+		logFields = append(logFields, "offset", info.offset)
+	}
+
+	if info.failureThreshold != defaultThreshold {
+		logFields = append(logFields, "failureThreshold", info.failureThreshold)
+	}
+
+	if info.successThreshold != defaultThreshold {
+		logFields = append(logFields, "successThreshold", info.successThreshold)
+	}
+
+	if info.startupGrace > 0 {
+		logFields = append(logFields, "startupGrace", info.startupGrace)
+	}
+
 	s.logger.Info("pinger registered", logFields...)
 }
 
+// ShutdownGroup returns shutdown.GroupServers: health checks stop before the checked components.
+func (s *Service) ShutdownGroup() shutdown.Group {
+	return shutdown.GroupServers
+}
+
 // Start starts the pinger service in a goroutine
 func (s *Service) Start(ctx context.Context) error {
 	if s.inShutdown.Load() {
@@ -257,7 +480,7 @@ func (s *Service) run(ctx context.Context) {
 	defer ticker.Stop()
 
 	// Run first ping immediately
-	s.runPingers(ctx, logger)
+	s.runPingers(ctx, logger, false)
 
 	// Close ready channel immediately - pingers start on first interval
 	close(s.ready)
@@ -272,7 +495,7 @@ func (s *Service) run(ctx context.Context) {
 
 		select {
 		case <-ticker.C:
-			s.runPingers(ctx, logger)
+			s.runPingers(ctx, logger, true)
 		case <-ctx.Done():
 			logger.InfoContext(ctx, "terminating pinger loop")
 
@@ -281,24 +504,33 @@ func (s *Service) run(ctx context.Context) {
 	}
 }
 
-// runPingers executes all registered pingers in parallel
-func (s *Service) runPingers(ctx context.Context, logger *slog.Logger) {
-	pingers := s.copyPingers()
+// runPingers executes all due pingers in parallel, each after its offset when jittered
+func (s *Service) runPingers(ctx context.Context, logger *slog.Logger, jittered bool) {
+	pingers := s.duePingers(time.Now())
 
 	if len(pingers) == 0 {
 		return
 	}
 
-	s.executePingers(ctx, logger, pingers)
+	s.executePingers(ctx, logger, pingers, jittered)
 }
 
-// copyPingers creates a thread-safe copy of pingers map
-func (s *Service) copyPingers() map[string]*pingerInfo {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
+// duePingers returns the pingers due at now and schedules their next run. Half a tick of slack
+// keeps ticker drift from postponing a pinger by a whole tick.
+func (s *Service) duePingers(now time.Time) map[string]*pingerInfo {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
 	pingers := make(map[string]*pingerInfo, len(s.pingers))
-	maps.Copy(pingers, s.pingers)
+
+	for name, info := range s.pingers {
+		if now.Add(s.interval / 2).Before(info.nextRun) {
+			continue
+		}
+
+		info.nextRun = now.Add(info.interval)
+		pingers[name] = info
+	}
 
 	return pingers
 }
@@ -308,6 +540,7 @@ func (s *Service) executePingers(
 	ctx context.Context,
 	logger *slog.Logger,
 	pingers map[string]*pingerInfo,
+	jittered bool,
 ) {
 	var wg sync.WaitGroup
 
@@ -319,7 +552,12 @@ func (s *Service) executePingers(
 		wg.Add(1)
 		s.wg.Add(1)
 
-		go s.runSinglePinger(ctx, logger, name, info, &wg)
+		var delay time.Duration
+		if jittered {
+			delay = info.offset
+		}
+
+		go s.runSinglePinger(ctx, logger, name, info, delay, &wg)
 	}
 
 	s.waitForPingers(ctx, &wg)
@@ -341,11 +579,23 @@ func (s *Service) runSinglePinger(
 	logger *slog.Logger,
 	name string,
 	info *pingerInfo,
+	delay time.Duration,
 	wg *sync.WaitGroup,
 ) {
 	defer wg.Done()
 	defer s.wg.Done()
 
+	if delay > 0 {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+	}
+
 	pingCtx, cancel := context.WithTimeout(ctx, info.timeout)
 	defer cancel()
 
@@ -353,7 +603,12 @@ func (s *Service) runSinglePinger(
 	err := info.pinger.Ping(pingCtx)
 	latency := time.Since(start)
 
+	if !s.isRegistered(name, info) {
+		return
+	}
+
 	s.updateStats(name, latency, err)
+	metrics.RecordPing(name, latency, err != nil)
 	s.logPingerResult(ctx, logger, name, latency, err)
 }
 
@@ -400,10 +655,11 @@ func (s *Service) waitForPingers(ctx context.Context, wg *sync.WaitGroup) {
 // updateStats updates statistics for a pinger in a thread-safe manner
 func (s *Service) updateStats(name string, latency time.Duration, err error) {
 	s.mu.RLock()
-	stats, exists := s.stats[name]
+	info, infoExists := s.pingers[name]
+	stats, statsExists := s.stats[name]
 	s.mu.RUnlock()
 
-	if !exists {
+	if !infoExists || !statsExists {
 		return
 	}
 
@@ -411,15 +667,36 @@ func (s *Service) updateStats(name string, latency time.Duration, err error) {
 	defer stats.mu.Unlock()
 
 	now := time.Now()
+	s.flap.recordTransition(stats, now, err != nil)
 	stats.LastRun = now
 
+	if err != nil {
+		stats.ConsecutiveFailures++
+		stats.ConsecutiveSuccesses = 0
+
+		if stats.ConsecutiveFailures >= info.failureThreshold {
+			stats.Failing = true
+		}
+	} else {
+		stats.ConsecutiveSuccesses++
+		stats.ConsecutiveFailures = 0
+
+		if stats.ConsecutiveSuccesses >= info.successThreshold {
+			stats.Failing = false
+		}
+	}
+
+	s.flap.recordSteadyState(stats, now)
+
 	if err != nil {
 		stats.LastError = err
-		stats.LastErrorSnapshot = &ErrorSnapshot{
+		snapshot := ErrorSnapshot{
 			Timestamp: now,
 			Latency:   latency,
 			Error:     err,
 		}
+		stats.LastErrorSnapshot = &snapshot
+		stats.addErrorSnapshot(snapshot)
 		stats.ErrorLatencies.Add(latency)
 	} else {
 		stats.LastError = nil
@@ -3,8 +3,10 @@ package pinger
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
 	"strconv"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -62,6 +64,52 @@ func TestService_Register(t *testing.T) {
 	})
 }
 
+func TestService_Deregister_Replace(t *testing.T) {
+	t.Parallel()
+
+	logger := slog.Default()
+	service := New(logger, time.Second)
+
+	if err := service.Deregister("missing"); !errors.Is(err, ErrPingerNotFound) {
+		t.Fatalf("expected error %v, got %v", ErrPingerNotFound, err)
+	}
+
+	if err := service.Register(&mockPinger{name: "component", shouldError: true}); err != nil {
+		t.Fatalf("failed to register pinger: %v", err)
+	}
+
+	service.updateStats("component", time.Millisecond, errors.New("ping failed"))
+
+	if err := service.Replace(&mockPinger{name: "component"}); err != nil {
+		t.Fatalf("failed to replace pinger: %v", err)
+	}
+
+	stats, err := service.GetStats("component")
+	if err != nil {
+		t.Fatalf("failed to get stats: %v", err)
+	}
+
+	if stats.ErrorCount != 0 || !stats.IsReady {
+		t.Errorf("expected fresh statistics after replace, got %d errors, ready %v", stats.ErrorCount, stats.IsReady)
+	}
+
+	if err := service.Replace(&mockPinger{name: "new"}); err != nil {
+		t.Fatalf("failed to replace unregistered pinger: %v", err)
+	}
+
+	if err := service.Deregister("component"); err != nil {
+		t.Fatalf("failed to deregister pinger: %v", err)
+	}
+
+	if _, err := service.GetStats("component"); !errors.Is(err, ErrPingerNotFound) {
+		t.Fatalf("expected error %v after deregister, got %v", ErrPingerNotFound, err)
+	}
+
+	if all := service.GetAllStats(); len(all) != 1 {
+		t.Errorf("expected 1 remaining pinger, got %d", len(all))
+	}
+}
+
 func TestService_GetStats(t *testing.T) {
 	t.Parallel()
 
@@ -686,6 +734,282 @@ func TestService_PingerTimeout(t *testing.T) {
 	_ = service.Shutdown(shutdownCtx)
 }
 
+func TestService_PingerInterval(t *testing.T) {
+	t.Parallel()
+
+	logger := slog.Default()
+	service := New(logger, 50*time.Millisecond)
+
+	cheap := &intervalMockPinger{name: "cheap"}
+	heavy := &intervalMockPinger{name: "heavy", interval: 200 * time.Millisecond}
+
+	for _, p := range []Pinger{cheap, heavy} {
+		if err := service.Register(p); err != nil {
+			t.Fatalf("register %s failed: %v", p.Name(), err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := service.Start(ctx); err != nil {
+		t.Fatalf("start failed: %v", err)
+	}
+
+	// Let it run for about eight ticks
+	time.Sleep(420 * time.Millisecond)
+	cancel()
+
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer shutdownCancel()
+
+	_ = service.Shutdown(shutdownCtx)
+
+	// The heavy pinger runs at 0, 200ms and 400ms; the cheap one on every tick.
+	if got := heavy.pings.Load(); got < 2 || got > 3 {
+		t.Errorf("expected 2 or 3 heavy pings, got %d", got)
+	}
+
+	if got := cheap.pings.Load(); got < 6 {
+		t.Errorf("expected at least 6 cheap pings, got %d", got)
+	}
+}
+
+func TestService_PingerOffset(t *testing.T) {
+	t.Parallel()
+
+	logger := slog.Default()
+
+	if offset := New(logger, time.Second).pingerOffset("a"); offset != 0 {
+		t.Errorf("expected no offset without jitter, got %v", offset)
+	}
+
+	service := New(logger, time.Second, WithJitter(time.Hour))
+	if service.jitter != 500*time.Millisecond {
+		t.Fatalf("expected jitter capped at half the interval, got %v", service.jitter)
+	}
+
+	offsets := make(map[time.Duration]struct{})
+
+	for i := range 10 {
+		name := "pinger-" + strconv.Itoa(i)
+
+		offset := service.pingerOffset(name)
+		if offset < 0 || offset >= service.jitter {
+			t.Errorf("offset of %s out of range: %v", name, offset)
+		}
+
+		if again := service.pingerOffset(name); again != offset {
+			t.Errorf("offset of %s not stable: %v, then %v", name, offset, again)
+		}
+
+		offsets[offset] = struct{}{}
+	}
+
+	if len(offsets) < 2 {
+		t.Error("expected pingers to get different offsets")
+	}
+}
+
+func TestService_ErrorSnapshots(t *testing.T) {
+	t.Parallel()
+
+	service := New(slog.Default(), time.Second)
+
+	pinger := &mockPinger{name: "intermittent"}
+	if err := service.Register(pinger); err != nil {
+		t.Fatalf("failed to register pinger: %v", err)
+	}
+
+	const errorCount = ErrorSnapshotBufferSize + 2
+
+	for i := range errorCount {
+		service.updateStats(pinger.Name(), time.Millisecond, fmt.Errorf("error %d", i))
+		service.updateStats(pinger.Name(), time.Millisecond, nil)
+	}
+
+	stats, err := service.GetStats(pinger.Name())
+	if err != nil {
+		t.Fatalf("failed to get stats: %v", err)
+	}
+
+	if len(stats.ErrorSnapshots) != ErrorSnapshotBufferSize {
+		t.Fatalf("expected %d error snapshots, got %d", ErrorSnapshotBufferSize, len(stats.ErrorSnapshots))
+	}
+
+	for i, snapshot := range stats.ErrorSnapshots {
+		want := fmt.Sprintf("error %d", errorCount-1-i)
+		if snapshot.Error == nil || snapshot.Error.Error() != want {
+			t.Errorf("snapshot %d: expected %q, got %v", i, want, snapshot.Error)
+		}
+	}
+}
+
+func TestService_StartupGrace(t *testing.T) {
+	t.Parallel()
+
+	logger := slog.Default()
+	errPing := errors.New("metrics-server unavailable")
+
+	service := New(logger, time.Second, WithStartupGrace(time.Hour))
+
+	if err := service.Register(&mockPinger{name: "warming-up"}); err != nil {
+		t.Fatalf("failed to register pinger: %v", err)
+	}
+
+	if err := service.Register(&graceMockPinger{name: "no-grace", grace: 0}); err != nil {
+		t.Fatalf("failed to register pinger: %v", err)
+	}
+
+	service.updateStats("warming-up", time.Millisecond, errPing)
+	service.updateStats("no-grace", time.Millisecond, errPing)
+
+	stats, err := service.GetStats("warming-up")
+	if err != nil {
+		t.Fatalf("failed to get stats: %v", err)
+	}
+
+	if !stats.InStartupGrace || !stats.IsReady || !stats.IsHealthy || stats.ErrorCount != 1 {
+		t.Errorf("expected recorded failure ignored during grace, got %+v", stats)
+	}
+
+	stats, err = service.GetStats("no-grace")
+	if err != nil {
+		t.Fatalf("failed to get stats: %v", err)
+	}
+
+	if stats.InStartupGrace || stats.IsReady {
+		t.Errorf("expected pinger without grace not ready, got %+v", stats)
+	}
+
+	// The grace period ends with the first success.
+	service.updateStats("warming-up", time.Millisecond, nil)
+	service.updateStats("warming-up", time.Millisecond, errPing)
+
+	stats, err = service.GetStats("warming-up")
+	if err != nil {
+		t.Fatalf("failed to get stats: %v", err)
+	}
+
+	if stats.InStartupGrace || stats.IsReady || stats.IsHealthy {
+		t.Errorf("expected failure after the first success to count, got %+v", stats)
+	}
+}
+
+func TestService_Thresholds(t *testing.T) {
+	t.Parallel()
+
+	logger := slog.Default()
+	errPing := errors.New("ping failed")
+
+	service := New(logger, time.Second, WithThresholds(3, 2))
+
+	pinger := &mockPinger{name: "transient"}
+	if err := service.Register(pinger); err != nil {
+		t.Fatalf("failed to register pinger: %v", err)
+	}
+
+	steps := []struct {
+		result    error
+		wantReady bool
+	}{
+		{result: errPing, wantReady: true},
+		{result: errPing, wantReady: true},
+		{result: nil, wantReady: true},
+		{result: errPing, wantReady: true},
+		{result: errPing, wantReady: true},
+		{result: errPing, wantReady: false},
+		{result: nil, wantReady: false},
+		{result: nil, wantReady: true},
+	}
+
+	for i, step := range steps {
+		service.updateStats(pinger.Name(), time.Millisecond, step.result)
+
+		stats, err := service.GetStats(pinger.Name())
+		if err != nil {
+			t.Fatalf("failed to get stats: %v", err)
+		}
+
+		if stats.IsReady != step.wantReady || stats.IsHealthy != step.wantReady {
+			t.Errorf("step %d: expected ready and healthy %v, got %v and %v", i, step.wantReady, stats.IsReady, stats.IsHealthy)
+		}
+	}
+}
+
+func TestService_FlapDetection(t *testing.T) {
+	t.Parallel()
+
+	logger := slog.Default()
+	errPing := errors.New("ping failed")
+
+	tests := []struct {
+		name          string
+		holdReady     bool
+		results       []error
+		wantFlapping  bool
+		wantIsReady   bool
+		wantIsHealthy bool
+	}{
+		{
+			name:          "steady failure is not flapping",
+			results:       []error{nil, errPing, errPing, errPing},
+			wantFlapping:  false,
+			wantIsReady:   false,
+			wantIsHealthy: false,
+		},
+		{
+			name:          "flapping follows the last result",
+			results:       []error{nil, errPing, nil, errPing},
+			wantFlapping:  true,
+			wantIsReady:   false,
+			wantIsHealthy: false,
+		},
+		{
+			name:          "flapping holds readiness",
+			holdReady:     true,
+			results:       []error{nil, errPing, nil, errPing},
+			wantFlapping:  true,
+			wantIsReady:   true,
+			wantIsHealthy: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			service := New(logger, time.Second, WithFlapDetection(3, time.Minute, tt.holdReady))
+
+			pinger := &mockPinger{name: "flaky"}
+			if err := service.Register(pinger); err != nil {
+				t.Fatalf("failed to register pinger: %v", err)
+			}
+
+			for _, err := range tt.results {
+				service.updateStats(pinger.Name(), time.Millisecond, err)
+			}
+
+			stats, err := service.GetStats(pinger.Name())
+			if err != nil {
+				t.Fatalf("failed to get stats: %v", err)
+			}
+
+			if stats.IsFlapping != tt.wantFlapping {
+				t.Errorf("expected IsFlapping=%v, got %v (transitions %d)", tt.wantFlapping, stats.IsFlapping, stats.Transitions)
+			}
+
+			if stats.IsReady != tt.wantIsReady {
+				t.Errorf("expected IsReady=%v, got %v", tt.wantIsReady, stats.IsReady)
+			}
+
+			if stats.IsHealthy != tt.wantIsHealthy {
+				t.Errorf("expected IsHealthy=%v, got %v", tt.wantIsHealthy, stats.IsHealthy)
+			}
+		})
+	}
+}
+
 // mockPinger is a test implementation of Pinger
 type mockPinger struct {
 	shouldError bool
@@ -780,3 +1104,42 @@ func (m *timeoutMockPinger) Ping(ctx context.Context) error {
 func (m *timeoutMockPinger) PingerTimeout() time.Duration {
 	return m.timeout
 }
+
+// intervalMockPinger is a test implementation with its own interval that counts its pings
+type intervalMockPinger struct {
+	name     string
+	interval time.Duration
+	pings    atomic.Int32
+}
+
+func (m *intervalMockPinger) Name() string {
+	return m.name
+}
+
+func (m *intervalMockPinger) Ping(context.Context) error {
+	m.pings.Add(1)
+
+	return nil
+}
+
+func (m *intervalMockPinger) PingerInterval() time.Duration {
+	return m.interval
+}
+
+// graceMockPinger is a test implementation of Pinger with a startup grace period
+type graceMockPinger struct {
+	name  string
+	grace time.Duration
+}
+
+func (m *graceMockPinger) Name() string {
+	return m.name
+}
+
+func (m *graceMockPinger) Ping(_ context.Context) error {
+	return nil
+}
+
+func (m *graceMockPinger) PingerStartupGrace() time.Duration {
+	return m.grace
+}
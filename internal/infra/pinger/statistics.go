@@ -14,6 +14,9 @@ const (
 	// ErrorLatencyBufferSize is the number of error ping latencies to track
 	ErrorLatencyBufferSize = 10
 
+	// ErrorSnapshotBufferSize is the number of error snapshots to keep
+	ErrorSnapshotBufferSize = 10
+
 	// PercentileMax is the maximum percentile value (100%)
 	PercentileMax = 100.0
 
@@ -106,20 +109,43 @@ type Stats struct {
 	LastRun           time.Time
 	LastError         error
 	LastErrorSnapshot *ErrorSnapshot
-	SuccessLatencies  *LatencyBuffer
-	ErrorLatencies    *LatencyBuffer
-	mu                sync.RWMutex
+	// ErrorSnapshots are the last ErrorSnapshotBufferSize errors, oldest first.
+	ErrorSnapshots   []ErrorSnapshot
+	SuccessLatencies *LatencyBuffer
+	ErrorLatencies   *LatencyBuffer
+	// ConsecutiveFailures and ConsecutiveSuccesses count the last results of the same kind.
+	ConsecutiveFailures  int
+	ConsecutiveSuccesses int
+	// Failing is set once the consecutive failures reached the failure threshold, and cleared once the
+	// consecutive successes reached the success threshold.
+	Failing bool
+	mu      sync.RWMutex
+	// transitions are the times the result changed between success and failure within the flap window.
+	transitions []time.Time
+	// steadyFailed is whether the pinger was failing before it started flapping.
+	steadyFailed bool
 }
 
 // NewPingerStats creates a new PingerStats instance
 func NewPingerStats(name string) *Stats {
 	return &Stats{
 		Name:             name,
+		ErrorSnapshots:   make([]ErrorSnapshot, 0, ErrorSnapshotBufferSize),
 		SuccessLatencies: NewLatencyBuffer(SuccessLatencyBufferSize),
 		ErrorLatencies:   NewLatencyBuffer(ErrorLatencyBufferSize),
 	}
 }
 
+// addErrorSnapshot records an error snapshot, dropping the oldest beyond ErrorSnapshotBufferSize.
+// The caller holds s.mu.
+func (s *Stats) addErrorSnapshot(snapshot ErrorSnapshot) {
+	if len(s.ErrorSnapshots) >= ErrorSnapshotBufferSize {
+		s.ErrorSnapshots = append(s.ErrorSnapshots[:0], s.ErrorSnapshots[1:]...)
+	}
+
+	s.ErrorSnapshots = append(s.ErrorSnapshots, snapshot)
+}
+
 // LatencyMetrics contains calculated latency statistics
 type LatencyMetrics struct {
 	Count   int
@@ -141,6 +167,17 @@ type Statistics struct {
 	ErrorCount        int
 	SuccessLatencies  LatencyMetrics
 	ErrorLatencies    LatencyMetrics
+	// IsFlapping is set when the result changed between success and failure too often within the
+	// flap window; Transitions is the number of changes within the window.
+	IsFlapping  bool
+	Transitions int
+	// ConsecutiveFailures and ConsecutiveSuccesses count the last results of the same kind.
+	ConsecutiveFailures  int
+	ConsecutiveSuccesses int
+	// ErrorSnapshots are the last errors, newest first.
+	ErrorSnapshots []ErrorSnapshot
+	// InStartupGrace is set during the startup grace period, in which failures do not count.
+	InStartupGrace bool
 }
 
 // CalculatePercentile calculates the percentile value from a sorted slice of durations
@@ -250,21 +287,45 @@ func GetStatistics(stats *Stats, info *pingerInfo) *Statistics {
 		}
 	}
 
-	// Calculate IsReady: if readyCritical is false, always true; otherwise based on LastError
-	isReady := !info.readyCritical || stats.LastError == nil
+	errorSnapshots := make([]ErrorSnapshot, len(stats.ErrorSnapshots))
+	for i, snapshot := range stats.ErrorSnapshots {
+		errorSnapshots[len(errorSnapshots)-1-i] = snapshot
+	}
+
+	now := time.Now()
+	isFlapping := info.flap.isFlapping(stats.transitions, now)
+
+	// Failures do not count during the startup grace period, until the first success
+	inStartupGrace := now.Before(info.registeredAt.Add(info.startupGrace)) && len(successLatencies) == 0
+	failing := stats.Failing && !inStartupGrace
+
+	// Calculate IsReady: if readyCritical is false, always true; otherwise based on failing, or on
+	// the state before flapping when readiness is held
+	readyFailed := failing
+	if isFlapping && info.flap.holdReady {
+		readyFailed = stats.steadyFailed && !inStartupGrace
+	}
+
+	isReady := !info.readyCritical || !readyFailed
 
-	// Calculate IsHealthy: if healthCritical is false, always true; otherwise based on LastError
-	isHealthy := !info.healthCritical || stats.LastError == nil
+	// Calculate IsHealthy: if healthCritical is false, always true; otherwise based on failing
+	isHealthy := !info.healthCritical || !failing
 
 	return &Statistics{
-		IsReady:           isReady,
-		IsHealthy:         isHealthy,
-		LastRun:           stats.LastRun,
-		LastError:         stats.LastError,
-		LastErrorSnapshot: lastErrorSnapshot,
-		SuccessCount:      len(successLatencies),
-		ErrorCount:        len(errorLatencies),
-		SuccessLatencies:  calculateLatencyMetrics(successLatencies),
-		ErrorLatencies:    calculateLatencyMetrics(errorLatencies),
+		IsReady:              isReady,
+		IsHealthy:            isHealthy,
+		LastRun:              stats.LastRun,
+		LastError:            stats.LastError,
+		LastErrorSnapshot:    lastErrorSnapshot,
+		SuccessCount:         len(successLatencies),
+		ErrorCount:           len(errorLatencies),
+		SuccessLatencies:     calculateLatencyMetrics(successLatencies),
+		ErrorLatencies:       calculateLatencyMetrics(errorLatencies),
+		IsFlapping:           isFlapping,
+		Transitions:          len(info.flap.recent(stats.transitions, now)),
+		ConsecutiveFailures:  stats.ConsecutiveFailures,
+		ConsecutiveSuccesses: stats.ConsecutiveSuccesses,
+		ErrorSnapshots:       errorSnapshots,
+		InStartupGrace:       inStartupGrace,
 	}
 }
@@ -3,6 +3,7 @@ package shutdown
 import (
 	"context"
 	"os"
+	"time"
 )
 
 // Shutdowner is the interface that components must implement for graceful shutdown
@@ -11,6 +12,18 @@ type Shutdowner interface {
 	Shutdown(ctx context.Context) error
 }
 
+// Optional interface types for type assertions
+
+// groupShutdowner is shut down with its group; components without a group are in GroupControllers.
+type groupShutdowner interface {
+	ShutdownGroup() Group
+}
+
+// timeoutShutdowner overrides the default timeout of its shutdown.
+type timeoutShutdowner interface {
+	ShutdownTimeout() time.Duration
+}
+
 type quiter interface {
 	Quit() <-chan os.Signal
 }
@@ -1,20 +1,52 @@
 package shutdown
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
 	"log/slog"
 	"os"
 	"os/signal"
+	"slices"
 	"syscall"
 	"time"
 )
 
+// ErrTerminationFileFound is returned by CheckTermination when the termination file exists.
+var ErrTerminationFileFound = errors.New("termination file found")
+
 const (
+	// defaultShutdownTimeout bounds the shutdown of each component without its own timeout
 	defaultShutdownTimeout = 5 * time.Second
 )
 
+// Group orders the shutdown of components: groups are shut down in increasing order, the components
+// of a group in reverse registration order.
+type Group int
+
+const (
+	// GroupServers stop first, so that no request reaches a stopped component.
+	GroupServers Group = iota
+	// GroupControllers stop next; it is the group of components that declare none.
+	GroupControllers
+	// GroupSinks stop last, after the controllers emitted their last events and spans.
+	GroupSinks
+)
+
+func (g Group) String() string {
+	switch g {
+	case GroupServers:
+		return "servers"
+	case GroupControllers:
+		return "controllers"
+	case GroupSinks:
+		return "sinks"
+	default:
+		return fmt.Sprintf("group %d", int(g))
+	}
+}
+
 // Notify returns a channel that will receive SIGTERM and SIGINT signals.
 // This should be called as the first thing in main() before any other initialization.
 func Notify() <-chan os.Signal {
@@ -27,13 +59,17 @@ func Notify() <-chan os.Signal {
 type Handler struct {
 	logger *slog.Logger
 	quiter quiter
+	// terminationFile is the path of the termination file; empty disables the check.
+	terminationFile string
 }
 
-// New creates a new shutdown handler.
-func New(logger *slog.Logger, quiter quiter) *Handler {
+// New creates a new shutdown handler checking terminationFile before startup; an empty
+// terminationFile disables the check.
+func New(logger *slog.Logger, quiter quiter, terminationFile string) *Handler {
 	return &Handler{
-		logger: logger,
-		quiter: quiter,
+		logger:          logger,
+		quiter:          quiter,
+		terminationFile: terminationFile,
 	}
 }
 
@@ -52,6 +88,8 @@ func (h *Handler) HandleSignals(ctx context.Context, cancel func()) {
 	cancel()
 }
 
+// CheckTermination returns an error when the context is done or the termination file exists, so
+// that a pod already being terminated does not start.
 func (h *Handler) CheckTermination(ctx context.Context) error {
 	select {
 	case <-ctx.Done():
@@ -59,16 +97,23 @@ func (h *Handler) CheckTermination(ctx context.Context) error {
 	default:
 	}
 
-	terminationFile := "/mnt/signal/terminating"
-	if _, err := os.Stat(terminationFile); err == nil {
-		return fmt.Errorf("termination file found: %w", err)
+	if h.terminationFile == "" {
+		return nil
+	}
+
+	if _, err := os.Stat(h.terminationFile); err == nil {
+		return fmt.Errorf("%w: %s", ErrTerminationFileFound, h.terminationFile)
 	}
 
 	return nil
 }
 
-// CheckTerminationFile checks if the termination file exists
+// CheckTerminationFile checks if the termination file exists; an empty path is never found.
 func CheckTerminationFile(ctx context.Context, logger *slog.Logger, terminationFile string) bool {
+	if terminationFile == "" {
+		return false
+	}
+
 	_, err := os.Stat(terminationFile)
 	if err != nil {
 		if !os.IsNotExist(err) {
@@ -88,24 +133,22 @@ func CheckTerminationFile(ctx context.Context, logger *slog.Logger, terminationF
 	return true
 }
 
-// GracefulShutdown performs graceful shutdown of the components with timeout.
+// GracefulShutdown performs graceful shutdown of the components by group (see Group), each bounded by
+// its own timeout: ShutdownTimeout when the component implements it, defaultShutdownTimeout otherwise.
 func GracefulShutdown(
 	originCtx context.Context,
 	logger *slog.Logger,
 	shutdowners []Shutdowner,
 ) error {
 	// Use context.WithoutCancel to ensure shutdown continues even if originCtx is cancelled
-	ctx, cancel := context.WithTimeout(context.WithoutCancel(originCtx), defaultShutdownTimeout)
-	defer cancel()
+	ctx := context.WithoutCancel(originCtx)
 
 	componentsShutdownErrors := make(chan error, len(shutdowners))
 
-	// Shutdown components in reverse order to ensure dependencies are met
-	for i := len(shutdowners) - 1; i >= 0; i-- {
+	for _, shutdowner := range shutdownOrder(shutdowners) {
 		start := time.Now()
-		shutdowner := shutdowners[i]
 
-		if err := shutdowner.Shutdown(ctx); err != nil {
+		if err := shutdownComponent(ctx, shutdowner); err != nil {
 			logger.ErrorContext(ctx, "component shutdown failed",
 				"component", shutdowner.Name(),
 				"duration", time.Since(start),
@@ -120,6 +163,7 @@ func GracefulShutdown(
 
 		logger.InfoContext(ctx, "component shutdown completed",
 			"component", shutdowner.Name(),
+			"group", groupOf(shutdowner).String(),
 			"duration", time.Since(start),
 		)
 	}
@@ -134,3 +178,37 @@ func GracefulShutdown(
 
 	return errs
 }
+
+// shutdownOrder returns the shutdowners by group, in reverse registration order within a group so
+// that dependencies are met.
+func shutdownOrder(shutdowners []Shutdowner) []Shutdowner {
+	ordered := slices.Clone(shutdowners)
+	slices.Reverse(ordered)
+	slices.SortStableFunc(ordered, func(a, b Shutdowner) int {
+		return cmp.Compare(groupOf(a), groupOf(b))
+	})
+
+	return ordered
+}
+
+// groupOf returns the group of the shutdowner, GroupControllers when it declares none.
+func groupOf(shutdowner Shutdowner) Group {
+	if gs, ok := shutdowner.(groupShutdowner); ok {
+		return gs.ShutdownGroup()
+	}
+
+	return GroupControllers
+}
+
+// shutdownComponent shuts the component down within its timeout.
+func shutdownComponent(ctx context.Context, shutdowner Shutdowner) error {
+	timeout := defaultShutdownTimeout
+	if ts, ok := shutdowner.(timeoutShutdowner); ok && ts.ShutdownTimeout() > 0 {
+		timeout = ts.ShutdownTimeout()
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	return shutdowner.Shutdown(ctx)
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -39,6 +40,44 @@ func TestCheckTerminationFile(t *testing.T) {
 		got := shutdown.CheckTerminationFile(t.Context(), logger, path)
 		require.True(t, got)
 	})
+
+	t.Run("empty path returns false", func(t *testing.T) {
+		t.Parallel()
+
+		got := shutdown.CheckTerminationFile(t.Context(), logger, "")
+		require.False(t, got)
+	})
+}
+
+func TestHandler_CheckTermination(t *testing.T) {
+	t.Parallel()
+
+	logger := slog.Default()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "terminating")
+	require.NoError(t, os.WriteFile(path, nil, 0o600))
+
+	t.Run("file exists returns error", func(t *testing.T) {
+		t.Parallel()
+
+		h := shutdown.New(logger, nil, path)
+		require.ErrorIs(t, h.CheckTermination(t.Context()), shutdown.ErrTerminationFileFound)
+	})
+
+	t.Run("file missing returns nil", func(t *testing.T) {
+		t.Parallel()
+
+		h := shutdown.New(logger, nil, filepath.Join(dir, "nonexistent"))
+		require.NoError(t, h.CheckTermination(t.Context()))
+	})
+
+	t.Run("disabled returns nil", func(t *testing.T) {
+		t.Parallel()
+
+		h := shutdown.New(logger, nil, "")
+		require.NoError(t, h.CheckTermination(t.Context()))
+	})
 }
 
 func TestGracefulShutdown(t *testing.T) {
@@ -90,4 +129,59 @@ func TestGracefulShutdown(t *testing.T) {
 		err := shutdown.GracefulShutdown(t.Context(), logger, []shutdown.Shutdowner{first, second})
 		require.NoError(t, err)
 	})
+	t.Run("groups shut down in order with their own timeouts", func(t *testing.T) {
+		t.Parallel()
+
+		var order []string
+
+		shutdowners := []shutdown.Shutdowner{
+			&groupedShutdowner{name: "nats", group: shutdown.GroupSinks, order: &order},
+			&groupedShutdowner{name: "controller", group: shutdown.GroupControllers, order: &order},
+			&groupedShutdowner{name: "http", group: shutdown.GroupServers, order: &order},
+			&groupedShutdowner{name: "metrics", group: shutdown.GroupServers, timeout: time.Minute, order: &order},
+		}
+
+		err := shutdown.GracefulShutdown(t.Context(), logger, shutdowners)
+		require.NoError(t, err)
+		require.Equal(t, []string{"metrics", "http", "controller", "nats"}, order)
+
+		metrics, ok := shutdowners[3].(*groupedShutdowner)
+		require.True(t, ok)
+		require.Greater(t, metrics.deadline, 30*time.Second)
+
+		http, ok := shutdowners[2].(*groupedShutdowner)
+		require.True(t, ok)
+		require.LessOrEqual(t, http.deadline, 5*time.Second)
+	})
+}
+
+// groupedShutdowner records its shutdown order and the time left until its deadline.
+type groupedShutdowner struct {
+	name     string
+	group    shutdown.Group
+	timeout  time.Duration
+	order    *[]string
+	deadline time.Duration
+}
+
+func (s *groupedShutdowner) Name() string {
+	return s.name
+}
+
+func (s *groupedShutdowner) Shutdown(ctx context.Context) error {
+	*s.order = append(*s.order, s.name)
+
+	if deadline, ok := ctx.Deadline(); ok {
+		s.deadline = time.Until(deadline)
+	}
+
+	return nil
+}
+
+func (s *groupedShutdowner) ShutdownGroup() shutdown.Group {
+	return s.group
+}
+
+func (s *groupedShutdowner) ShutdownTimeout() time.Duration {
+	return s.timeout
 }
@@ -0,0 +1,78 @@
+// Package tracing exports OpenTelemetry traces of the controller over OTLP/HTTP.
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/skillcoder/preoomkiller-controller/internal/infra/shutdown"
+)
+
+// serviceName is the default service.name of the exported spans; OTEL_SERVICE_NAME overrides it.
+const serviceName = "preoomkiller-controller"
+
+// Provider is the global tracer provider. It implements shutdown.Shutdowner so that buffered spans
+// are flushed on graceful shutdown.
+type Provider struct {
+	tracerProvider *sdktrace.TracerProvider
+}
+
+// New installs a global tracer provider batching spans to an OTLP/HTTP exporter. The exporter and
+// sampler are configured with the standard OTEL_EXPORTER_OTLP_* and OTEL_TRACES_SAMPLER variables.
+func New(ctx context.Context, serviceVersion string) (*Provider, error) {
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("create otlp trace exporter: %w", err)
+	}
+
+	// Detectors are applied in order, so OTEL_SERVICE_NAME and OTEL_RESOURCE_ATTRIBUTES take precedence.
+	res, err := resource.New(ctx,
+		resource.WithTelemetrySDK(),
+		resource.WithAttributes(
+			attribute.String("service.name", serviceName),
+			attribute.String("service.version", serviceVersion),
+		),
+		resource.WithFromEnv(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create trace resource: %w", err)
+	}
+
+	tracerProvider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+
+	otel.SetTracerProvider(tracerProvider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	return &Provider{tracerProvider: tracerProvider}, nil
+}
+
+func (p *Provider) Name() string {
+	return "tracing"
+}
+
+// ShutdownGroup returns shutdown.GroupSinks: spans are flushed after the controllers ended theirs.
+func (p *Provider) ShutdownGroup() shutdown.Group {
+	return shutdown.GroupSinks
+}
+
+// Shutdown flushes the buffered spans and stops the exporter. It is safe to call more than once.
+func (p *Provider) Shutdown(ctx context.Context) error {
+	if err := p.tracerProvider.Shutdown(ctx); err != nil {
+		return fmt.Errorf("shutdown tracer provider: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,135 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// Container aggregation modes: how container memory usages combine into the pod value compared
+// against the threshold. "named:<container>" uses the usage of a single container.
+const (
+	ContainerAggregationSum = "sum"
+	ContainerAggregationMax = "max"
+
+	containerAggregationNamedPrefix = "named:"
+)
+
+// ValidateContainerAggregation returns an error when mode is not sum, max or named:<container>.
+func ValidateContainerAggregation(mode string) error {
+	switch mode {
+	case ContainerAggregationSum, ContainerAggregationMax:
+		return nil
+	}
+
+	if name, ok := strings.CutPrefix(mode, containerAggregationNamedPrefix); ok && name != "" {
+		return nil
+	}
+
+	return fmt.Errorf("%w: want sum, max or named:<container>, got %q", ErrContainerAggregationParse, mode)
+}
+
+// containerAggregation returns the pod's container-aggregation annotation, or the configured default
+// when it is absent. The error wraps ErrContainerAggregationParse when the annotation is invalid.
+func (s *Service) containerAggregation(pod *Pod) (string, error) {
+	mode, ok := pod.Annotations[PreoomkillerAnnotationContainerAggregationKey]
+	if !ok {
+		return s.containerAggregationMode, nil
+	}
+
+	if err := ValidateContainerAggregation(mode); err != nil {
+		return "", err
+	}
+
+	return mode, nil
+}
+
+// memoryLimitFor returns the memory limit a threshold relative to the limit is resolved against in
+// mode: the named container's limit for named:<container>, the largest container limit for max, whose
+// containers are then each compared with their own limit, and the pod's total limit for sum.
+func memoryLimitFor(pod *Pod, mode string) *resource.Quantity {
+	if name, named := strings.CutPrefix(mode, containerAggregationNamedPrefix); named {
+		return containerMemoryLimit(pod, name)
+	}
+
+	if mode != ContainerAggregationMax {
+		return pod.MemoryLimit
+	}
+
+	var largest *resource.Quantity
+
+	for name := range pod.ContainerMemoryLimits {
+		if limit := containerMemoryLimit(pod, name); largest == nil || limit.Cmp(*largest) == 1 {
+			largest = limit
+		}
+	}
+
+	return largest
+}
+
+// containerMemoryLimit returns the memory limit of the named container; nil when it sets none.
+func containerMemoryLimit(pod *Pod, name string) *resource.Quantity {
+	limit, ok := pod.ContainerMemoryLimits[name]
+	if !ok {
+		return nil
+	}
+
+	return &limit
+}
+
+// thresholdFunc resolves the effective memory threshold against a memory limit.
+type thresholdFunc func(memoryLimit *resource.Quantity) (resource.Quantity, error)
+
+// aggregateMemoryUsage returns the usage compared in mode and the threshold it is compared against:
+// the pod total against the pod's limit for sum and for sources that do not report containers, the
+// named container against its own limit, or for max the container closest to its own threshold.
+// Containers without a limit are left out when the threshold is relative to it. ok is false when no
+// usage can be compared, e.g. the named container has no usage.
+func aggregateMemoryUsage(
+	pod *Pod,
+	podMetrics *PodMetrics,
+	mode string,
+	threshold thresholdFunc,
+) (usage, usageThreshold resource.Quantity, ok bool, err error) {
+	if mode == ContainerAggregationSum || len(podMetrics.Containers) == 0 {
+		if podMetrics.MemoryUsage == nil {
+			return resource.Quantity{}, resource.Quantity{}, false, nil
+		}
+
+		usageThreshold, err = threshold(pod.MemoryLimit)
+		if err != nil {
+			return resource.Quantity{}, resource.Quantity{}, false, err
+		}
+
+		return *podMetrics.MemoryUsage, usageThreshold, true, nil
+	}
+
+	name, named := strings.CutPrefix(mode, containerAggregationNamedPrefix)
+
+	var closest float64
+
+	for i := range podMetrics.Containers {
+		container := &podMetrics.Containers[i]
+		if container.MemoryUsage == nil || named && container.Name != name {
+			continue
+		}
+
+		containerThreshold, err := threshold(containerMemoryLimit(pod, container.Name))
+		if errors.Is(err, ErrMemoryLimitNotDefined) || err == nil && containerThreshold.IsZero() {
+			continue
+		}
+
+		if err != nil {
+			return resource.Quantity{}, resource.Quantity{}, false, err
+		}
+
+		ratio := container.MemoryUsage.AsApproximateFloat64() / containerThreshold.AsApproximateFloat64()
+		if !ok || ratio > closest {
+			usage, usageThreshold, closest, ok = *container.MemoryUsage, containerThreshold, ratio, true
+		}
+	}
+
+	return usage, usageThreshold, ok, nil
+}
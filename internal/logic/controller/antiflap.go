@@ -0,0 +1,62 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// flapSafeRestartAt returns the first schedule run at or after nextRun that is at least the minimum
+// restart interval after the pod was created, i.e. after its previous restart. deferred reports
+// whether nextRun had to be moved. It guards against schedules that fire far more often than
+// intended, such as "* * * * *".
+func (s *Service) flapSafeRestartAt(pod *Pod, nextRun time.Time) (time.Time, bool, error) {
+	if s.restartMinInterval <= 0 || pod.CreatedAt.IsZero() {
+		return nextRun, false, nil
+	}
+
+	earliest := pod.CreatedAt.Add(s.restartMinInterval)
+	if !nextRun.Before(earliest) {
+		return nextRun, false, nil
+	}
+
+	deferred, err := s.nextRestartAt(pod, earliest)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+
+	return deferred, true, nil
+}
+
+// deferFlappingRestart applies flapSafeRestartAt and reports a deferral with a Warning Event on the pod.
+func (s *Service) deferFlappingRestart(
+	ctx context.Context,
+	logger *slog.Logger,
+	pod *Pod,
+	nextRun time.Time,
+) (time.Time, error) {
+	restartAt, deferred, err := s.flapSafeRestartAt(pod, nextRun)
+	if err != nil || !deferred {
+		return restartAt, err
+	}
+
+	logger.WarnContext(ctx, "scheduled restart too soon after previous restart, deferring",
+		"nextRun", nextRun.Format(time.RFC3339),
+		"deferredTo", restartAt.Format(time.RFC3339),
+		"minInterval", s.restartMinInterval,
+	)
+
+	s.emitPodEvent(ctx, logger, pod, PodEvent{
+		Type:   EventTypeWarning,
+		Reason: EventReasonRestartTooFrequent,
+		Message: fmt.Sprintf(
+			"scheduled restart at %s is less than %s after the pod was created; deferred to %s",
+			nextRun.Format(time.RFC3339),
+			s.restartMinInterval,
+			restartAt.Format(time.RFC3339),
+		),
+	})
+
+	return restartAt, nil
+}
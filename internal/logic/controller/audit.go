@@ -0,0 +1,136 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// evictionInputs are the policy inputs of an eviction, reported in its Events, the audit log and notifications.
+type evictionInputs struct {
+	// detail describes what triggered a non-scheduled eviction.
+	detail    string
+	usage     *resource.Quantity
+	threshold *resource.Quantity
+	promQL    string
+}
+
+// decisionRecord is an eviction decision about a pod.
+type decisionRecord struct {
+	outcome Outcome
+	trigger EvictionTrigger
+	// reason explains a skipped or deferred decision.
+	reason string
+	inputs evictionInputs
+	// dueAt is when a deferred scheduled eviction is due.
+	dueAt time.Time
+}
+
+// recordDecision writes the decision to the audit log and sends it to the notifiers.
+func (s *Service) recordDecision(ctx context.Context, logger *slog.Logger, pod *Pod, record decisionRecord) {
+	if s.auditLogger == nil && len(s.notifiers) == 0 {
+		return
+	}
+
+	decision := s.evictionDecision(pod, record)
+
+	s.audit(ctx, decision)
+	s.notify(ctx, logger, decision)
+}
+
+// evictionDecision describes the decision about pod with the policy inputs of its trigger.
+func (s *Service) evictionDecision(pod *Pod, record decisionRecord) EvictionDecision {
+	decision := EvictionDecision{
+		Time:      time.Now(),
+		Cluster:   s.cluster,
+		Outcome:   record.outcome,
+		Trigger:   record.trigger,
+		Namespace: pod.Namespace,
+		Pod:       pod.Name,
+		PodUID:    pod.UID,
+		Owner:     pod.Owner,
+		Reason:    record.reason,
+		Detail:    record.inputs.detail,
+		PromQL:    record.inputs.promQL,
+	}
+
+	if record.inputs.threshold != nil {
+		decision.MemoryUsage = record.inputs.usage
+		decision.MemoryThreshold = record.inputs.threshold
+		decision.MemoryThresholdAnnotation = pod.Annotations[s.annotationMemoryThresholdKey]
+	}
+
+	if record.trigger == TriggerSchedule {
+		decision.RestartSchedule, _ = s.restartSpec(pod)
+		decision.TZ = s.restartTZ(pod)
+	}
+
+	if !record.dueAt.IsZero() {
+		decision.DueAt = &record.dueAt
+	}
+
+	return decision
+}
+
+// audit writes the decision to the audit log, when one is configured.
+func (s *Service) audit(ctx context.Context, decision EvictionDecision) {
+	if s.auditLogger == nil {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("decision", string(decision.Outcome)),
+		slog.String("trigger", string(decision.Trigger)),
+		slog.String("namespace", decision.Namespace),
+		slog.String("pod", decision.Pod),
+		slog.String("podUID", decision.PodUID),
+	}
+
+	if decision.Cluster != "" {
+		attrs = append(attrs, slog.String("cluster", decision.Cluster))
+	}
+
+	if decision.Owner != nil {
+		attrs = append(attrs, slog.String("owner", decision.Owner.Kind+"/"+decision.Owner.Name))
+	}
+
+	if decision.Reason != "" {
+		attrs = append(attrs, slog.String("reason", decision.Reason))
+	}
+
+	attrs = append(attrs, auditInputs(decision)...)
+
+	s.auditLogger.LogAttrs(ctx, slog.LevelInfo, "eviction decision", attrs...)
+}
+
+// auditInputs returns the policy inputs of the decision's trigger.
+func auditInputs(decision EvictionDecision) []slog.Attr {
+	var attrs []slog.Attr
+
+	if decision.MemoryThreshold != nil {
+		attrs = append(attrs,
+			slog.Int64("memoryUsageBytes", decision.MemoryUsage.Value()),
+			slog.String("memoryThresholdAnnotation", decision.MemoryThresholdAnnotation),
+			slog.Int64("memoryThresholdBytes", decision.MemoryThreshold.Value()),
+		)
+	}
+
+	if decision.PromQL != "" {
+		attrs = append(attrs, slog.String("promql", decision.PromQL))
+	}
+
+	if decision.Trigger == TriggerSchedule {
+		attrs = append(attrs,
+			slog.String("restartSchedule", decision.RestartSchedule),
+			slog.String("tz", decision.TZ),
+		)
+	}
+
+	if decision.DueAt != nil {
+		attrs = append(attrs, slog.String("dueAt", decision.DueAt.Format(time.RFC3339)))
+	}
+
+	return attrs
+}
@@ -0,0 +1,71 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+)
+
+// recordMetricsLookup counts the pod as needing metrics when its threshold check looked up its memory
+// usage, and as fetched when the usage was found.
+func (r *reconcileRun) recordMetricsLookup(check thresholdCheck, err error) {
+	var needed, fetched bool
+
+	switch {
+	case errors.Is(err, ErrGetPodMetrics):
+		needed = true
+	case err != nil:
+	case check.skipReason == SkipReasonMetricsMissing:
+		needed = true
+	case !check.usage.IsZero():
+		needed, fetched = true, true
+	}
+
+	if !needed {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.metricsNeeded++
+
+	if fetched {
+		r.metricsFetched++
+	}
+}
+
+// trackMetricsBlindness counts the consecutive reconciles that needed pod metrics but could fetch none,
+// e.g. because metrics-server is down. Reconciles without pods needing metrics leave the count as is.
+func (s *Service) trackMetricsBlindness(ctx context.Context, logger *slog.Logger, run *reconcileRun) {
+	if run.metricsNeeded == 0 {
+		return
+	}
+
+	if run.metricsFetched > 0 {
+		s.metricsBlindReconciles.Store(0)
+
+		return
+	}
+
+	blind := s.metricsBlindReconciles.Add(1)
+	logger.WarnContext(ctx, "no pod metrics could be fetched",
+		"pods", run.metricsNeeded,
+		"consecutiveReconciles", blind,
+	)
+}
+
+// metricsBlindnessError returns ErrMetricsBlind once the consecutive reconciles without pod metrics
+// reach the configured limit, nil otherwise or when the limit is disabled.
+func (s *Service) metricsBlindnessError() error {
+	if s.metricsBlindLimit <= 0 {
+		return nil
+	}
+
+	if blind := s.metricsBlindReconciles.Load(); blind >= int64(s.metricsBlindLimit) {
+		return fmt.Errorf("%w: %d consecutive reconciles", ErrMetricsBlind, blind)
+	}
+
+	return nil
+}
@@ -0,0 +1,15 @@
+package controller
+
+import (
+	"context"
+)
+
+// DropCachesCommand drops the per-pod caches rebuilt by the next reconciles: the memory usage
+// history and the enrolled pods. Called by the memory guard when the controller itself runs short
+// of memory; trends and /-/pods are incomplete until the caches fill again.
+func (s *Service) DropCachesCommand(ctx context.Context) {
+	s.usageHistory.clear()
+	s.rememberEnrolledPods(nil)
+
+	s.logger.InfoContext(ctx, "per-pod caches dropped")
+}
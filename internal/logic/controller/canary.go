@@ -0,0 +1,226 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/skillcoder/preoomkiller-controller/internal/infra/metrics"
+)
+
+// canaryResult is the outcome of a canary eviction.
+type canaryResult int
+
+const (
+	// canarySkipped means the canary pod was not evicted (e.g. an eviction guard blocked it);
+	// another replica of the batch becomes the canary.
+	canarySkipped canaryResult = iota
+	canaryPassed
+	canaryFailed
+)
+
+// canaryBatch tracks the canary of one scheduled restart of an owner's replicas.
+type canaryBatch struct {
+	startedAt time.Time
+	done      chan struct{}
+	// result is written before done is closed.
+	result canaryResult
+}
+
+// runCanaryEviction evicts the first replica of an owner's scheduled restart as a canary and soaks it.
+// The remaining replicas of the batch wait for the canary: they are evicted when it passes and
+// rescheduled to the next schedule run when it fails. Returns true when the service shut down before
+// the pod was evicted.
+func (s *Service) runCanaryEviction(logger *slog.Logger, pod Pod) bool {
+	if pod.Owner == nil {
+		return s.evictScheduledReplica(logger, pod)
+	}
+
+	for {
+		batch, isCanary := s.joinCanaryBatch(pod.Owner.UID, time.Now())
+		if isCanary {
+			s.finishCanaryBatch(pod.Owner.UID, batch, s.runCanary(logger, pod))
+
+			return false
+		}
+
+		select {
+		case <-batch.done:
+		case <-s.stopCh:
+		}
+
+		// The canary's soak ends early on shutdown: its result does not count.
+		if s.inShutdown.Load() {
+			return true
+		}
+
+		switch batch.result {
+		case canaryPassed:
+			return s.evictScheduledReplica(logger, pod)
+		case canaryFailed:
+			s.skipAbortedBatch(logger, pod)
+
+			return false
+		case canarySkipped:
+		}
+	}
+}
+
+// joinCanaryBatch returns the owner's current batch, or starts a new one with the caller as canary.
+// Replicas of one scheduled restart fire within the restart spread plus jitter, so a batch covers
+// evictions starting within that window plus the soak period.
+func (s *Service) joinCanaryBatch(ownerUID string, now time.Time) (*canaryBatch, bool) {
+	s.workloadMu.Lock()
+	defer s.workloadMu.Unlock()
+
+	window := s.restartSpread + s.jitterMax + s.canarySoak
+
+	if batch, ok := s.canaryBatches[ownerUID]; ok && now.Sub(batch.startedAt) <= window {
+		return batch, false
+	}
+
+	batch := &canaryBatch{startedAt: now, done: make(chan struct{})}
+	s.canaryBatches[ownerUID] = batch
+
+	return batch, true
+}
+
+func (s *Service) finishCanaryBatch(ownerUID string, batch *canaryBatch, result canaryResult) {
+	s.workloadMu.Lock()
+	defer s.workloadMu.Unlock()
+
+	if result == canarySkipped {
+		delete(s.canaryBatches, ownerUID)
+	}
+
+	batch.result = result
+	close(batch.done)
+}
+
+// runCanary evicts the canary and soaks its replacement.
+func (s *Service) runCanary(logger *slog.Logger, pod Pod) canaryResult {
+	// Pod creation timestamps have second precision.
+	evictedAt := time.Now().Truncate(time.Second)
+
+	if !s.executeScheduledEviction(logger, pod.Namespace, pod.Name, &pod) {
+		return canarySkipped
+	}
+
+	logger = logger.With("pod", pod.Name, "namespace", pod.Namespace, "owner", pod.Owner.Name)
+	logger.Info("canary evicted, soaking replacement", "soak", s.canarySoak)
+
+	if s.soakReplacement(logger, pod, evictedAt) {
+		logger.Info("canary passed, evicting remaining replicas")
+
+		return canaryPassed
+	}
+
+	if s.inShutdown.Load() {
+		logger.Info("shutting down during canary soak, handing off remaining replicas")
+
+		return canarySkipped
+	}
+
+	logger.Error("canary failed, aborting scheduled restart of remaining replicas")
+	metrics.RecordCanaryAborted(s.cluster, pod.Namespace, pod.Owner.Kind, pod.Owner.Name)
+
+	return canaryFailed
+}
+
+// soakReplacement watches the owner's pods created after evictedAt for the soak period.
+// It fails as soon as a replacement is in CrashLoopBackOff and passes when a replacement
+// is Ready at the end of the soak.
+func (s *Service) soakReplacement(logger *slog.Logger, evicted Pod, evictedAt time.Time) bool {
+	soak := time.NewTimer(s.canarySoak)
+	defer soak.Stop()
+
+	ticker := time.NewTicker(_replacementPollInterval)
+	defer ticker.Stop()
+
+	for {
+		final := false
+
+		select {
+		case <-s.stopCh:
+			return false
+		case <-soak.C:
+			final = true
+		case <-ticker.C:
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), _evictionTimeout)
+		pods, err := s.repo.ListOwnerPodsQuery(ctx, evicted.Namespace, *evicted.Owner)
+
+		cancel()
+
+		if err != nil {
+			logger.Warn("list owner pods for canary soak failed", "reason", err)
+
+			if final {
+				return false
+			}
+
+			continue
+		}
+
+		healthy := false
+
+		for i := range pods {
+			if pods[i].CreatedAt.Before(evictedAt) {
+				continue
+			}
+
+			if pods[i].CrashLoopBackOff {
+				return false
+			}
+
+			healthy = healthy || !pods[i].NotReady
+		}
+
+		if final {
+			return healthy
+		}
+	}
+}
+
+// evictScheduledReplica evicts a replica whose batch was not aborted. Returns true when the service
+// shut down before the pod was evicted.
+func (s *Service) evictScheduledReplica(logger *slog.Logger, pod Pod) bool {
+	if s.serialRestart {
+		return s.evictSerialized(logger, pod)
+	}
+
+	// The pod may have changed while waiting for the canary; let evictPodCommand re-fetch it.
+	s.executeScheduledEviction(logger, pod.Namespace, pod.Name, nil)
+
+	return false
+}
+
+// skipAbortedBatch moves the pod's restart-at annotation to its next scheduled restart, computed
+// like a reconcile does, so the aborted restart is not retried through the missed-eviction path.
+func (s *Service) skipAbortedBatch(logger *slog.Logger, pod Pod) {
+	ctx, cancel := context.WithTimeout(context.Background(), _evictionTimeout)
+	defer cancel()
+
+	logger = logger.With("pod", pod.Name, "namespace", pod.Namespace)
+
+	staggerOffset := s.ownerStaggerOffsets(ctx, logger, &pod)[pod.Namespace+"/"+pod.Name]
+
+	nextRun, err := s.scheduledRestartAt(ctx, logger, &pod, staggerOffset)
+	if err != nil {
+		logger.WarnContext(ctx, "invalid restart schedule", "reason", err)
+
+		return
+	}
+
+	restartAtValue := nextRun.Format(time.RFC3339)
+
+	logger.WarnContext(ctx, "canary failed, skipping scheduled restart until next run",
+		"restartAt", restartAtValue,
+		"staggerOffset", staggerOffset,
+	)
+
+	if err := s.setRestartAt(ctx, logger, &pod, restartAtValue); err != nil {
+		logger.ErrorContext(ctx, "set restart-at annotation", "reason", err)
+	}
+}
@@ -6,6 +6,42 @@ const (
 	PreoomkillerAnnotationRestartScheduleKey = "preoomkiller.beta.k8s.skillcoder.com/restart-schedule"
 	PreoomkillerAnnotationTZKey              = "preoomkiller.beta.k8s.skillcoder.com/tz"
 	PreoomkillerAnnotationRestartAtKey       = "preoomkiller.beta.k8s.skillcoder.com/restart-at"
+	// PreoomkillerAnnotationRestartAtSpecKey records the schedule restart-at was computed from, so restart-at is
+	// recomputed when the schedule changes.
+	PreoomkillerAnnotationRestartAtSpecKey = "preoomkiller.beta.k8s.skillcoder.com/restart-at-spec"
+	// PreoomkillerAnnotationRestartWindowKey is an alternative to restart-schedule: a daily "HH:MM-HH:MM" window
+	// in which each pod restarts at a random time.
+	PreoomkillerAnnotationRestartWindowKey = "preoomkiller.beta.k8s.skillcoder.com/restart-window"
+	// PreoomkillerAnnotationSkipDatesKey lists dates ("2025-12-24,2025-12-31") on which scheduled restarts are deferred
+	// to the next occurrence.
+	PreoomkillerAnnotationSkipDatesKey = "preoomkiller.beta.k8s.skillcoder.com/skip-dates"
+	// PreoomkillerAnnotationPromQLKey holds a PromQL condition; the pod is evicted while it returns a result.
+	// $POD and $NAMESPACE are replaced with the pod name and namespace.
+	PreoomkillerAnnotationPromQLKey = "preoomkiller.beta.k8s.skillcoder.com/promql"
+	// PreoomkillerAnnotationContainerAggregationKey overrides how container usages combine into the pod value:
+	// "sum", "max" or "named:<container>".
+	PreoomkillerAnnotationContainerAggregationKey = "preoomkiller.beta.k8s.skillcoder.com/container-aggregation"
+	// PreoomkillerAnnotationRisingForKey holds a duration ("30m"): a pod above its memory threshold is only evicted
+	// when its usage also rose monotonically over that duration.
+	PreoomkillerAnnotationRisingForKey = "preoomkiller.beta.k8s.skillcoder.com/rising-for"
+	// PreoomkillerAnnotationLastOOMAtKey records the last OOMKilled termination already accounted for.
+	PreoomkillerAnnotationLastOOMAtKey = "preoomkiller.beta.k8s.skillcoder.com/last-oom-at"
+	// PreoomkillerAnnotationLastObservedUsageKey records the memory usage and threshold of the last check
+	// ("950Mi/1Gi"), for app teams without access to the controller logs.
+	PreoomkillerAnnotationLastObservedUsageKey = "preoomkiller.beta.k8s.skillcoder.com/last-observed-usage"
+	// PreoomkillerAnnotationLastCheckedAtKey records when last-observed-usage was written.
+	PreoomkillerAnnotationLastCheckedAtKey = "preoomkiller.beta.k8s.skillcoder.com/last-checked-at"
+	// PreoomkillerAnnotationEvictedReasonKey is written just before the eviction with its trigger and detail
+	// ("threshold: memory usage 950Mi exceeded threshold 900Mi"), so the terminating pod carries the reason.
+	PreoomkillerAnnotationEvictedReasonKey = "preoomkiller.beta.k8s.skillcoder.com/evicted-reason"
+	// PreoomkillerAnnotationDefaultMemoryThresholdKey on a Namespace is the memory threshold of its pods without one.
+	PreoomkillerAnnotationDefaultMemoryThresholdKey = "preoomkiller.beta.k8s.skillcoder.com/default-memory-threshold"
+	// PreoomkillerAnnotationDefaultRestartScheduleKey on a Namespace is the restart schedule of its pods without a
+	// restart-schedule or restart-window.
+	PreoomkillerAnnotationDefaultRestartScheduleKey = "preoomkiller.beta.k8s.skillcoder.com/default-restart-schedule"
+
+	// memoryThresholdLimitPrefix starts a memory threshold given as a headroom below the memory limit ("limit-128Mi").
+	memoryThresholdLimitPrefix = "limit-"
 
 	// percentScale is the divisor for percentage values (e.g. 80% -> 80/100).
 	percentScale = 100
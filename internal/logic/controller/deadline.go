@@ -0,0 +1,42 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/skillcoder/preoomkiller-controller/internal/infra/metrics"
+)
+
+// WithReconcileTimeout bounds each reconcile of RunCommand by timeout, so that a stuck reconcile, e.g.
+// on a hung API call, does not hold up the following ones. A reconcile that overruns is cancelled and
+// fails the controller pinger until a reconcile finishes in time.
+func WithReconcileTimeout(timeout time.Duration) Option {
+	return func(s *Service) {
+		s.reconcileTimeout = timeout
+	}
+}
+
+// reconcileWithDeadline runs ReconcileCommand bounded by the reconcile timeout, if any, and records
+// whether it overran.
+func (s *Service) reconcileWithDeadline(ctx context.Context, logger *slog.Logger) error {
+	if s.reconcileTimeout <= 0 {
+		return s.ReconcileCommand(ctx)
+	}
+
+	reconcileCtx, cancel := context.WithTimeout(ctx, s.reconcileTimeout)
+	defer cancel()
+
+	err := s.ReconcileCommand(reconcileCtx)
+
+	overran := ctx.Err() == nil && errors.Is(reconcileCtx.Err(), context.DeadlineExceeded)
+	if overran {
+		logger.WarnContext(ctx, "reconcile exceeded its deadline, cancelled", "timeout", s.reconcileTimeout)
+		metrics.RecordReconcileOverrun(s.cluster)
+	}
+
+	s.reconcileOverran.Store(overran)
+
+	return err
+}
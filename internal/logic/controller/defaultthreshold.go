@@ -0,0 +1,58 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"maps"
+)
+
+// WithDefaultMemoryThreshold applies the memory threshold, in the syntax of the memory-threshold
+// annotation (e.g. "90%"), to the selected pods that have a memory limit but neither their own
+// threshold nor a namespace default.
+func WithDefaultMemoryThreshold(threshold string) Option {
+	return func(s *Service) {
+		s.defaultMemoryThreshold = threshold
+	}
+}
+
+// ValidateMemoryThreshold returns an error wrapping ErrMemoryThresholdParse when threshold is not a
+// valid memory-threshold annotation value. Values relative to the memory limit are only checked for
+// their syntax.
+func ValidateMemoryThreshold(threshold string) error {
+	pod := Pod{Annotations: map[string]string{PreoomkillerAnnotationMemoryThresholdKey: threshold}}
+
+	_, err := resolveMemoryThreshold(
+		context.Background(),
+		slog.New(slog.DiscardHandler),
+		pod,
+		PreoomkillerAnnotationMemoryThresholdKey,
+		nil,
+	)
+	if errors.Is(err, ErrMemoryLimitNotDefined) {
+		return nil
+	}
+
+	return err
+}
+
+// applyDefaultMemoryThreshold sets the default memory threshold on the pod when it has a memory limit
+// but no threshold.
+func (s *Service) applyDefaultMemoryThreshold(pod *Pod) {
+	if s.defaultMemoryThreshold == "" || pod.MemoryLimit == nil || pod.MemoryLimit.IsZero() {
+		return
+	}
+
+	if _, ok := pod.Annotations[s.annotationMemoryThresholdKey]; ok {
+		return
+	}
+
+	// Listed pods may share their annotations with the caller.
+	annotations := maps.Clone(pod.Annotations)
+	if annotations == nil {
+		annotations = make(map[string]string, 1)
+	}
+
+	annotations[s.annotationMemoryThresholdKey] = s.defaultMemoryThreshold
+	pod.Annotations = annotations
+}
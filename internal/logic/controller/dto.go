@@ -6,20 +6,44 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 )
 
+// Owner identifies the controlling owner (workload) of a pod, e.g. a ReplicaSet or StatefulSet.
+type Owner struct {
+	APIVersion string
+	Kind       string
+	Name       string
+	UID        string
+}
+
 // Pod represents a Kubernetes pod in the domain layer.
 type Pod struct {
 	Name        string
 	Namespace   string
+	UID         string
 	Annotations map[string]string
+	// Owner is the controlling owner reference; nil for bare pods.
+	Owner *Owner
 	// MemoryLimit is the sum of all container memory limits; nil when no container sets a limit.
 	MemoryLimit *resource.Quantity
+	// ContainerMemoryLimits maps container names to their memory limits; containers without a limit are absent.
+	ContainerMemoryLimits map[string]resource.Quantity
 	// CreatedAt is the pod creation timestamp; used to detect missed scheduled restarts after controller downtime.
 	CreatedAt time.Time
+	// LastOOMKilledAt is the most recent time any container was terminated with reason OOMKilled; nil when never.
+	LastOOMKilledAt *time.Time
+	// CrashLoopBackOff is true when any container is waiting in CrashLoopBackOff.
+	CrashLoopBackOff bool
+	// NotReady is true when the pod Ready condition is not True.
+	NotReady bool
+	// Terminating is true when the pod has a deletion timestamp; it may still report Ready while shutting down.
+	Terminating bool
 }
 
 // PodMetrics represents pod metrics in the domain layer.
 type PodMetrics struct {
+	// MemoryUsage is the sum of the container usages.
 	MemoryUsage *resource.Quantity
+	// Containers holds per-container usage; empty when the source only reports pod totals.
+	Containers []ContainerMetrics
 }
 
 // ContainerMetrics represents container metrics in the domain layer.
@@ -28,3 +52,273 @@ type ContainerMetrics struct {
 	MemoryUsage *resource.Quantity
 	CPUUsage    *resource.Quantity
 }
+
+// EvictionTrigger identifies which feature wants a pod evicted.
+type EvictionTrigger string
+
+const (
+	// TriggerThreshold is a memory-threshold eviction.
+	TriggerThreshold EvictionTrigger = "threshold"
+	// TriggerSchedule is a scheduled restart.
+	TriggerSchedule EvictionTrigger = "schedule"
+	// TriggerPromQL is an eviction requested by the pod's PromQL condition.
+	TriggerPromQL EvictionTrigger = "promql"
+	// TriggerManual is an eviction requested by an operator through the admin API.
+	TriggerManual EvictionTrigger = "manual"
+)
+
+// Outcome is what the controller did about a pod one of its triggers wanted evicted.
+type Outcome string
+
+const (
+	// OutcomeEvicted means the pod was evicted.
+	OutcomeEvicted Outcome = "evicted"
+	// OutcomeSkipped means an eviction guard prevented the eviction.
+	OutcomeSkipped Outcome = "skipped"
+	// OutcomeDeferred means the eviction happens later.
+	OutcomeDeferred Outcome = "deferred"
+	// OutcomeFailed means the eviction failed with an error; a later run retries it.
+	OutcomeFailed Outcome = "failed"
+)
+
+// Reasons of deferred evictions; skipped evictions carry their SkipReason.
+const (
+	// DeferReasonRestartScheduled means a restart-schedule eviction was scheduled for a later time.
+	DeferReasonRestartScheduled = "restart_scheduled"
+	// DeferReasonEvictionRefused means the eviction API refused the eviction (e.g. by a
+	// PodDisruptionBudget); a later run retries it.
+	DeferReasonEvictionRefused = "eviction_refused"
+)
+
+// EvictionDecision is an eviction decision about a pod and its policy inputs, as written to the
+// audit log and sent to notifiers.
+type EvictionDecision struct {
+	Time time.Time
+	// Cluster is the cluster name set with WithCluster; empty in single-cluster mode.
+	Cluster   string
+	Outcome   Outcome
+	Trigger   EvictionTrigger
+	Namespace string
+	Pod       string
+	PodUID    string
+	Owner     *Owner
+	// Reason explains a skipped, deferred or failed eviction.
+	Reason string
+	// Detail describes what triggered a non-scheduled eviction, e.g. the breached threshold.
+	Detail string
+	// MemoryUsage, MemoryThreshold and MemoryThresholdAnnotation are set for threshold evictions.
+	MemoryUsage               *resource.Quantity
+	MemoryThreshold           *resource.Quantity
+	MemoryThresholdAnnotation string
+	// PromQL is the condition of PromQL evictions.
+	PromQL string
+	// RestartSchedule and TZ are set for scheduled evictions.
+	RestartSchedule string
+	TZ              string
+	// DueAt is when a deferred scheduled eviction is due.
+	DueAt *time.Time
+}
+
+// MissedOOM is an OOMKilled container termination that happened before the controller evicted the pod.
+type MissedOOM struct {
+	// Cluster is the cluster name set with WithCluster; empty in single-cluster mode.
+	Cluster     string
+	Namespace   string
+	Pod         string
+	PodUID      string
+	Owner       *Owner
+	OOMKilledAt time.Time
+	// MemoryThresholdAnnotation is the pod's memory threshold annotation; empty without one.
+	MemoryThresholdAnnotation string
+}
+
+// VPARecommendation is the state of a VerticalPodAutoscaler targeting a pod's workload.
+type VPARecommendation struct {
+	Name string
+	// UpdateMode is the VPA update mode, e.g. "Auto", "Recreate" or "Off".
+	UpdateMode string
+	// MemoryUpperBound is the sum of the containers' recommended memory upper bounds; nil without a recommendation.
+	MemoryUpperBound *resource.Quantity
+}
+
+// RestartRecord describes the last eviction of a workload's pod.
+type RestartRecord struct {
+	Namespace string
+	// OwnerKind and OwnerName identify the workload; bare pods are recorded as kind "Pod".
+	OwnerKind string
+	OwnerName string
+	Pod       string
+	Reason    EvictionTrigger
+	At        time.Time
+}
+
+// DecisionAction is the outcome of evaluating a pod.
+type DecisionAction string
+
+const (
+	// ActionNone means the pod is within its limits; nothing to do.
+	ActionNone DecisionAction = "none"
+	// ActionEvict means the pod is (or would be) evicted now.
+	ActionEvict DecisionAction = "evict"
+	// ActionSchedule means a restart is (or would be) scheduled for later.
+	ActionSchedule DecisionAction = "schedule"
+	// ActionSkip means the pod would qualify for action but is skipped; see SkipReason.
+	ActionSkip DecisionAction = "skip"
+)
+
+// SkipReason explains why a pod was not evicted or could not be evaluated.
+type SkipReason string
+
+const (
+	SkipReasonPodTooYoung       SkipReason = "pod_too_young"
+	SkipReasonCrashLoopBackOff  SkipReason = "crash_loop_backoff"
+	SkipReasonNotReady          SkipReason = "not_ready"
+	SkipReasonReadyReplicas     SkipReason = "insufficient_ready_replicas"
+	SkipReasonWorkloadSuspended SkipReason = "workload_suspended"
+	SkipReasonBlackout          SkipReason = "blackout"
+	SkipReasonNoMemoryLimit     SkipReason = "no_memory_limit"
+	SkipReasonZeroThreshold     SkipReason = "zero_threshold"
+	SkipReasonMetricsMissing    SkipReason = "metrics_missing"
+	SkipReasonInvalidThreshold  SkipReason = "invalid_threshold"
+	SkipReasonInvalidSchedule   SkipReason = "invalid_schedule"
+	SkipReasonMetricsError      SkipReason = "metrics_error"
+	SkipReasonVPAManaged        SkipReason = "vpa_managed"
+	SkipReasonNotRising         SkipReason = "not_rising"
+	SkipReasonInvalidRisingFor  SkipReason = "invalid_rising_for"
+	// SkipReasonInvalidContainerAggregation means the container-aggregation annotation is invalid.
+	SkipReasonInvalidContainerAggregation SkipReason = "invalid_container_aggregation"
+	// SkipReasonPDBBlocked means the eviction API refused the eviction with 429, e.g. by a PodDisruptionBudget.
+	SkipReasonPDBBlocked SkipReason = "pdb_blocked"
+	// SkipReasonPromQLNotConfigured means the pod has a PromQL condition but no Prometheus server is configured.
+	SkipReasonPromQLNotConfigured SkipReason = "promql_not_configured"
+)
+
+// Decision describes what the controller would do with a pod for one trigger.
+type Decision struct {
+	// Cluster is the cluster name set with WithCluster; empty in single-cluster mode.
+	Cluster         string
+	Namespace       string
+	Name            string
+	Trigger         EvictionTrigger
+	Action          DecisionAction
+	SkipReason      SkipReason
+	MemoryUsage     *resource.Quantity
+	MemoryThreshold *resource.Quantity
+	RestartAt       *time.Time
+}
+
+// PendingEviction is a persisted pending scheduled eviction.
+type PendingEviction struct {
+	Namespace string
+	Name      string
+	// At is the fire time, including jitter and blackout deferral.
+	At time.Time
+}
+
+// EvictionRequestResult is the decision about an eviction requested through the admin API.
+type EvictionRequestResult struct {
+	Outcome Outcome
+	// Reason explains a skipped, deferred or failed eviction.
+	Reason string
+}
+
+// ScheduledEviction is a pending scheduled eviction armed in the controller.
+type ScheduledEviction struct {
+	Cluster   string
+	Namespace string
+	Name      string
+	// RestartAt is the restart-at annotation the eviction was scheduled for; zero when unknown.
+	RestartAt time.Time
+	// FireAt is when the eviction runs: RestartAt plus jitter, deferred past blackout windows.
+	FireAt time.Time
+}
+
+// SuspendedWorkload is a workload whose evictions are suspended because no replacement of an evicted
+// pod became Ready within the verification timeout.
+type SuspendedWorkload struct {
+	Cluster   string
+	Namespace string
+	Owner     Owner
+	// Pod is the evicted pod whose replacement did not become Ready.
+	Pod         string
+	SuspendedAt time.Time
+}
+
+// TightenedThreshold is the memory threshold of a workload lowered after one of its pods was OOMKilled.
+type TightenedThreshold struct {
+	Namespace string
+	// Owner is the controlling owner of the workload's pods; a pod without owner is its own owner, of
+	// kind Pod.
+	Owner     Owner
+	Threshold resource.Quantity
+	At        time.Time
+}
+
+// EnrolledPod is a pod selected by the controller.
+type EnrolledPod struct {
+	Cluster   string
+	Namespace string
+	Name      string
+	// MemoryThreshold is the memory-threshold annotation, including defaults; empty when unset.
+	MemoryThreshold string
+	// RestartSchedule is the restart-schedule or restart-window annotation; empty when unset.
+	RestartSchedule string
+	// RestartAt is the restart-at annotation; zero when unset.
+	RestartAt time.Time
+	// MemoryUsage is the usage of the last threshold check; nil before the first check.
+	MemoryUsage *resource.Quantity
+	// EffectiveThreshold is the threshold MemoryUsage was compared against, after percentages, VPA
+	// and OOM tightening; nil before the first check.
+	EffectiveThreshold *resource.Quantity
+	// CheckedAt is when MemoryUsage was checked.
+	CheckedAt time.Time
+	// SuspendedAt is when evictions of the pod's workload were suspended; zero when they are not.
+	SuspendedAt time.Time
+}
+
+// EventType is the type of a Kubernetes Event.
+type EventType string
+
+const (
+	EventTypeNormal  EventType = "Normal"
+	EventTypeWarning EventType = "Warning"
+)
+
+// Event reasons reported on pods and their owners.
+const (
+	// EventReasonPreOOMEvicted means the pod was evicted by its memory threshold or PromQL condition.
+	EventReasonPreOOMEvicted = "PreOOMEvicted"
+	// EventReasonPreOOMScheduledRestart means the pod was evicted by its restart schedule.
+	EventReasonPreOOMScheduledRestart = "PreOOMScheduledRestart"
+
+	// EventReasonEvictionsSuspended means evictions of the workload are suspended because no replacement
+	// of an evicted pod became Ready.
+	EventReasonEvictionsSuspended = "EvictionsSuspended"
+	// EventReasonEvictionsResumed means suspended evictions of the workload were resumed through the admin API.
+	EventReasonEvictionsResumed = "EvictionsResumed"
+	// EventReasonRestartTooFrequent means a scheduled restart was deferred by the minimum restart interval.
+	EventReasonRestartTooFrequent = "RestartTooFrequent"
+	// EventReasonInvalidMemoryThreshold means the memory threshold annotation cannot be parsed.
+	EventReasonInvalidMemoryThreshold = "InvalidMemoryThreshold"
+	// EventReasonMissingMemoryLimit means the memory threshold is relative to the memory limit but the pod has no
+	// memory limit.
+	EventReasonMissingMemoryLimit = "MissingMemoryLimit"
+	// EventReasonInvalidRestartSchedule means the restart schedule (or its window or skip dates) cannot be evaluated.
+	EventReasonInvalidRestartSchedule = "InvalidRestartSchedule"
+	// EventReasonInvalidRisingFor means the rising-for annotation cannot be parsed or is longer than the usage history covers.
+	EventReasonInvalidRisingFor = "InvalidRisingFor"
+	// EventReasonInvalidContainerAggregation means the container-aggregation annotation is not sum, max or
+	// named:<container>.
+	EventReasonInvalidContainerAggregation = "InvalidContainerAggregation"
+	// EventReasonInvalidTimezone means the tz annotation is not a valid IANA time zone and UTC is used instead.
+	EventReasonInvalidTimezone = "InvalidTimezone"
+	// EventReasonPromQLNotConfigured means the pod has a PromQL condition but no Prometheus server is configured.
+	EventReasonPromQLNotConfigured = "PromQLNotConfigured"
+)
+
+// PodEvent is a Kubernetes Event reported on a pod or its owner.
+type PodEvent struct {
+	Type    EventType
+	Reason  string
+	Message string
+}
@@ -0,0 +1,55 @@
+package controller
+
+import (
+	"cmp"
+	"slices"
+)
+
+// rememberEnrolledPods keeps the pods listed by the reconcile for EnrolledPodsQuery.
+func (s *Service) rememberEnrolledPods(pods []Pod) {
+	s.enrolledMu.Lock()
+	s.enrolledPods = pods
+	s.enrolledMu.Unlock()
+}
+
+// EnrolledPodsQuery returns the pods selected by the last reconcile with their eviction settings and
+// last checked memory usage, sorted by namespace and name.
+func (s *Service) EnrolledPodsQuery() []EnrolledPod {
+	s.enrolledMu.Lock()
+	pods := s.enrolledPods
+	s.enrolledMu.Unlock()
+
+	enrolled := make([]EnrolledPod, 0, len(pods))
+
+	for i := range pods {
+		pod := &pods[i]
+		spec, _ := s.restartSpec(pod)
+
+		e := EnrolledPod{
+			Cluster:         s.cluster,
+			Namespace:       pod.Namespace,
+			Name:            pod.Name,
+			MemoryThreshold: pod.Annotations[s.annotationMemoryThresholdKey],
+			RestartSchedule: spec,
+			RestartAt:       s.podRestartAt(pod),
+			SuspendedAt:     s.workloadSuspendedAt(pod),
+		}
+
+		if history := s.usageHistory.history(pod.UID); len(history) > 0 {
+			last := history[len(history)-1]
+			e.MemoryUsage, e.CheckedAt = &last.usage, last.at
+
+			if !last.threshold.IsZero() {
+				e.EffectiveThreshold = &last.threshold
+			}
+		}
+
+		enrolled = append(enrolled, e)
+	}
+
+	slices.SortFunc(enrolled, func(a, b EnrolledPod) int {
+		return cmp.Or(cmp.Compare(a.Namespace, b.Namespace), cmp.Compare(a.Name, b.Name))
+	})
+
+	return enrolled
+}
@@ -3,8 +3,23 @@ package controller
 import "errors"
 
 var (
-	ErrMemoryThresholdParse  = errors.New("parse memory threshold")
-	ErrMemoryLimitNotDefined = errors.New("memory limit not defined")
-	ErrGetPodMetrics         = errors.New("get pod metrics")
-	ErrEvictPod              = errors.New("evict pod")
+	ErrMemoryThresholdParse      = errors.New("parse memory threshold")
+	ErrRestartWindowParse        = errors.New("parse restart window")
+	ErrSkipDatesParse            = errors.New("parse skip dates")
+	ErrContainerAggregationParse = errors.New("parse container aggregation")
+	ErrRisingForParse            = errors.New("parse rising-for")
+	ErrRisingForUncovered        = errors.New("rising-for not covered by the usage history")
+	ErrMemoryLimitNotDefined     = errors.New("memory limit not defined")
+	ErrGetPodMetrics             = errors.New("get pod metrics")
+	ErrEvaluatePromQL            = errors.New("evaluate promql condition")
+	ErrEvictPod                  = errors.New("evict pod")
+	ErrListOwnerPods             = errors.New("list owner pods")
+	ErrReconcilePodsFailed       = errors.New("reconcile pods failed")
+	ErrPodNotFound               = errors.New("pod not found")
+	ErrPodNotSelected            = errors.New("pod is not selected by the controller")
+	ErrNoPendingEviction         = errors.New("no pending scheduled eviction")
+	ErrWorkloadNotSuspended      = errors.New("workload evictions are not suspended")
+	ErrReconcileOverrun          = errors.New("last reconcile exceeded its deadline")
+	ErrMetricsBlind              = errors.New("no pod metrics could be fetched")
+	ErrReadinessGate             = errors.New("waiting for a reconcile listing the pods and their metrics")
 )
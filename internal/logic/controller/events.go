@@ -0,0 +1,111 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+// emitPodEvent reports an Event on the pod. Failures are logged only: Events are informational.
+func (s *Service) emitPodEvent(ctx context.Context, logger *slog.Logger, pod *Pod, event PodEvent) {
+	if err := s.repo.CreatePodEventCommand(ctx, *pod, event); err != nil {
+		logger.WarnContext(ctx, "create pod event failed",
+			"pod", pod.Name,
+			"namespace", pod.Namespace,
+			"eventReason", event.Reason,
+			"reason", err,
+		)
+	}
+}
+
+// emitEvictionEvent reports an eviction on the pod and its controlling owner, so that
+// "kubectl describe" explains the restart. detail describes what triggered a non-scheduled eviction.
+func (s *Service) emitEvictionEvent(
+	ctx context.Context,
+	logger *slog.Logger,
+	pod *Pod,
+	trigger EvictionTrigger,
+	detail string,
+) {
+	event := PodEvent{
+		Type:    EventTypeWarning,
+		Reason:  EventReasonPreOOMEvicted,
+		Message: fmt.Sprintf("evicted pod %s: %s", pod.Name, detail),
+	}
+
+	if trigger == TriggerSchedule {
+		event = PodEvent{
+			Type:    EventTypeNormal,
+			Reason:  EventReasonPreOOMScheduledRestart,
+			Message: fmt.Sprintf("evicted pod %s: %s", pod.Name, s.scheduleEventDetail(pod)),
+		}
+	}
+
+	s.emitPodEvent(ctx, logger, pod, event)
+
+	if pod.Owner != nil {
+		s.emitOwnerEvent(ctx, logger, pod.Namespace, *pod.Owner, event)
+	}
+}
+
+// emitOwnerEvent reports an Event on a controlling owner. Failures are logged only: Events are informational.
+func (s *Service) emitOwnerEvent(ctx context.Context, logger *slog.Logger, namespace string, owner Owner, event PodEvent) {
+	if err := s.repo.CreateOwnerEventCommand(ctx, namespace, owner, event); err != nil {
+		logger.WarnContext(ctx, "create owner event failed",
+			"namespace", namespace,
+			"owner", owner.Kind+"/"+owner.Name,
+			"eventReason", event.Reason,
+			"reason", err,
+		)
+	}
+}
+
+// scheduleEventDetail describes the pod's restart schedule for the eviction Event.
+func (s *Service) scheduleEventDetail(pod *Pod) string {
+	spec, _ := s.restartSpec(pod)
+	detail := fmt.Sprintf("restart schedule %q (tz %s)", spec, s.restartTZ(pod))
+
+	if restartAt, ok := pod.Annotations[s.annotationRestartAtKey]; ok {
+		detail += " due at " + restartAt
+	}
+
+	return detail
+}
+
+// annotateEvictedReason writes the evicted-reason annotation before the pod is evicted, so tools
+// capturing the terminating pod and its logs see why it was evicted. A failure does not prevent the
+// eviction. Returns whether the annotation was written.
+func (s *Service) annotateEvictedReason(ctx context.Context, logger *slog.Logger, pod *Pod, record decisionRecord) bool {
+	detail := record.inputs.detail
+	if record.trigger == TriggerSchedule {
+		detail = s.scheduleEventDetail(pod)
+	}
+
+	if err := s.repo.SetAnnotationCommand(
+		ctx,
+		pod.Namespace,
+		pod.Name,
+		PreoomkillerAnnotationEvictedReasonKey,
+		string(record.trigger)+": "+detail,
+	); err != nil {
+		logger.WarnContext(ctx, "set evicted-reason annotation", "reason", err)
+
+		return false
+	}
+
+	return true
+}
+
+// clearEvictedReason removes the evicted-reason annotation of a pod whose eviction was refused or
+// failed, so that the still running pod does not claim to be evicted.
+func (s *Service) clearEvictedReason(ctx context.Context, logger *slog.Logger, pod *Pod) {
+	if err := s.repo.SetAnnotationCommand(
+		ctx,
+		pod.Namespace,
+		pod.Name,
+		PreoomkillerAnnotationEvictedReasonKey,
+		"",
+	); err != nil {
+		logger.WarnContext(ctx, "remove evicted-reason annotation", "reason", err)
+	}
+}
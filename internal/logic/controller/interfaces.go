@@ -19,19 +19,130 @@ type Repository interface {
 		name string,
 	) (Pod, error)
 
+	// PodSelectedQuery reports whether the pod is selected by the label selector, or by its annotations
+	// when pods are discovered by annotation; the error implements IsNotFound when there is no such pod.
+	PodSelectedQuery(
+		ctx context.Context,
+		namespace,
+		name,
+		labelSelector string,
+	) (bool, error)
+
+	// ListNamespaceAnnotationsQuery returns the annotations of all namespaces, keyed by namespace.
+	ListNamespaceAnnotationsQuery(ctx context.Context) (map[string]map[string]string, error)
+
+	// ListOwnerPodsQuery lists pods in the namespace controlled by the given owner.
+	ListOwnerPodsQuery(
+		ctx context.Context,
+		namespace string,
+		owner Owner,
+	) ([]Pod, error)
+
+	// RecordRestartCommand stores the record as the workload's last restart.
+	RecordRestartCommand(
+		ctx context.Context,
+		record RestartRecord,
+	) error
+
+	// SavePendingEvictionCommand persists the fire time of the pod's pending scheduled eviction.
+	SavePendingEvictionCommand(
+		ctx context.Context,
+		namespace,
+		name string,
+		at time.Time,
+	) error
+
+	// DeletePendingEvictionCommand removes the pod's persisted pending scheduled eviction.
+	DeletePendingEvictionCommand(
+		ctx context.Context,
+		namespace,
+		name string,
+	) error
+
+	// ListPendingEvictionsQuery returns all persisted pending scheduled evictions.
+	ListPendingEvictionsQuery(ctx context.Context) ([]PendingEviction, error)
+
+	// PruneRestartRecordsCommand removes the restart records, workload suspensions and tightened thresholds
+	// of workloads that no longer exist, and returns how many were removed.
+	PruneRestartRecordsCommand(ctx context.Context) (int, error)
+
+	// SuspendWorkloadCommand persists the suspension of the workload's evictions.
+	SuspendWorkloadCommand(
+		ctx context.Context,
+		workload SuspendedWorkload,
+	) error
+
+	// ResumeWorkloadCommand removes the persisted suspension of the owner's evictions.
+	ResumeWorkloadCommand(
+		ctx context.Context,
+		owner Owner,
+	) error
+
+	// ListSuspendedWorkloadsQuery returns all persisted workload suspensions.
+	ListSuspendedWorkloadsQuery(ctx context.Context) ([]SuspendedWorkload, error)
+
+	// SaveTightenedThresholdCommand persists the tightened memory threshold of the workload.
+	SaveTightenedThresholdCommand(
+		ctx context.Context,
+		threshold TightenedThreshold,
+	) error
+
+	// ListTightenedThresholdsQuery returns all persisted tightened memory thresholds.
+	ListTightenedThresholdsQuery(ctx context.Context) ([]TightenedThreshold, error)
+
+	// CreatePodEventCommand reports a Kubernetes Event on the pod.
+	CreatePodEventCommand(
+		ctx context.Context,
+		pod Pod,
+		event PodEvent,
+	) error
+
+	// CreateOwnerEventCommand reports a Kubernetes Event on the pod's controlling owner.
+	CreateOwnerEventCommand(
+		ctx context.Context,
+		namespace string,
+		owner Owner,
+		event PodEvent,
+	) error
+
+	// ListPodMetricsQuery lists metrics of pods matching the label selector, keyed by "namespace/name".
+	// An empty namespace lists all namespaces.
+	ListPodMetricsQuery(
+		ctx context.Context,
+		namespace,
+		labelSelector string,
+	) (map[string]*PodMetrics, error)
+
+	// ListVPARecommendationsQuery returns the VerticalPodAutoscalers of the namespace, keyed by the
+	// "kind/name" of the pod owners they apply to: their target workload and, for a Deployment, its ReplicaSets.
+	ListVPARecommendationsQuery(
+		ctx context.Context,
+		namespace string,
+	) (map[string]*VPARecommendation, error)
+
+	// EvaluatePromQLQuery evaluates a PromQL condition; it holds when the query returns a non-empty
+	// vector or a non-zero scalar.
+	EvaluatePromQLQuery(
+		ctx context.Context,
+		expr string,
+	) (bool, error)
+
 	GetPodMetricsQuery(
 		ctx context.Context,
 		namespace,
 		name string,
 	) (*PodMetrics, error)
 
+	// EvictPodCommand evicts the pod. A non-empty uid is an eviction precondition: when the pod was
+	// recreated under the same name in the meantime, nothing is evicted and a not-found error is returned.
 	EvictPodCommand(
 		ctx context.Context,
 		namespace,
-		name string,
+		name,
+		uid string,
 	) error
 
-	// SetAnnotationCommand sets (or removes when value is empty) a single annotation on the given pod via a merge-patch.
+	// SetAnnotationCommand sets (or removes when value is empty) a single annotation on the given pod.
 	SetAnnotationCommand(
 		ctx context.Context,
 		namespace,
@@ -39,6 +150,14 @@ type Repository interface {
 		key,
 		value string,
 	) error
+
+	// WatchPodAnnotationsQuery calls changed for each running pod whose value of one of the annotation
+	// keys changed, until ctx is done.
+	WatchPodAnnotationsQuery(
+		ctx context.Context,
+		keys []string,
+		changed func(namespace, name string),
+	) error
 }
 
 // scheduleParser computes the next cron occurrence. Implemented by infra/cronparser using go-cron.
@@ -46,6 +165,12 @@ type scheduleParser interface {
 	NextAfter(spec, tz string, after time.Time) (time.Time, error)
 }
 
+// blackoutPolicy reports controller-wide eviction blackout windows. Implemented by infra/blackout.
+type blackoutPolicy interface {
+	Active(t time.Time) bool
+	NextAllowed(t time.Time) time.Time
+}
+
 // notFound is a private interface for checking "not found" errors
 // without importing the adapter package.
 type notFound interface {
@@ -57,3 +182,26 @@ type notFound interface {
 type tooManyRequests interface {
 	IsTooManyRequests()
 }
+
+// notConfigured is a private interface for checking "not configured" errors
+// without importing the adapter package.
+type notConfigured interface {
+	IsNotConfigured()
+}
+
+// Notifier is the port for sending eviction decisions and missed OOMs to external systems
+// (webhooks, chat, alerting).
+type Notifier interface {
+	// Name identifies the notifier in logs.
+	Name() string
+
+	NotifyCommand(
+		ctx context.Context,
+		decision EvictionDecision,
+	) error
+
+	NotifyMissedOOMCommand(
+		ctx context.Context,
+		missed MissedOOM,
+	) error
+}
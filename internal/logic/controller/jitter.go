@@ -0,0 +1,24 @@
+package controller
+
+import (
+	"hash/fnv"
+	"math/rand"
+	"time"
+)
+
+// scheduleJitter returns the delay added to a scheduled eviction of the pod with the given UID,
+// uniformly distributed in [0, jitterMax]. With deterministic jitter the delay is derived from
+// the UID, so it stays the same across controller restarts.
+func (s *Service) scheduleJitter(uid string) time.Duration {
+	if s.deterministicJitter && uid != "" {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(uid))
+
+		//nolint:gosec // jitterMax is non-negative and the remainder is at most jitterMax.
+		return time.Duration(h.Sum64() % uint64(s.jitterMax+1))
+	}
+
+	// Jitter does not require cryptographic randomness.
+	// #nosec G404
+	return time.Duration(rand.Int63n(int64(s.jitterMax + 1)))
+}
@@ -0,0 +1,61 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// LabelSelectorSeparator separates alternative label selectors: a pod is selected when it matches
+// any of them. Within a selector, commas still mean AND.
+const LabelSelectorSeparator = ";"
+
+// SplitLabelSelectors returns the alternative selectors of a label selector, e.g.
+// "preoomkiller-enabled=true; preoomkiller.beta.k8s.skillcoder.com/enabled=true". Empty selectors
+// are dropped, unless all are empty: the empty selector selects all pods.
+func SplitLabelSelectors(labelSelector string) []string {
+	var selectors []string
+
+	for _, selector := range strings.Split(labelSelector, LabelSelectorSeparator) {
+		if selector = strings.TrimSpace(selector); selector != "" {
+			selectors = append(selectors, selector)
+		}
+	}
+
+	if len(selectors) == 0 {
+		return []string{""}
+	}
+
+	return selectors
+}
+
+// listSelectedPods lists the pods matching any of the label selectors, each pod once.
+func (s *Service) listSelectedPods(ctx context.Context) ([]Pod, error) {
+	selectors := SplitLabelSelectors(s.currentLabelSelector())
+	if len(selectors) == 1 {
+		return s.repo.ListPodsQuery(ctx, selectors[0])
+	}
+
+	var pods []Pod
+
+	listed := make(map[string]struct{})
+
+	for _, selector := range selectors {
+		selected, err := s.repo.ListPodsQuery(ctx, selector)
+		if err != nil {
+			return nil, fmt.Errorf("selector %q: %w", selector, err)
+		}
+
+		for i := range selected {
+			key := selected[i].Namespace + "/" + selected[i].Name
+			if _, ok := listed[key]; ok {
+				continue
+			}
+
+			listed[key] = struct{}{}
+			pods = append(pods, selected[i])
+		}
+	}
+
+	return pods, nil
+}
@@ -0,0 +1,132 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// manualEvictionDetail describes manual evictions in Events and decisions.
+const manualEvictionDetail = "requested through the admin API"
+
+// RequestEvictionCommand evicts the pod on an operator's request. Only pods selected by the controller
+// can be evicted: others fail with ErrPodNotSelected. The eviction guards of automatic evictions
+// (blackout windows, minimum pod age, unhealthy pods, minimum ready replicas) still apply, and the
+// decision is recorded like any other.
+func (s *Service) RequestEvictionCommand(ctx context.Context, namespace, name string) (EvictionRequestResult, error) {
+	logger := s.logger.With("pod", name, "namespace", namespace, "trigger", TriggerManual)
+
+	pod, err := s.getPod(ctx, namespace, name)
+	if err != nil {
+		var target notFound
+		if errors.As(err, &target) {
+			return EvictionRequestResult{}, ErrPodNotFound
+		}
+
+		return EvictionRequestResult{}, fmt.Errorf("get pod: %w", err)
+	}
+
+	if err := s.ensureSelected(ctx, namespace, name); err != nil {
+		logger.WarnContext(ctx, "manual eviction refused", "reason", err)
+
+		return EvictionRequestResult{}, err
+	}
+
+	logger.InfoContext(ctx, "manual eviction requested")
+
+	record, err := s.evictGuardedPod(ctx, logger, &pod, TriggerManual, evictionInputs{detail: manualEvictionDetail})
+	if err != nil {
+		return EvictionRequestResult{Outcome: record.outcome, Reason: record.reason}, err
+	}
+
+	if record.outcome == "" {
+		return EvictionRequestResult{}, ErrPodNotFound
+	}
+
+	return EvictionRequestResult{Outcome: record.outcome, Reason: record.reason}, nil
+}
+
+// CancelPendingEvictionCommand cancels the pending scheduled eviction of the pod on an operator's
+// request. Like RequestEvictionCommand, it fails with ErrPodNotSelected for pods the controller does
+// not select. The schedule itself is kept: the pod is rescheduled for the following occurrence, whose
+// restart-at is returned.
+func (s *Service) CancelPendingEvictionCommand(ctx context.Context, namespace, name string) (time.Time, error) {
+	logger := s.logger.With("pod", name, "namespace", namespace)
+	key := namespace + "/" + name
+
+	if !s.hasPendingEviction(key) {
+		return time.Time{}, ErrNoPendingEviction
+	}
+
+	pod, err := s.getPod(ctx, namespace, name)
+	if err != nil {
+		var target notFound
+		if errors.As(err, &target) {
+			return time.Time{}, ErrPodNotFound
+		}
+
+		return time.Time{}, fmt.Errorf("get pod: %w", err)
+	}
+
+	if err := s.ensureSelected(ctx, namespace, name); err != nil {
+		logger.WarnContext(ctx, "pending eviction cancellation refused", "reason", err)
+
+		return time.Time{}, err
+	}
+
+	// Skip the cancelled occurrence; restart-at is moved first so that a concurrent reconcile does not
+	// re-arm it.
+	after := time.Now()
+	if restartAt := s.podRestartAt(&pod); restartAt.After(after) {
+		after = restartAt
+	}
+
+	next, err := s.nextRestartAt(&pod, after)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("next restart: %w", err)
+	}
+
+	if err := s.setRestartAt(ctx, logger, &pod, next.Format(time.RFC3339)); err != nil {
+		return time.Time{}, fmt.Errorf("set restart-at annotation: %w", err)
+	}
+
+	s.cancelPendingEviction(key)
+
+	logger.InfoContext(ctx, "pending eviction cancelled through admin api, rescheduled",
+		"restartAt", next.Format(time.RFC3339),
+	)
+
+	s.recordDecision(ctx, logger, &pod, decisionRecord{
+		outcome: OutcomeDeferred,
+		trigger: TriggerSchedule,
+		reason:  DeferReasonRestartScheduled,
+		dueAt:   next,
+	})
+	s.scheduleEviction(ctx, logger, &pod, next)
+
+	return next, nil
+}
+
+// ensureSelected returns ErrPodNotSelected unless the pod is selected by one of the label selectors,
+// or by the annotation discovery, so that the admin API only acts on the pods the controller manages
+// and cannot be used to evict any pod with the controller's permissions.
+func (s *Service) ensureSelected(ctx context.Context, namespace, name string) error {
+	for _, selector := range SplitLabelSelectors(s.currentLabelSelector()) {
+		selected, err := s.repo.PodSelectedQuery(ctx, namespace, name, selector)
+		if err != nil {
+			var target notFound
+			if errors.As(err, &target) {
+				return ErrPodNotFound
+			}
+
+			return fmt.Errorf("check pod selection: %w", err)
+		}
+
+		if selected {
+			return nil
+		}
+	}
+
+	return ErrPodNotSelected
+}
@@ -0,0 +1,67 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/skillcoder/preoomkiller-controller/internal/infra/metrics"
+)
+
+// recordMemoryGauges exports the pod's memory usage and effective threshold. Pods are exported in the
+// order they are first seen until memoryGaugesMaxPods is reached; further pods are counted in the run.
+func (s *Service) recordMemoryGauges(pod *Pod, check thresholdCheck, run *reconcileRun) {
+	if s.memoryGaugesMaxPods <= 0 || check.usage.IsZero() {
+		return
+	}
+
+	key := pod.Namespace + "/" + pod.Name
+
+	s.gaugeMu.Lock()
+	defer s.gaugeMu.Unlock()
+
+	if _, ok := s.gaugedPods[key]; !ok {
+		if len(s.gaugedPods) >= s.memoryGaugesMaxPods {
+			run.gaugesCapped++
+
+			return
+		}
+
+		s.gaugedPods[key] = struct{}{}
+	}
+
+	run.gauged[key] = struct{}{}
+
+	metrics.SetPodMemory(s.cluster, pod.Namespace, pod.Name,
+		check.usage.AsApproximateFloat64(),
+		check.threshold.AsApproximateFloat64(),
+	)
+}
+
+// pruneMemoryGauges removes the gauges of pods that were not evaluated in the run, e.g. because they
+// were evicted, deleted or lost their threshold annotation.
+func (s *Service) pruneMemoryGauges(ctx context.Context, logger *slog.Logger, run *reconcileRun) {
+	if s.memoryGaugesMaxPods <= 0 {
+		return
+	}
+
+	s.gaugeMu.Lock()
+	defer s.gaugeMu.Unlock()
+
+	for key := range s.gaugedPods {
+		if _, ok := run.gauged[key]; ok {
+			continue
+		}
+
+		namespace, name, _ := strings.Cut(key, "/")
+		metrics.DeletePodMemory(s.cluster, namespace, name)
+		delete(s.gaugedPods, key)
+	}
+
+	if run.gaugesCapped > 0 {
+		logger.WarnContext(ctx, "pod memory gauges limit reached, some pods are not exported",
+			"maxPods", s.memoryGaugesMaxPods,
+			"notExported", run.gaugesCapped,
+		)
+	}
+}
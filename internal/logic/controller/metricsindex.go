@@ -0,0 +1,90 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+)
+
+// podMetricsIndex holds the metrics of all threshold-annotated pods, listed once per reconcile and
+// keyed by "namespace/name". A nil index makes lookups fall back to one GetPodMetricsQuery per pod.
+type podMetricsIndex map[string]*PodMetrics
+
+// listPodMetrics lists the metrics of the pods that have a memory threshold with a single request
+// per label selector, scoped to their namespace when they all share one. Returns a nil index when no
+// pod has a threshold or a list fails; the failure is logged and returned.
+func (s *Service) listPodMetrics(ctx context.Context, logger *slog.Logger, pods []Pod) (podMetricsIndex, error) {
+	namespaces := make(map[string]struct{})
+
+	for i := range pods {
+		if _, hasThreshold := pods[i].Annotations[s.annotationMemoryThresholdKey]; hasThreshold {
+			namespaces[pods[i].Namespace] = struct{}{}
+		}
+	}
+
+	if len(namespaces) == 0 {
+		return nil, nil
+	}
+
+	namespace := ""
+	if len(namespaces) == 1 {
+		for ns := range namespaces {
+			namespace = ns
+		}
+	}
+
+	var index podMetricsIndex
+
+	for _, selector := range SplitLabelSelectors(s.currentLabelSelector()) {
+		selected, err := s.repo.ListPodMetricsQuery(ctx, namespace, selector)
+		if err != nil {
+			logger.WarnContext(ctx, "list pod metrics failed, falling back to per-pod requests", "reason", err)
+
+			return nil, fmt.Errorf("list pod metrics: %w", err)
+		}
+
+		if index == nil {
+			index = selected
+		} else {
+			maps.Copy(index, selected)
+		}
+	}
+
+	return index, nil
+}
+
+// lookupPodMetrics returns the pod's metrics from the index, or fetches them when the index is nil.
+// skip is true when the pod has no metrics (yet).
+func (s *Service) lookupPodMetrics(
+	ctx context.Context,
+	logger *slog.Logger,
+	pod Pod,
+	index podMetricsIndex,
+) (*PodMetrics, bool, error) {
+	if index != nil {
+		podMetrics, ok := index[pod.Namespace+"/"+pod.Name]
+		if !ok {
+			logger.WarnContext(ctx, "pod metrics not found, skipping")
+
+			return nil, true, nil
+		}
+
+		return podMetrics, false, nil
+	}
+
+	podMetrics, err := s.repo.GetPodMetricsQuery(ctx, pod.Namespace, pod.Name)
+	if err != nil {
+		var target notFound
+		if errors.As(err, &target) {
+			logger.WarnContext(ctx, "pod metrics not found, skipping")
+
+			return nil, true, nil
+		}
+
+		return nil, false, fmt.Errorf("%w: %w", ErrGetPodMetrics, err)
+	}
+
+	return podMetrics, false, nil
+}
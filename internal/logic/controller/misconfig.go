@@ -0,0 +1,52 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+)
+
+// reportMisconfiguration reports a pod annotation the controller cannot act on with a Warning Event,
+// so that application teams see it without access to the controller logs. The same message is
+// reported once per pod; a changed message (e.g. after fixing one annotation and breaking another)
+// is reported again.
+func (s *Service) reportMisconfiguration(ctx context.Context, logger *slog.Logger, pod *Pod, reason, message string) {
+	s.misconfigMu.Lock()
+	if s.misconfigReported == nil {
+		s.misconfigReported = make(map[string]map[string]string)
+	}
+
+	if s.misconfigReported[pod.UID] == nil {
+		s.misconfigReported[pod.UID] = make(map[string]string)
+	}
+
+	reported := s.misconfigReported[pod.UID][reason] == message
+	s.misconfigReported[pod.UID][reason] = message
+	s.misconfigMu.Unlock()
+
+	if reported {
+		return
+	}
+
+	s.emitPodEvent(ctx, logger, pod, PodEvent{
+		Type:    EventTypeWarning,
+		Reason:  reason,
+		Message: message,
+	})
+}
+
+// forgetMisconfigurations drops the reported misconfigurations of pods that are no longer listed.
+func (s *Service) forgetMisconfigurations(pods []Pod) {
+	listed := make(map[string]struct{}, len(pods))
+	for i := range pods {
+		listed[pods[i].UID] = struct{}{}
+	}
+
+	s.misconfigMu.Lock()
+	defer s.misconfigMu.Unlock()
+
+	for uid := range s.misconfigReported {
+		if _, ok := listed[uid]; !ok {
+			delete(s.misconfigReported, uid)
+		}
+	}
+}
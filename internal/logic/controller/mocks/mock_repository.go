@@ -6,6 +6,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/skillcoder/preoomkiller-controller/internal/logic/controller"
 	mock "github.com/stretchr/testify/mock"
@@ -38,12 +39,144 @@ func (_m *MockRepository) EXPECT() *MockRepository_Expecter {
 	return &MockRepository_Expecter{mock: &_m.Mock}
 }
 
-// EvictPodCommand provides a mock function for the type MockRepository
-func (_mock *MockRepository) EvictPodCommand(ctx context.Context, namespace string, name string) error {
+// CreateOwnerEventCommand provides a mock function for the type MockRepository
+func (_mock *MockRepository) CreateOwnerEventCommand(ctx context.Context, namespace string, owner controller.Owner, event controller.PodEvent) error {
+	ret := _mock.Called(ctx, namespace, owner, event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateOwnerEventCommand")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, controller.Owner, controller.PodEvent) error); ok {
+		r0 = returnFunc(ctx, namespace, owner, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepository_CreateOwnerEventCommand_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOwnerEventCommand'
+type MockRepository_CreateOwnerEventCommand_Call struct {
+	*mock.Call
+}
+
+// CreateOwnerEventCommand is a helper method to define mock.On call
+//   - ctx context.Context
+//   - namespace string
+//   - owner controller.Owner
+//   - event controller.PodEvent
+func (_e *MockRepository_Expecter) CreateOwnerEventCommand(ctx interface{}, namespace interface{}, owner interface{}, event interface{}) *MockRepository_CreateOwnerEventCommand_Call {
+	return &MockRepository_CreateOwnerEventCommand_Call{Call: _e.mock.On("CreateOwnerEventCommand", ctx, namespace, owner, event)}
+}
+
+func (_c *MockRepository_CreateOwnerEventCommand_Call) Run(run func(ctx context.Context, namespace string, owner controller.Owner, event controller.PodEvent)) *MockRepository_CreateOwnerEventCommand_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 controller.Owner
+		if args[2] != nil {
+			arg2 = args[2].(controller.Owner)
+		}
+		var arg3 controller.PodEvent
+		if args[3] != nil {
+			arg3 = args[3].(controller.PodEvent)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_CreateOwnerEventCommand_Call) Return(err error) *MockRepository_CreateOwnerEventCommand_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepository_CreateOwnerEventCommand_Call) RunAndReturn(run func(ctx context.Context, namespace string, owner controller.Owner, event controller.PodEvent) error) *MockRepository_CreateOwnerEventCommand_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreatePodEventCommand provides a mock function for the type MockRepository
+func (_mock *MockRepository) CreatePodEventCommand(ctx context.Context, pod controller.Pod, event controller.PodEvent) error {
+	ret := _mock.Called(ctx, pod, event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePodEventCommand")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, controller.Pod, controller.PodEvent) error); ok {
+		r0 = returnFunc(ctx, pod, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepository_CreatePodEventCommand_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreatePodEventCommand'
+type MockRepository_CreatePodEventCommand_Call struct {
+	*mock.Call
+}
+
+// CreatePodEventCommand is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pod controller.Pod
+//   - event controller.PodEvent
+func (_e *MockRepository_Expecter) CreatePodEventCommand(ctx interface{}, pod interface{}, event interface{}) *MockRepository_CreatePodEventCommand_Call {
+	return &MockRepository_CreatePodEventCommand_Call{Call: _e.mock.On("CreatePodEventCommand", ctx, pod, event)}
+}
+
+func (_c *MockRepository_CreatePodEventCommand_Call) Run(run func(ctx context.Context, pod controller.Pod, event controller.PodEvent)) *MockRepository_CreatePodEventCommand_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 controller.Pod
+		if args[1] != nil {
+			arg1 = args[1].(controller.Pod)
+		}
+		var arg2 controller.PodEvent
+		if args[2] != nil {
+			arg2 = args[2].(controller.PodEvent)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_CreatePodEventCommand_Call) Return(err error) *MockRepository_CreatePodEventCommand_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepository_CreatePodEventCommand_Call) RunAndReturn(run func(ctx context.Context, pod controller.Pod, event controller.PodEvent) error) *MockRepository_CreatePodEventCommand_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeletePendingEvictionCommand provides a mock function for the type MockRepository
+func (_mock *MockRepository) DeletePendingEvictionCommand(ctx context.Context, namespace string, name string) error {
 	ret := _mock.Called(ctx, namespace, name)
 
 	if len(ret) == 0 {
-		panic("no return value specified for EvictPodCommand")
+		panic("no return value specified for DeletePendingEvictionCommand")
 	}
 
 	var r0 error
@@ -55,6 +188,135 @@ func (_mock *MockRepository) EvictPodCommand(ctx context.Context, namespace stri
 	return r0
 }
 
+// MockRepository_DeletePendingEvictionCommand_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeletePendingEvictionCommand'
+type MockRepository_DeletePendingEvictionCommand_Call struct {
+	*mock.Call
+}
+
+// DeletePendingEvictionCommand is a helper method to define mock.On call
+//   - ctx context.Context
+//   - namespace string
+//   - name string
+func (_e *MockRepository_Expecter) DeletePendingEvictionCommand(ctx interface{}, namespace interface{}, name interface{}) *MockRepository_DeletePendingEvictionCommand_Call {
+	return &MockRepository_DeletePendingEvictionCommand_Call{Call: _e.mock.On("DeletePendingEvictionCommand", ctx, namespace, name)}
+}
+
+func (_c *MockRepository_DeletePendingEvictionCommand_Call) Run(run func(ctx context.Context, namespace string, name string)) *MockRepository_DeletePendingEvictionCommand_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_DeletePendingEvictionCommand_Call) Return(err error) *MockRepository_DeletePendingEvictionCommand_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepository_DeletePendingEvictionCommand_Call) RunAndReturn(run func(ctx context.Context, namespace string, name string) error) *MockRepository_DeletePendingEvictionCommand_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EvaluatePromQLQuery provides a mock function for the type MockRepository
+func (_mock *MockRepository) EvaluatePromQLQuery(ctx context.Context, expr string) (bool, error) {
+	ret := _mock.Called(ctx, expr)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EvaluatePromQLQuery")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return returnFunc(ctx, expr)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = returnFunc(ctx, expr)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, expr)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepository_EvaluatePromQLQuery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EvaluatePromQLQuery'
+type MockRepository_EvaluatePromQLQuery_Call struct {
+	*mock.Call
+}
+
+// EvaluatePromQLQuery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - expr string
+func (_e *MockRepository_Expecter) EvaluatePromQLQuery(ctx interface{}, expr interface{}) *MockRepository_EvaluatePromQLQuery_Call {
+	return &MockRepository_EvaluatePromQLQuery_Call{Call: _e.mock.On("EvaluatePromQLQuery", ctx, expr)}
+}
+
+func (_c *MockRepository_EvaluatePromQLQuery_Call) Run(run func(ctx context.Context, expr string)) *MockRepository_EvaluatePromQLQuery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_EvaluatePromQLQuery_Call) Return(b bool, err error) *MockRepository_EvaluatePromQLQuery_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockRepository_EvaluatePromQLQuery_Call) RunAndReturn(run func(ctx context.Context, expr string) (bool, error)) *MockRepository_EvaluatePromQLQuery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EvictPodCommand provides a mock function for the type MockRepository
+func (_mock *MockRepository) EvictPodCommand(ctx context.Context, namespace string, name string, uid string) error {
+	ret := _mock.Called(ctx, namespace, name, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EvictPodCommand")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = returnFunc(ctx, namespace, name, uid)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
 // MockRepository_EvictPodCommand_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EvictPodCommand'
 type MockRepository_EvictPodCommand_Call struct {
 	*mock.Call
@@ -64,11 +326,12 @@ type MockRepository_EvictPodCommand_Call struct {
 //   - ctx context.Context
 //   - namespace string
 //   - name string
-func (_e *MockRepository_Expecter) EvictPodCommand(ctx interface{}, namespace interface{}, name interface{}) *MockRepository_EvictPodCommand_Call {
-	return &MockRepository_EvictPodCommand_Call{Call: _e.mock.On("EvictPodCommand", ctx, namespace, name)}
+//   - uid string
+func (_e *MockRepository_Expecter) EvictPodCommand(ctx interface{}, namespace interface{}, name interface{}, uid interface{}) *MockRepository_EvictPodCommand_Call {
+	return &MockRepository_EvictPodCommand_Call{Call: _e.mock.On("EvictPodCommand", ctx, namespace, name, uid)}
 }
 
-func (_c *MockRepository_EvictPodCommand_Call) Run(run func(ctx context.Context, namespace string, name string)) *MockRepository_EvictPodCommand_Call {
+func (_c *MockRepository_EvictPodCommand_Call) Run(run func(ctx context.Context, namespace string, name string, uid string)) *MockRepository_EvictPodCommand_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -82,10 +345,15 @@ func (_c *MockRepository_EvictPodCommand_Call) Run(run func(ctx context.Context,
 		if args[2] != nil {
 			arg2 = args[2].(string)
 		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3,
 		)
 	})
 	return _c
@@ -96,7 +364,7 @@ func (_c *MockRepository_EvictPodCommand_Call) Return(err error) *MockRepository
 	return _c
 }
 
-func (_c *MockRepository_EvictPodCommand_Call) RunAndReturn(run func(ctx context.Context, namespace string, name string) error) *MockRepository_EvictPodCommand_Call {
+func (_c *MockRepository_EvictPodCommand_Call) RunAndReturn(run func(ctx context.Context, namespace string, name string, uid string) error) *MockRepository_EvictPodCommand_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -247,107 +515,110 @@ func (_c *MockRepository_GetPodQuery_Call) RunAndReturn(run func(ctx context.Con
 	return _c
 }
 
-// ListPodsQuery provides a mock function for the type MockRepository
-func (_mock *MockRepository) ListPodsQuery(ctx context.Context, labelSelector string) ([]controller.Pod, error) {
-	ret := _mock.Called(ctx, labelSelector)
+// ListNamespaceAnnotationsQuery provides a mock function for the type MockRepository
+func (_mock *MockRepository) ListNamespaceAnnotationsQuery(ctx context.Context) (map[string]map[string]string, error) {
+	ret := _mock.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListPodsQuery")
+		panic("no return value specified for ListNamespaceAnnotationsQuery")
 	}
 
-	var r0 []controller.Pod
+	var r0 map[string]map[string]string
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]controller.Pod, error)); ok {
-		return returnFunc(ctx, labelSelector)
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (map[string]map[string]string, error)); ok {
+		return returnFunc(ctx)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []controller.Pod); ok {
-		r0 = returnFunc(ctx, labelSelector)
+	if returnFunc, ok := ret.Get(0).(func(context.Context) map[string]map[string]string); ok {
+		r0 = returnFunc(ctx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]controller.Pod)
+			r0 = ret.Get(0).(map[string]map[string]string)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, labelSelector)
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockRepository_ListPodsQuery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPodsQuery'
-type MockRepository_ListPodsQuery_Call struct {
+// MockRepository_ListNamespaceAnnotationsQuery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListNamespaceAnnotationsQuery'
+type MockRepository_ListNamespaceAnnotationsQuery_Call struct {
 	*mock.Call
 }
 
-// ListPodsQuery is a helper method to define mock.On call
+// ListNamespaceAnnotationsQuery is a helper method to define mock.On call
 //   - ctx context.Context
-//   - labelSelector string
-func (_e *MockRepository_Expecter) ListPodsQuery(ctx interface{}, labelSelector interface{}) *MockRepository_ListPodsQuery_Call {
-	return &MockRepository_ListPodsQuery_Call{Call: _e.mock.On("ListPodsQuery", ctx, labelSelector)}
+func (_e *MockRepository_Expecter) ListNamespaceAnnotationsQuery(ctx interface{}) *MockRepository_ListNamespaceAnnotationsQuery_Call {
+	return &MockRepository_ListNamespaceAnnotationsQuery_Call{Call: _e.mock.On("ListNamespaceAnnotationsQuery", ctx)}
 }
 
-func (_c *MockRepository_ListPodsQuery_Call) Run(run func(ctx context.Context, labelSelector string)) *MockRepository_ListPodsQuery_Call {
+func (_c *MockRepository_ListNamespaceAnnotationsQuery_Call) Run(run func(ctx context.Context)) *MockRepository_ListNamespaceAnnotationsQuery_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
-		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
 		run(
 			arg0,
-			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockRepository_ListPodsQuery_Call) Return(pods []controller.Pod, err error) *MockRepository_ListPodsQuery_Call {
-	_c.Call.Return(pods, err)
+func (_c *MockRepository_ListNamespaceAnnotationsQuery_Call) Return(stringToStringToString map[string]map[string]string, err error) *MockRepository_ListNamespaceAnnotationsQuery_Call {
+	_c.Call.Return(stringToStringToString, err)
 	return _c
 }
 
-func (_c *MockRepository_ListPodsQuery_Call) RunAndReturn(run func(ctx context.Context, labelSelector string) ([]controller.Pod, error)) *MockRepository_ListPodsQuery_Call {
+func (_c *MockRepository_ListNamespaceAnnotationsQuery_Call) RunAndReturn(run func(ctx context.Context) (map[string]map[string]string, error)) *MockRepository_ListNamespaceAnnotationsQuery_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SetAnnotationCommand provides a mock function for the type MockRepository
-func (_mock *MockRepository) SetAnnotationCommand(ctx context.Context, namespace string, name string, key string, value string) error {
-	ret := _mock.Called(ctx, namespace, name, key, value)
+// ListOwnerPodsQuery provides a mock function for the type MockRepository
+func (_mock *MockRepository) ListOwnerPodsQuery(ctx context.Context, namespace string, owner controller.Owner) ([]controller.Pod, error) {
+	ret := _mock.Called(ctx, namespace, owner)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SetAnnotationCommand")
+		panic("no return value specified for ListOwnerPodsQuery")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string) error); ok {
-		r0 = returnFunc(ctx, namespace, name, key, value)
+	var r0 []controller.Pod
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, controller.Owner) ([]controller.Pod, error)); ok {
+		return returnFunc(ctx, namespace, owner)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, controller.Owner) []controller.Pod); ok {
+		r0 = returnFunc(ctx, namespace, owner)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]controller.Pod)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, controller.Owner) error); ok {
+		r1 = returnFunc(ctx, namespace, owner)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockRepository_SetAnnotationCommand_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetAnnotationCommand'
-type MockRepository_SetAnnotationCommand_Call struct {
+// MockRepository_ListOwnerPodsQuery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListOwnerPodsQuery'
+type MockRepository_ListOwnerPodsQuery_Call struct {
 	*mock.Call
 }
 
-// SetAnnotationCommand is a helper method to define mock.On call
+// ListOwnerPodsQuery is a helper method to define mock.On call
 //   - ctx context.Context
 //   - namespace string
-//   - name string
-//   - key string
-//   - value string
-func (_e *MockRepository_Expecter) SetAnnotationCommand(ctx interface{}, namespace interface{}, name interface{}, key interface{}, value interface{}) *MockRepository_SetAnnotationCommand_Call {
-	return &MockRepository_SetAnnotationCommand_Call{Call: _e.mock.On("SetAnnotationCommand", ctx, namespace, name, key, value)}
+//   - owner controller.Owner
+func (_e *MockRepository_Expecter) ListOwnerPodsQuery(ctx interface{}, namespace interface{}, owner interface{}) *MockRepository_ListOwnerPodsQuery_Call {
+	return &MockRepository_ListOwnerPodsQuery_Call{Call: _e.mock.On("ListOwnerPodsQuery", ctx, namespace, owner)}
 }
 
-func (_c *MockRepository_SetAnnotationCommand_Call) Run(run func(ctx context.Context, namespace string, name string, key string, value string)) *MockRepository_SetAnnotationCommand_Call {
+func (_c *MockRepository_ListOwnerPodsQuery_Call) Run(run func(ctx context.Context, namespace string, owner controller.Owner)) *MockRepository_ListOwnerPodsQuery_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -357,13 +628,852 @@ func (_c *MockRepository_SetAnnotationCommand_Call) Run(run func(ctx context.Con
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 string
+		var arg2 controller.Owner
 		if args[2] != nil {
-			arg2 = args[2].(string)
-		}
-		var arg3 string
-		if args[3] != nil {
-			arg3 = args[3].(string)
+			arg2 = args[2].(controller.Owner)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_ListOwnerPodsQuery_Call) Return(pods []controller.Pod, err error) *MockRepository_ListOwnerPodsQuery_Call {
+	_c.Call.Return(pods, err)
+	return _c
+}
+
+func (_c *MockRepository_ListOwnerPodsQuery_Call) RunAndReturn(run func(ctx context.Context, namespace string, owner controller.Owner) ([]controller.Pod, error)) *MockRepository_ListOwnerPodsQuery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPendingEvictionsQuery provides a mock function for the type MockRepository
+func (_mock *MockRepository) ListPendingEvictionsQuery(ctx context.Context) ([]controller.PendingEviction, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPendingEvictionsQuery")
+	}
+
+	var r0 []controller.PendingEviction
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]controller.PendingEviction, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []controller.PendingEviction); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]controller.PendingEviction)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepository_ListPendingEvictionsQuery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPendingEvictionsQuery'
+type MockRepository_ListPendingEvictionsQuery_Call struct {
+	*mock.Call
+}
+
+// ListPendingEvictionsQuery is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockRepository_Expecter) ListPendingEvictionsQuery(ctx interface{}) *MockRepository_ListPendingEvictionsQuery_Call {
+	return &MockRepository_ListPendingEvictionsQuery_Call{Call: _e.mock.On("ListPendingEvictionsQuery", ctx)}
+}
+
+func (_c *MockRepository_ListPendingEvictionsQuery_Call) Run(run func(ctx context.Context)) *MockRepository_ListPendingEvictionsQuery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_ListPendingEvictionsQuery_Call) Return(pendingEvictions []controller.PendingEviction, err error) *MockRepository_ListPendingEvictionsQuery_Call {
+	_c.Call.Return(pendingEvictions, err)
+	return _c
+}
+
+func (_c *MockRepository_ListPendingEvictionsQuery_Call) RunAndReturn(run func(ctx context.Context) ([]controller.PendingEviction, error)) *MockRepository_ListPendingEvictionsQuery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPodMetricsQuery provides a mock function for the type MockRepository
+func (_mock *MockRepository) ListPodMetricsQuery(ctx context.Context, namespace string, labelSelector string) (map[string]*controller.PodMetrics, error) {
+	ret := _mock.Called(ctx, namespace, labelSelector)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPodMetricsQuery")
+	}
+
+	var r0 map[string]*controller.PodMetrics
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (map[string]*controller.PodMetrics, error)); ok {
+		return returnFunc(ctx, namespace, labelSelector)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) map[string]*controller.PodMetrics); ok {
+		r0 = returnFunc(ctx, namespace, labelSelector)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]*controller.PodMetrics)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, namespace, labelSelector)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepository_ListPodMetricsQuery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPodMetricsQuery'
+type MockRepository_ListPodMetricsQuery_Call struct {
+	*mock.Call
+}
+
+// ListPodMetricsQuery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - namespace string
+//   - labelSelector string
+func (_e *MockRepository_Expecter) ListPodMetricsQuery(ctx interface{}, namespace interface{}, labelSelector interface{}) *MockRepository_ListPodMetricsQuery_Call {
+	return &MockRepository_ListPodMetricsQuery_Call{Call: _e.mock.On("ListPodMetricsQuery", ctx, namespace, labelSelector)}
+}
+
+func (_c *MockRepository_ListPodMetricsQuery_Call) Run(run func(ctx context.Context, namespace string, labelSelector string)) *MockRepository_ListPodMetricsQuery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_ListPodMetricsQuery_Call) Return(stringToPodMetrics map[string]*controller.PodMetrics, err error) *MockRepository_ListPodMetricsQuery_Call {
+	_c.Call.Return(stringToPodMetrics, err)
+	return _c
+}
+
+func (_c *MockRepository_ListPodMetricsQuery_Call) RunAndReturn(run func(ctx context.Context, namespace string, labelSelector string) (map[string]*controller.PodMetrics, error)) *MockRepository_ListPodMetricsQuery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPodsQuery provides a mock function for the type MockRepository
+func (_mock *MockRepository) ListPodsQuery(ctx context.Context, labelSelector string) ([]controller.Pod, error) {
+	ret := _mock.Called(ctx, labelSelector)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPodsQuery")
+	}
+
+	var r0 []controller.Pod
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]controller.Pod, error)); ok {
+		return returnFunc(ctx, labelSelector)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []controller.Pod); ok {
+		r0 = returnFunc(ctx, labelSelector)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]controller.Pod)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, labelSelector)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepository_ListPodsQuery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPodsQuery'
+type MockRepository_ListPodsQuery_Call struct {
+	*mock.Call
+}
+
+// ListPodsQuery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - labelSelector string
+func (_e *MockRepository_Expecter) ListPodsQuery(ctx interface{}, labelSelector interface{}) *MockRepository_ListPodsQuery_Call {
+	return &MockRepository_ListPodsQuery_Call{Call: _e.mock.On("ListPodsQuery", ctx, labelSelector)}
+}
+
+func (_c *MockRepository_ListPodsQuery_Call) Run(run func(ctx context.Context, labelSelector string)) *MockRepository_ListPodsQuery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_ListPodsQuery_Call) Return(pods []controller.Pod, err error) *MockRepository_ListPodsQuery_Call {
+	_c.Call.Return(pods, err)
+	return _c
+}
+
+func (_c *MockRepository_ListPodsQuery_Call) RunAndReturn(run func(ctx context.Context, labelSelector string) ([]controller.Pod, error)) *MockRepository_ListPodsQuery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListSuspendedWorkloadsQuery provides a mock function for the type MockRepository
+func (_mock *MockRepository) ListSuspendedWorkloadsQuery(ctx context.Context) ([]controller.SuspendedWorkload, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSuspendedWorkloadsQuery")
+	}
+
+	var r0 []controller.SuspendedWorkload
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]controller.SuspendedWorkload, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []controller.SuspendedWorkload); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]controller.SuspendedWorkload)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepository_ListSuspendedWorkloadsQuery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSuspendedWorkloadsQuery'
+type MockRepository_ListSuspendedWorkloadsQuery_Call struct {
+	*mock.Call
+}
+
+// ListSuspendedWorkloadsQuery is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockRepository_Expecter) ListSuspendedWorkloadsQuery(ctx interface{}) *MockRepository_ListSuspendedWorkloadsQuery_Call {
+	return &MockRepository_ListSuspendedWorkloadsQuery_Call{Call: _e.mock.On("ListSuspendedWorkloadsQuery", ctx)}
+}
+
+func (_c *MockRepository_ListSuspendedWorkloadsQuery_Call) Run(run func(ctx context.Context)) *MockRepository_ListSuspendedWorkloadsQuery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_ListSuspendedWorkloadsQuery_Call) Return(suspendedWorkloads []controller.SuspendedWorkload, err error) *MockRepository_ListSuspendedWorkloadsQuery_Call {
+	_c.Call.Return(suspendedWorkloads, err)
+	return _c
+}
+
+func (_c *MockRepository_ListSuspendedWorkloadsQuery_Call) RunAndReturn(run func(ctx context.Context) ([]controller.SuspendedWorkload, error)) *MockRepository_ListSuspendedWorkloadsQuery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTightenedThresholdsQuery provides a mock function for the type MockRepository
+func (_mock *MockRepository) ListTightenedThresholdsQuery(ctx context.Context) ([]controller.TightenedThreshold, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTightenedThresholdsQuery")
+	}
+
+	var r0 []controller.TightenedThreshold
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]controller.TightenedThreshold, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []controller.TightenedThreshold); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]controller.TightenedThreshold)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepository_ListTightenedThresholdsQuery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTightenedThresholdsQuery'
+type MockRepository_ListTightenedThresholdsQuery_Call struct {
+	*mock.Call
+}
+
+// ListTightenedThresholdsQuery is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockRepository_Expecter) ListTightenedThresholdsQuery(ctx interface{}) *MockRepository_ListTightenedThresholdsQuery_Call {
+	return &MockRepository_ListTightenedThresholdsQuery_Call{Call: _e.mock.On("ListTightenedThresholdsQuery", ctx)}
+}
+
+func (_c *MockRepository_ListTightenedThresholdsQuery_Call) Run(run func(ctx context.Context)) *MockRepository_ListTightenedThresholdsQuery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_ListTightenedThresholdsQuery_Call) Return(tightenedThresholds []controller.TightenedThreshold, err error) *MockRepository_ListTightenedThresholdsQuery_Call {
+	_c.Call.Return(tightenedThresholds, err)
+	return _c
+}
+
+func (_c *MockRepository_ListTightenedThresholdsQuery_Call) RunAndReturn(run func(ctx context.Context) ([]controller.TightenedThreshold, error)) *MockRepository_ListTightenedThresholdsQuery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListVPARecommendationsQuery provides a mock function for the type MockRepository
+func (_mock *MockRepository) ListVPARecommendationsQuery(ctx context.Context, namespace string) (map[string]*controller.VPARecommendation, error) {
+	ret := _mock.Called(ctx, namespace)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListVPARecommendationsQuery")
+	}
+
+	var r0 map[string]*controller.VPARecommendation
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (map[string]*controller.VPARecommendation, error)); ok {
+		return returnFunc(ctx, namespace)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) map[string]*controller.VPARecommendation); ok {
+		r0 = returnFunc(ctx, namespace)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]*controller.VPARecommendation)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, namespace)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepository_ListVPARecommendationsQuery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListVPARecommendationsQuery'
+type MockRepository_ListVPARecommendationsQuery_Call struct {
+	*mock.Call
+}
+
+// ListVPARecommendationsQuery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - namespace string
+func (_e *MockRepository_Expecter) ListVPARecommendationsQuery(ctx interface{}, namespace interface{}) *MockRepository_ListVPARecommendationsQuery_Call {
+	return &MockRepository_ListVPARecommendationsQuery_Call{Call: _e.mock.On("ListVPARecommendationsQuery", ctx, namespace)}
+}
+
+func (_c *MockRepository_ListVPARecommendationsQuery_Call) Run(run func(ctx context.Context, namespace string)) *MockRepository_ListVPARecommendationsQuery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_ListVPARecommendationsQuery_Call) Return(vPARecommendations map[string]*controller.VPARecommendation, err error) *MockRepository_ListVPARecommendationsQuery_Call {
+	_c.Call.Return(vPARecommendations, err)
+	return _c
+}
+
+func (_c *MockRepository_ListVPARecommendationsQuery_Call) RunAndReturn(run func(ctx context.Context, namespace string) (map[string]*controller.VPARecommendation, error)) *MockRepository_ListVPARecommendationsQuery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PodSelectedQuery provides a mock function for the type MockRepository
+func (_mock *MockRepository) PodSelectedQuery(ctx context.Context, namespace string, name string, labelSelector string) (bool, error) {
+	ret := _mock.Called(ctx, namespace, name, labelSelector)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PodSelectedQuery")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) (bool, error)); ok {
+		return returnFunc(ctx, namespace, name, labelSelector)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) bool); ok {
+		r0 = returnFunc(ctx, namespace, name, labelSelector)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = returnFunc(ctx, namespace, name, labelSelector)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepository_PodSelectedQuery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PodSelectedQuery'
+type MockRepository_PodSelectedQuery_Call struct {
+	*mock.Call
+}
+
+// PodSelectedQuery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - namespace string
+//   - name string
+//   - labelSelector string
+func (_e *MockRepository_Expecter) PodSelectedQuery(ctx interface{}, namespace interface{}, name interface{}, labelSelector interface{}) *MockRepository_PodSelectedQuery_Call {
+	return &MockRepository_PodSelectedQuery_Call{Call: _e.mock.On("PodSelectedQuery", ctx, namespace, name, labelSelector)}
+}
+
+func (_c *MockRepository_PodSelectedQuery_Call) Run(run func(ctx context.Context, namespace string, name string, labelSelector string)) *MockRepository_PodSelectedQuery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_PodSelectedQuery_Call) Return(b bool, err error) *MockRepository_PodSelectedQuery_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockRepository_PodSelectedQuery_Call) RunAndReturn(run func(ctx context.Context, namespace string, name string, labelSelector string) (bool, error)) *MockRepository_PodSelectedQuery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PruneRestartRecordsCommand provides a mock function for the type MockRepository
+func (_mock *MockRepository) PruneRestartRecordsCommand(ctx context.Context) (int, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PruneRestartRecordsCommand")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepository_PruneRestartRecordsCommand_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PruneRestartRecordsCommand'
+type MockRepository_PruneRestartRecordsCommand_Call struct {
+	*mock.Call
+}
+
+// PruneRestartRecordsCommand is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockRepository_Expecter) PruneRestartRecordsCommand(ctx interface{}) *MockRepository_PruneRestartRecordsCommand_Call {
+	return &MockRepository_PruneRestartRecordsCommand_Call{Call: _e.mock.On("PruneRestartRecordsCommand", ctx)}
+}
+
+func (_c *MockRepository_PruneRestartRecordsCommand_Call) Run(run func(ctx context.Context)) *MockRepository_PruneRestartRecordsCommand_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_PruneRestartRecordsCommand_Call) Return(n int, err error) *MockRepository_PruneRestartRecordsCommand_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockRepository_PruneRestartRecordsCommand_Call) RunAndReturn(run func(ctx context.Context) (int, error)) *MockRepository_PruneRestartRecordsCommand_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordRestartCommand provides a mock function for the type MockRepository
+func (_mock *MockRepository) RecordRestartCommand(ctx context.Context, record controller.RestartRecord) error {
+	ret := _mock.Called(ctx, record)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordRestartCommand")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, controller.RestartRecord) error); ok {
+		r0 = returnFunc(ctx, record)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepository_RecordRestartCommand_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordRestartCommand'
+type MockRepository_RecordRestartCommand_Call struct {
+	*mock.Call
+}
+
+// RecordRestartCommand is a helper method to define mock.On call
+//   - ctx context.Context
+//   - record controller.RestartRecord
+func (_e *MockRepository_Expecter) RecordRestartCommand(ctx interface{}, record interface{}) *MockRepository_RecordRestartCommand_Call {
+	return &MockRepository_RecordRestartCommand_Call{Call: _e.mock.On("RecordRestartCommand", ctx, record)}
+}
+
+func (_c *MockRepository_RecordRestartCommand_Call) Run(run func(ctx context.Context, record controller.RestartRecord)) *MockRepository_RecordRestartCommand_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 controller.RestartRecord
+		if args[1] != nil {
+			arg1 = args[1].(controller.RestartRecord)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_RecordRestartCommand_Call) Return(err error) *MockRepository_RecordRestartCommand_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepository_RecordRestartCommand_Call) RunAndReturn(run func(ctx context.Context, record controller.RestartRecord) error) *MockRepository_RecordRestartCommand_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResumeWorkloadCommand provides a mock function for the type MockRepository
+func (_mock *MockRepository) ResumeWorkloadCommand(ctx context.Context, owner controller.Owner) error {
+	ret := _mock.Called(ctx, owner)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResumeWorkloadCommand")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, controller.Owner) error); ok {
+		r0 = returnFunc(ctx, owner)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepository_ResumeWorkloadCommand_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResumeWorkloadCommand'
+type MockRepository_ResumeWorkloadCommand_Call struct {
+	*mock.Call
+}
+
+// ResumeWorkloadCommand is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner controller.Owner
+func (_e *MockRepository_Expecter) ResumeWorkloadCommand(ctx interface{}, owner interface{}) *MockRepository_ResumeWorkloadCommand_Call {
+	return &MockRepository_ResumeWorkloadCommand_Call{Call: _e.mock.On("ResumeWorkloadCommand", ctx, owner)}
+}
+
+func (_c *MockRepository_ResumeWorkloadCommand_Call) Run(run func(ctx context.Context, owner controller.Owner)) *MockRepository_ResumeWorkloadCommand_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 controller.Owner
+		if args[1] != nil {
+			arg1 = args[1].(controller.Owner)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_ResumeWorkloadCommand_Call) Return(err error) *MockRepository_ResumeWorkloadCommand_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepository_ResumeWorkloadCommand_Call) RunAndReturn(run func(ctx context.Context, owner controller.Owner) error) *MockRepository_ResumeWorkloadCommand_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SavePendingEvictionCommand provides a mock function for the type MockRepository
+func (_mock *MockRepository) SavePendingEvictionCommand(ctx context.Context, namespace string, name string, at time.Time) error {
+	ret := _mock.Called(ctx, namespace, name, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SavePendingEvictionCommand")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, time.Time) error); ok {
+		r0 = returnFunc(ctx, namespace, name, at)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepository_SavePendingEvictionCommand_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SavePendingEvictionCommand'
+type MockRepository_SavePendingEvictionCommand_Call struct {
+	*mock.Call
+}
+
+// SavePendingEvictionCommand is a helper method to define mock.On call
+//   - ctx context.Context
+//   - namespace string
+//   - name string
+//   - at time.Time
+func (_e *MockRepository_Expecter) SavePendingEvictionCommand(ctx interface{}, namespace interface{}, name interface{}, at interface{}) *MockRepository_SavePendingEvictionCommand_Call {
+	return &MockRepository_SavePendingEvictionCommand_Call{Call: _e.mock.On("SavePendingEvictionCommand", ctx, namespace, name, at)}
+}
+
+func (_c *MockRepository_SavePendingEvictionCommand_Call) Run(run func(ctx context.Context, namespace string, name string, at time.Time)) *MockRepository_SavePendingEvictionCommand_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 time.Time
+		if args[3] != nil {
+			arg3 = args[3].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_SavePendingEvictionCommand_Call) Return(err error) *MockRepository_SavePendingEvictionCommand_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepository_SavePendingEvictionCommand_Call) RunAndReturn(run func(ctx context.Context, namespace string, name string, at time.Time) error) *MockRepository_SavePendingEvictionCommand_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveTightenedThresholdCommand provides a mock function for the type MockRepository
+func (_mock *MockRepository) SaveTightenedThresholdCommand(ctx context.Context, threshold controller.TightenedThreshold) error {
+	ret := _mock.Called(ctx, threshold)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveTightenedThresholdCommand")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, controller.TightenedThreshold) error); ok {
+		r0 = returnFunc(ctx, threshold)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepository_SaveTightenedThresholdCommand_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveTightenedThresholdCommand'
+type MockRepository_SaveTightenedThresholdCommand_Call struct {
+	*mock.Call
+}
+
+// SaveTightenedThresholdCommand is a helper method to define mock.On call
+//   - ctx context.Context
+//   - threshold controller.TightenedThreshold
+func (_e *MockRepository_Expecter) SaveTightenedThresholdCommand(ctx interface{}, threshold interface{}) *MockRepository_SaveTightenedThresholdCommand_Call {
+	return &MockRepository_SaveTightenedThresholdCommand_Call{Call: _e.mock.On("SaveTightenedThresholdCommand", ctx, threshold)}
+}
+
+func (_c *MockRepository_SaveTightenedThresholdCommand_Call) Run(run func(ctx context.Context, threshold controller.TightenedThreshold)) *MockRepository_SaveTightenedThresholdCommand_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 controller.TightenedThreshold
+		if args[1] != nil {
+			arg1 = args[1].(controller.TightenedThreshold)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_SaveTightenedThresholdCommand_Call) Return(err error) *MockRepository_SaveTightenedThresholdCommand_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepository_SaveTightenedThresholdCommand_Call) RunAndReturn(run func(ctx context.Context, threshold controller.TightenedThreshold) error) *MockRepository_SaveTightenedThresholdCommand_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetAnnotationCommand provides a mock function for the type MockRepository
+func (_mock *MockRepository) SetAnnotationCommand(ctx context.Context, namespace string, name string, key string, value string) error {
+	ret := _mock.Called(ctx, namespace, name, key, value)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetAnnotationCommand")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string) error); ok {
+		r0 = returnFunc(ctx, namespace, name, key, value)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepository_SetAnnotationCommand_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetAnnotationCommand'
+type MockRepository_SetAnnotationCommand_Call struct {
+	*mock.Call
+}
+
+// SetAnnotationCommand is a helper method to define mock.On call
+//   - ctx context.Context
+//   - namespace string
+//   - name string
+//   - key string
+//   - value string
+func (_e *MockRepository_Expecter) SetAnnotationCommand(ctx interface{}, namespace interface{}, name interface{}, key interface{}, value interface{}) *MockRepository_SetAnnotationCommand_Call {
+	return &MockRepository_SetAnnotationCommand_Call{Call: _e.mock.On("SetAnnotationCommand", ctx, namespace, name, key, value)}
+}
+
+func (_c *MockRepository_SetAnnotationCommand_Call) Run(run func(ctx context.Context, namespace string, name string, key string, value string)) *MockRepository_SetAnnotationCommand_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
 		}
 		var arg4 string
 		if args[4] != nil {
@@ -389,3 +1499,123 @@ func (_c *MockRepository_SetAnnotationCommand_Call) RunAndReturn(run func(ctx co
 	_c.Call.Return(run)
 	return _c
 }
+
+// SuspendWorkloadCommand provides a mock function for the type MockRepository
+func (_mock *MockRepository) SuspendWorkloadCommand(ctx context.Context, workload controller.SuspendedWorkload) error {
+	ret := _mock.Called(ctx, workload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SuspendWorkloadCommand")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, controller.SuspendedWorkload) error); ok {
+		r0 = returnFunc(ctx, workload)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepository_SuspendWorkloadCommand_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SuspendWorkloadCommand'
+type MockRepository_SuspendWorkloadCommand_Call struct {
+	*mock.Call
+}
+
+// SuspendWorkloadCommand is a helper method to define mock.On call
+//   - ctx context.Context
+//   - workload controller.SuspendedWorkload
+func (_e *MockRepository_Expecter) SuspendWorkloadCommand(ctx interface{}, workload interface{}) *MockRepository_SuspendWorkloadCommand_Call {
+	return &MockRepository_SuspendWorkloadCommand_Call{Call: _e.mock.On("SuspendWorkloadCommand", ctx, workload)}
+}
+
+func (_c *MockRepository_SuspendWorkloadCommand_Call) Run(run func(ctx context.Context, workload controller.SuspendedWorkload)) *MockRepository_SuspendWorkloadCommand_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 controller.SuspendedWorkload
+		if args[1] != nil {
+			arg1 = args[1].(controller.SuspendedWorkload)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_SuspendWorkloadCommand_Call) Return(err error) *MockRepository_SuspendWorkloadCommand_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepository_SuspendWorkloadCommand_Call) RunAndReturn(run func(ctx context.Context, workload controller.SuspendedWorkload) error) *MockRepository_SuspendWorkloadCommand_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WatchPodAnnotationsQuery provides a mock function for the type MockRepository
+func (_mock *MockRepository) WatchPodAnnotationsQuery(ctx context.Context, keys []string, changed func(namespace string, name string)) error {
+	ret := _mock.Called(ctx, keys, changed)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WatchPodAnnotationsQuery")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, func(namespace string, name string)) error); ok {
+		r0 = returnFunc(ctx, keys, changed)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepository_WatchPodAnnotationsQuery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WatchPodAnnotationsQuery'
+type MockRepository_WatchPodAnnotationsQuery_Call struct {
+	*mock.Call
+}
+
+// WatchPodAnnotationsQuery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - keys []string
+//   - changed func(namespace string, name string)
+func (_e *MockRepository_Expecter) WatchPodAnnotationsQuery(ctx interface{}, keys interface{}, changed interface{}) *MockRepository_WatchPodAnnotationsQuery_Call {
+	return &MockRepository_WatchPodAnnotationsQuery_Call{Call: _e.mock.On("WatchPodAnnotationsQuery", ctx, keys, changed)}
+}
+
+func (_c *MockRepository_WatchPodAnnotationsQuery_Call) Run(run func(ctx context.Context, keys []string, changed func(namespace string, name string))) *MockRepository_WatchPodAnnotationsQuery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		var arg2 func(namespace string, name string)
+		if args[2] != nil {
+			arg2 = args[2].(func(namespace string, name string))
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_WatchPodAnnotationsQuery_Call) Return(err error) *MockRepository_WatchPodAnnotationsQuery_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepository_WatchPodAnnotationsQuery_Call) RunAndReturn(run func(ctx context.Context, keys []string, changed func(namespace string, name string)) error) *MockRepository_WatchPodAnnotationsQuery_Call {
+	_c.Call.Return(run)
+	return _c
+}
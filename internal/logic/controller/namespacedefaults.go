@@ -0,0 +1,133 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+)
+
+// WithNamespaceDefaults resolves the default-memory-threshold and default-restart-schedule annotations
+// of Namespaces for the pods in them that lack their own memory-threshold, respectively
+// restart-schedule and restart-window.
+func WithNamespaceDefaults() Option {
+	return func(s *Service) {
+		s.namespaceDefaults = true
+	}
+}
+
+// namespaceDefaultKeys maps the Namespace annotations holding defaults to the pod annotations they
+// default.
+func (s *Service) namespaceDefaultKeys() map[string]string {
+	return map[string]string{
+		PreoomkillerAnnotationDefaultMemoryThresholdKey: s.annotationMemoryThresholdKey,
+		PreoomkillerAnnotationDefaultRestartScheduleKey: s.annotationRestartScheduleKey,
+	}
+}
+
+// listPods lists the selected pods with the defaults of their namespaces, then the default memory
+// threshold, applied. The namespace defaults are refreshed on each list; when they cannot be listed,
+// the previous ones are kept.
+func (s *Service) listPods(ctx context.Context, logger *slog.Logger) ([]Pod, error) {
+	pods, err := s.listSelectedPods(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list pods: %w", err)
+	}
+
+	if s.namespaceDefaults {
+		if err := s.refreshNamespaceDefaults(ctx); err != nil {
+			logger.WarnContext(ctx, "list namespace defaults, keeping the previous ones", "reason", err)
+		}
+	}
+
+	for i := range pods {
+		s.applyDefaults(&pods[i])
+	}
+
+	return pods, nil
+}
+
+// getPod fetches the pod with the defaults of its namespace, as of the last list, then the default
+// memory threshold, applied.
+func (s *Service) getPod(ctx context.Context, namespace, name string) (Pod, error) {
+	pod, err := s.repo.GetPodQuery(ctx, namespace, name)
+	if err != nil {
+		return Pod{}, err
+	}
+
+	s.applyDefaults(&pod)
+
+	return pod, nil
+}
+
+// applyDefaults applies the defaults of the pod namespace, then the default memory threshold.
+func (s *Service) applyDefaults(pod *Pod) {
+	if s.namespaceDefaults {
+		s.applyNamespaceDefaults(pod)
+	}
+
+	s.applyDefaultMemoryThreshold(pod)
+}
+
+func (s *Service) refreshNamespaceDefaults(ctx context.Context) error {
+	annotations, err := s.repo.ListNamespaceAnnotationsQuery(ctx)
+	if err != nil {
+		return fmt.Errorf("list namespace annotations: %w", err)
+	}
+
+	keys := s.namespaceDefaultKeys()
+	defaults := make(map[string]map[string]string)
+
+	for namespace, nsAnnotations := range annotations {
+		for defaultKey, podKey := range keys {
+			value, ok := nsAnnotations[defaultKey]
+			if !ok {
+				continue
+			}
+
+			if defaults[namespace] == nil {
+				defaults[namespace] = make(map[string]string, len(keys))
+			}
+
+			defaults[namespace][podKey] = value
+		}
+	}
+
+	s.namespaceDefaultsMu.Lock()
+	s.namespaceDefaultValues = defaults
+	s.namespaceDefaultsMu.Unlock()
+
+	return nil
+}
+
+// applyNamespaceDefaults adds the defaults of the pod's namespace it lacks. A restart-window counts
+// as the pod's own restart schedule.
+func (s *Service) applyNamespaceDefaults(pod *Pod) {
+	s.namespaceDefaultsMu.RLock()
+	defaults := s.namespaceDefaultValues[pod.Namespace]
+	s.namespaceDefaultsMu.RUnlock()
+
+	if len(defaults) == 0 {
+		return
+	}
+
+	// Listed pods may share their annotations with the caller.
+	annotations := maps.Clone(pod.Annotations)
+	if annotations == nil {
+		annotations = make(map[string]string, len(defaults))
+	}
+
+	for key, value := range defaults {
+		if _, ok := annotations[key]; ok {
+			continue
+		}
+
+		if _, ok := annotations[PreoomkillerAnnotationRestartWindowKey]; ok && key == s.annotationRestartScheduleKey {
+			continue
+		}
+
+		annotations[key] = value
+	}
+
+	pod.Annotations = annotations
+}
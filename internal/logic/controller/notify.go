@@ -0,0 +1,44 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// notify sends the decision to every notifier. Failures are logged only: notifications are informational.
+func (s *Service) notify(ctx context.Context, logger *slog.Logger, decision EvictionDecision) {
+	for _, notifier := range s.notifiers {
+		if err := notifier.NotifyCommand(ctx, decision); err != nil {
+			logger.WarnContext(ctx, "notify eviction decision failed",
+				"notifier", notifier.Name(),
+				"pod", decision.Pod,
+				"namespace", decision.Namespace,
+				"decision", decision.Outcome,
+				"reason", err,
+			)
+		}
+	}
+}
+
+// notifyMissedOOM sends the pod's OOMKilled termination at oomKilledAt to every notifier.
+func (s *Service) notifyMissedOOM(ctx context.Context, logger *slog.Logger, pod *Pod, oomKilledAt time.Time) {
+	missed := MissedOOM{
+		Cluster:                   s.cluster,
+		Namespace:                 pod.Namespace,
+		Pod:                       pod.Name,
+		PodUID:                    pod.UID,
+		Owner:                     pod.Owner,
+		OOMKilledAt:               oomKilledAt,
+		MemoryThresholdAnnotation: pod.Annotations[s.annotationMemoryThresholdKey],
+	}
+
+	for _, notifier := range s.notifiers {
+		if err := notifier.NotifyMissedOOMCommand(ctx, missed); err != nil {
+			logger.WarnContext(ctx, "notify missed oom failed",
+				"notifier", notifier.Name(),
+				"reason", err,
+			)
+		}
+	}
+}
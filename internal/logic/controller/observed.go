@@ -0,0 +1,69 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// WithObservedAnnotations writes the memory usage and threshold of each check to the
+// last-observed-usage and last-checked-at annotations of the pod, at most once per interval per pod.
+func WithObservedAnnotations(interval time.Duration) Option {
+	return func(s *Service) {
+		s.observedAnnotationsInterval = interval
+	}
+}
+
+// observedUsageDue reports whether the last-observed-usage annotations of the pod are due for a write:
+// they are missing, unreadable or older than the interval.
+func (s *Service) observedUsageDue(pod *Pod, now time.Time) bool {
+	if s.observedAnnotationsInterval <= 0 {
+		return false
+	}
+
+	checkedAt, err := time.Parse(time.RFC3339, pod.Annotations[PreoomkillerAnnotationLastCheckedAtKey])
+	if err != nil {
+		return true
+	}
+
+	return now.Sub(checkedAt) >= s.observedAnnotationsInterval
+}
+
+// recordObservedUsage writes the checked usage and threshold to the pod annotations when due. A failure
+// is only logged: the annotations are informational.
+func (s *Service) recordObservedUsage(
+	ctx context.Context,
+	logger *slog.Logger,
+	pod *Pod,
+	check thresholdCheck,
+	now time.Time,
+) {
+	if !s.observedUsageDue(pod, now) {
+		return
+	}
+
+	observed := check.usage.String() + "/" + check.threshold.String()
+
+	if err := s.repo.SetAnnotationCommand(
+		ctx,
+		pod.Namespace,
+		pod.Name,
+		PreoomkillerAnnotationLastObservedUsageKey,
+		observed,
+	); err != nil {
+		logger.WarnContext(ctx, "set last-observed-usage annotation", "reason", err)
+
+		return
+	}
+
+	// Written last, so a failure above retries the write at the next check.
+	if err := s.repo.SetAnnotationCommand(
+		ctx,
+		pod.Namespace,
+		pod.Name,
+		PreoomkillerAnnotationLastCheckedAtKey,
+		now.UTC().Format(time.RFC3339),
+	); err != nil {
+		logger.WarnContext(ctx, "set last-checked-at annotation", "reason", err)
+	}
+}
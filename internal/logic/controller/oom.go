@@ -0,0 +1,232 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/skillcoder/preoomkiller-controller/internal/infra/metrics"
+)
+
+// processOOMFeedback records an OOMKilled termination that was not accounted for yet
+// and, when enabled, tightens the pod's effective memory threshold.
+func (s *Service) processOOMFeedback(
+	ctx context.Context,
+	logger *slog.Logger,
+	pod *Pod,
+) {
+	if pod.LastOOMKilledAt == nil {
+		return
+	}
+
+	oomKilledAt := pod.LastOOMKilledAt.UTC().Truncate(time.Second)
+
+	if recordedStr, ok := pod.Annotations[PreoomkillerAnnotationLastOOMAtKey]; ok {
+		recorded, err := time.Parse(time.RFC3339, recordedStr)
+		if err == nil && !oomKilledAt.After(recorded) {
+			return
+		}
+	}
+
+	logger = logger.With("pod", pod.Name, "namespace", pod.Namespace)
+
+	logger.WarnContext(ctx, "container was OOMKilled before the controller acted",
+		"oomKilledAt", oomKilledAt.Format(time.RFC3339),
+	)
+	metrics.RecordMissedOOM(s.cluster, pod.Namespace, pod.Name)
+	s.notifyMissedOOM(ctx, logger, pod, oomKilledAt)
+
+	if err := s.repo.SetAnnotationCommand(
+		ctx,
+		pod.Namespace,
+		pod.Name,
+		PreoomkillerAnnotationLastOOMAtKey,
+		oomKilledAt.Format(time.RFC3339),
+	); err != nil {
+		logger.ErrorContext(ctx, "set last-oom-at annotation",
+			"reason", err,
+		)
+
+		return
+	}
+
+	if s.oomTightenPercent > 0 {
+		s.tightenThreshold(ctx, logger, pod)
+	}
+}
+
+// tightenThreshold lowers the effective threshold of the pod's workload by oomTightenPercent, so that
+// the other pods of the workload, and its later pods, are evicted earlier too. The threshold is
+// persisted with the restart records, when they are kept.
+func (s *Service) tightenThreshold(
+	ctx context.Context,
+	logger *slog.Logger,
+	pod *Pod,
+) {
+	if _, hasThreshold := pod.Annotations[s.annotationMemoryThresholdKey]; !hasThreshold {
+		return
+	}
+
+	mode, err := s.containerAggregation(pod)
+	if err != nil {
+		logger.DebugContext(ctx, "invalid container aggregation, not tightening", "reason", err)
+
+		return
+	}
+
+	current, err := s.effectiveThreshold(ctx, logger, *pod, memoryLimitFor(pod, mode))
+	if err != nil || current.IsZero() {
+		logger.DebugContext(ctx, "cannot resolve memory threshold, not tightening",
+			"reason", err,
+		)
+
+		return
+	}
+
+	tightenedBytes := current.AsApproximateFloat64() * (1 - s.oomTightenPercent/percentScale)
+	tightened := TightenedThreshold{
+		Namespace: pod.Namespace,
+		Owner:     thresholdOwner(pod),
+		Threshold: *resource.NewQuantity(int64(tightenedBytes), resource.BinarySI),
+		At:        time.Now(),
+	}
+
+	s.tightenedMu.Lock()
+	s.tightenedThresholds[tightened.Owner.UID] = tightened
+	s.tightenedMu.Unlock()
+
+	logger.InfoContext(ctx, "memory threshold tightened after OOMKilled",
+		"ownerKind", tightened.Owner.Kind,
+		"owner", tightened.Owner.Name,
+		"previousThreshold", current.String(),
+		"memoryThreshold", tightened.Threshold.String(),
+	)
+
+	if !s.recordRestarts {
+		return
+	}
+
+	s.saveTightenedThreshold(ctx, logger, tightened)
+}
+
+// saveTightenedThreshold persists the tightened threshold. One that fails to persist is kept in
+// memory, merged into the persisted ones by refreshTightenedThresholds, until it is saved.
+func (s *Service) saveTightenedThreshold(ctx context.Context, logger *slog.Logger, tightened TightenedThreshold) {
+	err := s.repo.SaveTightenedThresholdCommand(ctx, tightened)
+
+	s.tightenedMu.Lock()
+	defer s.tightenedMu.Unlock()
+
+	if err == nil {
+		delete(s.unsavedTightenings, tightened.Owner.UID)
+
+		return
+	}
+
+	s.unsavedTightenings[tightened.Owner.UID] = struct{}{}
+
+	logger.ErrorContext(ctx, "persist tightened memory threshold failed",
+		"ownerKind", tightened.Owner.Kind,
+		"owner", tightened.Owner.Name,
+		"reason", err,
+	)
+}
+
+// thresholdOwner returns the owner the tightened threshold of the pod is kept for: its controlling
+// owner, or the pod itself when it has none.
+func thresholdOwner(pod *Pod) Owner {
+	if pod.Owner != nil {
+		return *pod.Owner
+	}
+
+	return Owner{APIVersion: "v1", Kind: _barePodOwnerKind, Name: pod.Name, UID: pod.UID}
+}
+
+// refreshTightenedThresholds reloads the persisted tightened thresholds, so that they survive
+// controller restarts and run-once mode, and so that deleting one resets the workload's threshold.
+// Thresholds that failed to persist are saved again and kept over the persisted ones. When they
+// cannot be listed, the thresholds of the previous reconcile are kept.
+func (s *Service) refreshTightenedThresholds(ctx context.Context, logger *slog.Logger) {
+	if s.oomTightenPercent <= 0 || !s.recordRestarts {
+		return
+	}
+
+	s.tightenedMu.Lock()
+
+	unsaved := make([]TightenedThreshold, 0, len(s.unsavedTightenings))
+	for uid := range s.unsavedTightenings {
+		unsaved = append(unsaved, s.tightenedThresholds[uid])
+	}
+
+	s.tightenedMu.Unlock()
+
+	for _, tightened := range unsaved {
+		s.saveTightenedThreshold(ctx, logger, tightened)
+	}
+
+	thresholds, err := s.repo.ListTightenedThresholdsQuery(ctx)
+	if err != nil {
+		logger.WarnContext(ctx, "list persisted tightened memory thresholds failed", "reason", err)
+
+		return
+	}
+
+	byOwner := make(map[string]TightenedThreshold, len(thresholds))
+	for _, threshold := range thresholds {
+		byOwner[threshold.Owner.UID] = threshold
+	}
+
+	s.tightenedMu.Lock()
+	defer s.tightenedMu.Unlock()
+
+	for uid := range s.unsavedTightenings {
+		byOwner[uid] = s.tightenedThresholds[uid]
+	}
+
+	s.tightenedThresholds = byOwner
+}
+
+// forgetVanishedTightenings forgets the tightened thresholds of workloads without listed pods, e.g.
+// deleted workloads and bare pods. Persisted ones are pruned with the restart records.
+func (s *Service) forgetVanishedTightenings(pods []Pod) {
+	listed := make(map[string]struct{}, len(pods))
+	for i := range pods {
+		listed[thresholdOwner(&pods[i]).UID] = struct{}{}
+	}
+
+	s.tightenedMu.Lock()
+	defer s.tightenedMu.Unlock()
+
+	for uid := range s.tightenedThresholds {
+		if _, ok := listed[uid]; !ok {
+			delete(s.tightenedThresholds, uid)
+			delete(s.unsavedTightenings, uid)
+		}
+	}
+}
+
+// effectiveThreshold resolves the memory threshold annotation against memoryLimit and applies the
+// lower tightened threshold of the pod's workload, if any.
+func (s *Service) effectiveThreshold(
+	ctx context.Context,
+	logger *slog.Logger,
+	pod Pod,
+	memoryLimit *resource.Quantity,
+) (resource.Quantity, error) {
+	threshold, err := resolveMemoryThreshold(ctx, logger, pod, s.annotationMemoryThresholdKey, memoryLimit)
+	if err != nil {
+		return resource.Quantity{}, err
+	}
+
+	s.tightenedMu.Lock()
+	tightened, ok := s.tightenedThresholds[thresholdOwner(&pod).UID]
+	s.tightenedMu.Unlock()
+
+	if ok && tightened.Threshold.Cmp(threshold) < 0 {
+		return tightened.Threshold, nil
+	}
+
+	return threshold, nil
+}
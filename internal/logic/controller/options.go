@@ -0,0 +1,174 @@
+package controller
+
+import (
+	"log/slog"
+	"time"
+)
+
+// Option configures optional Service behavior. Optional features stay disabled
+// unless the corresponding option is passed to New.
+type Option func(*Service)
+
+// WithBlackout forbids evictions while the blackout policy is active and defers
+// scheduled evictions to the end of the blackout period.
+func WithBlackout(policy blackoutPolicy) Option {
+	return func(s *Service) {
+		s.blackout = policy
+	}
+}
+
+// WithReadinessGate keeps the controller not ready until a reconcile listed the pods and the metrics
+// of those with a memory threshold, instead of becoming ready before the first reconcile.
+func WithReadinessGate() Option {
+	return func(s *Service) {
+		s.readinessGate = true
+	}
+}
+
+// WithMetricsBlindLimit fails Ping once reconciles needing pod metrics could fetch none for n
+// consecutive reconciles, so a silently dead metrics API makes the controller unhealthy.
+func WithMetricsBlindLimit(n int) Option {
+	return func(s *Service) {
+		s.metricsBlindLimit = n
+	}
+}
+
+// WithOneShot disables in-process timers for scheduled evictions. The restart-at
+// annotation is still written; a later run evicts the pod through the missed-eviction path.
+// Intended for run-once (CronJob) usage where the process exits after a single reconcile.
+func WithOneShot() Option {
+	return func(s *Service) {
+		s.oneShot = true
+	}
+}
+
+// WithOOMThresholdTightening lowers the effective memory threshold of a pod by percent
+// each time a new OOMKilled termination is observed, so the controller acts earlier next time.
+func WithOOMThresholdTightening(percent float64) Option {
+	return func(s *Service) {
+		s.oomTightenPercent = percent
+	}
+}
+
+// WithMinReadyReplicas only allows evicting a pod when its owning workload has at least n
+// other Ready replicas. Bare pods without a controlling owner are never evicted when n > 0.
+func WithMinReadyReplicas(n int) Option {
+	return func(s *Service) {
+		s.minReadyReplicas = n
+	}
+}
+
+// WithRestartSpread spreads scheduled restarts of pods that share an owner and a restart schedule
+// evenly across the spread window, so replicas do not restart within seconds of each other.
+func WithRestartSpread(spread time.Duration) Option {
+	return func(s *Service) {
+		s.restartSpread = spread
+	}
+}
+
+// WithDeterministicJitter derives the jitter of a scheduled eviction from a hash of the pod UID
+// instead of picking it at random, so a pod keeps its restart time across controller restarts
+// and replicas are spread evenly across the jitter window.
+func WithDeterministicJitter() Option {
+	return func(s *Service) {
+		s.deterministicJitter = true
+	}
+}
+
+// WithRestartMinInterval defers a scheduled restart that would fire less than interval after the pod
+// was created to the first schedule run after that, and reports a Warning Event on the pod.
+func WithRestartMinInterval(interval time.Duration) Option {
+	return func(s *Service) {
+		s.restartMinInterval = interval
+	}
+}
+
+// WithPendingEvictionPersistence persists pending scheduled evictions through the repository and
+// restores them on start, so a controller restart right before a scheduled eviction neither loses it
+// nor runs it twice.
+func WithPendingEvictionPersistence() Option {
+	return func(s *Service) {
+		s.persistPending = true
+	}
+}
+
+// WithContainerAggregation sets how container memory usages combine into the pod value compared against
+// the threshold: sum (default), max or named:<container>. Pods override it with the container-aggregation annotation.
+func WithContainerAggregation(mode string) Option {
+	return func(s *Service) {
+		s.containerAggregationMode = mode
+	}
+}
+
+// WithVPAMode makes threshold evictions aware of VerticalPodAutoscalers targeting the pod's workload:
+// VPAModeUpperBound compares usage against the recommended memory upper bound instead of the threshold,
+// VPAModeDefer leaves pods of workloads that VPA restarts itself to VPA.
+func WithVPAMode(mode VPAMode) Option {
+	return func(s *Service) {
+		s.vpaMode = mode
+	}
+}
+
+// WithCluster names the cluster the service reconciles when one process watches several clusters.
+// The name is added to logs, metrics and simulate decisions, and distinguishes the service's pinger.
+func WithCluster(name string) Option {
+	return func(s *Service) {
+		s.cluster = name
+		s.logger = s.logger.With("cluster", name)
+	}
+}
+
+// WithSerialRestart evicts scheduled replicas of the same owner one at a time: after each eviction
+// the next one waits until the owner has as many Ready pods as before, or until readyTimeout elapses.
+func WithSerialRestart(readyTimeout time.Duration) Option {
+	return func(s *Service) {
+		s.serialRestart = true
+		s.serialReadyTimeout = readyTimeout
+	}
+}
+
+// WithEvictionVerification checks after each eviction that a replacement pod of the same owner
+// becomes Ready within timeout; otherwise further evictions of that workload are suspended.
+func WithEvictionVerification(timeout time.Duration) Option {
+	return func(s *Service) {
+		s.verifyTimeout = timeout
+	}
+}
+
+// WithCanary evicts the first replica of an owner's scheduled restart as a canary and soaks its
+// replacement for soak before evicting the rest; the remaining replicas skip this run if it fails.
+func WithCanary(soak time.Duration) Option {
+	return func(s *Service) {
+		s.canarySoak = soak
+	}
+}
+
+// WithRestartRecording stores the time and reason of every eviction as the owning workload's last restart.
+func WithRestartRecording() Option {
+	return func(s *Service) {
+		s.recordRestarts = true
+	}
+}
+
+// WithPodMemoryGauges exports the memory usage and effective threshold of up to maxPods pods as gauges.
+func WithPodMemoryGauges(maxPods int) Option {
+	return func(s *Service) {
+		s.memoryGaugesMaxPods = maxPods
+	}
+}
+
+// WithAuditLogger records every eviction decision (evicted, skipped or deferred) and its policy
+// inputs with logger.
+func WithAuditLogger(logger *slog.Logger) Option {
+	return func(s *Service) {
+		s.auditLogger = logger
+	}
+}
+
+// WithNotifier sends every eviction decision (evicted, skipped or deferred) to notifier. Several
+// notifiers may be added.
+func WithNotifier(notifier Notifier) Option {
+	return func(s *Service) {
+		s.notifiers = append(s.notifiers, notifier)
+	}
+}
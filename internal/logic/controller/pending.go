@@ -0,0 +1,139 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"maps"
+	"slices"
+	"time"
+)
+
+// persistPendingEviction stores the fire time of a pending scheduled eviction. Failures are logged
+// only: the restart-at annotation still recovers the eviction after a controller restart.
+func (s *Service) persistPendingEviction(
+	ctx context.Context,
+	logger *slog.Logger,
+	namespace,
+	name string,
+	fireAt time.Time,
+) {
+	if !s.persistPending {
+		return
+	}
+
+	if err := s.repo.SavePendingEvictionCommand(ctx, namespace, name, fireAt); err != nil {
+		logger.WarnContext(ctx, "persist pending eviction failed",
+			"pod", name,
+			"namespace", namespace,
+			"reason", err,
+		)
+	}
+}
+
+// forgetPendingEviction removes a persisted pending scheduled eviction once it ran or was cancelled.
+func (s *Service) forgetPendingEviction(logger *slog.Logger, namespace, name string) {
+	if !s.persistPending {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), _evictionTimeout)
+	defer cancel()
+
+	if err := s.repo.DeletePendingEvictionCommand(ctx, namespace, name); err != nil {
+		logger.WarnContext(ctx, "forget pending eviction failed",
+			"pod", name,
+			"namespace", namespace,
+			"reason", err,
+		)
+	}
+}
+
+// handOffPendingEvictions persists the pending scheduled evictions stopped on shutdown, so that the
+// next controller instance re-arms them at the same fire time even when persisting them failed when
+// they were scheduled.
+func (s *Service) handOffPendingEvictions(ctx context.Context, logger *slog.Logger, stopped []ScheduledEviction) {
+	if !s.persistPending || len(stopped) == 0 {
+		return
+	}
+
+	for _, pending := range stopped {
+		s.persistPendingEviction(ctx, logger, pending.Namespace, pending.Name, pending.FireAt)
+	}
+
+	logger.InfoContext(ctx, "handed off pending evictions", "count", len(stopped))
+}
+
+// handOffInterruptedEviction keeps the persisted entry of a scheduled eviction the shutdown
+// interrupted before the pod was evicted, e.g. while waiting for its owner's lock or canary, so that
+// the next controller instance runs it right away.
+func (s *Service) handOffInterruptedEviction(logger *slog.Logger, scheduled ScheduledEviction) {
+	if !s.persistPending {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), _evictionTimeout)
+	defer cancel()
+
+	s.persistPendingEviction(ctx, logger, scheduled.Namespace, scheduled.Name, scheduled.FireAt)
+
+	logger.InfoContext(ctx, "handed off interrupted eviction",
+		"pod", scheduled.Name,
+		"namespace", scheduled.Namespace,
+	)
+}
+
+// restorePendingEvictions re-arms persisted pending scheduled evictions at their original fire time.
+// Entries of pods that are gone, were already restarted after the fire time or no longer have a
+// valid schedule are dropped.
+func (s *Service) restorePendingEvictions(ctx context.Context, logger *slog.Logger) {
+	if !s.persistPending || s.oneShot {
+		return
+	}
+
+	pending, err := s.repo.ListPendingEvictionsQuery(ctx)
+	if err != nil {
+		logger.WarnContext(ctx, "list persisted pending evictions failed", "reason", err)
+
+		return
+	}
+
+	restored := 0
+
+	for _, p := range pending {
+		pod, ok := s.fetchScheduledPod(logger, p.Namespace, p.Name)
+		if !ok || !pod.CreatedAt.Before(p.At) || !s.scheduleStillValid(logger, &pod) {
+			s.forgetPendingEviction(logger, p.Namespace, p.Name)
+
+			continue
+		}
+
+		if s.armEvictionTimer(logger, p.Namespace, p.Name, s.podRestartAt(&pod), p.At) {
+			restored++
+		}
+	}
+
+	logger.InfoContext(ctx, "restored pending evictions", "count", restored, "persisted", len(pending))
+}
+
+// podRestartAt returns the restart-at annotation of the pod, zero when it is missing or invalid.
+func (s *Service) podRestartAt(pod *Pod) time.Time {
+	restartAt, err := time.Parse(time.RFC3339, pod.Annotations[s.annotationRestartAtKey])
+	if err != nil {
+		return time.Time{}
+	}
+
+	return restartAt
+}
+
+// PendingEvictionsQuery returns the pending scheduled evictions, soonest first.
+func (s *Service) PendingEvictionsQuery() []ScheduledEviction {
+	s.timerMu.Lock()
+	pending := slices.Collect(maps.Values(s.pendingEvictions))
+	s.timerMu.Unlock()
+
+	slices.SortFunc(pending, func(a, b ScheduledEviction) int {
+		return a.FireAt.Compare(b.FireAt)
+	})
+
+	return pending
+}
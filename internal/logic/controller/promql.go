@@ -0,0 +1,66 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/skillcoder/preoomkiller-controller/internal/infra/metrics"
+)
+
+// promQLExpr returns the pod's PromQL condition with $POD and $NAMESPACE replaced.
+func promQLExpr(pod *Pod) string {
+	return strings.NewReplacer(
+		"$POD", pod.Name,
+		"$NAMESPACE", pod.Namespace,
+	).Replace(pod.Annotations[PreoomkillerAnnotationPromQLKey])
+}
+
+// processPromQL evicts the pod when its PromQL condition holds. Returns true if the pod was evicted.
+func (s *Service) processPromQL(
+	ctx context.Context,
+	logger *slog.Logger,
+	pod Pod,
+) (bool, error) {
+	expr := promQLExpr(&pod)
+	logger = logger.With("pod", pod.Name, "namespace", pod.Namespace, "controller", "processPromQL", "promql", expr)
+
+	holds, err := s.repo.EvaluatePromQLQuery(ctx, expr)
+	if err != nil {
+		// A missing Prometheus server is a setup problem, not a failure of the pod: retrying it would not help.
+		var target notConfigured
+		if errors.As(err, &target) {
+			metrics.RecordEvictionSkipped(s.cluster, string(SkipReasonPromQLNotConfigured))
+			s.reportMisconfiguration(ctx, logger, &pod, EventReasonPromQLNotConfigured,
+				fmt.Sprintf("promql condition %q cannot be evaluated, the controller has no Prometheus server configured; "+
+					"the pod is not evicted by it", pod.Annotations[PreoomkillerAnnotationPromQLKey]),
+			)
+
+			return false, nil
+		}
+
+		return false, fmt.Errorf("%w: %w", ErrEvaluatePromQL, err)
+	}
+
+	if !holds {
+		logger.DebugContext(ctx, "promql condition does not hold")
+
+		return false, nil
+	}
+
+	ok, err := s.evictPodCommand(ctx, logger, pod.Namespace, pod.Name, &pod, TriggerPromQL, evictionInputs{
+		detail: fmt.Sprintf("PromQL condition %q holds", expr),
+		promQL: expr,
+	})
+	if err != nil {
+		return false, fmt.Errorf("%w: %w", ErrEvictPod, err)
+	}
+
+	if ok {
+		logger.InfoContext(ctx, "pod evicted by promql condition")
+	}
+
+	return ok, nil
+}
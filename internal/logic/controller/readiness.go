@@ -0,0 +1,19 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+)
+
+// markReady closes ready, once. With the readiness gate, it is called by the first reconcile that
+// listed the pods and the metrics of those with a memory threshold, so a controller lacking RBAC
+// permissions or a metrics API never becomes ready.
+func (s *Service) markReady(ctx context.Context, logger *slog.Logger) {
+	s.readyOnce.Do(func() {
+		if s.readinessGate {
+			logger.InfoContext(ctx, "readiness gate passed: pods and their metrics were listed")
+		}
+
+		close(s.ready)
+	})
+}
@@ -0,0 +1,41 @@
+package controller
+
+import "time"
+
+// ReloadCommand applies reloaded settings: the reconcile interval and the pod label selector. Pending
+// scheduled evictions are kept for the pods still selected. When a setting changed, a reconcile runs
+// right away with the new settings, and the interval restarts from it.
+func (s *Service) ReloadCommand(interval time.Duration, labelSelector string) {
+	s.settingsMu.Lock()
+	changed := interval != s.interval || labelSelector != s.labelSelector
+	s.interval = interval
+	s.labelSelector = labelSelector
+	s.settingsMu.Unlock()
+
+	if !changed {
+		return
+	}
+
+	s.logger.Info("controller settings reloaded",
+		"cluster", s.cluster,
+		"interval", interval,
+		"labelSelector", labelSelector,
+	)
+	s.TriggerReconcileCommand()
+}
+
+// currentInterval returns the reconcile interval, which may be reloaded.
+func (s *Service) currentInterval() time.Duration {
+	s.settingsMu.RLock()
+	defer s.settingsMu.RUnlock()
+
+	return s.interval
+}
+
+// currentLabelSelector returns the pod label selector, which may be reloaded.
+func (s *Service) currentLabelSelector() string {
+	s.settingsMu.RLock()
+	defer s.settingsMu.RUnlock()
+
+	return s.labelSelector
+}
@@ -0,0 +1,50 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+)
+
+// readyReplicasSkipReason returns SkipReasonReadyReplicas when the pod's owning workload has fewer
+// than minReadyReplicas other Ready pods. The check is independent of PodDisruptionBudgets.
+func (s *Service) readyReplicasSkipReason(ctx context.Context, pod *Pod) (SkipReason, error) {
+	if s.minReadyReplicas <= 0 {
+		return "", nil
+	}
+
+	if pod.Owner == nil {
+		return SkipReasonReadyReplicas, nil
+	}
+
+	ready, err := s.countReadyOwnerPods(ctx, *pod, pod.Name)
+	if err != nil {
+		return "", err
+	}
+
+	if ready < s.minReadyReplicas {
+		return SkipReasonReadyReplicas, nil
+	}
+
+	return "", nil
+}
+
+// countReadyOwnerPods returns the number of Ready pods controlled by pod's owner, ignoring the pod named exclude.
+// Terminating pods are not counted: they may still report Ready while they shut down.
+func (s *Service) countReadyOwnerPods(ctx context.Context, pod Pod, exclude string) (int, error) {
+	pods, err := s.repo.ListOwnerPodsQuery(ctx, pod.Namespace, *pod.Owner)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrListOwnerPods, err)
+	}
+
+	ready := 0
+
+	for i := range pods {
+		if pods[i].Name == exclude || pods[i].NotReady || pods[i].Terminating {
+			continue
+		}
+
+		ready++
+	}
+
+	return ready, nil
+}
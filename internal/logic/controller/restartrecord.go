@@ -0,0 +1,80 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// _barePodOwnerKind is recorded as owner kind for pods without a controlling owner.
+const _barePodOwnerKind = "Pod"
+
+// _restartRecordPruneInterval is the minimum time between two prunes of the restart records.
+const _restartRecordPruneInterval = time.Hour
+
+// recordRestart stores the eviction as the workload's last restart. Failures are logged only:
+// the eviction already happened and must not be reported as failed.
+func (s *Service) recordRestart(
+	ctx context.Context,
+	logger *slog.Logger,
+	pod *Pod,
+	trigger EvictionTrigger,
+	at time.Time,
+) {
+	if !s.recordRestarts {
+		return
+	}
+
+	record := RestartRecord{
+		Namespace: pod.Namespace,
+		OwnerKind: _barePodOwnerKind,
+		OwnerName: pod.Name,
+		Pod:       pod.Name,
+		Reason:    trigger,
+		At:        at,
+	}
+
+	if pod.Owner != nil {
+		record.OwnerKind = pod.Owner.Kind
+		record.OwnerName = pod.Owner.Name
+	}
+
+	if err := s.repo.RecordRestartCommand(ctx, record); err != nil {
+		logger.WarnContext(ctx, "record last restart failed",
+			"pod", pod.Name,
+			"namespace", pod.Namespace,
+			"reason", err,
+		)
+	}
+}
+
+// pruneRestartRecords removes the restart records, workload suspensions and tightened thresholds of
+// deleted workloads, at most once per _restartRecordPruneInterval, so that the ConfigMap does not grow
+// with every workload ever evicted. Failures are logged only: the next prune retries.
+func (s *Service) pruneRestartRecords(ctx context.Context, logger *slog.Logger) {
+	if !s.recordRestarts {
+		return
+	}
+
+	now := time.Now()
+
+	prunedAt := s.restartRecordsPrunedAt.Load()
+	if prunedAt != 0 && now.Sub(time.Unix(0, prunedAt)) < _restartRecordPruneInterval {
+		return
+	}
+
+	if !s.restartRecordsPrunedAt.CompareAndSwap(prunedAt, now.UnixNano()) {
+		return
+	}
+
+	pruned, err := s.repo.PruneRestartRecordsCommand(ctx)
+	if err != nil {
+		logger.WarnContext(ctx, "prune restart records failed", "reason", err)
+
+		return
+	}
+
+	if pruned > 0 {
+		logger.InfoContext(ctx, "pruned restart records of deleted workloads", "count", pruned)
+	}
+}
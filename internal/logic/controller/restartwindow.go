@@ -0,0 +1,129 @@
+package controller
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+// restartWindowClockLayout is the layout of restart-window start/end times.
+const restartWindowClockLayout = "15:04"
+
+// restartSpec returns the pod's restart-schedule, or its restart-window when no schedule is set.
+// ok is false when the pod has neither.
+func (s *Service) restartSpec(pod *Pod) (string, bool) {
+	if spec, ok := pod.Annotations[s.annotationRestartScheduleKey]; ok {
+		return spec, true
+	}
+
+	spec, ok := pod.Annotations[PreoomkillerAnnotationRestartWindowKey]
+
+	return spec, ok
+}
+
+// nextRestartAt returns the pod's next scheduled restart after `after`: the next cron occurrence of
+// restart-schedule, or a uniformly random time inside the next occurrence of restart-window.
+// Occurrences on the pod's skip-dates are deferred to the next occurrence.
+func (s *Service) nextRestartAt(pod *Pod, after time.Time) (time.Time, error) {
+	skip, err := parseSkipDates(pod.Annotations[PreoomkillerAnnotationSkipDatesKey])
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if len(skip) == 0 {
+		return s.nextOccurrence(pod, after)
+	}
+
+	location, err := time.LoadLocation(s.restartTZ(pod))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("load location: %w", err)
+	}
+
+	// Each skipped occurrence moves past one listed date, so len(skip)+1 attempts suffice.
+	for range len(skip) + 1 {
+		next, err := s.nextOccurrence(pod, after)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		local := next.In(location)
+		if _, skipped := skip[local.Format(time.DateOnly)]; !skipped {
+			return next, nil
+		}
+
+		// Continue from the last instant of the skipped day.
+		after = time.Date(local.Year(), local.Month(), local.Day()+1, 0, 0, 0, 0, location).Add(-time.Nanosecond)
+	}
+
+	return time.Time{}, fmt.Errorf("%w: no occurrence outside skip-dates", ErrSkipDatesParse)
+}
+
+// nextOccurrence returns the next restart-schedule or restart-window occurrence after `after`.
+func (s *Service) nextOccurrence(pod *Pod, after time.Time) (time.Time, error) {
+	tz := s.restartTZ(pod)
+
+	if spec, ok := pod.Annotations[s.annotationRestartScheduleKey]; ok {
+		return s.scheduleParser.NextAfter(spec, tz, after)
+	}
+
+	startSpec, length, err := parseRestartWindow(pod.Annotations[PreoomkillerAnnotationRestartWindowKey])
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	start, err := s.scheduleParser.NextAfter(startSpec, tz, after)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	// The restart time does not require cryptographic randomness.
+	// #nosec G404
+	return start.Add(time.Duration(rand.Int63n(int64(length)))), nil
+}
+
+// parseRestartWindow parses "HH:MM-HH:MM" into a daily cron spec for the window start and the
+// window length. A window whose end is not after its start spans midnight (e.g. 23:00-01:00).
+func parseRestartWindow(spec string) (string, time.Duration, error) {
+	startStr, endStr, ok := strings.Cut(strings.TrimSpace(spec), "-")
+	if !ok {
+		return "", 0, fmt.Errorf("%w: %q: expected HH:MM-HH:MM", ErrRestartWindowParse, spec)
+	}
+
+	start, err := time.Parse(restartWindowClockLayout, startStr)
+	if err != nil {
+		return "", 0, fmt.Errorf("%w: %q: %w", ErrRestartWindowParse, spec, err)
+	}
+
+	end, err := time.Parse(restartWindowClockLayout, endStr)
+	if err != nil {
+		return "", 0, fmt.Errorf("%w: %q: %w", ErrRestartWindowParse, spec, err)
+	}
+
+	length := end.Sub(start)
+	if length <= 0 {
+		length += 24 * time.Hour
+	}
+
+	return fmt.Sprintf("%d %d * * *", start.Minute(), start.Hour()), length, nil
+}
+
+// parseSkipDates parses a comma-separated list of YYYY-MM-DD dates into a set.
+func parseSkipDates(spec string) (map[string]struct{}, error) {
+	dates := make(map[string]struct{})
+
+	for raw := range strings.SplitSeq(spec, ",") {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+
+		if _, err := time.Parse(time.DateOnly, raw); err != nil {
+			return nil, fmt.Errorf("%w: %q: %w", ErrSkipDatesParse, raw, err)
+		}
+
+		dates[raw] = struct{}{}
+	}
+
+	return dates, nil
+}
@@ -0,0 +1,215 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/skillcoder/preoomkiller-controller/internal/infra/metrics"
+)
+
+// retryQueue holds the pods whose eviction triggers failed, keyed by "namespace/name", with a per-pod
+// exponential backoff: the delay before the next retry doubles with each consecutive failure.
+type retryQueue struct {
+	baseDelay time.Duration
+	maxDelay  time.Duration
+	mu        sync.Mutex
+	// failures counts the consecutive failures of each pod.
+	failures map[string]int
+	// due is the time of the next retry of each pod.
+	due map[string]time.Time
+}
+
+// WithRetry retries the eviction triggers of a pod that failed, e.g. on a failed eviction or metrics
+// fetch, after baseDelay instead of at the next reconcile. The delay doubles with each consecutive
+// failure of the pod, up to maxDelay.
+func WithRetry(baseDelay, maxDelay time.Duration) Option {
+	return func(s *Service) {
+		s.retry = &retryQueue{
+			baseDelay: baseDelay,
+			maxDelay:  max(baseDelay, maxDelay),
+			failures:  make(map[string]int),
+			due:       make(map[string]time.Time),
+		}
+	}
+}
+
+// add records a failure of the pod and schedules its retry. Returns the delay before the retry.
+func (q *retryQueue) add(key string, now time.Time) time.Duration {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.failures[key]++
+
+	delay := q.baseDelay
+	for range q.failures[key] - 1 {
+		if delay >= q.maxDelay/2 {
+			delay = q.maxDelay
+
+			break
+		}
+
+		delay *= 2
+	}
+
+	q.due[key] = now.Add(delay)
+
+	return delay
+}
+
+// forget drops the pod from the queue and resets its backoff.
+func (q *retryQueue) forget(key string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	delete(q.failures, key)
+	delete(q.due, key)
+}
+
+// retain drops the pods that are not in keys, e.g. because they were deleted.
+func (q *retryQueue) retain(keys map[string]struct{}) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for key := range q.failures {
+		if _, ok := keys[key]; !ok {
+			delete(q.failures, key)
+			delete(q.due, key)
+		}
+	}
+}
+
+// next returns the time of the earliest retry; false when no retry is scheduled.
+func (q *retryQueue) next() (time.Time, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	var next time.Time
+
+	for _, at := range q.due {
+		if next.IsZero() || at.Before(next) {
+			next = at
+		}
+	}
+
+	return next, !next.IsZero()
+}
+
+// popDue removes the retries due at now from the queue and returns their pods, sorted. Their backoff
+// is kept until they are forgotten.
+func (q *retryQueue) popDue(now time.Time) []string {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	var keys []string
+
+	for key, at := range q.due {
+		if !at.After(now) {
+			keys = append(keys, key)
+			delete(q.due, key)
+		}
+	}
+
+	slices.Sort(keys)
+
+	return keys
+}
+
+// retryTimer returns a channel receiving when the earliest retry is due, nil when none is scheduled,
+// and a func stopping the timer.
+func (s *Service) retryTimer() (<-chan time.Time, func()) {
+	if s.retry == nil {
+		return nil, func() {}
+	}
+
+	next, ok := s.retry.next()
+	if !ok {
+		return nil, func() {}
+	}
+
+	timer := time.NewTimer(max(time.Until(next), 0))
+
+	return timer.C, func() { timer.Stop() }
+}
+
+// trackRetry schedules a retry of the pod when its eviction triggers failed, and resets its backoff
+// when they succeeded.
+func (s *Service) trackRetry(ctx context.Context, logger *slog.Logger, pod *Pod, failed bool) {
+	if s.retry == nil {
+		return
+	}
+
+	key := pod.Namespace + "/" + pod.Name
+
+	if !failed {
+		s.retry.forget(key)
+
+		return
+	}
+
+	delay := s.retry.add(key, time.Now())
+
+	logger.InfoContext(ctx, "pod processing failed, retrying",
+		"pod", pod.Name,
+		"namespace", pod.Namespace,
+		"delay", delay,
+	)
+}
+
+// forgetVanishedRetries drops the retries of pods that are no longer listed.
+func (s *Service) forgetVanishedRetries(pods []Pod) {
+	if s.retry == nil {
+		return
+	}
+
+	listed := make(map[string]struct{}, len(pods))
+	for i := range pods {
+		listed[pods[i].Namespace+"/"+pods[i].Name] = struct{}{}
+	}
+
+	s.retry.retain(listed)
+}
+
+// retryFailedPods runs the eviction triggers of the pods whose retry is due. Pods that fail again are
+// retried after a longer delay.
+func (s *Service) retryFailedPods(ctx context.Context) {
+	logger := s.logger.With("controller", "retryFailedPods")
+
+	for _, key := range s.retry.popDue(time.Now()) {
+		if ctx.Err() != nil {
+			return
+		}
+
+		namespace, name, _ := strings.Cut(key, "/")
+
+		pod, err := s.getPod(ctx, namespace, name)
+		if err != nil {
+			var target notFound
+			if errors.As(err, &target) {
+				s.retry.forget(key)
+
+				continue
+			}
+
+			logger.WarnContext(ctx, "get pod for retry failed", "pod", name, "namespace", namespace, "reason", err)
+			s.trackRetry(ctx, logger, &Pod{Namespace: namespace, Name: name}, true)
+			metrics.RecordPodRetry(s.cluster, metrics.RetryResultFailure)
+
+			continue
+		}
+
+		failed := s.processEvictionTriggers(ctx, logger, pod, &reconcileRun{gauged: make(map[string]struct{})})
+
+		result := metrics.RetryResultSuccess
+		if failed {
+			result = metrics.RetryResultFailure
+		}
+
+		s.trackRetry(ctx, logger, &pod, failed)
+		metrics.RecordPodRetry(s.cluster, result)
+	}
+}
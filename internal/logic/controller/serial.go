@@ -0,0 +1,146 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+)
+
+// _replacementPollInterval is how often the owner's pods are checked while waiting for a replacement.
+const _replacementPollInterval = 5 * time.Second
+
+// fetchScheduledPod fetches the pod for a scheduled eviction. Returns false when the pod is gone
+// or cannot be fetched; the failure is logged.
+func (s *Service) fetchScheduledPod(logger *slog.Logger, namespace, name string) (Pod, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), _evictionTimeout)
+	defer cancel()
+
+	pod, err := s.getPod(ctx, namespace, name)
+	if err != nil {
+		var target notFound
+		if errors.As(err, &target) {
+			logger.DebugContext(ctx, "pod not found when fetching for scheduled eviction")
+
+			return Pod{}, false
+		}
+
+		logger.ErrorContext(ctx, "get pod for scheduled eviction failed",
+			"pod", name,
+			"namespace", namespace,
+			"reason", err,
+		)
+
+		return Pod{}, false
+	}
+
+	return pod, true
+}
+
+// evictSerialized evicts the pod while holding its owner's lock, then waits for a
+// replacement to become Ready before releasing it, so replicas restart one at a time.
+// Returns true when the service shut down before the pod was evicted.
+func (s *Service) evictSerialized(logger *slog.Logger, pod Pod) bool {
+	namespace, name := pod.Namespace, pod.Name
+
+	if pod.Owner == nil {
+		s.executeScheduledEviction(logger, namespace, name, &pod)
+
+		return false
+	}
+
+	release, acquired := s.acquireWorkload(pod.Owner.UID)
+	if !acquired {
+		return true
+	}
+	defer release()
+
+	countCtx, cancel := context.WithTimeout(context.Background(), _evictionTimeout)
+	readyBefore, err := s.countReadyOwnerPods(countCtx, pod, "")
+
+	cancel()
+
+	if err != nil {
+		logger.Error("count ready replicas before serialized eviction failed",
+			"pod", name,
+			"namespace", namespace,
+			"reason", err,
+		)
+
+		return false
+	}
+
+	// The pod may have changed while waiting for the lock; let evictPodCommand re-fetch it.
+	if !s.executeScheduledEviction(logger, namespace, name, nil) {
+		return false
+	}
+
+	s.waitForReplacement(logger, pod, readyBefore)
+
+	return false
+}
+
+// acquireWorkload blocks until the owner's lock is held or the service shuts down.
+// The returned release func must be called once the workload may restart the next replica.
+func (s *Service) acquireWorkload(ownerUID string) (func(), bool) {
+	s.workloadMu.Lock()
+
+	lock, ok := s.workloadLocks[ownerUID]
+	if !ok {
+		lock = make(chan struct{}, 1)
+		s.workloadLocks[ownerUID] = lock
+	}
+
+	s.workloadMu.Unlock()
+
+	select {
+	case lock <- struct{}{}:
+		return func() { <-lock }, true
+	case <-s.stopCh:
+		return nil, false
+	}
+}
+
+// waitForReplacement polls the owner's pods until, without the evicted pod, at least
+// readyBefore of them are Ready again, the serial ready timeout elapses, or the service shuts down.
+// Terminating pods are counted neither in readyBefore nor while polling.
+func (s *Service) waitForReplacement(logger *slog.Logger, evicted Pod, readyBefore int) {
+	logger = logger.With("pod", evicted.Name, "namespace", evicted.Namespace, "owner", evicted.Owner.Name)
+
+	timeout := time.NewTimer(s.serialReadyTimeout)
+	defer timeout.Stop()
+
+	ticker := time.NewTicker(_replacementPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		case <-timeout.C:
+			logger.Warn("timed out waiting for replacement pod to become ready, continuing",
+				"timeout", s.serialReadyTimeout,
+			)
+
+			return
+		case <-ticker.C:
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), _evictionTimeout)
+		ready, err := s.countReadyOwnerPods(ctx, evicted, evicted.Name)
+
+		cancel()
+
+		if err != nil {
+			logger.Warn("count ready replicas failed", "reason", err)
+
+			continue
+		}
+
+		if ready >= readyBefore {
+			logger.Info("replacement pod is ready", "ready", ready)
+
+			return
+		}
+	}
+}
@@ -5,13 +5,14 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
-	"math/rand"
 	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"k8s.io/apimachinery/pkg/api/resource"
 
 	"github.com/skillcoder/preoomkiller-controller/internal/infra/metrics"
@@ -28,15 +29,91 @@ type Service struct {
 	annotationTZKey              string
 	annotationRestartAtKey       string
 	jitterMax                    time.Duration
+	deterministicJitter          bool
 	minPodAgeBeforeEviction      time.Duration
-	ready                        chan struct{}
-	doneCh                       chan struct{}
-	inShutdown                   atomic.Bool
-	mu                           sync.RWMutex
-	lastReconcileEndTime         time.Time
-	timerMu                      sync.Mutex
-	pendingTimers                map[string]*time.Timer
-	inFlightWg                   sync.WaitGroup
+	blackout                     blackoutPolicy
+	oneShot                      bool
+	oomTightenPercent            float64
+	minReadyReplicas             int
+	restartSpread                time.Duration
+	restartMinInterval           time.Duration
+	serialRestart                bool
+	serialReadyTimeout           time.Duration
+	stopCh                       chan struct{}
+	workloadMu                   sync.Mutex
+	workloadLocks                map[string]chan struct{}
+	verifyTimeout                time.Duration
+	suspendedWorkloads           map[string]SuspendedWorkload
+	canarySoak                   time.Duration
+	canaryBatches                map[string]*canaryBatch
+	memoryGaugesMaxPods          int
+	gaugeMu                      sync.Mutex
+	// gaugedPods holds the "namespace/name" keys of pods with exported memory gauges.
+	gaugedPods  map[string]struct{}
+	misconfigMu sync.Mutex
+	// misconfigReported maps pod UID to the reported misconfiguration messages by Event reason.
+	misconfigReported        map[string]map[string]string
+	recordRestarts           bool
+	persistPending           bool
+	containerAggregationMode string
+	vpaMode                  VPAMode
+	cluster                  string
+	auditLogger              *slog.Logger
+	notifiers                []Notifier
+	ready                    chan struct{}
+	readyOnce                sync.Once
+	// metricsBlindLimit is the number of consecutive reconciles without pod metrics that fail Ping;
+	// 0 disables.
+	metricsBlindLimit      int
+	metricsBlindReconciles atomic.Int64
+	// readinessGate keeps ready open until a reconcile listed the pods and their metrics.
+	readinessGate        bool
+	doneCh               chan struct{}
+	inShutdown           atomic.Bool
+	mu                   sync.RWMutex
+	lastReconcileEndTime time.Time
+	timerMu              sync.Mutex
+	pendingTimers        map[string]*time.Timer
+	// pendingEvictions describes the eviction of each pending timer.
+	pendingEvictions map[string]ScheduledEviction
+	inFlightWg       sync.WaitGroup
+	// reconcileNow requests a reconcile before the next tick; buffered so requests coalesce.
+	reconcileNow chan struct{}
+	// retry holds the pods whose eviction triggers are retried before the next reconcile; nil disables.
+	retry *retryQueue
+	// annotationWatch queues the pods whose annotations changed; nil disables.
+	annotationWatch *annotationWatch
+	// usageHistory keeps the recent memory usage samples of each pod.
+	usageHistory *usageHistory
+	// reconcileConcurrency is the number of workers processing the pods of a reconcile.
+	reconcileConcurrency int
+	// podPacing is the pause of a reconcile worker between two pods.
+	podPacing time.Duration
+	// reconcileTimeout bounds each reconcile of RunCommand; 0 disables.
+	reconcileTimeout time.Duration
+	// reconcileOverran is set when the last reconcile of RunCommand exceeded reconcileTimeout.
+	reconcileOverran atomic.Bool
+	// defaultMemoryThreshold is the memory threshold of pods with a memory limit but none of their own.
+	defaultMemoryThreshold string
+	// observedAnnotationsInterval is the minimum time between two writes of the last-observed-usage
+	// annotations of a pod; 0 disables them.
+	observedAnnotationsInterval time.Duration
+	// settingsMu guards the settings applied by ReloadCommand: interval and labelSelector.
+	settingsMu          sync.RWMutex
+	namespaceDefaults   bool
+	namespaceDefaultsMu sync.RWMutex
+	// namespaceDefaultValues maps namespaces to the pod annotations defaulted by their Namespace annotations.
+	namespaceDefaultValues map[string]map[string]string
+	enrolledMu             sync.Mutex
+	// enrolledPods are the pods listed by the last reconcile.
+	enrolledPods []Pod
+	tightenedMu  sync.Mutex
+	// tightenedThresholds maps the owner UID of a workload to its memory threshold tightened after OOMKills.
+	tightenedThresholds map[string]TightenedThreshold
+	// unsavedTightenings holds the owner UIDs of the tightened thresholds that failed to persist.
+	unsavedTightenings map[string]struct{}
+	// restartRecordsPrunedAt is the UnixNano time of the last prune of the restart records.
+	restartRecordsPrunedAt atomic.Int64
 }
 
 // New creates a new controller service.
@@ -52,8 +129,9 @@ func New(
 	annotationRestartAtKey string,
 	jitterMax time.Duration,
 	minPodAgeBeforeEviction time.Duration,
+	opts ...Option,
 ) *Service {
-	return &Service{
+	s := &Service{
 		logger:                       logger,
 		repo:                         repo,
 		scheduleParser:               parser,
@@ -67,8 +145,28 @@ func New(
 		minPodAgeBeforeEviction:      minPodAgeBeforeEviction,
 		ready:                        make(chan struct{}),
 		doneCh:                       make(chan struct{}),
+		stopCh:                       make(chan struct{}),
+		reconcileNow:                 make(chan struct{}, 1),
+		workloadLocks:                make(map[string]chan struct{}),
+		suspendedWorkloads:           make(map[string]SuspendedWorkload),
+		tightenedThresholds:          make(map[string]TightenedThreshold),
+		unsavedTightenings:           make(map[string]struct{}),
+		canaryBatches:                make(map[string]*canaryBatch),
+		misconfigReported:            make(map[string]map[string]string),
+		gaugedPods:                   make(map[string]struct{}),
 		pendingTimers:                make(map[string]*time.Timer, _defaultPendingTimersCapacity),
+		pendingEvictions:             make(map[string]ScheduledEviction, _defaultPendingTimersCapacity),
+		containerAggregationMode:     ContainerAggregationSum,
+		reconcileConcurrency:         1,
+		podPacing:                    _defaultPodPacing,
+		usageHistory:                 newUsageHistory(_defaultUsageHistorySize),
 	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
 }
 
 func (s *Service) Start(ctx context.Context) error {
@@ -78,6 +176,10 @@ func (s *Service) Start(ctx context.Context) error {
 		return nil
 	}
 
+	if s.annotationWatch != nil {
+		go s.watchAnnotations(ctx)
+	}
+
 	go s.RunCommand(ctx)
 
 	return nil
@@ -85,21 +187,38 @@ func (s *Service) Start(ctx context.Context) error {
 
 // Name returns the name of the server component
 func (s *Service) Name() string {
+	if s.cluster != "" {
+		return "preoomkiller-controller/" + s.cluster
+	}
+
 	return "preoomkiller-controller"
 }
 
+// Cluster returns the cluster name of the controller, empty outside multi-cluster mode.
+func (s *Service) Cluster() string {
+	return s.cluster
+}
+
 func (s *Service) Ping(ctx context.Context) error {
 	select {
 	case <-ctx.Done():
 		return ctx.Err()
 	case <-s.ready:
 		lastReconsileAge := s.getLastReconcileAge()
-		if lastReconsileAge > 2*s.interval {
+		if lastReconsileAge > 2*s.currentInterval() {
 			return fmt.Errorf("last reconcile was too long ago: %s", lastReconsileAge.Round(time.Second).String())
 		}
 
-		return nil
+		if s.reconcileOverran.Load() {
+			return fmt.Errorf("%w: %s", ErrReconcileOverrun, s.reconcileTimeout)
+		}
+
+		return s.metricsBlindnessError()
 	default:
+		if s.readinessGate {
+			return ErrReadinessGate
+		}
+
 		return fmt.Errorf("controller service is not ready")
 	}
 }
@@ -117,7 +236,8 @@ func (s *Service) Shutdown(ctx context.Context) error {
 
 	s.logger.InfoContext(ctx, "shutting down controller service")
 
-	s.stopPendingTimers()
+	close(s.stopCh)
+	s.handOffPendingEvictions(ctx, s.logger, s.stopPendingTimers())
 
 	select {
 	case <-ctx.Done():
@@ -143,43 +263,67 @@ func (s *Service) Shutdown(ctx context.Context) error {
 	return nil
 }
 
-func (s *Service) stopPendingTimers() {
+// stopPendingTimers stops the pending timers and returns the evictions of those that had not fired.
+func (s *Service) stopPendingTimers() []ScheduledEviction {
 	s.timerMu.Lock()
 	defer s.timerMu.Unlock()
 
+	var stopped []ScheduledEviction
+
 	for key, timer := range s.pendingTimers {
 		if timer.Stop() {
 			s.inFlightWg.Done()
+
+			stopped = append(stopped, s.pendingEvictions[key])
 		}
 
-		delete(s.pendingTimers, key)
+		s.deletePendingTimer(key)
 	}
+
+	return stopped
 }
 
+// processScheduledRestart sets the restart-at annotation to the next schedule run, shifted by
+// staggerOffset, and schedules the eviction.
 func (s *Service) processScheduledRestart(
 	ctx context.Context,
 	logger *slog.Logger,
 	pod Pod,
+	staggerOffset time.Duration,
 ) {
 	logger = logger.With("pod", pod.Name, "namespace", pod.Namespace)
 
 	restartAtStr, hasRestartAt := pod.Annotations[s.annotationRestartAtKey]
+	if hasRestartAt && s.restartAtStale(&pod) {
+		logger.InfoContext(ctx, "restart schedule changed, rescheduling",
+			"restartAt", restartAtStr,
+		)
+		s.cancelPendingEviction(pod.Namespace + "/" + pod.Name)
+
+		hasRestartAt = false
+	}
+
 	if hasRestartAt {
 		if s.handleExistingRestartAt(ctx, logger, pod, restartAtStr) {
 			return
 		}
 	}
 
-	spec := pod.Annotations[s.annotationRestartScheduleKey]
-	tz := pod.Annotations[s.annotationTZKey]
+	s.reportInvalidTZ(ctx, logger, &pod)
 
-	nextRun, err := s.scheduleParser.NextAfter(spec, tz, time.Now())
+	spec, _ := s.restartSpec(&pod)
+	tz := s.restartTZ(&pod)
+
+	nextRun, err := s.scheduledRestartAt(ctx, logger, &pod, staggerOffset)
 	if err != nil {
 		logger.WarnContext(ctx, "invalid restart schedule",
 			"spec", spec,
 			"tz", tz,
 			"reason", err,
 		)
+		s.reportMisconfiguration(ctx, logger, &pod, EventReasonInvalidRestartSchedule,
+			fmt.Sprintf("restart schedule %q cannot be evaluated, no restart is scheduled: %v", spec, err),
+		)
 
 		return
 	}
@@ -190,15 +334,10 @@ func (s *Service) processScheduledRestart(
 		"restartAt", restartAtValue,
 		"tz", tz,
 		"spec", spec,
+		"staggerOffset", staggerOffset,
 	)
 
-	if err := s.repo.SetAnnotationCommand(
-		ctx,
-		pod.Namespace,
-		pod.Name,
-		s.annotationRestartAtKey,
-		restartAtValue,
-	); err != nil {
+	if err := s.setRestartAt(ctx, logger, &pod, restartAtValue); err != nil {
 		logger.ErrorContext(ctx, "set restart-at annotation",
 			"reason", err,
 		)
@@ -206,7 +345,34 @@ func (s *Service) processScheduledRestart(
 		return
 	}
 
-	s.scheduleEviction(ctx, logger, pod.Namespace, pod.Name, nextRun)
+	s.recordDecision(ctx, logger, &pod, decisionRecord{
+		outcome: OutcomeDeferred,
+		trigger: TriggerSchedule,
+		reason:  DeferReasonRestartScheduled,
+		dueAt:   nextRun,
+	})
+	s.scheduleEviction(ctx, logger, &pod, nextRun)
+}
+
+// scheduledRestartAt returns when the pod's next scheduled restart is due: the next schedule run,
+// deferred when it is too soon after the previous restart, plus the pod's stagger offset.
+func (s *Service) scheduledRestartAt(
+	ctx context.Context,
+	logger *slog.Logger,
+	pod *Pod,
+	staggerOffset time.Duration,
+) (time.Time, error) {
+	nextRun, err := s.nextRestartAt(pod, time.Now())
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	nextRun, err = s.deferFlappingRestart(ctx, logger, pod, nextRun)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return nextRun.Add(staggerOffset), nil
 }
 
 func (s *Service) handleExistingRestartAt(
@@ -231,7 +397,7 @@ func (s *Service) handleExistingRestartAt(
 		logger.DebugContext(ctx, "recovering scheduled eviction",
 			"restartAt", restartAtStr,
 		)
-		s.scheduleEviction(ctx, logger, pod.Namespace, pod.Name, restartAt)
+		s.scheduleEviction(ctx, logger, &pod, restartAt)
 
 		return true
 	}
@@ -242,7 +408,7 @@ func (s *Service) handleExistingRestartAt(
 			"podCreatedAt", pod.CreatedAt.Format(time.RFC3339),
 		)
 
-		ok, evictErr := s.evictPodCommand(ctx, logger, pod.Namespace, pod.Name, &pod)
+		ok, evictErr := s.evictPodCommand(ctx, logger, pod.Namespace, pod.Name, &pod, TriggerSchedule, evictionInputs{})
 		if evictErr != nil {
 			logger.ErrorContext(ctx, "missed eviction failed",
 				"reason", evictErr,
@@ -275,45 +441,119 @@ const (
 func (s *Service) scheduleEviction(
 	ctx context.Context,
 	logger *slog.Logger,
-	namespace,
-	name string,
+	pod *Pod,
 	at time.Time,
 ) {
+	namespace, name := pod.Namespace, pod.Name
+
 	if s.inShutdown.Load() {
 		return
 	}
 
+	if s.oneShot {
+		logger.InfoContext(ctx, "one-shot mode, scheduled eviction left to a later run",
+			"pod", name,
+			"namespace", namespace,
+			"at", at.Format(time.RFC3339),
+		)
+
+		return
+	}
+
+	if s.hasPendingEviction(namespace + "/" + name) {
+		return
+	}
+
+	fireAt := s.deferPastBlackout(ctx, logger, at.Add(s.scheduleJitter(pod.UID)))
+	if !s.armEvictionTimer(logger, namespace, name, at, fireAt) {
+		return
+	}
+
+	s.persistPendingEviction(ctx, logger, namespace, name, fireAt)
+
+	logger.InfoContext(ctx, "scheduled eviction goroutine",
+		"pod", name,
+		"namespace", namespace,
+		"at", at.Format(time.RFC3339),
+		"delay", max(time.Until(fireAt), 0),
+	)
+}
+
+func (s *Service) hasPendingEviction(key string) bool {
+	s.timerMu.Lock()
+	defer s.timerMu.Unlock()
+
+	_, exists := s.pendingTimers[key]
+
+	return exists
+}
+
+// armEvictionTimer starts the timer of a scheduled eviction for restartAt firing at fireAt.
+// Returns false when the pod already has a pending eviction.
+func (s *Service) armEvictionTimer(logger *slog.Logger, namespace, name string, restartAt, fireAt time.Time) bool {
 	key := namespace + "/" + name
 
 	s.timerMu.Lock()
 	defer s.timerMu.Unlock()
 
 	if _, exists := s.pendingTimers[key]; exists {
-		return
+		return false
 	}
 
-	delay := max(time.Until(at), 0)
-
-	// Jitter does not require cryptographic randomness.
-	// #nosec G404
-	jitter := time.Duration(rand.Int63n(int64(s.jitterMax + 1)))
-
 	s.inFlightWg.Add(1)
 
-	// Callback runs asynchronously; passing ctx would be incorrect (it may be cancelled by then).
-	//nolint:contextcheck // runScheduledEviction uses context.Background() for the eviction call.
-	timer := time.AfterFunc(delay+jitter, func() {
+	// Callback runs asynchronously; the caller's ctx may be cancelled by then.
+	// runScheduledEviction uses context.Background() for the eviction call.
+	s.pendingTimers[key] = time.AfterFunc(max(time.Until(fireAt), 0), func() {
 		s.runScheduledEviction(logger, key, namespace, name)
 	})
+	s.pendingEvictions[key] = ScheduledEviction{
+		Cluster:   s.cluster,
+		Namespace: namespace,
+		Name:      name,
+		RestartAt: restartAt,
+		FireAt:    fireAt,
+	}
+	s.reportPendingTimers()
 
-	s.pendingTimers[key] = timer
+	return true
+}
 
-	logger.InfoContext(ctx, "scheduled eviction goroutine",
-		"pod", name,
-		"namespace", namespace,
-		"at", at.Format(time.RFC3339),
-		"delay", delay+jitter,
-	)
+// deletePendingTimer forgets the pending timer of key. The caller must hold timerMu.
+func (s *Service) deletePendingTimer(key string) {
+	delete(s.pendingTimers, key)
+	delete(s.pendingEvictions, key)
+	s.reportPendingTimers()
+}
+
+// reportPendingTimers updates the pending scheduled eviction metrics. The caller must hold timerMu.
+func (s *Service) reportPendingTimers() {
+	var next time.Time
+
+	for _, pending := range s.pendingEvictions {
+		if next.IsZero() || pending.FireAt.Before(next) {
+			next = pending.FireAt
+		}
+	}
+
+	metrics.SetScheduledEvictionsPending(s.cluster, len(s.pendingTimers), next)
+}
+
+// deferPastBlackout moves fireAt to the end of the blackout period it falls into, if any.
+func (s *Service) deferPastBlackout(ctx context.Context, logger *slog.Logger, fireAt time.Time) time.Time {
+	if s.blackout == nil {
+		return fireAt
+	}
+
+	allowedAt := s.blackout.NextAllowed(fireAt)
+	if allowedAt.After(fireAt) {
+		logger.InfoContext(ctx, "scheduled eviction falls into blackout window, deferring",
+			"fireAt", fireAt.Format(time.RFC3339),
+			"deferredTo", allowedAt.Format(time.RFC3339),
+		)
+	}
+
+	return allowedAt
 }
 
 func (s *Service) runScheduledEviction(
@@ -324,14 +564,49 @@ func (s *Service) runScheduledEviction(
 ) {
 	defer s.inFlightWg.Done()
 
-	if s.inShutdown.Load() {
-		s.timerMu.Lock()
-		delete(s.pendingTimers, key)
-		s.timerMu.Unlock()
+	s.timerMu.Lock()
+	scheduled, armed := s.pendingEvictions[key]
+	s.timerMu.Unlock()
+
+	interrupted := s.inShutdown.Load()
+	if interrupted {
+		logger.Info("scheduled eviction fired during shutdown, handing off", "pod", name, "namespace", namespace)
+	} else if pod, ok := s.fetchScheduledPod(logger, namespace, name); ok && s.scheduleStillValid(logger, &pod) {
+		switch {
+		case s.canarySoak > 0:
+			interrupted = s.runCanaryEviction(logger, pod)
+		case s.serialRestart:
+			interrupted = s.evictSerialized(logger, pod)
+		default:
+			s.executeScheduledEviction(logger, namespace, name, &pod)
+		}
+	}
+
+	s.timerMu.Lock()
+	s.deletePendingTimer(key)
+	s.timerMu.Unlock()
+
+	// The next controller instance resumes an eviction interrupted by the shutdown. When the shutdown
+	// dropped the timer before it ran, its persisted entry is left as it was.
+	if interrupted {
+		if armed {
+			s.handOffInterruptedEviction(logger, scheduled)
+		}
 
 		return
 	}
 
+	s.forgetPendingEviction(logger, namespace, name)
+}
+
+// executeScheduledEviction evicts the pod and reports whether it was evicted.
+// pod may be nil, in which case it is fetched before the eviction guards run.
+func (s *Service) executeScheduledEviction(
+	logger *slog.Logger,
+	namespace,
+	name string,
+	pod *Pod,
+) bool {
 	evictCtx, cancel := context.WithTimeout(context.Background(), _evictionTimeout)
 	defer cancel()
 
@@ -340,7 +615,7 @@ func (s *Service) runScheduledEviction(
 		"namespace", namespace,
 	)
 
-	ok, err := s.evictPodCommand(evictCtx, logger, namespace, name, nil)
+	ok, err := s.evictPodCommand(evictCtx, logger, namespace, name, pod, TriggerSchedule, evictionInputs{})
 	if err != nil {
 		logger.ErrorContext(evictCtx, "scheduled eviction failed",
 			"pod", name,
@@ -356,49 +631,99 @@ func (s *Service) runScheduledEviction(
 		)
 	}
 
-	s.timerMu.Lock()
-	delete(s.pendingTimers, key)
-	s.timerMu.Unlock()
+	return ok
 }
 
 // ReconcileCommand runs one iteration of the reconciliation loop.
 func (s *Service) ReconcileCommand(ctx context.Context) error {
+	ctx, span := tracer.Start(ctx, "ReconcileCommand", trace.WithAttributes(attribute.String("cluster", s.cluster)))
+	defer span.End()
+
+	err := s.reconcile(ctx)
+	recordSpanError(span, err)
+
+	return err
+}
+
+// reconcile processes all selected pods once.
+func (s *Service) reconcile(ctx context.Context) error {
 	logger := s.logger.With("controller", "ReconcileCommand")
 
-	pods, err := s.repo.ListPodsQuery(ctx, s.labelSelector)
+	pods, err := s.listPods(ctx, logger)
 	if err != nil {
-		return fmt.Errorf("list pods: %w", err)
+		return err
 	}
 
 	logger.DebugContext(ctx, "starting to process pods", "count", len(pods))
 
-	evictedCount := 0
-
-	for i := range pods {
-		if done := s.reconcileOnePod(ctx, logger, pods[i], &evictedCount); done {
-			return nil
-		}
+	s.pruneRestartRecords(ctx, logger)
+	s.refreshTightenedThresholds(ctx, logger)
+	s.forgetVanishedTightenings(pods)
+	s.cancelVanishedEvictions(ctx, logger, pods)
+	s.forgetVanishedRetries(pods)
+	s.rememberListedPods(pods)
+	s.forgetVanishedUsage(pods)
+	s.forgetMisconfigurations(pods)
+	s.rememberEnrolledPods(pods)
+
+	podMetrics, metricsErr := s.listPodMetrics(ctx, logger, pods)
+	if metricsErr == nil {
+		s.markReady(ctx, logger)
+	}
 
-		select {
-		case <-ctx.Done():
-			logger.InfoContext(ctx, "context done, stopping reconciliation")
+	run := &reconcileRun{
+		staggerOffsets: s.staggerOffsets(pods),
+		podMetrics:     podMetrics,
+		vpas:           newVPAIndex(),
+		gauged:         make(map[string]struct{}),
+	}
 
-			return nil
-		case <-time.After(1 * time.Second):
-		}
+	if done := s.reconcilePods(ctx, logger, pods, run); done {
+		return nil
 	}
 
-	logger.InfoContext(ctx, "pods evicted", "count", len(pods), "evicted", evictedCount)
+	s.trackMetricsBlindness(ctx, logger, run)
+
+	s.pruneMemoryGauges(ctx, logger, run)
+
+	logger.InfoContext(ctx, "pods evicted", "count", len(pods), "evicted", run.evicted)
+	trace.SpanFromContext(ctx).SetAttributes(
+		attribute.Int("pods", len(pods)),
+		attribute.Int("evicted", run.evicted),
+		attribute.Int("failed", run.failed),
+	)
+
+	if run.failed > 0 {
+		return fmt.Errorf("%w: %d of %d", ErrReconcilePodsFailed, run.failed, len(pods))
+	}
 
 	return nil
 }
 
+// reconcileRun holds the state of a single ReconcileCommand iteration.
+type reconcileRun struct {
+	// mu guards evicted and failed, counted by the reconcile workers.
+	mu      sync.Mutex
+	evicted int
+	failed  int
+	// staggerOffsets maps "namespace/name" to the pod's offset within its owner's restart spread window.
+	staggerOffsets map[string]time.Duration
+	podMetrics     podMetricsIndex
+	vpas           *vpaIndex
+	// gauged holds the "namespace/name" keys of pods whose memory gauges were updated in this run.
+	gauged       map[string]struct{}
+	gaugesCapped int
+	// metricsNeeded counts the pods whose memory usage was looked up, metricsFetched those it was found for.
+	metricsNeeded  int
+	metricsFetched int
+}
+
 // reconcileOnePod processes one pod (schedule-based and memory-threshold). Returns true if context is done.
 func (s *Service) reconcileOnePod(
 	ctx context.Context,
 	logger *slog.Logger,
 	pod Pod,
-	evictedCount *int,
+	run *reconcileRun,
 ) bool {
 	select {
 	case <-ctx.Done():
@@ -408,38 +733,81 @@ func (s *Service) reconcileOnePod(
 	default:
 	}
 
-	if _, hasSchedule := pod.Annotations[s.annotationRestartScheduleKey]; hasSchedule {
-		s.processScheduledRestart(ctx, logger, pod)
+	ctx, span := tracer.Start(ctx, "reconcilePod", podAttributes(&pod))
+	defer span.End()
+
+	s.processOOMFeedback(ctx, logger, &pod)
+
+	if _, hasSchedule := s.restartSpec(&pod); hasSchedule {
+		s.processScheduledRestart(ctx, logger, pod, run.staggerOffsets[pod.Namespace+"/"+pod.Name])
+	} else if _, hasRestartAt := pod.Annotations[s.annotationRestartAtKey]; hasRestartAt {
+		s.removeStaleRestartAt(ctx, logger, pod)
 	}
 
-	if _, hasThreshold := pod.Annotations[s.annotationMemoryThresholdKey]; hasThreshold {
-		evicted, err := s.processPod(ctx, logger, pod)
-		if err != nil {
-			logger.ErrorContext(ctx, "process pod error",
-				"pod", pod.Name,
-				"namespace", pod.Namespace,
-				"reason", err,
-			)
+	failed := s.processEvictionTriggers(ctx, logger, pod, run)
+	s.trackRetry(ctx, logger, &pod, failed)
 
-			return false
-		}
+	return false
+}
 
-		if evicted {
-			*evictedCount++
+// processEvictionTriggers evicts the pod when its memory threshold or PromQL condition asks for it.
+// Returns true when a trigger failed.
+func (s *Service) processEvictionTriggers(ctx context.Context, logger *slog.Logger, pod Pod, run *reconcileRun) bool {
+	if _, hasThreshold := pod.Annotations[s.annotationMemoryThresholdKey]; hasThreshold {
+		evicted, err := s.processPod(ctx, logger, pod, run)
+		if run.record(ctx, logger, pod, evicted, err) {
+			return err != nil
 		}
 	}
 
+	if _, hasPromQL := pod.Annotations[PreoomkillerAnnotationPromQLKey]; hasPromQL {
+		evicted, err := s.processPromQL(ctx, logger, pod)
+		run.record(ctx, logger, pod, evicted, err)
+
+		return err != nil
+	}
+
 	return false
 }
 
+// record counts the outcome of an eviction trigger. Returns true when the pod was evicted or
+// failed, so no further trigger should process it in this run.
+func (r *reconcileRun) record(ctx context.Context, logger *slog.Logger, pod Pod, evicted bool, err error) bool {
+	if err != nil {
+		logger.ErrorContext(ctx, "process pod error",
+			"pod", pod.Name,
+			"namespace", pod.Namespace,
+			"reason", err,
+		)
+		recordSpanError(trace.SpanFromContext(ctx), err)
+
+		r.mu.Lock()
+		r.failed++
+		r.mu.Unlock()
+
+		return true
+	}
+
+	if evicted {
+		r.mu.Lock()
+		r.evicted++
+		r.mu.Unlock()
+	}
+
+	return evicted
+}
+
 // resolveMemoryThreshold returns the effective memory threshold from the pod annotation.
-// The annotation may be an absolute quantity (e.g. "512Mi") or a percentage of the pod's memory limit (e.g. "80%").
-// Returns ErrMemoryLimitNotDefined when the annotation is a percentage but the pod has no memory limit (caller should skip eviction).
+// The annotation may be an absolute quantity (e.g. "512Mi"), a percentage of memoryLimit (e.g. "80%")
+// or a headroom below memoryLimit (e.g. "limit-128Mi"); memoryLimit is the pod's or a container's limit.
+// Returns ErrMemoryLimitNotDefined when the annotation is relative to the memory limit but memoryLimit is
+// nil (caller should skip eviction).
 func resolveMemoryThreshold(
 	ctx context.Context,
 	logger *slog.Logger,
 	pod Pod,
 	annotationKey string,
+	memoryLimit *resource.Quantity,
 ) (resource.Quantity, error) {
 	memoryThresholdStr, ok := pod.Annotations[annotationKey]
 	if !ok {
@@ -456,7 +824,11 @@ func resolveMemoryThreshold(
 	)
 
 	if before, ok0 := strings.CutSuffix(memoryThresholdStr, "%"); ok0 {
-		return resolveMemoryThresholdFromPercent(ctx, logger, strings.TrimSpace(before), pod.MemoryLimit)
+		return resolveMemoryThresholdFromPercent(ctx, logger, strings.TrimSpace(before), memoryLimit)
+	}
+
+	if after, ok0 := strings.CutPrefix(memoryThresholdStr, memoryThresholdLimitPrefix); ok0 {
+		return resolveMemoryThresholdFromHeadroom(ctx, logger, strings.TrimSpace(after), memoryLimit)
 	}
 
 	// Absolute quantity
@@ -472,6 +844,12 @@ func resolveMemoryThreshold(
 	return threshold, nil
 }
 
+// relativeToLimit reports whether the memory threshold annotation is a percentage of or a headroom
+// below the memory limit.
+func relativeToLimit(memoryThresholdStr string) bool {
+	return strings.HasSuffix(memoryThresholdStr, "%") || strings.HasPrefix(memoryThresholdStr, memoryThresholdLimitPrefix)
+}
+
 // resolveMemoryThresholdFromPercent interprets percentStr as a percentage of the memory limit
 // and returns the corresponding absolute threshold.
 func resolveMemoryThresholdFromPercent(
@@ -511,87 +889,268 @@ func resolveMemoryThresholdFromPercent(
 	return *threshold, nil
 }
 
-// getPodMemoryUsageOrSkip fetches pod metrics; skip is true when the pod should be skipped (e.g. not found, no metrics).
-func (s *Service) getPodMemoryUsageOrSkip(ctx context.Context, logger *slog.Logger, pod Pod) (resource.Quantity, bool, error) {
-	podMetrics, err := s.repo.GetPodMetricsQuery(ctx, pod.Namespace, pod.Name)
+// resolveMemoryThresholdFromHeadroom interprets headroomStr as a quantity below the memory limit and
+// returns the corresponding absolute threshold.
+func resolveMemoryThresholdFromHeadroom(
+	ctx context.Context,
+	logger *slog.Logger,
+	headroomStr string,
+	memoryLimit *resource.Quantity,
+) (resource.Quantity, error) {
+	headroom, err := resource.ParseQuantity(headroomStr)
 	if err != nil {
-		var target notFound
-		if errors.As(err, &target) {
-			logger.WarnContext(ctx, "pod metrics not found, skipping")
+		return resource.Quantity{}, fmt.Errorf("%w: invalid headroom %q: %w", ErrMemoryThresholdParse, headroomStr, err)
+	}
 
-			return resource.Quantity{}, true, nil
-		}
+	if headroom.Sign() <= 0 {
+		return resource.Quantity{}, fmt.Errorf("%w: headroom must be positive, got %q",
+			ErrMemoryThresholdParse, headroomStr,
+		)
+	}
+
+	if memoryLimit == nil || memoryLimit.IsZero() {
+		logger.WarnContext(ctx, "memory threshold is relative to the memory limit but pod has no memory limit, skipping eviction",
+			"memoryLimitSet", false,
+		)
 
-		return resource.Quantity{}, false, fmt.Errorf("%w: %w", ErrGetPodMetrics, err)
+		return resource.Quantity{}, ErrMemoryLimitNotDefined
+	}
+
+	if headroom.Cmp(*memoryLimit) >= 0 {
+		return resource.Quantity{}, fmt.Errorf("%w: headroom %s is not below the memory limit %s",
+			ErrMemoryThresholdParse, headroom.String(), memoryLimit.String(),
+		)
+	}
+
+	threshold := memoryLimit.DeepCopy()
+	threshold.Sub(headroom)
+
+	logger.DebugContext(ctx, "resolved headroom threshold",
+		"memoryLimit", memoryLimit.String(),
+		"memoryThreshold", threshold.String(),
+	)
+
+	return threshold, nil
+}
+
+// getPodMemoryUsageOrSkip fetches pod metrics and returns the usage compared in the container aggregation
+// mode with the threshold it is compared against; skip is true when the pod should be skipped (e.g. not
+// found, no metrics).
+func (s *Service) getPodMemoryUsageOrSkip(
+	ctx context.Context,
+	logger *slog.Logger,
+	pod Pod,
+	index podMetricsIndex,
+	mode string,
+) (resource.Quantity, resource.Quantity, bool, error) {
+	podMetrics, skip, err := s.lookupPodMetrics(ctx, logger, pod, index)
+	if skip || err != nil {
+		return resource.Quantity{}, resource.Quantity{}, skip, err
+	}
+
+	relative := relativeToLimit(pod.Annotations[s.annotationMemoryThresholdKey])
+
+	memoryUsage, threshold, ok, err := aggregateMemoryUsage(&pod, podMetrics, mode,
+		func(memoryLimit *resource.Quantity) (resource.Quantity, error) {
+			if relative && (memoryLimit == nil || memoryLimit.IsZero()) {
+				return resource.Quantity{}, ErrMemoryLimitNotDefined
+			}
+
+			return s.effectiveThreshold(ctx, logger, pod, memoryLimit)
+		},
+	)
+	if err != nil {
+		return resource.Quantity{}, resource.Quantity{}, false, err
 	}
 
-	if podMetrics.MemoryUsage == nil {
-		logger.WarnContext(ctx, "pod memory usage is nil, skipping")
+	if !ok {
+		logger.WarnContext(ctx, "pod memory usage is nil, skipping", "containerAggregation", mode)
 
-		return resource.Quantity{}, true, nil
+		return resource.Quantity{}, resource.Quantity{}, true, nil
 	}
 
-	if podMetrics.MemoryUsage.IsZero() {
-		logger.WarnContext(ctx, "pod memory usage is zero, skipping")
+	if memoryUsage.IsZero() {
+		logger.WarnContext(ctx, "pod memory usage is zero, skipping", "containerAggregation", mode)
 
-		return resource.Quantity{}, true, nil
+		return resource.Quantity{}, resource.Quantity{}, true, nil
 	}
 
-	return *podMetrics.MemoryUsage, false, nil
+	return memoryUsage, threshold, false, nil
 }
 
-func (s *Service) processPod(
+// thresholdCheck is the side-effect free outcome of comparing pod memory usage with its threshold.
+type thresholdCheck struct {
+	threshold  resource.Quantity
+	usage      resource.Quantity
+	breached   bool
+	skipReason SkipReason
+}
+
+// checkThreshold resolves the pod memory threshold and fetches its usage without modifying anything.
+// A non-empty skipReason means the pod cannot be evaluated (e.g. no metrics).
+func (s *Service) checkThreshold(
 	ctx context.Context,
 	logger *slog.Logger,
 	pod Pod,
-) (bool, error) {
-	logger = logger.With("pod", pod.Name, "namespace", pod.Namespace, "controller", "processPod")
+	index podMetricsIndex,
+	vpas *vpaIndex,
+) (thresholdCheck, error) {
+	mode, err := s.containerAggregation(&pod)
+	if err != nil {
+		logger.WarnContext(ctx, "invalid container-aggregation annotation, skipping", "reason", err)
+
+		return thresholdCheck{skipReason: SkipReasonInvalidContainerAggregation}, nil
+	}
 
-	podMemoryThreshold, err := resolveMemoryThreshold(ctx, logger, pod, s.annotationMemoryThresholdKey)
+	memoryLimit := memoryLimitFor(&pod, mode)
+
+	podMemoryThreshold, err := s.effectiveThreshold(ctx, logger, pod, memoryLimit)
 	if err != nil {
 		if errors.Is(err, ErrMemoryLimitNotDefined) {
-			return false, nil
+			return thresholdCheck{skipReason: SkipReasonNoMemoryLimit}, nil
 		}
 
-		return false, err
+		return thresholdCheck{}, err
 	}
 
 	logger = logger.With("memoryThreshold", podMemoryThreshold.String())
 
-	if pod.MemoryLimit != nil {
-		logger = logger.With("memoryLimit", pod.MemoryLimit.String())
+	if memoryLimit != nil {
+		logger = logger.With("memoryLimit", memoryLimit.String())
 	}
 
 	if podMemoryThreshold.IsZero() {
 		logger.WarnContext(ctx, "memory threshold is zero, skipping")
 
-		return false, nil
+		return thresholdCheck{skipReason: SkipReasonZeroThreshold}, nil
 	}
 
 	logger.DebugContext(ctx, "processing pod")
 
-	podMemoryUsage, skip, err := s.getPodMemoryUsageOrSkip(ctx, logger, pod)
+	podMemoryUsage, usageThreshold, skip, err := s.getPodMemoryUsageOrSkip(ctx, logger, pod, index, mode)
 	if skip {
-		return false, nil
+		return thresholdCheck{threshold: podMemoryThreshold, skipReason: SkipReasonMetricsMissing}, nil
 	}
 
 	if err != nil {
-		return false, err
+		return thresholdCheck{}, err
 	}
 
-	logger.DebugContext(ctx, "pod memory usage", "memoryUsage", podMemoryUsage.String())
+	logger.DebugContext(ctx, "pod memory usage",
+		"memoryUsage", podMemoryUsage.String(),
+		"usageThreshold", usageThreshold.String(),
+	)
 
-	if podMemoryUsage.Cmp(podMemoryThreshold) == 1 {
-		ok, err := s.evictPodCommand(ctx, logger, pod.Namespace, pod.Name, &pod)
-		if err != nil {
-			return false, fmt.Errorf("%w: %w", ErrEvictPod, err)
+	return s.applyVPA(ctx, logger, &pod, vpas, thresholdCheck{
+		threshold: usageThreshold,
+		usage:     podMemoryUsage,
+		breached:  podMemoryUsage.Cmp(usageThreshold) == 1,
+	}), nil
+}
+
+func (s *Service) processPod(
+	ctx context.Context,
+	logger *slog.Logger,
+	pod Pod,
+	run *reconcileRun,
+) (bool, error) {
+	logger = logger.With("pod", pod.Name, "namespace", pod.Namespace, "controller", "processPod")
+
+	check, err := s.checkThreshold(ctx, logger, pod, run.podMetrics, run.vpas)
+	run.recordMetricsLookup(check, err)
+
+	if err != nil {
+		if errors.Is(err, ErrMemoryThresholdParse) {
+			s.reportMisconfiguration(ctx, logger, &pod, EventReasonInvalidMemoryThreshold,
+				fmt.Sprintf("memory threshold %q is invalid, the pod is not evicted by memory usage: %v",
+					pod.Annotations[s.annotationMemoryThresholdKey], err),
+			)
 		}
 
-		if ok {
-			logger.InfoContext(ctx, "pod evicted", "memoryUsage", podMemoryUsage.String())
+		return false, err
+	}
 
-			return true, nil
+	s.recordMemoryGauges(&pod, check, run)
+
+	if check.skipReason != "" {
+		metrics.RecordEvictionSkipped(s.cluster, string(check.skipReason))
+	}
+
+	now := time.Now()
+
+	var delta *usageDelta
+	if check.skipReason == "" {
+		delta = s.recordUsage(&pod, check, now)
+		if delta != nil {
+			logger.DebugContext(ctx, "memory usage delta since last check",
+				"memoryUsage", check.usage.String(),
+				"memoryUsageDelta", delta.String(),
+			)
 		}
+
+		s.recordObservedUsage(ctx, logger, &pod, check, now)
+	}
+
+	if check.skipReason == SkipReasonInvalidContainerAggregation {
+		s.reportMisconfiguration(ctx, logger, &pod, EventReasonInvalidContainerAggregation,
+			fmt.Sprintf("container aggregation %q is invalid, want sum, max or named:<container>; "+
+				"the pod is not evicted by memory usage", pod.Annotations[PreoomkillerAnnotationContainerAggregationKey]),
+		)
+	}
+
+	if check.skipReason == SkipReasonNoMemoryLimit {
+		s.reportMisconfiguration(ctx, logger, &pod, EventReasonMissingMemoryLimit,
+			fmt.Sprintf("memory threshold %q is relative to the memory limit but no container sets a memory limit, "+
+				"the pod is not evicted by memory usage", pod.Annotations[s.annotationMemoryThresholdKey]),
+		)
+	}
+
+	if !check.breached {
+		return false, nil
+	}
+
+	reason, err := risingForSkipReason(&pod, s.usageHistory.history(pod.UID), now, s.usageHistoryCoverage())
+	if err != nil {
+		s.reportMisconfiguration(ctx, logger, &pod, EventReasonInvalidRisingFor,
+			fmt.Sprintf("rising-for %q is invalid, the pod is not evicted by memory usage: %v",
+				pod.Annotations[PreoomkillerAnnotationRisingForKey], err),
+		)
+
+		return false, err
+	}
+
+	if reason == SkipReasonNotRising {
+		metrics.RecordEvictionSkipped(s.cluster, string(reason))
+		logger.InfoContext(ctx, "memory threshold exceeded but usage is not rising, not evicting",
+			"memoryUsage", check.usage.String(),
+			"risingFor", pod.Annotations[PreoomkillerAnnotationRisingForKey],
+		)
+
+		return false, nil
+	}
+
+	detail := fmt.Sprintf("memory usage %s exceeded threshold %s", check.usage.String(), check.threshold.String())
+	if delta != nil {
+		detail += fmt.Sprintf(" (%s since last check)", delta)
+		logger = logger.With("memoryUsageDelta", delta.String())
+	}
+
+	ok, err := s.evictPodCommand(ctx, logger, pod.Namespace, pod.Name, &pod, TriggerThreshold, evictionInputs{
+		detail:    detail,
+		usage:     &check.usage,
+		threshold: &check.threshold,
+	})
+	if err != nil {
+		return false, fmt.Errorf("%w: %w", ErrEvictPod, err)
+	}
+
+	if ok {
+		logger.InfoContext(ctx, "pod evicted",
+			"memoryUsage", check.usage.String(),
+			"memoryThreshold", check.threshold.String(),
+		)
+
+		return true, nil
 	}
 
 	return false, nil
@@ -607,41 +1166,87 @@ func (s *Service) RunCommand(ctx context.Context) {
 
 	logger := s.logger.With("controller", "RunCommand")
 
-	ticker := time.NewTicker(s.interval)
+	ticker := time.NewTicker(s.currentInterval())
 	defer ticker.Stop()
 
 	// NOTE: set immidiatly to speed up first ready signal for pinger.
 	s.setLastReconcileEndTime()
 
-	close(s.ready)
+	s.restorePendingEvictions(ctx, logger)
+	s.restoreSuspendedWorkloads(ctx, logger)
+
+	if !s.readinessGate {
+		s.markReady(ctx, logger)
+	}
 
 	for {
-		err := s.ReconcileCommand(ctx)
+		err := s.reconcileWithDeadline(ctx, logger)
 		if err != nil {
 			logger.ErrorContext(ctx, "reconcile error", "reason", err)
 		}
 
 		s.setLastReconcileEndTime()
 
+		if !s.waitNextReconcile(ctx, logger, ticker) {
+			return
+		}
+	}
+}
+
+// waitNextReconcile waits for the next tick or a requested reconcile, retrying failed pods and
+// reconciling pods whose annotations changed in the meantime. Returns false when ctx is done.
+func (s *Service) waitNextReconcile(ctx context.Context, logger *slog.Logger, ticker *time.Ticker) bool {
+	for {
+		retryC, stopRetry := s.retryTimer()
+
 		select {
 		case <-ticker.C:
+			stopRetry()
+
+			return true
+		case <-s.reconcileNow:
+			stopRetry()
+			logger.InfoContext(ctx, "reconcile requested")
+			ticker.Reset(s.currentInterval())
+
+			return true
+		case <-retryC:
+			s.retryFailedPods(ctx)
+		case <-s.annotationChanges():
+			stopRetry()
+			s.reconcileChangedPods(ctx)
 		case <-ctx.Done():
+			stopRetry()
 			logger.InfoContext(ctx, "terminating main controller loop")
 
-			return
+			return false
 		}
 	}
 }
 
+// TriggerReconcileCommand makes the controller loop reconcile now instead of at the next tick, which
+// then restarts from the triggered reconcile. Requests made while one is already pending are merged
+// into it.
+func (s *Service) TriggerReconcileCommand() {
+	select {
+	case s.reconcileNow <- struct{}{}:
+	default:
+	}
+}
+
+// evictPodCommand evicts the pod unless an eviction guard skips it, and records the decision (audit
+// log, notifiers) and the eviction with Events. pod may be nil, in which case it is fetched first.
 func (s *Service) evictPodCommand(
 	ctx context.Context,
 	logger *slog.Logger,
 	namespace,
 	name string,
 	pod *Pod,
+	trigger EvictionTrigger,
+	inputs evictionInputs,
 ) (bool, error) {
 	if pod == nil {
-		fetched, getErr := s.repo.GetPodQuery(ctx, namespace, name)
+		fetched, getErr := s.getPod(ctx, namespace, name)
 		if getErr != nil {
 			var target notFound
 			if errors.As(getErr, &target) {
@@ -653,6 +1258,7 @@ func (s *Service) evictPodCommand(
 			logger.ErrorContext(ctx, "get pod for eviction failed, skipping eviction",
 				"reason", getErr,
 			)
+			metrics.RecordEvictionError(s.cluster, namespace, evictionErrorGetPod)
 
 			return false, fmt.Errorf("get pod for eviction: %w", getErr)
 		}
@@ -660,39 +1266,183 @@ func (s *Service) evictPodCommand(
 		pod = &fetched
 	}
 
-	podAge := time.Since(pod.CreatedAt)
-	if s.minPodAgeBeforeEviction > 0 && podAge < s.minPodAgeBeforeEviction {
-		logger.WarnContext(ctx, "eviction skipped, pod too young",
-			"pod", name,
-			"namespace", namespace,
-			"podAge", podAge.Round(time.Second).String(),
-			"minAge", s.minPodAgeBeforeEviction.Round(time.Second).String(),
-		)
-		metrics.RecordEvictionSkippedPodTooYoung(namespace, name)
+	record, err := s.evictGuardedPod(ctx, logger, pod, trigger, inputs)
 
-		return false, nil
-	}
+	return record.outcome == OutcomeEvicted, err
+}
 
-	err := s.repo.EvictPodCommand(ctx, namespace, name)
-	if err != nil {
-		var target notFound
-		if errors.As(err, &target) {
-			logger.DebugContext(ctx, "pod not found when evicting")
+// evictGuardedPod evicts the pod unless an eviction guard skips it, and returns the recorded decision;
+// its outcome is empty when the pod vanished before the eviction.
+func (s *Service) evictGuardedPod(
+	ctx context.Context,
+	logger *slog.Logger,
+	pod *Pod,
+	trigger EvictionTrigger,
+	inputs evictionInputs,
+) (decisionRecord, error) {
+	record := decisionRecord{trigger: trigger, inputs: inputs}
 
-			return false, nil
+	reason := s.evictionSkipReason(pod, time.Now())
+	if reason == "" {
+		var err error
+
+		reason, err = s.readyReplicasSkipReason(ctx, pod)
+		if err != nil {
+			metrics.RecordEvictionError(s.cluster, pod.Namespace, evictionErrorListOwnerPods)
+
+			record.outcome, record.reason = OutcomeFailed, evictionErrorListOwnerPods
+			s.recordDecision(ctx, logger, pod, record)
+
+			return record, err
 		}
+	}
+
+	if reason != "" {
+		s.recordEvictionSkip(ctx, logger, pod, reason)
 
-		var tooManyRequestsTarget tooManyRequests
-		if errors.As(err, &tooManyRequestsTarget) {
-			logger.DebugContext(ctx, "too many requests when evicting, will retry later")
+		record.outcome, record.reason = OutcomeSkipped, string(reason)
+		s.recordDecision(ctx, logger, pod, record)
+
+		return record, nil
+	}
+
+	evictedAt := time.Now()
+
+	record, err := s.evictPod(ctx, logger, pod, record)
+	if record.outcome != OutcomeEvicted {
+		return record, err
+	}
 
-			return false, nil
+	metrics.RecordEviction(s.cluster, pod.Namespace, string(trigger))
+	s.emitEvictionEvent(ctx, logger, pod, trigger, inputs.detail)
+	s.recordRestart(ctx, logger, pod, trigger, evictedAt)
+	s.startReplacementVerification(logger, *pod, evictedAt)
+	s.cancelEvictedPodSchedule(ctx, logger, pod, trigger)
+
+	return record, nil
+}
+
+// Eviction error types reported in preoomkiller_eviction_errors_total.
+const (
+	evictionErrorGetPod          = "get_pod"
+	evictionErrorListOwnerPods   = "list_owner_pods"
+	evictionErrorNotFound        = "not_found"
+	evictionErrorTooManyRequests = "too_many_requests"
+	evictionErrorAPI             = "api_error"
+)
+
+// evictPod calls the eviction API and returns record completed with the outcome. A vanished pod and an
+// eviction refused with 429 (e.g. by a PodDisruptionBudget) are not errors; they are retried by a later run.
+// Evicted, refused and failed evictions are recorded as decisions; a vanished pod leaves the outcome empty.
+// The evicted-reason annotation written before the eviction is removed again when it is refused or fails.
+func (s *Service) evictPod(
+	ctx context.Context,
+	logger *slog.Logger,
+	pod *Pod,
+	record decisionRecord,
+) (decisionRecord, error) {
+	ctx, span := tracer.Start(ctx, "evictPod", podAttributes(pod))
+	defer span.End()
+
+	annotated := s.annotateEvictedReason(ctx, logger, pod, record)
+
+	// The UID recorded when the pod was evaluated guards against evicting a recreated pod of the same name.
+	err := s.repo.EvictPodCommand(ctx, pod.Namespace, pod.Name, pod.UID)
+	if err == nil {
+		record.outcome = OutcomeEvicted
+		s.recordDecision(ctx, logger, pod, record)
+
+		return record, nil
+	}
+
+	var target notFound
+	if errors.As(err, &target) {
+		logger.DebugContext(ctx, "pod not found when evicting")
+		metrics.RecordEvictionError(s.cluster, pod.Namespace, evictionErrorNotFound)
+		span.AddEvent("pod not found")
+
+		return record, nil
+	}
+
+	var tooManyRequestsTarget tooManyRequests
+	if errors.As(err, &tooManyRequestsTarget) {
+		logger.DebugContext(ctx, "too many requests when evicting, will retry later")
+		metrics.RecordEvictionError(s.cluster, pod.Namespace, evictionErrorTooManyRequests)
+		metrics.RecordEvictionSkipped(s.cluster, string(SkipReasonPDBBlocked))
+		span.AddEvent("eviction refused with too many requests")
+
+		if annotated {
+			s.clearEvictedReason(ctx, logger, pod)
 		}
 
-		return false, fmt.Errorf("%w: %w", ErrEvictPod, err)
+		record.outcome, record.reason = OutcomeDeferred, DeferReasonEvictionRefused
+		s.recordDecision(ctx, logger, pod, record)
+
+		return record, nil
 	}
 
-	return true, nil
+	metrics.RecordEvictionError(s.cluster, pod.Namespace, evictionErrorAPI)
+
+	if annotated {
+		s.clearEvictedReason(ctx, logger, pod)
+	}
+
+	record.outcome, record.reason = OutcomeFailed, evictionErrorAPI
+	s.recordDecision(ctx, logger, pod, record)
+
+	err = fmt.Errorf("%w: %w", ErrEvictPod, err)
+	recordSpanError(span, err)
+
+	return record, err
+}
+
+// evictionSkipReason returns why the pod must not be evicted at now, or an empty reason when eviction is allowed.
+func (s *Service) evictionSkipReason(pod *Pod, now time.Time) SkipReason {
+	if s.blackout != nil && s.blackout.Active(now) {
+		return SkipReasonBlackout
+	}
+
+	if s.isWorkloadSuspended(pod) {
+		return SkipReasonWorkloadSuspended
+	}
+
+	if s.minPodAgeBeforeEviction > 0 && now.Sub(pod.CreatedAt) < s.minPodAgeBeforeEviction {
+		return SkipReasonPodTooYoung
+	}
+
+	// Evicting an already unhealthy pod only adds churn.
+	if pod.CrashLoopBackOff {
+		return SkipReasonCrashLoopBackOff
+	}
+
+	if pod.NotReady {
+		return SkipReasonNotReady
+	}
+
+	return ""
+}
+
+// recordEvictionSkip logs a skipped eviction and updates the matching skip metric.
+func (s *Service) recordEvictionSkip(ctx context.Context, logger *slog.Logger, pod *Pod, reason SkipReason) {
+	metrics.RecordEvictionSkipped(s.cluster, string(reason))
+
+	switch reason {
+	case SkipReasonPodTooYoung:
+		metrics.RecordEvictionSkippedPodTooYoung(s.cluster, pod.Namespace, pod.Name)
+	case SkipReasonCrashLoopBackOff, SkipReasonNotReady:
+		metrics.RecordEvictionSkippedUnhealthyPod(s.cluster, pod.Namespace, pod.Name, string(reason))
+	case SkipReasonReadyReplicas:
+		metrics.RecordEvictionSkippedReadyReplicas(s.cluster, pod.Namespace, pod.Name)
+	default:
+	}
+
+	logger.WarnContext(ctx, "eviction skipped",
+		"pod", pod.Name,
+		"namespace", pod.Namespace,
+		"reason", reason,
+		"podAge", time.Since(pod.CreatedAt).Round(time.Second).String(),
+		"minAge", s.minPodAgeBeforeEviction.Round(time.Second).String(),
+	)
 }
 
 func (s *Service) getLastReconcileAge() time.Duration {
@@ -1,11 +1,20 @@
 package controller
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"log/slog"
+	"maps"
+	"slices"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/skillcoder/preoomkiller-controller/internal/infra/cronparser"
 )
 
 // testQty parses a quantity string; panics on error (test only).
@@ -100,6 +109,30 @@ func Test_resolveMemoryThreshold(t *testing.T) {
 			memoryLimit: ptrQty(testQty("1Gi")),
 			wantErr:     ErrMemoryThresholdParse,
 		},
+		{
+			name:        "headroom no limit",
+			annotations: annotThreshold("limit-128Mi"),
+			memoryLimit: nil,
+			wantErr:     ErrMemoryLimitNotDefined,
+		},
+		{
+			name:        "headroom invalid quantity",
+			annotations: annotThreshold("limit-lots"),
+			memoryLimit: ptrQty(testQty("1Gi")),
+			wantErr:     ErrMemoryThresholdParse,
+		},
+		{
+			name:        "headroom zero",
+			annotations: annotThreshold("limit-0"),
+			memoryLimit: ptrQty(testQty("1Gi")),
+			wantErr:     ErrMemoryThresholdParse,
+		},
+		{
+			name:        "headroom not below limit",
+			annotations: annotThreshold("limit-1Gi"),
+			memoryLimit: ptrQty(testQty("1Gi")),
+			wantErr:     ErrMemoryThresholdParse,
+		},
 		// Absolute threshold
 		{
 			name:        "absolute valid",
@@ -122,6 +155,19 @@ func Test_resolveMemoryThreshold(t *testing.T) {
 			// 50% of 1Gi = 536870912
 			wantQty: testQtyBytes(536870912),
 		},
+		// Headroom threshold
+		{
+			name:        "headroom valid",
+			annotations: annotThreshold("limit-128Mi"),
+			memoryLimit: ptrQty(testQty("1Gi")),
+			wantQty:     testQty("896Mi"),
+		},
+		{
+			name:        "headroom with spaces",
+			annotations: annotThreshold("limit- 256Mi"),
+			memoryLimit: ptrQty(testQty("1Gi")),
+			wantQty:     testQty("768Mi"),
+		},
 	}
 
 	for _, tt := range tests {
@@ -130,7 +176,7 @@ func Test_resolveMemoryThreshold(t *testing.T) {
 
 			pod := newTestPod(tt.annotations, tt.memoryLimit)
 
-			got, err := resolveMemoryThreshold(t.Context(), logger, pod, PreoomkillerAnnotationMemoryThresholdKey)
+			got, err := resolveMemoryThreshold(t.Context(), logger, pod, PreoomkillerAnnotationMemoryThresholdKey, pod.MemoryLimit)
 			if tt.wantErr != nil {
 				require.ErrorIs(t, err, tt.wantErr)
 
@@ -142,3 +188,1312 @@ func Test_resolveMemoryThreshold(t *testing.T) {
 		})
 	}
 }
+
+func Test_staggerOffsets(t *testing.T) {
+	t.Parallel()
+
+	owner := &Owner{Kind: "ReplicaSet", Name: "app-abc", UID: "rs-uid"}
+	scheduled := func(name, spec string, owner *Owner) Pod {
+		return Pod{
+			Name:        name,
+			Namespace:   "default",
+			Annotations: map[string]string{PreoomkillerAnnotationRestartScheduleKey: spec},
+			Owner:       owner,
+		}
+	}
+
+	pods := []Pod{
+		scheduled("app-c", "0 3 * * *", owner),
+		scheduled("app-a", "0 3 * * *", owner),
+		scheduled("app-b", "0 3 * * *", owner),
+		scheduled("app-other-schedule", "0 4 * * *", owner),
+		scheduled("bare", "0 3 * * *", nil),
+		{Name: "unscheduled", Namespace: "default", Owner: owner},
+	}
+
+	svc := &Service{
+		annotationRestartScheduleKey: PreoomkillerAnnotationRestartScheduleKey,
+		annotationTZKey:              PreoomkillerAnnotationTZKey,
+		restartSpread:                9 * time.Minute,
+	}
+
+	require.Equal(t, map[string]time.Duration{
+		"default/app-a":              0,
+		"default/app-b":              3 * time.Minute,
+		"default/app-c":              6 * time.Minute,
+		"default/app-other-schedule": 0,
+	}, svc.staggerOffsets(pods))
+
+	svc.restartSpread = 0
+	require.Nil(t, svc.staggerOffsets(pods))
+}
+
+func Test_acquireWorkload(t *testing.T) {
+	t.Parallel()
+
+	svc := &Service{
+		stopCh:        make(chan struct{}),
+		workloadLocks: make(map[string]chan struct{}),
+	}
+
+	release, ok := svc.acquireWorkload("rs-uid")
+	require.True(t, ok)
+
+	// Another owner is independent.
+	releaseOther, ok := svc.acquireWorkload("other-uid")
+	require.True(t, ok)
+	releaseOther()
+
+	acquired := make(chan bool)
+
+	go func() {
+		_, ok := svc.acquireWorkload("rs-uid")
+		acquired <- ok
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("workload lock acquired twice")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(svc.stopCh)
+	require.False(t, <-acquired)
+
+	release()
+}
+
+// Test_waitForReplacement_ignoresTerminatingPods restarts a replica whose only Ready sibling is
+// terminating, and checks that the replacement is awaited against the replicas that stay.
+func Test_waitForReplacement_ignoresTerminatingPods(t *testing.T) {
+	t.Parallel()
+
+	owner := &Owner{Kind: "ReplicaSet", Name: "app-abc", UID: "rs-uid"}
+	evicted := Pod{Name: "app-a", Namespace: "default", Owner: owner}
+	terminating := Pod{Name: "app-b", Namespace: "default", Owner: owner, Terminating: true}
+
+	repo := &ownerPodsRepo{pods: []Pod{evicted, terminating}}
+	svc := &Service{
+		repo:               repo,
+		stopCh:             make(chan struct{}),
+		serialReadyTimeout: time.Minute,
+	}
+
+	readyBefore, err := svc.countReadyOwnerPods(t.Context(), evicted, "")
+	require.NoError(t, err)
+	require.Equal(t, 1, readyBefore, "the terminating sibling is not counted")
+
+	evicted.Terminating = true
+	repo.setPods([]Pod{evicted, terminating, {Name: "app-c", Namespace: "default", Owner: owner}})
+
+	done := make(chan struct{})
+
+	go func() {
+		svc.waitForReplacement(slog.Default(), evicted, readyBefore)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * _replacementPollInterval):
+		close(svc.stopCh)
+		t.Fatal("replacement not detected")
+	}
+}
+
+// ownerPodsRepo is a Repository stub listing a fixed set of owner pods.
+type ownerPodsRepo struct {
+	Repository
+
+	mu   sync.Mutex
+	pods []Pod
+}
+
+func (r *ownerPodsRepo) setPods(pods []Pod) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.pods = pods
+}
+
+func (r *ownerPodsRepo) ListOwnerPodsQuery(context.Context, string, Owner) ([]Pod, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return slices.Clone(r.pods), nil
+}
+
+func Test_verifyReplacement_timeoutSuspendsWorkload(t *testing.T) {
+	t.Parallel()
+
+	owner := &Owner{Kind: "ReplicaSet", Name: "app-abc", UID: "rs-uid"}
+	evicted := Pod{Name: "app-a", Namespace: "default", Owner: owner}
+	sibling := Pod{Name: "app-b", Namespace: "default", Owner: owner}
+	other := Pod{Name: "other", Namespace: "default", Owner: &Owner{UID: "other-uid"}}
+
+	repo := &suspensionRepo{suspended: make(map[string]SuspendedWorkload)}
+	newService := func() *Service {
+		return &Service{
+			logger:             slog.Default(),
+			repo:               repo,
+			stopCh:             make(chan struct{}),
+			suspendedWorkloads: make(map[string]SuspendedWorkload),
+			verifyTimeout:      10 * time.Millisecond,
+			recordRestarts:     true,
+		}
+	}
+
+	svc := newService()
+	require.Empty(t, svc.evictionSkipReason(&sibling, time.Now()))
+
+	// The timeout fires before the first poll, so the repository is never queried for pods.
+	svc.verifyReplacement(slog.Default(), evicted, time.Now())
+
+	require.Equal(t, SkipReasonWorkloadSuspended, svc.evictionSkipReason(&sibling, time.Now()))
+	require.Empty(t, svc.evictionSkipReason(&other, time.Now()))
+	require.Contains(t, repo.suspended, "rs-uid", "the suspension is persisted")
+	require.Equal(t, []string{"Warning EvictionsSuspended"}, repo.events)
+
+	restarted := newService()
+	restarted.restoreSuspendedWorkloads(t.Context(), slog.Default())
+	require.Equal(t, SkipReasonWorkloadSuspended, restarted.evictionSkipReason(&sibling, time.Now()),
+		"the suspension survives a controller restart")
+	require.Len(t, restarted.SuspendedWorkloadsQuery(), 1)
+
+	resumed, err := restarted.ResumeWorkloadCommand(t.Context(), "default", "replicaset", "app-abc")
+	require.NoError(t, err)
+	require.Equal(t, "app-a", resumed.Pod)
+	require.Empty(t, restarted.evictionSkipReason(&sibling, time.Now()))
+	require.Empty(t, repo.suspended)
+	require.Equal(t, []string{"Warning EvictionsSuspended", "Normal EvictionsResumed"}, repo.events)
+
+	_, err = restarted.ResumeWorkloadCommand(t.Context(), "default", "replicaset", "app-abc")
+	require.ErrorIs(t, err, ErrWorkloadNotSuspended)
+}
+
+// suspensionRepo is a Repository stub for the workload suspension tests.
+type suspensionRepo struct {
+	Repository
+
+	mu        sync.Mutex
+	suspended map[string]SuspendedWorkload
+	// events holds the "type reason" of the owner Events.
+	events []string
+}
+
+func (r *suspensionRepo) SuspendWorkloadCommand(_ context.Context, workload SuspendedWorkload) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.suspended[workload.Owner.UID] = workload
+
+	return nil
+}
+
+func (r *suspensionRepo) ResumeWorkloadCommand(_ context.Context, owner Owner) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.suspended, owner.UID)
+
+	return nil
+}
+
+func (r *suspensionRepo) ListSuspendedWorkloadsQuery(context.Context) ([]SuspendedWorkload, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return slices.Collect(maps.Values(r.suspended)), nil
+}
+
+func (r *suspensionRepo) CreateOwnerEventCommand(_ context.Context, _ string, _ Owner, event PodEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.events = append(r.events, string(event.Type)+" "+event.Reason)
+
+	return nil
+}
+
+func Test_joinCanaryBatch(t *testing.T) {
+	t.Parallel()
+
+	svc := &Service{
+		canaryBatches: make(map[string]*canaryBatch),
+		canarySoak:    10 * time.Minute,
+		jitterMax:     time.Minute,
+	}
+
+	now := time.Now()
+
+	canary, isCanary := svc.joinCanaryBatch("rs-uid", now)
+	require.True(t, isCanary)
+
+	follower, isCanary := svc.joinCanaryBatch("rs-uid", now.Add(5*time.Minute))
+	require.False(t, isCanary)
+	require.Same(t, canary, follower)
+
+	_, isCanary = svc.joinCanaryBatch("other-uid", now)
+	require.True(t, isCanary, "owners have independent batches")
+
+	// A skipped canary releases the batch so a follower can become the canary.
+	svc.finishCanaryBatch("rs-uid", canary, canarySkipped)
+	<-follower.done
+	require.Equal(t, canarySkipped, follower.result)
+
+	next, isCanary := svc.joinCanaryBatch("rs-uid", now.Add(5*time.Minute))
+	require.True(t, isCanary)
+
+	svc.finishCanaryBatch("rs-uid", next, canaryPassed)
+
+	_, isCanary = svc.joinCanaryBatch("rs-uid", now.Add(6*time.Minute))
+	require.False(t, isCanary)
+
+	// The next scheduled run starts a new batch.
+	_, isCanary = svc.joinCanaryBatch("rs-uid", now.Add(time.Hour))
+	require.True(t, isCanary)
+}
+
+func Test_reportInvalidTZ_once(t *testing.T) {
+	t.Parallel()
+
+	repo := &podEventsRepo{}
+	svc := &Service{repo: repo, annotationTZKey: PreoomkillerAnnotationTZKey}
+	pod := Pod{
+		Name:        "app-1",
+		Namespace:   "default",
+		UID:         "uid-1",
+		Annotations: map[string]string{PreoomkillerAnnotationTZKey: "Europe/Atlantis"},
+	}
+
+	svc.reportInvalidTZ(t.Context(), slog.Default(), &pod)
+	svc.reportInvalidTZ(t.Context(), slog.Default(), &pod)
+	require.Equal(t, []string{EventReasonInvalidTimezone}, repo.reasons, "the Event is not repeated every reconcile")
+
+	pod.Annotations[PreoomkillerAnnotationTZKey] = "Europe/Lemuria"
+	svc.reportInvalidTZ(t.Context(), slog.Default(), &pod)
+	require.Len(t, repo.reasons, 2, "another invalid tz is reported again")
+}
+
+// podEventsRepo is a Repository stub recording the reasons of the pod Events.
+type podEventsRepo struct {
+	Repository
+
+	reasons []string
+}
+
+func (r *podEventsRepo) CreatePodEventCommand(_ context.Context, _ Pod, event PodEvent) error {
+	r.reasons = append(r.reasons, event.Reason)
+
+	return nil
+}
+
+func Test_parseRestartWindow(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		spec       string
+		wantSpec   string
+		wantLength time.Duration
+		wantErr    bool
+	}{
+		{name: "same day", spec: "02:00-04:00", wantSpec: "0 2 * * *", wantLength: 2 * time.Hour},
+		{name: "spans midnight", spec: "23:30-01:00", wantSpec: "30 23 * * *", wantLength: 90 * time.Minute},
+		{name: "surrounding spaces", spec: " 02:15-02:45 ", wantSpec: "15 2 * * *", wantLength: 30 * time.Minute},
+		{name: "missing end", spec: "02:00", wantErr: true},
+		{name: "invalid clock", spec: "02:00-25:00", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			spec, length, err := parseRestartWindow(tt.spec)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrRestartWindowParse)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.wantSpec, spec)
+			require.Equal(t, tt.wantLength, length)
+		})
+	}
+}
+
+func Test_nextRestartAt_window(t *testing.T) {
+	t.Parallel()
+
+	svc := &Service{
+		scheduleParser:               cronparser.New(),
+		annotationRestartScheduleKey: PreoomkillerAnnotationRestartScheduleKey,
+		annotationTZKey:              PreoomkillerAnnotationTZKey,
+	}
+	pod := Pod{Annotations: map[string]string{
+		PreoomkillerAnnotationRestartWindowKey: "02:00-04:00",
+		PreoomkillerAnnotationTZKey:            "Europe/Berlin",
+	}}
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	after := time.Date(2026, 2, 16, 12, 0, 0, 0, berlin)
+	windowStart := time.Date(2026, 2, 17, 2, 0, 0, 0, berlin)
+
+	for range 20 {
+		next, err := svc.nextRestartAt(&pod, after)
+		require.NoError(t, err)
+		require.False(t, next.Before(windowStart))
+		require.True(t, next.Before(windowStart.Add(2*time.Hour)))
+	}
+}
+
+func Test_nextRestartAt_skipDates(t *testing.T) {
+	t.Parallel()
+
+	svc := &Service{
+		scheduleParser:               cronparser.New(),
+		annotationRestartScheduleKey: PreoomkillerAnnotationRestartScheduleKey,
+		annotationTZKey:              PreoomkillerAnnotationTZKey,
+	}
+	after := time.Date(2026, 12, 23, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		skipDates string
+		want      time.Time
+		wantErr   error
+	}{
+		{
+			name: "no skip dates",
+			want: time.Date(2026, 12, 24, 3, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "next occurrence skipped",
+			skipDates: "2026-12-24",
+			want:      time.Date(2026, 12, 25, 3, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "consecutive dates skipped",
+			skipDates: "2026-12-24, 2026-12-25,2026-12-31",
+			want:      time.Date(2026, 12, 26, 3, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "invalid date",
+			skipDates: "2026-13-01",
+			wantErr:   ErrSkipDatesParse,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			pod := Pod{Annotations: map[string]string{
+				PreoomkillerAnnotationRestartScheduleKey: "0 3 * * *",
+				PreoomkillerAnnotationSkipDatesKey:       tt.skipDates,
+			}}
+
+			next, err := svc.nextRestartAt(&pod, after)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, next)
+		})
+	}
+}
+
+func Test_scheduleStillValid(t *testing.T) {
+	t.Parallel()
+
+	svc := &Service{
+		annotationRestartScheduleKey: PreoomkillerAnnotationRestartScheduleKey,
+		annotationTZKey:              PreoomkillerAnnotationTZKey,
+	}
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        bool
+	}{
+		{
+			name: "schedule unchanged",
+			annotations: map[string]string{
+				PreoomkillerAnnotationRestartScheduleKey: "0 3 * * *",
+				PreoomkillerAnnotationRestartAtSpecKey:   "0 3 * * *||",
+			},
+			want: true,
+		},
+		{
+			name: "restart-at without recorded spec",
+			annotations: map[string]string{
+				PreoomkillerAnnotationRestartScheduleKey: "0 3 * * *",
+			},
+			want: true,
+		},
+		{
+			name: "schedule removed",
+			annotations: map[string]string{
+				PreoomkillerAnnotationRestartAtSpecKey: "0 3 * * *||",
+			},
+		},
+		{
+			name: "schedule changed",
+			annotations: map[string]string{
+				PreoomkillerAnnotationRestartScheduleKey: "0 4 * * *",
+				PreoomkillerAnnotationRestartAtSpecKey:   "0 3 * * *||",
+			},
+		},
+		{
+			name: "timezone changed",
+			annotations: map[string]string{
+				PreoomkillerAnnotationRestartScheduleKey: "0 3 * * *",
+				PreoomkillerAnnotationTZKey:              "Europe/Berlin",
+				PreoomkillerAnnotationRestartAtSpecKey:   "0 3 * * *||",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			pod := Pod{Annotations: tt.annotations}
+
+			require.Equal(t, tt.want, svc.scheduleStillValid(slog.Default(), &pod))
+		})
+	}
+}
+
+// Test_skipAbortedBatch_restartAtNotStale aborts a canary batch of a pod whose schedule was edited
+// since its restart-at was computed, and checks that the rescheduled restart-at is kept.
+func Test_skipAbortedBatch_restartAtNotStale(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	repo := &pendingRepo{
+		pods: map[string]Pod{
+			"default/app-1": {
+				Namespace: "default",
+				Name:      "app-1",
+				Owner:     &Owner{Kind: "ReplicaSet", Name: "app", UID: "owner-uid"},
+				Annotations: map[string]string{
+					PreoomkillerAnnotationRestartScheduleKey: "0 4 * * *",
+					PreoomkillerAnnotationRestartAtKey:       now.Add(-time.Minute).Format(time.RFC3339),
+					PreoomkillerAnnotationRestartAtSpecKey:   "0 3 * * *||",
+				},
+				CreatedAt: now.Add(-time.Hour),
+			},
+		},
+	}
+	svc := &Service{
+		repo:                         repo,
+		scheduleParser:               cronparser.New(),
+		annotationRestartScheduleKey: PreoomkillerAnnotationRestartScheduleKey,
+		annotationTZKey:              PreoomkillerAnnotationTZKey,
+		annotationRestartAtKey:       PreoomkillerAnnotationRestartAtKey,
+	}
+
+	svc.skipAbortedBatch(slog.Default(), repo.pods["default/app-1"])
+
+	pod := repo.pods["default/app-1"]
+	restartAt, err := time.Parse(time.RFC3339, pod.Annotations[PreoomkillerAnnotationRestartAtKey])
+	require.NoError(t, err)
+	require.True(t, restartAt.After(now), "the restart moves to the next schedule run")
+	require.Equal(t, "0 4 * * *||", pod.Annotations[PreoomkillerAnnotationRestartAtSpecKey])
+	require.False(t, svc.restartAtStale(&pod))
+	require.True(t, svc.scheduleStillValid(slog.Default(), &pod))
+}
+
+func Test_cancelVanishedEvictions(t *testing.T) {
+	t.Parallel()
+
+	svc := &Service{pendingTimers: make(map[string]*time.Timer), pendingEvictions: make(map[string]ScheduledEviction)}
+
+	for _, key := range []string{"default/kept", "default/gone"} {
+		svc.inFlightWg.Add(1)
+		svc.pendingTimers[key] = time.AfterFunc(time.Hour, svc.inFlightWg.Done)
+	}
+
+	svc.cancelVanishedEvictions(t.Context(), slog.Default(), []Pod{{Namespace: "default", Name: "kept"}})
+
+	require.Len(t, svc.pendingTimers, 1)
+	require.Contains(t, svc.pendingTimers, "default/kept")
+
+	svc.stopPendingTimers()
+	svc.inFlightWg.Wait()
+}
+
+func Test_scheduleJitter_deterministic(t *testing.T) {
+	t.Parallel()
+
+	svc := &Service{jitterMax: time.Minute, deterministicJitter: true}
+
+	first := svc.scheduleJitter("0b6c1f5e-3c1d-4b8e-9d0a-7f2e4a1c9b33")
+	require.Equal(t, first, svc.scheduleJitter("0b6c1f5e-3c1d-4b8e-9d0a-7f2e4a1c9b33"))
+	require.GreaterOrEqual(t, first, time.Duration(0))
+	require.LessOrEqual(t, first, time.Minute)
+	require.NotEqual(t, first, svc.scheduleJitter("5d2a9e71-8f4b-4c6a-b1e3-2a7d9c0f4e18"))
+}
+
+func Test_nextRestartAt_invalidTZFallsBackToUTC(t *testing.T) {
+	t.Parallel()
+
+	svc := &Service{
+		scheduleParser:               cronparser.New(),
+		annotationRestartScheduleKey: PreoomkillerAnnotationRestartScheduleKey,
+		annotationTZKey:              PreoomkillerAnnotationTZKey,
+	}
+	pod := Pod{Annotations: map[string]string{
+		PreoomkillerAnnotationRestartScheduleKey: "0 3 * * *",
+		PreoomkillerAnnotationTZKey:              "Mars/Olympus_Mons",
+		PreoomkillerAnnotationSkipDatesKey:       "2026-12-24",
+	}}
+
+	next, err := svc.nextRestartAt(&pod, time.Date(2026, 12, 23, 12, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2026, 12, 25, 3, 0, 0, 0, time.UTC), next)
+}
+
+// pendingRepo is a Repository stub for the pending eviction persistence tests.
+type pendingRepo struct {
+	Repository
+
+	mu      sync.Mutex
+	pending []PendingEviction
+	pods    map[string]Pod
+	deleted []string
+	gets    int
+}
+
+func (r *pendingRepo) ListPendingEvictionsQuery(context.Context) ([]PendingEviction, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.pending, nil
+}
+
+func (r *pendingRepo) GetPodQuery(_ context.Context, namespace, name string) (Pod, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.gets++
+
+	return r.pods[namespace+"/"+name], nil
+}
+
+func (r *pendingRepo) SavePendingEvictionCommand(_ context.Context, namespace, name string, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.pending = slices.DeleteFunc(r.pending, func(p PendingEviction) bool {
+		return p.Namespace == namespace && p.Name == name
+	})
+	r.pending = append(r.pending, PendingEviction{Namespace: namespace, Name: name, At: at})
+
+	return nil
+}
+
+func (r *pendingRepo) DeletePendingEvictionCommand(_ context.Context, namespace, name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.deleted = append(r.deleted, namespace+"/"+name)
+
+	return nil
+}
+
+func (r *pendingRepo) getCount() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.gets
+}
+
+func (r *pendingRepo) SetAnnotationCommand(_ context.Context, namespace, name, key, value string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	pod := r.pods[namespace+"/"+name]
+	pod.Annotations = maps.Clone(pod.Annotations)
+
+	if value == "" {
+		delete(pod.Annotations, key)
+	} else {
+		pod.Annotations[key] = value
+	}
+
+	r.pods[namespace+"/"+name] = pod
+
+	return nil
+}
+
+func Test_restorePendingEvictions(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	schedule := map[string]string{PreoomkillerAnnotationRestartScheduleKey: "0 3 * * *"}
+	repo := &pendingRepo{
+		pending: []PendingEviction{
+			{Namespace: "default", Name: "waiting", At: now.Add(time.Hour)},
+			{Namespace: "default", Name: "restarted", At: now.Add(-time.Hour)},
+		},
+		pods: map[string]Pod{
+			"default/waiting":   {Namespace: "default", Name: "waiting", Annotations: schedule, CreatedAt: now.Add(-time.Hour)},
+			"default/restarted": {Namespace: "default", Name: "restarted", Annotations: schedule, CreatedAt: now},
+		},
+	}
+	svc := &Service{
+		logger:                       slog.Default(),
+		repo:                         repo,
+		annotationRestartScheduleKey: PreoomkillerAnnotationRestartScheduleKey,
+		persistPending:               true,
+		pendingTimers:                make(map[string]*time.Timer),
+		pendingEvictions:             make(map[string]ScheduledEviction),
+	}
+
+	svc.restorePendingEvictions(t.Context(), slog.Default())
+
+	require.Len(t, svc.pendingTimers, 1)
+	require.Contains(t, svc.pendingTimers, "default/waiting")
+	require.WithinDuration(t, now.Add(time.Hour), svc.pendingEvictions["default/waiting"].FireAt, time.Minute)
+	require.Equal(t, []string{"default/restarted"}, repo.deleted)
+
+	svc.stopPendingTimers()
+	svc.inFlightWg.Wait()
+}
+
+func Test_handOffPendingEvictions(t *testing.T) {
+	t.Parallel()
+
+	fireAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	repo := &pendingRepo{}
+	svc := &Service{
+		repo:             repo,
+		persistPending:   true,
+		pendingTimers:    make(map[string]*time.Timer),
+		pendingEvictions: make(map[string]ScheduledEviction),
+	}
+
+	require.True(t, svc.armEvictionTimer(slog.Default(), "default", "waiting", fireAt, fireAt))
+
+	svc.inShutdown.Store(true)
+	svc.handOffPendingEvictions(t.Context(), slog.Default(), svc.stopPendingTimers())
+	svc.inFlightWg.Wait()
+
+	require.Equal(t, []PendingEviction{{Namespace: "default", Name: "waiting", At: fireAt}}, repo.pending)
+	require.Empty(t, repo.deleted)
+}
+
+// Test_restartDuringPendingEviction shuts the controller down while a fired scheduled eviction waits
+// for its owner's lock, and checks that the next instance resumes it at its original fire time.
+func Test_restartDuringPendingEviction(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	fireAt := now.Add(-time.Minute).Truncate(time.Second)
+	owner := &Owner{Kind: "ReplicaSet", Name: "app", UID: "owner-uid"}
+	repo := &pendingRepo{
+		pods: map[string]Pod{
+			"default/app-1": {
+				Namespace:   "default",
+				Name:        "app-1",
+				Owner:       owner,
+				Annotations: map[string]string{PreoomkillerAnnotationRestartScheduleKey: "0 3 * * *"},
+				CreatedAt:   now.Add(-time.Hour),
+			},
+		},
+	}
+	newService := func() *Service {
+		return &Service{
+			logger:                       slog.Default(),
+			repo:                         repo,
+			annotationRestartScheduleKey: PreoomkillerAnnotationRestartScheduleKey,
+			persistPending:               true,
+			serialRestart:                true,
+			stopCh:                       make(chan struct{}),
+			workloadLocks:                make(map[string]chan struct{}),
+			pendingTimers:                make(map[string]*time.Timer),
+			pendingEvictions:             make(map[string]ScheduledEviction),
+		}
+	}
+
+	svc := newService()
+
+	// Another replica of the owner is restarting: the eviction waits for the lock.
+	svc.workloadLocks[owner.UID] = make(chan struct{}, 1)
+	svc.workloadLocks[owner.UID] <- struct{}{}
+
+	require.True(t, svc.armEvictionTimer(slog.Default(), "default", "app-1", fireAt, fireAt))
+	require.Eventually(t, func() bool { return repo.getCount() > 0 }, time.Second, 10*time.Millisecond)
+
+	svc.inShutdown.Store(true)
+	close(svc.stopCh)
+	svc.handOffPendingEvictions(t.Context(), slog.Default(), svc.stopPendingTimers())
+	svc.inFlightWg.Wait()
+
+	require.Empty(t, repo.deleted)
+	require.Equal(t, []PendingEviction{{Namespace: "default", Name: "app-1", At: fireAt}}, repo.pending)
+
+	next := newService()
+	next.workloadLocks[owner.UID] = make(chan struct{}, 1)
+	next.workloadLocks[owner.UID] <- struct{}{}
+
+	next.restorePendingEvictions(t.Context(), slog.Default())
+
+	require.Len(t, next.PendingEvictionsQuery(), 1)
+	require.Equal(t, fireAt, next.PendingEvictionsQuery()[0].FireAt)
+
+	next.inShutdown.Store(true)
+	close(next.stopCh)
+	next.stopPendingTimers()
+	next.inFlightWg.Wait()
+}
+
+func Test_PendingEvictionsQuery(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now().Truncate(time.Second)
+	svc := &Service{
+		cluster:          "prod",
+		pendingTimers:    make(map[string]*time.Timer),
+		pendingEvictions: make(map[string]ScheduledEviction),
+	}
+
+	require.True(t, svc.armEvictionTimer(slog.Default(), "default", "late", now.Add(2*time.Hour), now.Add(2*time.Hour+time.Minute)))
+	require.True(t, svc.armEvictionTimer(slog.Default(), "default", "soon", now.Add(time.Hour), now.Add(time.Hour+time.Minute)))
+	require.False(t, svc.armEvictionTimer(slog.Default(), "default", "soon", now, now))
+
+	require.Equal(t, []ScheduledEviction{
+		{Cluster: "prod", Namespace: "default", Name: "soon", RestartAt: now.Add(time.Hour), FireAt: now.Add(time.Hour + time.Minute)},
+		{Cluster: "prod", Namespace: "default", Name: "late", RestartAt: now.Add(2 * time.Hour), FireAt: now.Add(2*time.Hour + time.Minute)},
+	}, svc.PendingEvictionsQuery())
+
+	svc.stopPendingTimers()
+	svc.inFlightWg.Wait()
+
+	require.Empty(t, svc.PendingEvictionsQuery())
+}
+
+func Test_EnrolledPodsQuery(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now().Truncate(time.Second)
+	svc := &Service{
+		cluster:                      "prod",
+		annotationMemoryThresholdKey: PreoomkillerAnnotationMemoryThresholdKey,
+		annotationRestartScheduleKey: PreoomkillerAnnotationRestartScheduleKey,
+		usageHistory:                 newUsageHistory(_defaultUsageHistorySize),
+	}
+
+	svc.usageHistory.record("uid-b", usageSample{
+		at:        now,
+		usage:     resource.MustParse("600Mi"),
+		threshold: resource.MustParse("1Gi"),
+	}, 0)
+	svc.rememberEnrolledPods([]Pod{
+		{
+			Namespace:   "default",
+			Name:        "b",
+			UID:         "uid-b",
+			Annotations: map[string]string{PreoomkillerAnnotationMemoryThresholdKey: "1Gi"},
+		},
+		{
+			Namespace:   "default",
+			Name:        "a",
+			UID:         "uid-a",
+			Annotations: map[string]string{PreoomkillerAnnotationRestartScheduleKey: "0 3 * * *"},
+		},
+	})
+
+	usage, threshold := resource.MustParse("600Mi"), resource.MustParse("1Gi")
+
+	require.Equal(t, []EnrolledPod{
+		{Cluster: "prod", Namespace: "default", Name: "a", RestartSchedule: "0 3 * * *"},
+		{
+			Cluster:            "prod",
+			Namespace:          "default",
+			Name:               "b",
+			MemoryThreshold:    "1Gi",
+			MemoryUsage:        &usage,
+			EffectiveThreshold: &threshold,
+			CheckedAt:          now,
+		},
+	}, svc.EnrolledPodsQuery())
+}
+
+func Test_cancelEvictedPodSchedule(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	svc := &Service{
+		logger:           slog.Default(),
+		pendingTimers:    make(map[string]*time.Timer),
+		pendingEvictions: make(map[string]ScheduledEviction),
+	}
+
+	require.True(t, svc.armEvictionTimer(slog.Default(), "default", "a", now.Add(time.Hour), now.Add(time.Hour)))
+	require.True(t, svc.armEvictionTimer(slog.Default(), "default", "b", now.Add(time.Hour), now.Add(time.Hour)))
+
+	svc.cancelEvictedPodSchedule(t.Context(), slog.Default(), &Pod{Namespace: "default", Name: "a"}, TriggerSchedule)
+	svc.cancelEvictedPodSchedule(t.Context(), slog.Default(), &Pod{Namespace: "default", Name: "b"}, TriggerThreshold)
+
+	pending := svc.PendingEvictionsQuery()
+	require.Len(t, pending, 1, "only the schedule of the pod evicted by its threshold is cancelled")
+	require.Equal(t, "a", pending[0].Name)
+
+	svc.stopPendingTimers()
+	svc.inFlightWg.Wait()
+}
+
+func Test_retryQueue(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	svc := &Service{}
+	WithRetry(time.Second, 5*time.Second)(svc)
+	q := svc.retry
+
+	require.Equal(t, time.Second, q.add("default/a", now))
+	require.Equal(t, 2*time.Second, q.add("default/a", now))
+	require.Equal(t, 4*time.Second, q.add("default/a", now))
+	require.Equal(t, 5*time.Second, q.add("default/a", now), "the delay is capped")
+	require.Equal(t, time.Second, q.add("default/b", now))
+
+	next, ok := q.next()
+	require.True(t, ok)
+	require.Equal(t, now.Add(time.Second), next)
+
+	require.Equal(t, []string{"default/b"}, q.popDue(now.Add(time.Second)))
+	require.Empty(t, q.popDue(now.Add(time.Second)), "popped retries are not due again")
+
+	q.retain(map[string]struct{}{"default/b": {}})
+
+	_, ok = q.next()
+	require.False(t, ok, "retries of vanished pods are dropped")
+
+	require.Equal(t, 2*time.Second, q.add("default/b", now), "the backoff is kept until forgotten")
+
+	q.forget("default/b")
+	require.Equal(t, time.Second, q.add("default/b", now))
+}
+
+func Test_annotationWatch(t *testing.T) {
+	t.Parallel()
+
+	svc := &Service{}
+	WithAnnotationWatch()(svc)
+
+	svc.rememberListedPods([]Pod{
+		{Namespace: "default", Name: "b"},
+		{Namespace: "default", Name: "a"},
+	})
+
+	svc.podAnnotationsChanged("default", "b")
+	svc.podAnnotationsChanged("default", "a")
+	svc.podAnnotationsChanged("default", "b")
+	svc.podAnnotationsChanged("default", "unselected")
+
+	select {
+	case <-svc.annotationChanges():
+	default:
+		require.Fail(t, "the main loop is not woken")
+	}
+
+	require.Equal(t, []string{"default/a", "default/b"}, svc.annotationWatch.popChangedPods(),
+		"changes are merged and pods not listed by the last reconcile are dropped")
+	require.Empty(t, svc.annotationWatch.popChangedPods())
+	require.Nil(t, (&Service{}).annotationChanges(), "no channel when the watch is disabled")
+}
+
+// Test_reconcileChangedPods_rearmsSchedule edits the restart schedule of a pod with a pending
+// scheduled eviction and checks that the watch re-arms its timer for the new schedule.
+func Test_reconcileChangedPods_rearmsSchedule(t *testing.T) {
+	t.Parallel()
+
+	oldAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	repo := &pendingRepo{
+		pods: map[string]Pod{
+			"default/app-1": {
+				Namespace: "default",
+				Name:      "app-1",
+				Annotations: map[string]string{
+					PreoomkillerAnnotationRestartScheduleKey: "0 4 * * *",
+					PreoomkillerAnnotationRestartAtKey:       oldAt.Format(time.RFC3339),
+					PreoomkillerAnnotationRestartAtSpecKey:   "0 3 * * *||",
+				},
+			},
+		},
+	}
+	svc := &Service{
+		logger:                       slog.Default(),
+		repo:                         repo,
+		scheduleParser:               cronparser.New(),
+		annotationRestartScheduleKey: PreoomkillerAnnotationRestartScheduleKey,
+		annotationRestartAtKey:       PreoomkillerAnnotationRestartAtKey,
+		annotationTZKey:              PreoomkillerAnnotationTZKey,
+		pendingTimers:                make(map[string]*time.Timer),
+		pendingEvictions:             make(map[string]ScheduledEviction),
+	}
+	WithAnnotationWatch()(svc)
+
+	require.NotContains(t, svc.watchedAnnotationKeys(), PreoomkillerAnnotationRestartAtSpecKey,
+		"the controller's own writes do not trigger the watch")
+	require.True(t, svc.armEvictionTimer(slog.Default(), "default", "app-1", oldAt, oldAt))
+
+	svc.rememberListedPods([]Pod{repo.pods["default/app-1"]})
+	svc.podAnnotationsChanged("default", "app-1")
+	svc.reconcileChangedPods(t.Context())
+
+	pending := svc.PendingEvictionsQuery()
+	require.Len(t, pending, 1)
+	require.Equal(t, 4, pending[0].RestartAt.Hour(), "the timer is re-armed for the edited schedule")
+	require.Equal(t, pending[0].RestartAt.Format(time.RFC3339),
+		repo.pods["default/app-1"].Annotations[PreoomkillerAnnotationRestartAtKey])
+	require.Equal(t, "0 4 * * *||", repo.pods["default/app-1"].Annotations[PreoomkillerAnnotationRestartAtSpecKey])
+
+	svc.stopPendingTimers()
+	svc.inFlightWg.Wait()
+}
+
+func Test_recordUsage(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	svc := &Service{usageHistory: newUsageHistory(_defaultUsageHistorySize)}
+	WithUsageHistorySize(3)(svc)
+
+	pod := &Pod{UID: "uid-a"}
+
+	require.Nil(t, svc.recordUsage(pod, thresholdCheck{usage: resource.MustParse("100Mi")}, now), "no delta on the first check")
+
+	delta := svc.recordUsage(pod, thresholdCheck{usage: resource.MustParse("120Mi")}, now.Add(5*time.Minute))
+	require.NotNil(t, delta)
+	require.Equal(t, "+20Mi in 5m0s", delta.String())
+
+	delta = svc.recordUsage(pod, thresholdCheck{usage: resource.MustParse("90Mi")}, now.Add(10*time.Minute))
+	require.Equal(t, "-30Mi in 5m0s", delta.String())
+
+	svc.recordUsage(pod, thresholdCheck{usage: resource.MustParse("95Mi")}, now.Add(15*time.Minute))
+
+	svc.interval = 5 * time.Minute
+	delta = svc.recordUsage(pod, thresholdCheck{usage: resource.MustParse("99Mi")}, now.Add(16*time.Minute))
+	require.Equal(t, "+4Mi in 1m0s", delta.String(), "the delta is against the last sample")
+
+	history := svc.usageHistory.history(pod.UID)
+	require.Len(t, history, 3, "the oldest sample is dropped")
+	require.Equal(t, now.Add(5*time.Minute), history[0].at)
+	require.Equal(t, now.Add(15*time.Minute), history[2].at, "a check within the interval adds no sample")
+	require.Equal(t, 10*time.Minute, svc.usageHistoryCoverage())
+
+	svc.forgetVanishedUsage([]Pod{{UID: "uid-b"}})
+	require.Empty(t, svc.usageHistory.history(pod.UID), "the history of vanished pods is dropped")
+}
+
+func Test_tightenedThresholds_unsavedAndVanished(t *testing.T) {
+	t.Parallel()
+
+	app := Owner{Kind: "ReplicaSet", Name: "app-abc", UID: "rs-app"}
+	api := Owner{Kind: "ReplicaSet", Name: "api-abc", UID: "rs-api"}
+	repo := &tightenedRepo{
+		saveErr:   errTestSave,
+		persisted: map[string]TightenedThreshold{api.UID: {Namespace: "default", Owner: api}},
+	}
+	svc := &Service{
+		repo:                repo,
+		oomTightenPercent:   10,
+		recordRestarts:      true,
+		tightenedThresholds: make(map[string]TightenedThreshold),
+		unsavedTightenings:  make(map[string]struct{}),
+	}
+
+	tightened := TightenedThreshold{Namespace: "default", Owner: app, Threshold: testQty("200Mi")}
+	svc.tightenedThresholds[app.UID] = tightened
+	svc.saveTightenedThreshold(t.Context(), slog.Default(), tightened)
+
+	svc.refreshTightenedThresholds(t.Context(), slog.Default())
+	require.Contains(t, svc.tightenedThresholds, app.UID, "an unsaved tightening survives the refresh")
+	require.Contains(t, svc.tightenedThresholds, api.UID)
+
+	repo.saveErr = nil
+	svc.refreshTightenedThresholds(t.Context(), slog.Default())
+	require.Contains(t, repo.persisted, app.UID, "the unsaved tightening is saved again")
+	require.Empty(t, svc.unsavedTightenings)
+
+	bare := Pod{Name: "bare", Namespace: "default", UID: "pod-uid"}
+	svc.tightenedThresholds[bare.UID] = TightenedThreshold{Namespace: "default", Owner: thresholdOwner(&bare)}
+
+	svc.forgetVanishedTightenings([]Pod{{Name: "app-abc-1", Namespace: "default", Owner: &app}})
+	require.Equal(t, []string{app.UID}, slices.Collect(maps.Keys(svc.tightenedThresholds)),
+		"the tightenings of vanished workloads and bare pods are dropped")
+}
+
+var errTestSave = errors.New("save failed")
+
+// tightenedRepo is a Repository stub persisting tightened thresholds.
+type tightenedRepo struct {
+	Repository
+
+	saveErr   error
+	persisted map[string]TightenedThreshold
+}
+
+func (r *tightenedRepo) SaveTightenedThresholdCommand(_ context.Context, threshold TightenedThreshold) error {
+	if r.saveErr != nil {
+		return r.saveErr
+	}
+
+	r.persisted[threshold.Owner.UID] = threshold
+
+	return nil
+}
+
+func (r *tightenedRepo) ListTightenedThresholdsQuery(context.Context) ([]TightenedThreshold, error) {
+	return slices.Collect(maps.Values(r.persisted)), nil
+}
+
+// pruneRepo counts the prunes of the restart records.
+type pruneRepo struct {
+	Repository
+
+	prunes int
+}
+
+func (r *pruneRepo) PruneRestartRecordsCommand(context.Context) (int, error) {
+	r.prunes++
+
+	return 1, nil
+}
+
+func Test_pruneRestartRecords(t *testing.T) {
+	t.Parallel()
+
+	logger := slog.Default()
+	repo := &pruneRepo{}
+	svc := &Service{repo: repo}
+
+	svc.pruneRestartRecords(t.Context(), logger)
+	require.Zero(t, repo.prunes, "nothing to prune without restart recording")
+
+	WithRestartRecording()(svc)
+
+	svc.pruneRestartRecords(t.Context(), logger)
+	svc.pruneRestartRecords(t.Context(), logger)
+	require.Equal(t, 1, repo.prunes, "pruned at most once per interval")
+
+	svc.restartRecordsPrunedAt.Store(time.Now().Add(-_restartRecordPruneInterval).UnixNano())
+	svc.pruneRestartRecords(t.Context(), logger)
+	require.Equal(t, 2, repo.prunes)
+}
+
+func Test_applyDefaultMemoryThreshold(t *testing.T) {
+	t.Parallel()
+
+	svc := &Service{annotationMemoryThresholdKey: PreoomkillerAnnotationMemoryThresholdKey}
+	WithDefaultMemoryThreshold("90%")(svc)
+
+	own := annotThreshold("512Mi")
+	pods := []Pod{
+		newTestPod(nil, ptrQty(testQty("1Gi"))),
+		newTestPod(own, ptrQty(testQty("1Gi"))),
+		newTestPod(nil, nil),
+	}
+
+	for i := range pods {
+		svc.applyDefaultMemoryThreshold(&pods[i])
+	}
+
+	require.Equal(t, "90%", pods[0].Annotations[PreoomkillerAnnotationMemoryThresholdKey])
+	require.Equal(t, "512Mi", pods[1].Annotations[PreoomkillerAnnotationMemoryThresholdKey], "own threshold kept")
+	require.Empty(t, pods[2].Annotations, "pods without a memory limit are not affected")
+
+	require.NoError(t, ValidateMemoryThreshold("limit-128Mi"))
+	require.ErrorIs(t, ValidateMemoryThreshold("0%"), ErrMemoryThresholdParse)
+}
+
+func Test_observedUsageDue(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	checkedAt := func(at time.Time) *Pod {
+		return &Pod{Annotations: map[string]string{PreoomkillerAnnotationLastCheckedAtKey: at.Format(time.RFC3339)}}
+	}
+
+	svc := &Service{}
+	require.False(t, svc.observedUsageDue(&Pod{}, now), "disabled by default")
+
+	WithObservedAnnotations(10 * time.Minute)(svc)
+
+	require.True(t, svc.observedUsageDue(&Pod{}, now), "never written")
+	require.True(t, svc.observedUsageDue(&Pod{Annotations: map[string]string{
+		PreoomkillerAnnotationLastCheckedAtKey: "yesterday",
+	}}, now), "unreadable")
+	require.False(t, svc.observedUsageDue(checkedAt(now.Add(-5*time.Minute)), now))
+	require.True(t, svc.observedUsageDue(checkedAt(now.Add(-10*time.Minute)), now))
+}
+
+func Test_risingForSkipReason(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	samples := func(usages ...string) []usageSample {
+		out := make([]usageSample, 0, len(usages))
+		for i, usage := range usages {
+			out = append(out, usageSample{
+				at:    now.Add(-time.Duration(len(usages)-1-i) * 10 * time.Minute),
+				usage: resource.MustParse(usage),
+			})
+		}
+
+		return out
+	}
+
+	tests := []struct {
+		name       string
+		risingFor  string
+		samples    []usageSample
+		wantReason SkipReason
+		wantErr    error
+	}{
+		{name: "no condition", samples: samples("100Mi")},
+		{name: "rising", risingFor: "30m", samples: samples("100Mi", "110Mi", "110Mi", "130Mi")},
+		{name: "fell", risingFor: "30m", samples: samples("100Mi", "120Mi", "110Mi", "130Mi"), wantReason: SkipReasonNotRising},
+		{name: "flat", risingFor: "30m", samples: samples("100Mi", "100Mi", "100Mi", "100Mi"), wantReason: SkipReasonNotRising},
+		{name: "fell before the window", risingFor: "20m", samples: samples("200Mi", "100Mi", "110Mi", "130Mi")},
+		{name: "history shorter than the window", risingFor: "1h", samples: samples("100Mi", "110Mi"), wantReason: SkipReasonNotRising},
+		{name: "longer than the history covers", risingFor: "2h", samples: samples("100Mi"), wantErr: ErrRisingForUncovered},
+		{name: "invalid", risingFor: "soon", samples: samples("100Mi"), wantErr: ErrRisingForParse},
+		{name: "not positive", risingFor: "0s", samples: samples("100Mi"), wantErr: ErrRisingForParse},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			pod := &Pod{Annotations: map[string]string{}}
+			if tt.risingFor != "" {
+				pod.Annotations[PreoomkillerAnnotationRisingForKey] = tt.risingFor
+			}
+
+			reason, err := risingForSkipReason(pod, tt.samples, now, 90*time.Minute)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.wantReason, reason)
+		})
+	}
+}
+
+func Test_groupPodsByOwner(t *testing.T) {
+	t.Parallel()
+
+	a := &Owner{UID: "a"}
+	b := &Owner{UID: "b"}
+	pods := []Pod{
+		{Name: "a-1", Owner: a},
+		{Name: "bare"},
+		{Name: "b-1", Owner: b},
+		{Name: "a-2", Owner: a},
+	}
+
+	require.Equal(t, [][]Pod{
+		{{Name: "a-1", Owner: a}, {Name: "a-2", Owner: a}},
+		{{Name: "bare"}},
+		{{Name: "b-1", Owner: b}},
+	}, groupPodsByOwner(pods))
+}
+
+func Test_TriggerReconcileCommand(t *testing.T) {
+	t.Parallel()
+
+	svc := &Service{reconcileNow: make(chan struct{}, 1)}
+
+	svc.TriggerReconcileCommand()
+	svc.TriggerReconcileCommand()
+
+	require.Len(t, svc.reconcileNow, 1, "requests made while one is pending are merged")
+}
+
+func Test_trackMetricsBlindness(t *testing.T) {
+	t.Parallel()
+
+	svc := &Service{metricsBlindLimit: 2}
+	ctx, logger := t.Context(), slog.Default()
+	fetched := thresholdCheck{threshold: testQty("256Mi"), usage: testQty("128Mi")}
+	missing := thresholdCheck{threshold: testQty("256Mi"), skipReason: SkipReasonMetricsMissing}
+
+	blind := &reconcileRun{}
+	blind.recordMetricsLookup(missing, nil)
+	blind.recordMetricsLookup(thresholdCheck{}, fmt.Errorf("%w: unavailable", ErrGetPodMetrics))
+	blind.recordMetricsLookup(thresholdCheck{skipReason: SkipReasonNoMemoryLimit}, nil)
+	require.Equal(t, 2, blind.metricsNeeded)
+	require.Zero(t, blind.metricsFetched)
+
+	svc.trackMetricsBlindness(ctx, logger, blind)
+	require.NoError(t, svc.metricsBlindnessError())
+
+	svc.trackMetricsBlindness(ctx, logger, &reconcileRun{})
+	require.NoError(t, svc.metricsBlindnessError(), "reconciles needing no metrics are not counted")
+
+	svc.trackMetricsBlindness(ctx, logger, blind)
+	require.ErrorIs(t, svc.metricsBlindnessError(), ErrMetricsBlind)
+
+	partial := &reconcileRun{}
+	partial.recordMetricsLookup(missing, nil)
+	partial.recordMetricsLookup(fetched, nil)
+
+	svc.trackMetricsBlindness(ctx, logger, partial)
+	require.NoError(t, svc.metricsBlindnessError(), "one fetched pod resets the count")
+}
+
+func Test_scheduleEventDetail(t *testing.T) {
+	t.Parallel()
+
+	svc := &Service{
+		annotationRestartScheduleKey: PreoomkillerAnnotationRestartScheduleKey,
+		annotationTZKey:              PreoomkillerAnnotationTZKey,
+		annotationRestartAtKey:       PreoomkillerAnnotationRestartAtKey,
+	}
+	pod := Pod{Annotations: map[string]string{
+		PreoomkillerAnnotationRestartScheduleKey: "0 3 * * *",
+		PreoomkillerAnnotationTZKey:              "Europe/Berlin",
+		PreoomkillerAnnotationRestartAtKey:       "2026-03-01T03:00:00+01:00",
+	}}
+
+	require.Equal(t,
+		`restart schedule "0 3 * * *" (tz Europe/Berlin) due at 2026-03-01T03:00:00+01:00`,
+		svc.scheduleEventDetail(&pod),
+	)
+}
+
+func Test_recordMemoryGauges(t *testing.T) {
+	t.Parallel()
+
+	svc := &Service{memoryGaugesMaxPods: 1, gaugedPods: make(map[string]struct{})}
+	check := thresholdCheck{usage: resource.MustParse("512Mi"), threshold: resource.MustParse("1Gi")}
+
+	run := &reconcileRun{gauged: make(map[string]struct{})}
+	svc.recordMemoryGauges(&Pod{Namespace: "default", Name: "first"}, check, run)
+	svc.recordMemoryGauges(&Pod{Namespace: "default", Name: "second"}, check, run)
+	svc.pruneMemoryGauges(t.Context(), slog.Default(), run)
+
+	require.Equal(t, map[string]struct{}{"default/first": {}}, svc.gaugedPods)
+	require.Equal(t, 1, run.gaugesCapped)
+
+	// Once the first pod is gone, the second one takes its place.
+	run = &reconcileRun{gauged: make(map[string]struct{})}
+	svc.pruneMemoryGauges(t.Context(), slog.Default(), run)
+	svc.recordMemoryGauges(&Pod{Namespace: "default", Name: "second"}, check, run)
+
+	require.Equal(t, map[string]struct{}{"default/second": {}}, svc.gaugedPods)
+}
@@ -1,8 +1,12 @@
 package controller_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"log/slog"
+	"strings"
 	"testing"
 	"time"
 
@@ -10,12 +14,13 @@ import (
 	"github.com/stretchr/testify/require"
 	"k8s.io/apimachinery/pkg/api/resource"
 
+	"github.com/skillcoder/preoomkiller-controller/internal/infra/blackout"
 	"github.com/skillcoder/preoomkiller-controller/internal/infra/cronparser"
 	"github.com/skillcoder/preoomkiller-controller/internal/logic/controller"
 	"github.com/skillcoder/preoomkiller-controller/internal/logic/controller/mocks"
 )
 
-// testNotFoundError and testTooManyRequestsError implement the controller's private error interfaces
+// testNotFoundError, testTooManyRequestsError and testNotConfiguredError implement the controller's private error interfaces
 // so the mock can return them and the controller recognizes them.
 type testNotFoundError struct{}
 
@@ -27,6 +32,11 @@ type testTooManyRequestsError struct{}
 func (testTooManyRequestsError) Error() string      { return "too many requests" }
 func (testTooManyRequestsError) IsTooManyRequests() {}
 
+type testNotConfiguredError struct{}
+
+func (testNotConfiguredError) Error() string    { return "not configured" }
+func (testNotConfiguredError) IsNotConfigured() {}
+
 func ptrQty(q resource.Quantity) *resource.Quantity {
 	return &q
 }
@@ -115,6 +125,7 @@ func TestService_ReconcileCommand(t *testing.T) {
 		pod := controller.Pod{
 			Name:      "test-pod",
 			Namespace: "default",
+			UID:       "3f6c1a2e-uid",
 			Annotations: map[string]string{
 				controller.PreoomkillerAnnotationMemoryThresholdKey: "256Mi",
 			},
@@ -126,11 +137,25 @@ func TestService_ReconcileCommand(t *testing.T) {
 			Return([]controller.Pod{pod}, nil).
 			Once()
 		repo.EXPECT().
-			GetPodMetricsQuery(mock.Anything, "default", "test-pod").
-			Return(&controller.PodMetrics{MemoryUsage: ptrQty(testQty("512Mi"))}, nil).
+			ListPodMetricsQuery(mock.Anything, "default", "label").
+			Return(map[string]*controller.PodMetrics{"default/test-pod": {MemoryUsage: ptrQty(testQty("512Mi"))}}, nil).
+			Once()
+		repo.EXPECT().
+			SetAnnotationCommand(mock.Anything, "default", "test-pod",
+				controller.PreoomkillerAnnotationEvictedReasonKey,
+				"threshold: memory usage 512Mi exceeded threshold 256Mi").
+			Return(nil).
+			Once()
+		repo.EXPECT().
+			EvictPodCommand(mock.Anything, "default", "test-pod", "3f6c1a2e-uid").
+			Return(nil).
 			Once()
 		repo.EXPECT().
-			EvictPodCommand(mock.Anything, "default", "test-pod").
+			CreatePodEventCommand(mock.Anything, mock.Anything, mock.MatchedBy(func(event controller.PodEvent) bool {
+				return event.Type == controller.EventTypeWarning &&
+					event.Reason == controller.EventReasonPreOOMEvicted &&
+					event.Message == "evicted pod test-pod: memory usage 512Mi exceeded threshold 256Mi"
+			})).
 			Return(nil).
 			Once()
 
@@ -138,7 +163,7 @@ func TestService_ReconcileCommand(t *testing.T) {
 		require.NoError(t, err)
 	})
 
-	t.Run("evict too many requests skips", func(t *testing.T) {
+	t.Run("promql condition without prometheus is reported once, not failed", func(t *testing.T) {
 		t.Parallel()
 
 		repo := mocks.NewMockRepository(t)
@@ -159,10 +184,56 @@ func TestService_ReconcileCommand(t *testing.T) {
 		pod := controller.Pod{
 			Name:      "test-pod",
 			Namespace: "default",
+			UID:       "3f6c1a2e-uid",
 			Annotations: map[string]string{
-				controller.PreoomkillerAnnotationMemoryThresholdKey: "256Mi",
+				controller.PreoomkillerAnnotationPromQLKey: `go_goroutines{pod="$POD"} > 50000`,
+			},
+		}
+
+		repo.EXPECT().
+			ListPodsQuery(mock.Anything, "label").
+			Return([]controller.Pod{pod}, nil).
+			Twice()
+		repo.EXPECT().
+			EvaluatePromQLQuery(mock.Anything, `go_goroutines{pod="test-pod"} > 50000`).
+			Return(false, testNotConfiguredError{}).
+			Twice()
+		repo.EXPECT().
+			CreatePodEventCommand(mock.Anything, mock.Anything, mock.MatchedBy(func(event controller.PodEvent) bool {
+				return event.Type == controller.EventTypeWarning &&
+					event.Reason == controller.EventReasonPromQLNotConfigured
+			})).
+			Return(nil).
+			Once()
+
+		require.NoError(t, svc.ReconcileCommand(t.Context()))
+		require.NoError(t, svc.ReconcileCommand(t.Context()))
+	})
+
+	t.Run("pod with holding promql condition evicts", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mocks.NewMockRepository(t)
+		svc := controller.New(
+			logger,
+			repo,
+			cronparser.New(),
+			1*time.Second,
+			"label",
+			controller.PreoomkillerAnnotationMemoryThresholdKey,
+			controller.PreoomkillerAnnotationRestartScheduleKey,
+			controller.PreoomkillerAnnotationTZKey,
+			controller.PreoomkillerAnnotationRestartAtKey,
+			30*time.Second,
+			0,
+		)
+
+		pod := controller.Pod{
+			Name:      "test-pod",
+			Namespace: "default",
+			Annotations: map[string]string{
+				controller.PreoomkillerAnnotationPromQLKey: `go_goroutines{namespace="$NAMESPACE", pod="$POD"} > 50000`,
 			},
-			MemoryLimit: ptrQty(testQty("1Gi")),
 		}
 
 		repo.EXPECT().
@@ -170,23 +241,31 @@ func TestService_ReconcileCommand(t *testing.T) {
 			Return([]controller.Pod{pod}, nil).
 			Once()
 		repo.EXPECT().
-			GetPodMetricsQuery(mock.Anything, "default", "test-pod").
-			Return(&controller.PodMetrics{MemoryUsage: ptrQty(testQty("512Mi"))}, nil).
+			EvaluatePromQLQuery(mock.Anything, `go_goroutines{namespace="default", pod="test-pod"} > 50000`).
+			Return(true, nil).
 			Once()
 		repo.EXPECT().
-			EvictPodCommand(mock.Anything, "default", "test-pod").
-			Return(testTooManyRequestsError{}).
+			SetAnnotationCommand(mock.Anything, "default", "test-pod",
+				controller.PreoomkillerAnnotationEvictedReasonKey, mock.Anything).
+			Return(nil).
+			Once()
+		repo.EXPECT().
+			EvictPodCommand(mock.Anything, "default", "test-pod", "").
+			Return(nil).
+			Once()
+		repo.EXPECT().
+			CreatePodEventCommand(mock.Anything, mock.Anything, mock.Anything).
+			Return(nil).
 			Once()
 
 		err := svc.ReconcileCommand(t.Context())
 		require.NoError(t, err)
 	})
 
-	t.Run("pod over threshold but too young skips eviction", func(t *testing.T) {
+	t.Run("evict too many requests skips and removes the evicted reason", func(t *testing.T) {
 		t.Parallel()
 
 		repo := mocks.NewMockRepository(t)
-		minPodAge := 30 * time.Minute
 		svc := controller.New(
 			logger,
 			repo,
@@ -198,7 +277,7 @@ func TestService_ReconcileCommand(t *testing.T) {
 			controller.PreoomkillerAnnotationTZKey,
 			controller.PreoomkillerAnnotationRestartAtKey,
 			30*time.Second,
-			minPodAge,
+			0,
 		)
 
 		pod := controller.Pod{
@@ -208,7 +287,6 @@ func TestService_ReconcileCommand(t *testing.T) {
 				controller.PreoomkillerAnnotationMemoryThresholdKey: "256Mi",
 			},
 			MemoryLimit: ptrQty(testQty("1Gi")),
-			CreatedAt:   time.Now().Add(-10 * time.Minute),
 		}
 
 		repo.EXPECT().
@@ -216,19 +294,30 @@ func TestService_ReconcileCommand(t *testing.T) {
 			Return([]controller.Pod{pod}, nil).
 			Once()
 		repo.EXPECT().
-			GetPodMetricsQuery(mock.Anything, "default", "test-pod").
-			Return(&controller.PodMetrics{MemoryUsage: ptrQty(testQty("512Mi"))}, nil).
+			ListPodMetricsQuery(mock.Anything, "default", "label").
+			Return(map[string]*controller.PodMetrics{"default/test-pod": {MemoryUsage: ptrQty(testQty("512Mi"))}}, nil).
 			Once()
-		// EvictPodCommand must not be called (eviction skipped due to pod too young)
 		repo.EXPECT().
-			EvictPodCommand(mock.Anything, mock.Anything, mock.Anything).
-			Maybe()
+			SetAnnotationCommand(mock.Anything, "default", "test-pod",
+				controller.PreoomkillerAnnotationEvictedReasonKey, mock.Anything).
+			Return(nil).
+			Once()
+		repo.EXPECT().
+			EvictPodCommand(mock.Anything, "default", "test-pod", "").
+			Return(testTooManyRequestsError{}).
+			Once()
+		// The pod was not evicted, so it must not keep the evicted-reason annotation.
+		repo.EXPECT().
+			SetAnnotationCommand(mock.Anything, "default", "test-pod",
+				controller.PreoomkillerAnnotationEvictedReasonKey, "").
+			Return(nil).
+			Once()
 
 		err := svc.ReconcileCommand(t.Context())
 		require.NoError(t, err)
 	})
 
-	t.Run("missed scheduled eviction but pod too young skips eviction", func(t *testing.T) {
+	t.Run("pod over threshold but too young skips eviction", func(t *testing.T) {
 		t.Parallel()
 
 		repo := mocks.NewMockRepository(t)
@@ -247,34 +336,38 @@ func TestService_ReconcileCommand(t *testing.T) {
 			minPodAge,
 		)
 
-		now := time.Now()
-		restartAt := now.Add(-5 * time.Minute)
 		pod := controller.Pod{
-			Name:      "scheduled-pod",
+			Name:      "test-pod",
 			Namespace: "default",
 			Annotations: map[string]string{
-				controller.PreoomkillerAnnotationRestartScheduleKey: "0 * * * *",
-				controller.PreoomkillerAnnotationRestartAtKey:       restartAt.Format(time.RFC3339),
+				controller.PreoomkillerAnnotationMemoryThresholdKey: "256Mi",
 			},
-			CreatedAt: now.Add(-10 * time.Minute),
+			MemoryLimit: ptrQty(testQty("1Gi")),
+			CreatedAt:   time.Now().Add(-10 * time.Minute),
 		}
 
 		repo.EXPECT().
 			ListPodsQuery(mock.Anything, "label").
 			Return([]controller.Pod{pod}, nil).
 			Once()
-		// EvictPodCommand must not be called (pod too young)
 		repo.EXPECT().
-			EvictPodCommand(mock.Anything, mock.Anything, mock.Anything).
+			ListPodMetricsQuery(mock.Anything, "default", "label").
+			Return(map[string]*controller.PodMetrics{"default/test-pod": {MemoryUsage: ptrQty(testQty("512Mi"))}}, nil).
+			Once()
+		// EvictPodCommand must not be called (eviction skipped due to pod too young)
+		repo.EXPECT().
+			EvictPodCommand(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 			Maybe()
 
 		err := svc.ReconcileCommand(t.Context())
 		require.NoError(t, err)
 	})
 
-	t.Run("metrics not found skips pod", func(t *testing.T) {
+	t.Run("evicted and skipped decisions are audited", func(t *testing.T) {
 		t.Parallel()
 
+		var audit bytes.Buffer
+
 		repo := mocks.NewMockRepository(t)
 		svc := controller.New(
 			logger,
@@ -287,81 +380,148 @@ func TestService_ReconcileCommand(t *testing.T) {
 			controller.PreoomkillerAnnotationTZKey,
 			controller.PreoomkillerAnnotationRestartAtKey,
 			30*time.Second,
-			0,
+			30*time.Minute,
+			controller.WithAuditLogger(slog.New(slog.NewJSONHandler(&audit, nil))),
 		)
 
-		pod := controller.Pod{
-			Name:      "test-pod",
+		oldPod := controller.Pod{
+			Name:      "old-pod",
+			Namespace: "default",
+			UID:       "old-uid",
+			Annotations: map[string]string{
+				controller.PreoomkillerAnnotationMemoryThresholdKey: "256Mi",
+			},
+			MemoryLimit: ptrQty(testQty("1Gi")),
+			CreatedAt:   time.Now().Add(-time.Hour),
+		}
+		youngPod := controller.Pod{
+			Name:      "young-pod",
 			Namespace: "default",
+			UID:       "young-uid",
 			Annotations: map[string]string{
 				controller.PreoomkillerAnnotationMemoryThresholdKey: "256Mi",
 			},
 			MemoryLimit: ptrQty(testQty("1Gi")),
+			CreatedAt:   time.Now().Add(-10 * time.Minute),
 		}
 
 		repo.EXPECT().
 			ListPodsQuery(mock.Anything, "label").
-			Return([]controller.Pod{pod}, nil).
+			Return([]controller.Pod{oldPod, youngPod}, nil).
 			Once()
 		repo.EXPECT().
-			GetPodMetricsQuery(mock.Anything, "default", "test-pod").
-			Return(nil, testNotFoundError{}).
+			ListPodMetricsQuery(mock.Anything, "default", "label").
+			Return(map[string]*controller.PodMetrics{
+				"default/old-pod":   {MemoryUsage: ptrQty(testQty("512Mi"))},
+				"default/young-pod": {MemoryUsage: ptrQty(testQty("512Mi"))},
+			}, nil).
+			Once()
+		repo.EXPECT().
+			SetAnnotationCommand(mock.Anything, "default", "old-pod",
+				controller.PreoomkillerAnnotationEvictedReasonKey, mock.Anything).
+			Return(nil).
+			Once()
+		repo.EXPECT().
+			EvictPodCommand(mock.Anything, "default", "old-pod", "old-uid").
+			Return(nil).
+			Once()
+		repo.EXPECT().
+			CreatePodEventCommand(mock.Anything, mock.Anything, mock.Anything).
+			Return(nil).
 			Once()
 
 		err := svc.ReconcileCommand(t.Context())
 		require.NoError(t, err)
-	})
-}
-
-func TestService_Start_Ready_Shutdown(t *testing.T) {
-	t.Parallel()
 
-	logger := slog.Default()
-	repo := mocks.NewMockRepository(t)
-	svc := controller.New(
-		logger,
-		repo,
-		cronparser.New(),
-		10*time.Second,
-		"",
-		controller.PreoomkillerAnnotationMemoryThresholdKey,
-		controller.PreoomkillerAnnotationRestartScheduleKey,
-		controller.PreoomkillerAnnotationTZKey,
-		controller.PreoomkillerAnnotationRestartAtKey,
-		30*time.Second,
-		0,
-	)
+		lines := strings.Split(strings.TrimSpace(audit.String()), "\n")
+		require.Len(t, lines, 2)
 
-	repo.EXPECT().
-		ListPodsQuery(mock.Anything, mock.Anything).
-		Return([]controller.Pod{}, nil).
-		Maybe()
+		var evicted, skipped map[string]any
 
-	ctx, cancel := context.WithCancel(t.Context())
-	defer cancel()
+		require.NoError(t, json.Unmarshal([]byte(lines[0]), &evicted))
+		require.NoError(t, json.Unmarshal([]byte(lines[1]), &skipped))
 
-	require.NoError(t, svc.Start(ctx))
+		require.Equal(t, "evicted", evicted["decision"])
+		require.Equal(t, "threshold", evicted["trigger"])
+		require.Equal(t, "old-pod", evicted["pod"])
+		require.InDelta(t, 512*1024*1024, evicted["memoryUsageBytes"], 0)
+		require.InDelta(t, 256*1024*1024, evicted["memoryThresholdBytes"], 0)
+		require.Equal(t, "256Mi", evicted["memoryThresholdAnnotation"])
 
-	select {
-	case <-svc.Ready():
-	case <-time.After(2 * time.Second):
-		t.Fatal("service did not become ready")
-	}
+		require.Equal(t, "skipped", skipped["decision"])
+		require.Equal(t, "young-pod", skipped["pod"])
+		require.Equal(t, string(controller.SkipReasonPodTooYoung), skipped["reason"])
+	})
 
-	cancel()
+	t.Run("eviction is sent to notifiers and a failing notifier does not fail the reconcile", func(t *testing.T) {
+		t.Parallel()
 
-	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer shutdownCancel()
+		repo := mocks.NewMockRepository(t)
+		notifier := mocks.NewMockNotifier(t)
+		svc := controller.New(
+			logger,
+			repo,
+			cronparser.New(),
+			1*time.Second,
+			"label",
+			controller.PreoomkillerAnnotationMemoryThresholdKey,
+			controller.PreoomkillerAnnotationRestartScheduleKey,
+			controller.PreoomkillerAnnotationTZKey,
+			controller.PreoomkillerAnnotationRestartAtKey,
+			30*time.Second,
+			0,
+			controller.WithNotifier(notifier),
+		)
 
-	require.NoError(t, svc.Shutdown(shutdownCtx))
-}
+		pod := controller.Pod{
+			Name:      "test-pod",
+			Namespace: "default",
+			UID:       "3f6c1a2e-uid",
+			Annotations: map[string]string{
+				controller.PreoomkillerAnnotationMemoryThresholdKey: "256Mi",
+			},
+			MemoryLimit: ptrQty(testQty("1Gi")),
+		}
 
-func TestService_Ping(t *testing.T) {
-	t.Parallel()
+		repo.EXPECT().
+			ListPodsQuery(mock.Anything, "label").
+			Return([]controller.Pod{pod}, nil).
+			Once()
+		repo.EXPECT().
+			ListPodMetricsQuery(mock.Anything, "default", "label").
+			Return(map[string]*controller.PodMetrics{"default/test-pod": {MemoryUsage: ptrQty(testQty("512Mi"))}}, nil).
+			Once()
+		repo.EXPECT().
+			SetAnnotationCommand(mock.Anything, "default", "test-pod",
+				controller.PreoomkillerAnnotationEvictedReasonKey, mock.Anything).
+			Return(nil).
+			Once()
+		repo.EXPECT().
+			EvictPodCommand(mock.Anything, "default", "test-pod", "3f6c1a2e-uid").
+			Return(nil).
+			Once()
+		repo.EXPECT().
+			CreatePodEventCommand(mock.Anything, mock.Anything, mock.Anything).
+			Return(nil).
+			Once()
+		notifier.EXPECT().
+			NotifyCommand(mock.Anything, mock.MatchedBy(func(decision controller.EvictionDecision) bool {
+				return decision.Outcome == controller.OutcomeEvicted &&
+					decision.Trigger == controller.TriggerThreshold &&
+					decision.Pod == "test-pod" &&
+					decision.PodUID == "3f6c1a2e-uid" &&
+					decision.MemoryUsage.Cmp(testQty("512Mi")) == 0 &&
+					decision.MemoryThreshold.Cmp(testQty("256Mi")) == 0
+			})).
+			Return(context.DeadlineExceeded).
+			Once()
+		notifier.EXPECT().Name().Return("test").Maybe()
 
-	logger := slog.Default()
+		err := svc.ReconcileCommand(t.Context())
+		require.NoError(t, err)
+	})
 
-	t.Run("before ready returns error", func(t *testing.T) {
+	t.Run("container aggregation compares container usage instead of the sum", func(t *testing.T) {
 		t.Parallel()
 
 		repo := mocks.NewMockRepository(t)
@@ -369,21 +529,78 @@ func TestService_Ping(t *testing.T) {
 			logger,
 			repo,
 			cronparser.New(),
-			10*time.Second,
-			"",
+			1*time.Second,
+			"label",
 			controller.PreoomkillerAnnotationMemoryThresholdKey,
 			controller.PreoomkillerAnnotationRestartScheduleKey,
 			controller.PreoomkillerAnnotationTZKey,
 			controller.PreoomkillerAnnotationRestartAtKey,
 			30*time.Second,
 			0,
+			controller.WithContainerAggregation(controller.ContainerAggregationMax),
 		)
 
-		err := svc.Ping(t.Context())
-		require.Error(t, err)
+		maxPod := controller.Pod{
+			Name:      "max-pod",
+			Namespace: "default",
+			Annotations: map[string]string{
+				controller.PreoomkillerAnnotationMemoryThresholdKey: "256Mi",
+			},
+			MemoryLimit: ptrQty(testQty("1Gi")),
+		}
+		namedPod := controller.Pod{
+			Name:      "named-pod",
+			Namespace: "default",
+			Annotations: map[string]string{
+				controller.PreoomkillerAnnotationMemoryThresholdKey:      "256Mi",
+				controller.PreoomkillerAnnotationContainerAggregationKey: "named:app",
+			},
+			MemoryLimit: ptrQty(testQty("1Gi")),
+		}
+
+		repo.EXPECT().
+			ListPodsQuery(mock.Anything, "label").
+			Return([]controller.Pod{maxPod, namedPod}, nil).
+			Once()
+		repo.EXPECT().
+			ListPodMetricsQuery(mock.Anything, "default", "label").
+			Return(map[string]*controller.PodMetrics{
+				// Sum is over the threshold, but no single container is.
+				"default/max-pod": {
+					MemoryUsage: ptrQty(testQty("400Mi")),
+					Containers: []controller.ContainerMetrics{
+						{Name: "app", MemoryUsage: ptrQty(testQty("200Mi"))},
+						{Name: "sidecar", MemoryUsage: ptrQty(testQty("200Mi"))},
+					},
+				},
+				"default/named-pod": {
+					MemoryUsage: ptrQty(testQty("310Mi")),
+					Containers: []controller.ContainerMetrics{
+						{Name: "app", MemoryUsage: ptrQty(testQty("300Mi"))},
+						{Name: "sidecar", MemoryUsage: ptrQty(testQty("10Mi"))},
+					},
+				},
+			}, nil).
+			Once()
+		repo.EXPECT().
+			SetAnnotationCommand(mock.Anything, "default", "named-pod",
+				controller.PreoomkillerAnnotationEvictedReasonKey, mock.Anything).
+			Return(nil).
+			Once()
+		repo.EXPECT().
+			EvictPodCommand(mock.Anything, "default", "named-pod", "").
+			Return(nil).
+			Once()
+		repo.EXPECT().
+			CreatePodEventCommand(mock.Anything, mock.Anything, mock.Anything).
+			Return(nil).
+			Once()
+
+		err := svc.ReconcileCommand(t.Context())
+		require.NoError(t, err)
 	})
 
-	t.Run("after ready returns nil", func(t *testing.T) {
+	t.Run("container aggregation resolves relative thresholds against container limits", func(t *testing.T) {
 		t.Parallel()
 
 		repo := mocks.NewMockRepository(t)
@@ -391,23 +608,1993 @@ func TestService_Ping(t *testing.T) {
 			logger,
 			repo,
 			cronparser.New(),
-			10*time.Second,
-			"",
+			1*time.Second,
+			"label",
 			controller.PreoomkillerAnnotationMemoryThresholdKey,
 			controller.PreoomkillerAnnotationRestartScheduleKey,
 			controller.PreoomkillerAnnotationTZKey,
 			controller.PreoomkillerAnnotationRestartAtKey,
 			30*time.Second,
 			0,
+			controller.WithContainerAggregation(controller.ContainerAggregationMax),
 		)
 
+		limits := map[string]resource.Quantity{"app": testQty("256Mi"), "sidecar": testQty("1Gi")}
+		maxPod := controller.Pod{
+			Name:      "max-pod",
+			Namespace: "default",
+			Annotations: map[string]string{
+				controller.PreoomkillerAnnotationMemoryThresholdKey: "80%",
+			},
+			MemoryLimit:           ptrQty(testQty("1280Mi")),
+			ContainerMemoryLimits: limits,
+		}
+		namedPod := controller.Pod{
+			Name:      "named-pod",
+			Namespace: "default",
+			Annotations: map[string]string{
+				controller.PreoomkillerAnnotationMemoryThresholdKey:      "limit-16Mi",
+				controller.PreoomkillerAnnotationContainerAggregationKey: "named:app",
+			},
+			MemoryLimit:           ptrQty(testQty("1280Mi")),
+			ContainerMemoryLimits: limits,
+		}
+		invalidPod := controller.Pod{
+			Name:      "invalid-pod",
+			Namespace: "default",
+			Annotations: map[string]string{
+				controller.PreoomkillerAnnotationMemoryThresholdKey:      "80%",
+				controller.PreoomkillerAnnotationContainerAggregationKey: "median",
+			},
+			MemoryLimit:           ptrQty(testQty("1280Mi")),
+			ContainerMemoryLimits: limits,
+		}
+		// The app containers are above their thresholds of their own limit, but far below the thresholds
+		// of the pod total.
+		containers := []controller.ContainerMetrics{
+			{Name: "app", MemoryUsage: ptrQty(testQty("250Mi"))},
+			{Name: "sidecar", MemoryUsage: ptrQty(testQty("100Mi"))},
+		}
+
 		repo.EXPECT().
-			ListPodsQuery(mock.Anything, mock.Anything).
-			Return([]controller.Pod{}, nil).
-			Maybe()
+			ListPodsQuery(mock.Anything, "label").
+			Return([]controller.Pod{maxPod, namedPod, invalidPod}, nil).
+			Once()
+		repo.EXPECT().
+			ListPodMetricsQuery(mock.Anything, "default", "label").
+			Return(map[string]*controller.PodMetrics{
+				"default/max-pod":     {MemoryUsage: ptrQty(testQty("350Mi")), Containers: containers},
+				"default/named-pod":   {MemoryUsage: ptrQty(testQty("350Mi")), Containers: containers},
+				"default/invalid-pod": {MemoryUsage: ptrQty(testQty("350Mi")), Containers: containers},
+			}, nil).
+			Once()
 
-		ctx, cancel := context.WithCancel(t.Context())
-		defer cancel()
+		for _, name := range []string{"max-pod", "named-pod"} {
+			repo.EXPECT().
+				SetAnnotationCommand(mock.Anything, "default", name,
+					controller.PreoomkillerAnnotationEvictedReasonKey, mock.Anything).
+				Return(nil).
+				Once()
+			repo.EXPECT().
+				EvictPodCommand(mock.Anything, "default", name, "").
+				Return(nil).
+				Once()
+		}
+
+		repo.EXPECT().
+			CreatePodEventCommand(mock.Anything, mock.MatchedBy(func(pod controller.Pod) bool {
+				return pod.Name != "invalid-pod"
+			}), mock.Anything).
+			Return(nil).
+			Twice()
+		repo.EXPECT().
+			CreatePodEventCommand(mock.Anything, mock.MatchedBy(func(pod controller.Pod) bool {
+				return pod.Name == "invalid-pod"
+			}), mock.MatchedBy(func(event controller.PodEvent) bool {
+				return event.Type == controller.EventTypeWarning &&
+					event.Reason == controller.EventReasonInvalidContainerAggregation
+			})).
+			Return(nil).
+			Once()
+
+		err := svc.ReconcileCommand(t.Context())
+		require.NoError(t, err, "an invalid container aggregation is not a failure")
+	})
+
+	t.Run("vpa upper bound above usage skips eviction", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mocks.NewMockRepository(t)
+		svc := controller.New(
+			logger,
+			repo,
+			cronparser.New(),
+			1*time.Second,
+			"label",
+			controller.PreoomkillerAnnotationMemoryThresholdKey,
+			controller.PreoomkillerAnnotationRestartScheduleKey,
+			controller.PreoomkillerAnnotationTZKey,
+			controller.PreoomkillerAnnotationRestartAtKey,
+			30*time.Second,
+			0,
+			controller.WithVPAMode(controller.VPAModeUpperBound),
+		)
+
+		owner := controller.Owner{Kind: "ReplicaSet", Name: "app-5d9c"}
+		pod := controller.Pod{
+			Name:      "test-pod",
+			Namespace: "default",
+			Annotations: map[string]string{
+				controller.PreoomkillerAnnotationMemoryThresholdKey: "256Mi",
+			},
+			Owner:       &owner,
+			MemoryLimit: ptrQty(testQty("2Gi")),
+		}
+
+		repo.EXPECT().
+			ListPodsQuery(mock.Anything, "label").
+			Return([]controller.Pod{pod}, nil).
+			Once()
+		repo.EXPECT().
+			ListPodMetricsQuery(mock.Anything, "default", "label").
+			Return(map[string]*controller.PodMetrics{"default/test-pod": {MemoryUsage: ptrQty(testQty("512Mi"))}}, nil).
+			Once()
+		repo.EXPECT().
+			ListVPARecommendationsQuery(mock.Anything, "default").
+			Return(map[string]*controller.VPARecommendation{"ReplicaSet/app-5d9c": {
+				Name:             "app",
+				UpdateMode:       "Auto",
+				MemoryUpperBound: ptrQty(testQty("1Gi")),
+			}}, nil).
+			Once()
+
+		err := svc.ReconcileCommand(t.Context())
+		require.NoError(t, err)
+	})
+
+	t.Run("vpa defer mode leaves pods over threshold to vpa", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mocks.NewMockRepository(t)
+		svc := controller.New(
+			logger,
+			repo,
+			cronparser.New(),
+			1*time.Second,
+			"label",
+			controller.PreoomkillerAnnotationMemoryThresholdKey,
+			controller.PreoomkillerAnnotationRestartScheduleKey,
+			controller.PreoomkillerAnnotationTZKey,
+			controller.PreoomkillerAnnotationRestartAtKey,
+			30*time.Second,
+			0,
+			controller.WithVPAMode(controller.VPAModeDefer),
+		)
+
+		owner := controller.Owner{Kind: "ReplicaSet", Name: "app-5d9c"}
+		pod := controller.Pod{
+			Name:      "test-pod",
+			Namespace: "default",
+			Annotations: map[string]string{
+				controller.PreoomkillerAnnotationMemoryThresholdKey: "256Mi",
+			},
+			Owner:       &owner,
+			MemoryLimit: ptrQty(testQty("1Gi")),
+		}
+		sibling := pod
+		sibling.Name = "test-pod-2"
+
+		repo.EXPECT().
+			ListPodsQuery(mock.Anything, "label").
+			Return([]controller.Pod{pod, sibling}, nil).
+			Once()
+		repo.EXPECT().
+			ListPodMetricsQuery(mock.Anything, "default", "label").
+			Return(map[string]*controller.PodMetrics{
+				"default/test-pod":   {MemoryUsage: ptrQty(testQty("512Mi"))},
+				"default/test-pod-2": {MemoryUsage: ptrQty(testQty("512Mi"))},
+			}, nil).
+			Once()
+		// The namespace's VPAs are listed once per reconcile, not once per pod.
+		repo.EXPECT().
+			ListVPARecommendationsQuery(mock.Anything, "default").
+			Return(map[string]*controller.VPARecommendation{
+				"ReplicaSet/app-5d9c": {Name: "app", UpdateMode: "Recreate"},
+			}, nil).
+			Once()
+
+		err := svc.ReconcileCommand(t.Context())
+		require.NoError(t, err)
+	})
+
+	t.Run("vpa defer mode evicts pods whose vpa only sets initial resources", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mocks.NewMockRepository(t)
+		svc := controller.New(
+			logger,
+			repo,
+			cronparser.New(),
+			1*time.Second,
+			"label",
+			controller.PreoomkillerAnnotationMemoryThresholdKey,
+			controller.PreoomkillerAnnotationRestartScheduleKey,
+			controller.PreoomkillerAnnotationTZKey,
+			controller.PreoomkillerAnnotationRestartAtKey,
+			30*time.Second,
+			0,
+			controller.WithVPAMode(controller.VPAModeDefer),
+		)
+
+		owner := controller.Owner{Kind: "ReplicaSet", Name: "app-5d9c"}
+		pod := controller.Pod{
+			Name:      "test-pod",
+			Namespace: "default",
+			Annotations: map[string]string{
+				controller.PreoomkillerAnnotationMemoryThresholdKey: "256Mi",
+			},
+			Owner:       &owner,
+			MemoryLimit: ptrQty(testQty("1Gi")),
+		}
+
+		repo.EXPECT().
+			ListPodsQuery(mock.Anything, "label").
+			Return([]controller.Pod{pod}, nil).
+			Once()
+		repo.EXPECT().
+			ListPodMetricsQuery(mock.Anything, "default", "label").
+			Return(map[string]*controller.PodMetrics{"default/test-pod": {MemoryUsage: ptrQty(testQty("512Mi"))}}, nil).
+			Once()
+		repo.EXPECT().
+			ListVPARecommendationsQuery(mock.Anything, "default").
+			Return(map[string]*controller.VPARecommendation{
+				"ReplicaSet/app-5d9c": {Name: "app", UpdateMode: "Initial"},
+			}, nil).
+			Once()
+		repo.EXPECT().
+			SetAnnotationCommand(mock.Anything, "default", "test-pod",
+				controller.PreoomkillerAnnotationEvictedReasonKey, mock.Anything).
+			Return(nil).
+			Once()
+		repo.EXPECT().
+			EvictPodCommand(mock.Anything, "default", "test-pod", "").
+			Return(nil).
+			Once()
+		repo.EXPECT().
+			CreatePodEventCommand(mock.Anything, mock.Anything, mock.Anything).
+			Return(nil).
+			Once()
+		repo.EXPECT().
+			CreateOwnerEventCommand(mock.Anything, "default", owner, mock.Anything).
+			Return(nil).
+			Once()
+
+		err := svc.ReconcileCommand(t.Context())
+		require.NoError(t, err)
+	})
+
+	t.Run("missed scheduled eviction but pod too young skips eviction", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mocks.NewMockRepository(t)
+		minPodAge := 30 * time.Minute
+		svc := controller.New(
+			logger,
+			repo,
+			cronparser.New(),
+			1*time.Second,
+			"label",
+			controller.PreoomkillerAnnotationMemoryThresholdKey,
+			controller.PreoomkillerAnnotationRestartScheduleKey,
+			controller.PreoomkillerAnnotationTZKey,
+			controller.PreoomkillerAnnotationRestartAtKey,
+			30*time.Second,
+			minPodAge,
+		)
+
+		now := time.Now()
+		restartAt := now.Add(-5 * time.Minute)
+		pod := controller.Pod{
+			Name:      "scheduled-pod",
+			Namespace: "default",
+			Annotations: map[string]string{
+				controller.PreoomkillerAnnotationRestartScheduleKey: "0 * * * *",
+				controller.PreoomkillerAnnotationRestartAtKey:       restartAt.Format(time.RFC3339),
+			},
+			CreatedAt: now.Add(-10 * time.Minute),
+		}
+
+		repo.EXPECT().
+			ListPodsQuery(mock.Anything, "label").
+			Return([]controller.Pod{pod}, nil).
+			Once()
+		// EvictPodCommand must not be called (pod too young)
+		repo.EXPECT().
+			EvictPodCommand(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Maybe()
+
+		err := svc.ReconcileCommand(t.Context())
+		require.NoError(t, err)
+	})
+
+	t.Run("pod processing error returns error", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mocks.NewMockRepository(t)
+		svc := controller.New(
+			logger,
+			repo,
+			cronparser.New(),
+			1*time.Second,
+			"label",
+			controller.PreoomkillerAnnotationMemoryThresholdKey,
+			controller.PreoomkillerAnnotationRestartScheduleKey,
+			controller.PreoomkillerAnnotationTZKey,
+			controller.PreoomkillerAnnotationRestartAtKey,
+			30*time.Second,
+			0,
+		)
+
+		pod := controller.Pod{
+			Name:      "test-pod",
+			Namespace: "default",
+			Annotations: map[string]string{
+				controller.PreoomkillerAnnotationMemoryThresholdKey: "256Mi",
+			},
+			MemoryLimit: ptrQty(testQty("1Gi")),
+		}
+
+		repo.EXPECT().
+			ListPodsQuery(mock.Anything, "label").
+			Return([]controller.Pod{pod}, nil).
+			Once()
+		repo.EXPECT().
+			ListPodMetricsQuery(mock.Anything, "default", "label").
+			Return(nil, context.DeadlineExceeded).
+			Once()
+		repo.EXPECT().
+			GetPodMetricsQuery(mock.Anything, "default", "test-pod").
+			Return(nil, context.DeadlineExceeded).
+			Once()
+
+		err := svc.ReconcileCommand(t.Context())
+		require.ErrorIs(t, err, controller.ErrReconcilePodsFailed)
+	})
+
+	t.Run("failed eviction is sent to notifiers", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mocks.NewMockRepository(t)
+		notifier := mocks.NewMockNotifier(t)
+		svc := controller.New(
+			logger,
+			repo,
+			cronparser.New(),
+			1*time.Second,
+			"label",
+			controller.PreoomkillerAnnotationMemoryThresholdKey,
+			controller.PreoomkillerAnnotationRestartScheduleKey,
+			controller.PreoomkillerAnnotationTZKey,
+			controller.PreoomkillerAnnotationRestartAtKey,
+			30*time.Second,
+			0,
+			controller.WithNotifier(notifier),
+		)
+
+		pod := controller.Pod{
+			Name:      "test-pod",
+			Namespace: "default",
+			UID:       "3f6c1a2e-uid",
+			Annotations: map[string]string{
+				controller.PreoomkillerAnnotationMemoryThresholdKey: "256Mi",
+			},
+			MemoryLimit: ptrQty(testQty("1Gi")),
+		}
+
+		repo.EXPECT().
+			ListPodsQuery(mock.Anything, "label").
+			Return([]controller.Pod{pod}, nil).
+			Once()
+		repo.EXPECT().
+			ListPodMetricsQuery(mock.Anything, "default", "label").
+			Return(map[string]*controller.PodMetrics{"default/test-pod": {MemoryUsage: ptrQty(testQty("512Mi"))}}, nil).
+			Once()
+		repo.EXPECT().
+			SetAnnotationCommand(mock.Anything, "default", "test-pod",
+				controller.PreoomkillerAnnotationEvictedReasonKey, mock.Anything).
+			Return(nil).
+			Once()
+		repo.EXPECT().
+			EvictPodCommand(mock.Anything, "default", "test-pod", "3f6c1a2e-uid").
+			Return(context.DeadlineExceeded).
+			Once()
+		// The pod was not evicted, so it must not keep the evicted-reason annotation.
+		repo.EXPECT().
+			SetAnnotationCommand(mock.Anything, "default", "test-pod",
+				controller.PreoomkillerAnnotationEvictedReasonKey, "").
+			Return(nil).
+			Once()
+		notifier.EXPECT().
+			NotifyCommand(mock.Anything, mock.MatchedBy(func(decision controller.EvictionDecision) bool {
+				return decision.Outcome == controller.OutcomeFailed &&
+					decision.Reason == "api_error" &&
+					decision.Pod == "test-pod"
+			})).
+			Return(nil).
+			Once()
+
+		err := svc.ReconcileCommand(t.Context())
+		require.ErrorIs(t, err, controller.ErrReconcilePodsFailed)
+	})
+
+	t.Run("pod over threshold in CrashLoopBackOff skips eviction", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mocks.NewMockRepository(t)
+		svc := controller.New(
+			logger,
+			repo,
+			cronparser.New(),
+			1*time.Second,
+			"label",
+			controller.PreoomkillerAnnotationMemoryThresholdKey,
+			controller.PreoomkillerAnnotationRestartScheduleKey,
+			controller.PreoomkillerAnnotationTZKey,
+			controller.PreoomkillerAnnotationRestartAtKey,
+			30*time.Second,
+			0,
+		)
+
+		pod := controller.Pod{
+			Name:      "test-pod",
+			Namespace: "default",
+			Annotations: map[string]string{
+				controller.PreoomkillerAnnotationMemoryThresholdKey: "256Mi",
+			},
+			MemoryLimit:      ptrQty(testQty("1Gi")),
+			CrashLoopBackOff: true,
+			NotReady:         true,
+		}
+
+		repo.EXPECT().
+			ListPodsQuery(mock.Anything, "label").
+			Return([]controller.Pod{pod}, nil).
+			Once()
+		repo.EXPECT().
+			ListPodMetricsQuery(mock.Anything, "default", "label").
+			Return(map[string]*controller.PodMetrics{"default/test-pod": {MemoryUsage: ptrQty(testQty("512Mi"))}}, nil).
+			Once()
+		// EvictPodCommand must not be called (pod already unhealthy)
+
+		err := svc.ReconcileCommand(t.Context())
+		require.NoError(t, err)
+	})
+
+	t.Run("pod over threshold during blackout skips eviction", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mocks.NewMockRepository(t)
+
+		// An overnight window starting at midnight covers the whole day, every day.
+		schedule, err := blackout.Parse("00:00-00:00", "")
+		require.NoError(t, err)
+
+		svc := controller.New(
+			logger,
+			repo,
+			cronparser.New(),
+			1*time.Second,
+			"label",
+			controller.PreoomkillerAnnotationMemoryThresholdKey,
+			controller.PreoomkillerAnnotationRestartScheduleKey,
+			controller.PreoomkillerAnnotationTZKey,
+			controller.PreoomkillerAnnotationRestartAtKey,
+			30*time.Second,
+			0,
+			controller.WithBlackout(schedule),
+		)
+
+		pod := controller.Pod{
+			Name:      "test-pod",
+			Namespace: "default",
+			Annotations: map[string]string{
+				controller.PreoomkillerAnnotationMemoryThresholdKey: "256Mi",
+			},
+			MemoryLimit: ptrQty(testQty("1Gi")),
+		}
+
+		repo.EXPECT().
+			ListPodsQuery(mock.Anything, "label").
+			Return([]controller.Pod{pod}, nil).
+			Once()
+		repo.EXPECT().
+			ListPodMetricsQuery(mock.Anything, "default", "label").
+			Return(map[string]*controller.PodMetrics{"default/test-pod": {MemoryUsage: ptrQty(testQty("512Mi"))}}, nil).
+			Once()
+		// EvictPodCommand must not be called (blackout window active)
+
+		err = svc.ReconcileCommand(t.Context())
+		require.NoError(t, err)
+	})
+
+	t.Run("pod over threshold without enough ready replicas skips eviction", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mocks.NewMockRepository(t)
+		svc := controller.New(
+			logger,
+			repo,
+			cronparser.New(),
+			1*time.Second,
+			"label",
+			controller.PreoomkillerAnnotationMemoryThresholdKey,
+			controller.PreoomkillerAnnotationRestartScheduleKey,
+			controller.PreoomkillerAnnotationTZKey,
+			controller.PreoomkillerAnnotationRestartAtKey,
+			30*time.Second,
+			0,
+			controller.WithMinReadyReplicas(1),
+		)
+
+		owner := controller.Owner{Kind: "ReplicaSet", Name: "app-abc", UID: "rs-uid"}
+		pod := controller.Pod{
+			Name:      "test-pod",
+			Namespace: "default",
+			Annotations: map[string]string{
+				controller.PreoomkillerAnnotationMemoryThresholdKey: "256Mi",
+			},
+			MemoryLimit: ptrQty(testQty("1Gi")),
+			Owner:       &owner,
+		}
+		sibling := controller.Pod{Name: "test-pod-2", Namespace: "default", Owner: &owner, NotReady: true}
+
+		repo.EXPECT().
+			ListPodsQuery(mock.Anything, "label").
+			Return([]controller.Pod{pod}, nil).
+			Once()
+		repo.EXPECT().
+			ListPodMetricsQuery(mock.Anything, "default", "label").
+			Return(map[string]*controller.PodMetrics{"default/test-pod": {MemoryUsage: ptrQty(testQty("512Mi"))}}, nil).
+			Once()
+		repo.EXPECT().
+			ListOwnerPodsQuery(mock.Anything, "default", owner).
+			Return([]controller.Pod{pod, sibling}, nil).
+			Once()
+		// EvictPodCommand must not be called (the only sibling is not Ready)
+
+		err := svc.ReconcileCommand(t.Context())
+		require.NoError(t, err)
+	})
+
+	t.Run("pod over threshold with enough ready replicas evicts", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mocks.NewMockRepository(t)
+		svc := controller.New(
+			logger,
+			repo,
+			cronparser.New(),
+			1*time.Second,
+			"label",
+			controller.PreoomkillerAnnotationMemoryThresholdKey,
+			controller.PreoomkillerAnnotationRestartScheduleKey,
+			controller.PreoomkillerAnnotationTZKey,
+			controller.PreoomkillerAnnotationRestartAtKey,
+			30*time.Second,
+			0,
+			controller.WithMinReadyReplicas(1),
+		)
+
+		owner := controller.Owner{Kind: "ReplicaSet", Name: "app-abc", UID: "rs-uid"}
+		pod := controller.Pod{
+			Name:      "test-pod",
+			Namespace: "default",
+			Annotations: map[string]string{
+				controller.PreoomkillerAnnotationMemoryThresholdKey: "256Mi",
+			},
+			MemoryLimit: ptrQty(testQty("1Gi")),
+			Owner:       &owner,
+		}
+		sibling := controller.Pod{Name: "test-pod-2", Namespace: "default", Owner: &owner, NotReady: false}
+
+		repo.EXPECT().
+			ListPodsQuery(mock.Anything, "label").
+			Return([]controller.Pod{pod}, nil).
+			Once()
+		repo.EXPECT().
+			ListPodMetricsQuery(mock.Anything, "default", "label").
+			Return(map[string]*controller.PodMetrics{"default/test-pod": {MemoryUsage: ptrQty(testQty("512Mi"))}}, nil).
+			Once()
+		repo.EXPECT().
+			ListOwnerPodsQuery(mock.Anything, "default", owner).
+			Return([]controller.Pod{pod, sibling}, nil).
+			Once()
+		repo.EXPECT().
+			SetAnnotationCommand(mock.Anything, "default", "test-pod",
+				controller.PreoomkillerAnnotationEvictedReasonKey, mock.Anything).
+			Return(nil).
+			Once()
+		repo.EXPECT().
+			EvictPodCommand(mock.Anything, "default", "test-pod", "").
+			Return(nil).
+			Once()
+		repo.EXPECT().
+			CreatePodEventCommand(mock.Anything, mock.Anything, mock.Anything).
+			Return(nil).
+			Once()
+		repo.EXPECT().
+			CreateOwnerEventCommand(mock.Anything, "default", mock.Anything, mock.Anything).
+			Return(nil).
+			Once()
+
+		err := svc.ReconcileCommand(t.Context())
+		require.NoError(t, err)
+	})
+
+	t.Run("back-to-back sibling evictions do not count the terminating pod as ready", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mocks.NewMockRepository(t)
+		svc := controller.New(
+			logger,
+			repo,
+			cronparser.New(),
+			1*time.Second,
+			"label",
+			controller.PreoomkillerAnnotationMemoryThresholdKey,
+			controller.PreoomkillerAnnotationRestartScheduleKey,
+			controller.PreoomkillerAnnotationTZKey,
+			controller.PreoomkillerAnnotationRestartAtKey,
+			30*time.Second,
+			0,
+			controller.WithMinReadyReplicas(1),
+		)
+
+		owner := controller.Owner{Kind: "ReplicaSet", Name: "app-abc", UID: "rs-uid"}
+		annotations := map[string]string{
+			controller.PreoomkillerAnnotationMemoryThresholdKey: "256Mi",
+		}
+		first := controller.Pod{
+			Name: "test-pod-1", Namespace: "default", UID: "uid-1",
+			Annotations: annotations, MemoryLimit: ptrQty(testQty("1Gi")), Owner: &owner,
+		}
+		second := controller.Pod{
+			Name: "test-pod-2", Namespace: "default", UID: "uid-2",
+			Annotations: annotations, MemoryLimit: ptrQty(testQty("1Gi")), Owner: &owner,
+		}
+		usage := &controller.PodMetrics{MemoryUsage: ptrQty(testQty("512Mi"))}
+
+		repo.EXPECT().
+			ListPodsQuery(mock.Anything, "label").
+			Return([]controller.Pod{first}, nil).
+			Once()
+		repo.EXPECT().
+			ListPodMetricsQuery(mock.Anything, "default", "label").
+			Return(map[string]*controller.PodMetrics{"default/test-pod-1": usage}, nil).
+			Once()
+		repo.EXPECT().
+			ListOwnerPodsQuery(mock.Anything, "default", owner).
+			Return([]controller.Pod{first, second}, nil).
+			Once()
+		repo.EXPECT().
+			SetAnnotationCommand(mock.Anything, "default", "test-pod-1",
+				controller.PreoomkillerAnnotationEvictedReasonKey, mock.Anything).
+			Return(nil).
+			Once()
+		repo.EXPECT().
+			EvictPodCommand(mock.Anything, "default", "test-pod-1", "uid-1").
+			Return(nil).
+			Once()
+		repo.EXPECT().
+			CreatePodEventCommand(mock.Anything, mock.Anything, mock.Anything).
+			Return(nil).
+			Once()
+		repo.EXPECT().
+			CreateOwnerEventCommand(mock.Anything, "default", mock.Anything, mock.Anything).
+			Return(nil).
+			Once()
+
+		require.NoError(t, svc.ReconcileCommand(t.Context()))
+
+		// The first pod is still Ready while it shuts down; it must not let the second pod go too.
+		terminating := first
+		terminating.Terminating = true
+
+		repo.EXPECT().
+			ListPodsQuery(mock.Anything, "label").
+			Return([]controller.Pod{terminating, second}, nil).
+			Once()
+		repo.EXPECT().
+			ListPodMetricsQuery(mock.Anything, "default", "label").
+			Return(map[string]*controller.PodMetrics{"default/test-pod-2": usage}, nil).
+			Once()
+		repo.EXPECT().
+			ListOwnerPodsQuery(mock.Anything, "default", owner).
+			Return([]controller.Pod{terminating, second}, nil).
+			Once()
+		// EvictPodCommand must not be called for test-pod-2 (its only sibling is terminating)
+
+		require.NoError(t, svc.ReconcileCommand(t.Context()))
+	})
+
+	t.Run("evicted pod is recorded as the workload's last restart", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mocks.NewMockRepository(t)
+		svc := controller.New(
+			logger,
+			repo,
+			cronparser.New(),
+			1*time.Second,
+			"label",
+			controller.PreoomkillerAnnotationMemoryThresholdKey,
+			controller.PreoomkillerAnnotationRestartScheduleKey,
+			controller.PreoomkillerAnnotationTZKey,
+			controller.PreoomkillerAnnotationRestartAtKey,
+			30*time.Second,
+			0,
+			controller.WithRestartRecording(),
+		)
+
+		pod := controller.Pod{
+			Name:      "app-abc-1",
+			Namespace: "default",
+			Annotations: map[string]string{
+				controller.PreoomkillerAnnotationMemoryThresholdKey: "256Mi",
+			},
+			MemoryLimit: ptrQty(testQty("1Gi")),
+			Owner:       &controller.Owner{Kind: "ReplicaSet", Name: "app-abc", UID: "rs-uid"},
+		}
+
+		repo.EXPECT().
+			PruneRestartRecordsCommand(mock.Anything).
+			Return(0, nil).
+			Once()
+		repo.EXPECT().
+			ListPodsQuery(mock.Anything, "label").
+			Return([]controller.Pod{pod}, nil).
+			Once()
+		repo.EXPECT().
+			ListPodMetricsQuery(mock.Anything, "default", "label").
+			Return(map[string]*controller.PodMetrics{"default/app-abc-1": {MemoryUsage: ptrQty(testQty("512Mi"))}}, nil).
+			Once()
+		repo.EXPECT().
+			SetAnnotationCommand(mock.Anything, "default", "app-abc-1",
+				controller.PreoomkillerAnnotationEvictedReasonKey, mock.Anything).
+			Return(nil).
+			Once()
+		repo.EXPECT().
+			EvictPodCommand(mock.Anything, "default", "app-abc-1", "").
+			Return(nil).
+			Once()
+		repo.EXPECT().
+			CreatePodEventCommand(mock.Anything, mock.Anything, mock.Anything).
+			Return(nil).
+			Once()
+		repo.EXPECT().
+			CreateOwnerEventCommand(mock.Anything, "default", mock.Anything, mock.Anything).
+			Return(nil).
+			Once()
+		repo.EXPECT().
+			RecordRestartCommand(mock.Anything, mock.MatchedBy(func(r controller.RestartRecord) bool {
+				return r.Namespace == "default" &&
+					r.OwnerKind == "ReplicaSet" &&
+					r.OwnerName == "app-abc" &&
+					r.Pod == "app-abc-1" &&
+					r.Reason == controller.TriggerThreshold &&
+					!r.At.IsZero()
+			})).
+			Return(nil).
+			Once()
+
+		err := svc.ReconcileCommand(t.Context())
+		require.NoError(t, err)
+	})
+
+	t.Run("new OOMKilled termination is recorded and tightens threshold", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mocks.NewMockRepository(t)
+		svc := controller.New(
+			logger,
+			repo,
+			cronparser.New(),
+			1*time.Second,
+			"label",
+			controller.PreoomkillerAnnotationMemoryThresholdKey,
+			controller.PreoomkillerAnnotationRestartScheduleKey,
+			controller.PreoomkillerAnnotationTZKey,
+			controller.PreoomkillerAnnotationRestartAtKey,
+			30*time.Second,
+			0,
+			controller.WithOOMThresholdTightening(10),
+		)
+
+		oomKilledAt := time.Date(2026, 2, 15, 7, 0, 0, 0, time.UTC)
+		pod := controller.Pod{
+			Name:      "test-pod",
+			Namespace: "default",
+			Annotations: map[string]string{
+				controller.PreoomkillerAnnotationMemoryThresholdKey: "1000Mi",
+			},
+			MemoryLimit:     ptrQty(testQty("2Gi")),
+			LastOOMKilledAt: &oomKilledAt,
+		}
+
+		repo.EXPECT().
+			ListPodsQuery(mock.Anything, "label").
+			Return([]controller.Pod{pod}, nil).
+			Once()
+		repo.EXPECT().
+			SetAnnotationCommand(mock.Anything, "default", "test-pod",
+				controller.PreoomkillerAnnotationLastOOMAtKey, "2026-02-15T07:00:00Z").
+			Return(nil).
+			Once()
+		// 920Mi is below the original 1000Mi threshold but above the tightened 900Mi one.
+		repo.EXPECT().
+			ListPodMetricsQuery(mock.Anything, "default", "label").
+			Return(map[string]*controller.PodMetrics{"default/test-pod": {MemoryUsage: ptrQty(testQty("920Mi"))}}, nil).
+			Once()
+		repo.EXPECT().
+			SetAnnotationCommand(mock.Anything, "default", "test-pod",
+				controller.PreoomkillerAnnotationEvictedReasonKey, mock.Anything).
+			Return(nil).
+			Once()
+		repo.EXPECT().
+			EvictPodCommand(mock.Anything, "default", "test-pod", "").
+			Return(nil).
+			Once()
+		repo.EXPECT().
+			CreatePodEventCommand(mock.Anything, mock.Anything, mock.Anything).
+			Return(nil).
+			Once()
+
+		err := svc.ReconcileCommand(t.Context())
+		require.NoError(t, err)
+	})
+
+	t.Run("persisted tightened threshold applies to the workload's other pods", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mocks.NewMockRepository(t)
+		svc := controller.New(
+			logger,
+			repo,
+			cronparser.New(),
+			1*time.Second,
+			"label",
+			controller.PreoomkillerAnnotationMemoryThresholdKey,
+			controller.PreoomkillerAnnotationRestartScheduleKey,
+			controller.PreoomkillerAnnotationTZKey,
+			controller.PreoomkillerAnnotationRestartAtKey,
+			30*time.Second,
+			0,
+			controller.WithOOMThresholdTightening(10),
+			controller.WithRestartRecording(),
+		)
+
+		owner := controller.Owner{Kind: "ReplicaSet", Name: "app-abc", UID: "rs-uid"}
+		pod := controller.Pod{
+			Name:      "app-abc-2",
+			Namespace: "default",
+			Annotations: map[string]string{
+				controller.PreoomkillerAnnotationMemoryThresholdKey: "1000Mi",
+			},
+			MemoryLimit: ptrQty(testQty("2Gi")),
+			Owner:       &owner,
+		}
+
+		repo.EXPECT().
+			PruneRestartRecordsCommand(mock.Anything).
+			Return(0, nil).
+			Once()
+		repo.EXPECT().
+			ListTightenedThresholdsQuery(mock.Anything).
+			Return([]controller.TightenedThreshold{
+				{Namespace: "default", Owner: owner, Threshold: testQty("900Mi")},
+			}, nil).
+			Once()
+		repo.EXPECT().
+			ListPodsQuery(mock.Anything, "label").
+			Return([]controller.Pod{pod}, nil).
+			Once()
+		// 920Mi is below the pod's 1000Mi threshold but above the 900Mi one tightened for its workload.
+		repo.EXPECT().
+			ListPodMetricsQuery(mock.Anything, "default", "label").
+			Return(map[string]*controller.PodMetrics{"default/app-abc-2": {MemoryUsage: ptrQty(testQty("920Mi"))}}, nil).
+			Once()
+		repo.EXPECT().
+			SetAnnotationCommand(mock.Anything, "default", "app-abc-2",
+				controller.PreoomkillerAnnotationEvictedReasonKey, mock.Anything).
+			Return(nil).
+			Once()
+		repo.EXPECT().
+			EvictPodCommand(mock.Anything, "default", "app-abc-2", "").
+			Return(nil).
+			Once()
+		repo.EXPECT().
+			CreatePodEventCommand(mock.Anything, mock.Anything, mock.Anything).
+			Return(nil).
+			Once()
+		repo.EXPECT().
+			CreateOwnerEventCommand(mock.Anything, "default", mock.Anything, mock.Anything).
+			Return(nil).
+			Once()
+		repo.EXPECT().
+			RecordRestartCommand(mock.Anything, mock.Anything).
+			Return(nil).
+			Once()
+
+		err := svc.ReconcileCommand(t.Context())
+		require.NoError(t, err)
+	})
+
+	t.Run("new OOMKilled termination is sent to notifiers", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mocks.NewMockRepository(t)
+		notifier := mocks.NewMockNotifier(t)
+		svc := controller.New(
+			logger,
+			repo,
+			cronparser.New(),
+			1*time.Second,
+			"label",
+			controller.PreoomkillerAnnotationMemoryThresholdKey,
+			controller.PreoomkillerAnnotationRestartScheduleKey,
+			controller.PreoomkillerAnnotationTZKey,
+			controller.PreoomkillerAnnotationRestartAtKey,
+			30*time.Second,
+			0,
+			controller.WithNotifier(notifier),
+		)
+
+		oomKilledAt := time.Date(2026, 2, 15, 7, 0, 0, 0, time.UTC)
+		pod := controller.Pod{
+			Name:            "test-pod",
+			Namespace:       "default",
+			UID:             "3f6c1a2e-uid",
+			Owner:           &controller.Owner{Kind: "ReplicaSet", Name: "test-rs"},
+			LastOOMKilledAt: &oomKilledAt,
+		}
+
+		repo.EXPECT().
+			ListPodsQuery(mock.Anything, "label").
+			Return([]controller.Pod{pod}, nil).
+			Once()
+		repo.EXPECT().
+			ListPodMetricsQuery(mock.Anything, mock.Anything, mock.Anything).
+			Return(map[string]*controller.PodMetrics{}, nil).
+			Maybe()
+		repo.EXPECT().
+			SetAnnotationCommand(mock.Anything, "default", "test-pod",
+				controller.PreoomkillerAnnotationLastOOMAtKey, "2026-02-15T07:00:00Z").
+			Return(nil).
+			Once()
+		notifier.EXPECT().
+			NotifyMissedOOMCommand(mock.Anything, controller.MissedOOM{
+				Namespace:   "default",
+				Pod:         "test-pod",
+				PodUID:      "3f6c1a2e-uid",
+				Owner:       pod.Owner,
+				OOMKilledAt: oomKilledAt,
+			}).
+			Return(nil).
+			Once()
+
+		err := svc.ReconcileCommand(t.Context())
+		require.NoError(t, err)
+	})
+
+	t.Run("already recorded OOMKilled termination is ignored", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mocks.NewMockRepository(t)
+		svc := controller.New(
+			logger,
+			repo,
+			cronparser.New(),
+			1*time.Second,
+			"label",
+			controller.PreoomkillerAnnotationMemoryThresholdKey,
+			controller.PreoomkillerAnnotationRestartScheduleKey,
+			controller.PreoomkillerAnnotationTZKey,
+			controller.PreoomkillerAnnotationRestartAtKey,
+			30*time.Second,
+			0,
+			controller.WithOOMThresholdTightening(10),
+		)
+
+		oomKilledAt := time.Date(2026, 2, 15, 7, 0, 0, 0, time.UTC)
+		pod := controller.Pod{
+			Name:      "test-pod",
+			Namespace: "default",
+			Annotations: map[string]string{
+				controller.PreoomkillerAnnotationLastOOMAtKey: "2026-02-15T07:00:00Z",
+			},
+			LastOOMKilledAt: &oomKilledAt,
+		}
+
+		repo.EXPECT().
+			ListPodsQuery(mock.Anything, "label").
+			Return([]controller.Pod{pod}, nil).
+			Once()
+		// SetAnnotationCommand must not be called
+
+		err := svc.ReconcileCommand(t.Context())
+		require.NoError(t, err)
+	})
+
+	t.Run("metrics not found skips pod", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mocks.NewMockRepository(t)
+		svc := controller.New(
+			logger,
+			repo,
+			cronparser.New(),
+			1*time.Second,
+			"label",
+			controller.PreoomkillerAnnotationMemoryThresholdKey,
+			controller.PreoomkillerAnnotationRestartScheduleKey,
+			controller.PreoomkillerAnnotationTZKey,
+			controller.PreoomkillerAnnotationRestartAtKey,
+			30*time.Second,
+			0,
+		)
+
+		pod := controller.Pod{
+			Name:      "test-pod",
+			Namespace: "default",
+			Annotations: map[string]string{
+				controller.PreoomkillerAnnotationMemoryThresholdKey: "256Mi",
+			},
+			MemoryLimit: ptrQty(testQty("1Gi")),
+		}
+
+		repo.EXPECT().
+			ListPodsQuery(mock.Anything, "label").
+			Return([]controller.Pod{pod}, nil).
+			Once()
+		repo.EXPECT().
+			ListPodMetricsQuery(mock.Anything, "default", "label").
+			Return(map[string]*controller.PodMetrics{}, nil).
+			Once()
+
+		err := svc.ReconcileCommand(t.Context())
+		require.NoError(t, err)
+	})
+
+	t.Run("restart-at without restart schedule is removed", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mocks.NewMockRepository(t)
+		svc := controller.New(
+			logger,
+			repo,
+			cronparser.New(),
+			1*time.Second,
+			"label",
+			controller.PreoomkillerAnnotationMemoryThresholdKey,
+			controller.PreoomkillerAnnotationRestartScheduleKey,
+			controller.PreoomkillerAnnotationTZKey,
+			controller.PreoomkillerAnnotationRestartAtKey,
+			30*time.Second,
+			0,
+		)
+
+		pod := controller.Pod{
+			Name:      "test-pod",
+			Namespace: "default",
+			Annotations: map[string]string{
+				controller.PreoomkillerAnnotationRestartAtKey:     time.Now().Add(time.Hour).Format(time.RFC3339),
+				controller.PreoomkillerAnnotationRestartAtSpecKey: "0 3 * * *||",
+			},
+		}
+
+		repo.EXPECT().
+			ListPodsQuery(mock.Anything, "label").
+			Return([]controller.Pod{pod}, nil).
+			Once()
+		repo.EXPECT().
+			SetAnnotationCommand(mock.Anything, "default", "test-pod",
+				controller.PreoomkillerAnnotationRestartAtKey, "").
+			Return(nil).
+			Once()
+		repo.EXPECT().
+			SetAnnotationCommand(mock.Anything, "default", "test-pod",
+				controller.PreoomkillerAnnotationRestartAtSpecKey, "").
+			Return(nil).
+			Once()
+
+		err := svc.ReconcileCommand(t.Context())
+		require.NoError(t, err)
+	})
+
+	t.Run("scheduled restart too soon after pod creation is deferred", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mocks.NewMockRepository(t)
+		svc := controller.New(
+			logger,
+			repo,
+			cronparser.New(),
+			1*time.Second,
+			"label",
+			controller.PreoomkillerAnnotationMemoryThresholdKey,
+			controller.PreoomkillerAnnotationRestartScheduleKey,
+			controller.PreoomkillerAnnotationTZKey,
+			controller.PreoomkillerAnnotationRestartAtKey,
+			30*time.Second,
+			0,
+			controller.WithOneShot(),
+			controller.WithRestartMinInterval(time.Hour),
+		)
+
+		createdAt := time.Now().Add(-time.Minute)
+		pod := controller.Pod{
+			Name:      "test-pod",
+			Namespace: "default",
+			Annotations: map[string]string{
+				controller.PreoomkillerAnnotationRestartScheduleKey: "* * * * *",
+			},
+			CreatedAt: createdAt,
+		}
+
+		repo.EXPECT().
+			ListPodsQuery(mock.Anything, "label").
+			Return([]controller.Pod{pod}, nil).
+			Once()
+		repo.EXPECT().
+			CreatePodEventCommand(mock.Anything, mock.Anything, mock.MatchedBy(func(event controller.PodEvent) bool {
+				return event.Type == controller.EventTypeWarning &&
+					event.Reason == controller.EventReasonRestartTooFrequent
+			})).
+			Return(nil).
+			Once()
+		repo.EXPECT().
+			SetAnnotationCommand(mock.Anything, "default", "test-pod",
+				controller.PreoomkillerAnnotationRestartAtKey, mock.MatchedBy(func(value string) bool {
+					restartAt, err := time.Parse(time.RFC3339, value)
+
+					return err == nil && !restartAt.Before(createdAt.Add(time.Hour).Truncate(time.Second))
+				})).
+			Return(nil).
+			Once()
+		repo.EXPECT().
+			SetAnnotationCommand(mock.Anything, "default", "test-pod",
+				controller.PreoomkillerAnnotationRestartAtSpecKey, mock.Anything).
+			Return(nil).
+			Once()
+
+		err := svc.ReconcileCommand(t.Context())
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid tz is reported and the schedule falls back to UTC", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mocks.NewMockRepository(t)
+		svc := controller.New(
+			logger,
+			repo,
+			cronparser.New(),
+			1*time.Second,
+			"label",
+			controller.PreoomkillerAnnotationMemoryThresholdKey,
+			controller.PreoomkillerAnnotationRestartScheduleKey,
+			controller.PreoomkillerAnnotationTZKey,
+			controller.PreoomkillerAnnotationRestartAtKey,
+			30*time.Second,
+			0,
+			controller.WithOneShot(),
+		)
+
+		pod := controller.Pod{
+			Name:      "test-pod",
+			Namespace: "default",
+			Annotations: map[string]string{
+				controller.PreoomkillerAnnotationRestartScheduleKey: "0 3 * * *",
+				controller.PreoomkillerAnnotationTZKey:              "Europe/Atlantis",
+			},
+			CreatedAt: time.Now().Add(-time.Hour),
+		}
+
+		repo.EXPECT().
+			ListPodsQuery(mock.Anything, "label").
+			Return([]controller.Pod{pod}, nil).
+			Once()
+		repo.EXPECT().
+			CreatePodEventCommand(mock.Anything, mock.Anything, mock.MatchedBy(func(event controller.PodEvent) bool {
+				return event.Type == controller.EventTypeWarning &&
+					event.Reason == controller.EventReasonInvalidTimezone
+			})).
+			Return(nil).
+			Once()
+		repo.EXPECT().
+			SetAnnotationCommand(mock.Anything, "default", "test-pod",
+				controller.PreoomkillerAnnotationRestartAtKey, mock.MatchedBy(func(value string) bool {
+					restartAt, err := time.Parse(time.RFC3339, value)
+
+					return err == nil && restartAt.UTC().Hour() == 3 && restartAt.Minute() == 0
+				})).
+			Return(nil).
+			Once()
+		repo.EXPECT().
+			SetAnnotationCommand(mock.Anything, "default", "test-pod",
+				controller.PreoomkillerAnnotationRestartAtSpecKey, mock.Anything).
+			Return(nil).
+			Once()
+
+		err := svc.ReconcileCommand(t.Context())
+		require.NoError(t, err)
+	})
+
+	t.Run("percentage threshold without memory limit is reported once", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mocks.NewMockRepository(t)
+		svc := controller.New(
+			logger,
+			repo,
+			cronparser.New(),
+			1*time.Second,
+			"label",
+			controller.PreoomkillerAnnotationMemoryThresholdKey,
+			controller.PreoomkillerAnnotationRestartScheduleKey,
+			controller.PreoomkillerAnnotationTZKey,
+			controller.PreoomkillerAnnotationRestartAtKey,
+			30*time.Second,
+			0,
+		)
+
+		pod := controller.Pod{
+			Name:      "test-pod",
+			Namespace: "default",
+			UID:       "3f6c1a2e-uid",
+			Annotations: map[string]string{
+				controller.PreoomkillerAnnotationMemoryThresholdKey: "80%",
+			},
+		}
+
+		repo.EXPECT().
+			ListPodsQuery(mock.Anything, "label").
+			Return([]controller.Pod{pod}, nil).
+			Twice()
+		repo.EXPECT().
+			ListPodMetricsQuery(mock.Anything, "default", "label").
+			Return(map[string]*controller.PodMetrics{}, nil).
+			Twice()
+		repo.EXPECT().
+			CreatePodEventCommand(mock.Anything, mock.Anything, mock.MatchedBy(func(event controller.PodEvent) bool {
+				return event.Type == controller.EventTypeWarning &&
+					event.Reason == controller.EventReasonMissingMemoryLimit
+			})).
+			Return(nil).
+			Once()
+
+		require.NoError(t, svc.ReconcileCommand(t.Context()))
+		require.NoError(t, svc.ReconcileCommand(t.Context()))
+	})
+
+	t.Run("rising-for longer than the usage history covers is reported", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mocks.NewMockRepository(t)
+		svc := controller.New(
+			logger,
+			repo,
+			cronparser.New(),
+			1*time.Minute,
+			"label",
+			controller.PreoomkillerAnnotationMemoryThresholdKey,
+			controller.PreoomkillerAnnotationRestartScheduleKey,
+			controller.PreoomkillerAnnotationTZKey,
+			controller.PreoomkillerAnnotationRestartAtKey,
+			30*time.Second,
+			0,
+			controller.WithUsageHistorySize(10),
+		)
+
+		// 10 samples one minute apart cover 9 minutes, never 30.
+		pod := controller.Pod{
+			Name:      "test-pod",
+			Namespace: "default",
+			UID:       "3f6c1a2e-uid",
+			Annotations: map[string]string{
+				controller.PreoomkillerAnnotationMemoryThresholdKey: "256Mi",
+				controller.PreoomkillerAnnotationRisingForKey:       "30m",
+			},
+			MemoryLimit: ptrQty(testQty("1Gi")),
+		}
+
+		repo.EXPECT().
+			ListPodsQuery(mock.Anything, "label").
+			Return([]controller.Pod{pod}, nil).
+			Once()
+		repo.EXPECT().
+			ListPodMetricsQuery(mock.Anything, "default", "label").
+			Return(map[string]*controller.PodMetrics{"default/test-pod": {MemoryUsage: ptrQty(testQty("512Mi"))}}, nil).
+			Once()
+		repo.EXPECT().
+			CreatePodEventCommand(mock.Anything, mock.Anything, mock.MatchedBy(func(event controller.PodEvent) bool {
+				return event.Type == controller.EventTypeWarning &&
+					event.Reason == controller.EventReasonInvalidRisingFor
+			})).
+			Return(nil).
+			Once()
+
+		err := svc.ReconcileCommand(t.Context())
+		require.ErrorIs(t, err, controller.ErrReconcilePodsFailed)
+	})
+
+	t.Run("restart-at of a changed restart schedule is recomputed", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mocks.NewMockRepository(t)
+		svc := controller.New(
+			logger,
+			repo,
+			cronparser.New(),
+			1*time.Second,
+			"label",
+			controller.PreoomkillerAnnotationMemoryThresholdKey,
+			controller.PreoomkillerAnnotationRestartScheduleKey,
+			controller.PreoomkillerAnnotationTZKey,
+			controller.PreoomkillerAnnotationRestartAtKey,
+			30*time.Second,
+			0,
+			controller.WithOneShot(),
+		)
+
+		now := time.Now()
+		pod := controller.Pod{
+			Name:      "test-pod",
+			Namespace: "default",
+			Annotations: map[string]string{
+				controller.PreoomkillerAnnotationRestartScheduleKey: "0 4 * * *",
+				// A missed restart-at of the old schedule must not evict the pod.
+				controller.PreoomkillerAnnotationRestartAtKey:     now.Add(-time.Minute).Format(time.RFC3339),
+				controller.PreoomkillerAnnotationRestartAtSpecKey: "0 3 * * *||",
+			},
+			CreatedAt: now.Add(-time.Hour),
+		}
+
+		repo.EXPECT().
+			ListPodsQuery(mock.Anything, "label").
+			Return([]controller.Pod{pod}, nil).
+			Once()
+		repo.EXPECT().
+			SetAnnotationCommand(mock.Anything, "default", "test-pod",
+				controller.PreoomkillerAnnotationRestartAtKey, mock.Anything).
+			Return(nil).
+			Once()
+		repo.EXPECT().
+			SetAnnotationCommand(mock.Anything, "default", "test-pod",
+				controller.PreoomkillerAnnotationRestartAtSpecKey, "0 4 * * *||").
+			Return(nil).
+			Once()
+
+		err := svc.ReconcileCommand(t.Context())
+		require.NoError(t, err)
+	})
+}
+
+func TestService_RequestEvictionCommand(t *testing.T) {
+	t.Parallel()
+
+	logger := slog.Default()
+
+	newService := func(repo controller.Repository) *controller.Service {
+		return controller.New(
+			logger,
+			repo,
+			cronparser.New(),
+			1*time.Second,
+			"label",
+			controller.PreoomkillerAnnotationMemoryThresholdKey,
+			controller.PreoomkillerAnnotationRestartScheduleKey,
+			controller.PreoomkillerAnnotationTZKey,
+			controller.PreoomkillerAnnotationRestartAtKey,
+			30*time.Second,
+			30*time.Minute,
+		)
+	}
+
+	t.Run("pod is evicted", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mocks.NewMockRepository(t)
+		svc := newService(repo)
+
+		pod := controller.Pod{Name: "test-pod", Namespace: "default", UID: "uid-1", CreatedAt: time.Now().Add(-time.Hour)}
+
+		repo.EXPECT().GetPodQuery(mock.Anything, "default", "test-pod").Return(pod, nil).Once()
+		repo.EXPECT().PodSelectedQuery(mock.Anything, "default", "test-pod", "label").Return(true, nil).Once()
+		repo.EXPECT().
+			SetAnnotationCommand(mock.Anything, "default", "test-pod",
+				controller.PreoomkillerAnnotationEvictedReasonKey, "manual: requested through the admin API").
+			Return(nil).
+			Once()
+		repo.EXPECT().EvictPodCommand(mock.Anything, "default", "test-pod", "uid-1").Return(nil).Once()
+		repo.EXPECT().
+			CreatePodEventCommand(mock.Anything, mock.Anything, mock.MatchedBy(func(event controller.PodEvent) bool {
+				return event.Reason == controller.EventReasonPreOOMEvicted &&
+					strings.Contains(event.Message, "requested through the admin API")
+			})).
+			Return(nil).
+			Once()
+
+		result, err := svc.RequestEvictionCommand(t.Context(), "default", "test-pod")
+		require.NoError(t, err)
+		require.Equal(t, controller.EvictionRequestResult{Outcome: controller.OutcomeEvicted}, result)
+	})
+
+	t.Run("eviction guards apply", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mocks.NewMockRepository(t)
+		svc := newService(repo)
+
+		pod := controller.Pod{Name: "test-pod", Namespace: "default", CreatedAt: time.Now().Add(-time.Minute)}
+
+		repo.EXPECT().GetPodQuery(mock.Anything, "default", "test-pod").Return(pod, nil).Once()
+		repo.EXPECT().PodSelectedQuery(mock.Anything, "default", "test-pod", "label").Return(true, nil).Once()
+
+		result, err := svc.RequestEvictionCommand(t.Context(), "default", "test-pod")
+		require.NoError(t, err)
+		require.Equal(t, controller.EvictionRequestResult{
+			Outcome: controller.OutcomeSkipped,
+			Reason:  string(controller.SkipReasonPodTooYoung),
+		}, result)
+	})
+
+	t.Run("missing pod", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mocks.NewMockRepository(t)
+		svc := newService(repo)
+
+		repo.EXPECT().GetPodQuery(mock.Anything, "default", "gone").Return(controller.Pod{}, testNotFoundError{}).Once()
+
+		_, err := svc.RequestEvictionCommand(t.Context(), "default", "gone")
+		require.ErrorIs(t, err, controller.ErrPodNotFound)
+	})
+
+	t.Run("pod not selected", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mocks.NewMockRepository(t)
+		svc := newService(repo)
+
+		pod := controller.Pod{Name: "other-pod", Namespace: "default", CreatedAt: time.Now().Add(-time.Hour)}
+
+		repo.EXPECT().GetPodQuery(mock.Anything, "default", "other-pod").Return(pod, nil).Once()
+		repo.EXPECT().PodSelectedQuery(mock.Anything, "default", "other-pod", "label").Return(false, nil).Once()
+
+		_, err := svc.RequestEvictionCommand(t.Context(), "default", "other-pod")
+		require.ErrorIs(t, err, controller.ErrPodNotSelected)
+	})
+}
+
+func TestService_CancelPendingEvictionCommand(t *testing.T) {
+	t.Parallel()
+
+	logger := slog.Default()
+
+	t.Run("without pending eviction", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mocks.NewMockRepository(t)
+		svc := controller.New(
+			logger,
+			repo,
+			cronparser.New(),
+			1*time.Second,
+			"label",
+			controller.PreoomkillerAnnotationMemoryThresholdKey,
+			controller.PreoomkillerAnnotationRestartScheduleKey,
+			controller.PreoomkillerAnnotationTZKey,
+			controller.PreoomkillerAnnotationRestartAtKey,
+			30*time.Second,
+			0,
+		)
+
+		_, err := svc.CancelPendingEvictionCommand(t.Context(), "default", "test-pod")
+		require.ErrorIs(t, err, controller.ErrNoPendingEviction)
+	})
+
+	t.Run("pending eviction moves to the next occurrence", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mocks.NewMockRepository(t)
+		svc := controller.New(
+			logger,
+			repo,
+			cronparser.New(),
+			1*time.Second,
+			"label",
+			controller.PreoomkillerAnnotationMemoryThresholdKey,
+			controller.PreoomkillerAnnotationRestartScheduleKey,
+			controller.PreoomkillerAnnotationTZKey,
+			controller.PreoomkillerAnnotationRestartAtKey,
+			0,
+			0,
+		)
+
+		restartAt := time.Now().UTC().Truncate(time.Hour).Add(2 * time.Hour)
+		pod := controller.Pod{
+			Name:      "test-pod",
+			Namespace: "default",
+			Annotations: map[string]string{
+				controller.PreoomkillerAnnotationRestartScheduleKey: "0 * * * *",
+				controller.PreoomkillerAnnotationRestartAtKey:       restartAt.Format(time.RFC3339),
+			},
+			CreatedAt: time.Now().Add(-time.Hour),
+		}
+
+		repo.EXPECT().ListPodsQuery(mock.Anything, "label").Return([]controller.Pod{pod}, nil).Once()
+		require.NoError(t, svc.ReconcileCommand(t.Context()))
+		require.Len(t, svc.PendingEvictionsQuery(), 1)
+
+		next := restartAt.Add(time.Hour)
+
+		repo.EXPECT().GetPodQuery(mock.Anything, "default", "test-pod").Return(pod, nil).Once()
+		repo.EXPECT().PodSelectedQuery(mock.Anything, "default", "test-pod", "label").Return(true, nil).Once()
+		repo.EXPECT().
+			SetAnnotationCommand(mock.Anything, "default", "test-pod",
+				controller.PreoomkillerAnnotationRestartAtKey, next.Format(time.RFC3339)).
+			Return(nil).
+			Once()
+		repo.EXPECT().
+			SetAnnotationCommand(mock.Anything, "default", "test-pod",
+				controller.PreoomkillerAnnotationRestartAtSpecKey, mock.Anything).
+			Return(nil).
+			Once()
+
+		got, err := svc.CancelPendingEvictionCommand(t.Context(), "default", "test-pod")
+		require.NoError(t, err)
+		require.True(t, next.Equal(got))
+
+		pending := svc.PendingEvictionsQuery()
+		require.Len(t, pending, 1)
+		require.True(t, next.Equal(pending[0].RestartAt))
+	})
+}
+
+func TestService_ReloadCommand(t *testing.T) {
+	t.Parallel()
+
+	repo := mocks.NewMockRepository(t)
+	svc := controller.New(
+		slog.Default(),
+		repo,
+		cronparser.New(),
+		1*time.Second,
+		"legacy=true",
+		controller.PreoomkillerAnnotationMemoryThresholdKey,
+		controller.PreoomkillerAnnotationRestartScheduleKey,
+		controller.PreoomkillerAnnotationTZKey,
+		controller.PreoomkillerAnnotationRestartAtKey,
+		0,
+		0,
+	)
+
+	restartAt := time.Now().UTC().Truncate(time.Hour).Add(2 * time.Hour)
+	pod := controller.Pod{
+		Name:      "test-pod",
+		Namespace: "default",
+		Annotations: map[string]string{
+			controller.PreoomkillerAnnotationRestartScheduleKey: "0 * * * *",
+			controller.PreoomkillerAnnotationRestartAtKey:       restartAt.Format(time.RFC3339),
+		},
+		CreatedAt: time.Now().Add(-time.Hour),
+	}
+
+	repo.EXPECT().ListPodsQuery(mock.Anything, "legacy=true").Return([]controller.Pod{pod}, nil).Once()
+	require.NoError(t, svc.ReconcileCommand(t.Context()))
+	require.Len(t, svc.PendingEvictionsQuery(), 1)
+
+	svc.ReloadCommand(2*time.Second, "app.example.com/preoomkiller=true")
+
+	// The reloaded selector is used, and the pending eviction of the still selected pod is kept.
+	repo.EXPECT().
+		ListPodsQuery(mock.Anything, "app.example.com/preoomkiller=true").
+		Return([]controller.Pod{pod}, nil).
+		Once()
+	require.NoError(t, svc.ReconcileCommand(t.Context()))
+
+	pending := svc.PendingEvictionsQuery()
+	require.Len(t, pending, 1)
+	require.True(t, restartAt.Equal(pending[0].RestartAt))
+}
+
+func TestService_SimulateQuery(t *testing.T) {
+	t.Parallel()
+
+	logger := slog.Default()
+	repo := mocks.NewMockRepository(t)
+	svc := controller.New(
+		logger,
+		repo,
+		cronparser.New(),
+		1*time.Second,
+		"label",
+		controller.PreoomkillerAnnotationMemoryThresholdKey,
+		controller.PreoomkillerAnnotationRestartScheduleKey,
+		controller.PreoomkillerAnnotationTZKey,
+		controller.PreoomkillerAnnotationRestartAtKey,
+		30*time.Second,
+		30*time.Minute,
+	)
+
+	now := time.Now()
+	pods := []controller.Pod{
+		{
+			Name:      "over-threshold",
+			Namespace: "default",
+			Annotations: map[string]string{
+				controller.PreoomkillerAnnotationMemoryThresholdKey: "256Mi",
+			},
+			MemoryLimit: ptrQty(testQty("1Gi")),
+			CreatedAt:   now.Add(-time.Hour),
+		},
+		{
+			Name:      "too-young",
+			Namespace: "default",
+			Annotations: map[string]string{
+				controller.PreoomkillerAnnotationMemoryThresholdKey: "256Mi",
+			},
+			MemoryLimit: ptrQty(testQty("1Gi")),
+			CreatedAt:   now.Add(-time.Minute),
+		},
+		{
+			Name:      "no-limit",
+			Namespace: "default",
+			Annotations: map[string]string{
+				controller.PreoomkillerAnnotationMemoryThresholdKey: "80%",
+			},
+		},
+		{
+			Name:      "scheduled",
+			Namespace: "default",
+			Annotations: map[string]string{
+				controller.PreoomkillerAnnotationRestartScheduleKey: "0 3 * * *",
+			},
+		},
+		{
+			Name:      "bad-schedule",
+			Namespace: "default",
+			Annotations: map[string]string{
+				controller.PreoomkillerAnnotationRestartScheduleKey: "invalid",
+			},
+		},
+	}
+
+	repo.EXPECT().
+		ListPodsQuery(mock.Anything, "label").
+		Return(pods, nil).
+		Once()
+	repo.EXPECT().
+		ListPodMetricsQuery(mock.Anything, "default", "label").
+		Return(map[string]*controller.PodMetrics{
+			"default/over-threshold": {MemoryUsage: ptrQty(testQty("512Mi"))},
+			"default/too-young":      {MemoryUsage: ptrQty(testQty("512Mi"))},
+		}, nil).
+		Once()
+	// EvictPodCommand and SetAnnotationCommand must not be called
+
+	decisions, err := svc.SimulateQuery(t.Context())
+	require.NoError(t, err)
+	require.Len(t, decisions, 5)
+
+	byPod := make(map[string]controller.Decision, len(decisions))
+	for _, d := range decisions {
+		byPod[d.Name] = d
+	}
+
+	require.Equal(t, controller.ActionEvict, byPod["over-threshold"].Action)
+	require.Equal(t, controller.ActionSkip, byPod["too-young"].Action)
+	require.Equal(t, controller.SkipReasonPodTooYoung, byPod["too-young"].SkipReason)
+	require.Equal(t, controller.SkipReasonNoMemoryLimit, byPod["no-limit"].SkipReason)
+	require.Equal(t, controller.ActionSchedule, byPod["scheduled"].Action)
+	require.NotNil(t, byPod["scheduled"].RestartAt)
+	require.Equal(t, controller.SkipReasonInvalidSchedule, byPod["bad-schedule"].SkipReason)
+}
+
+func TestService_SimulateQuery_NamespaceDefaults(t *testing.T) {
+	t.Parallel()
+
+	repo := mocks.NewMockRepository(t)
+	svc := controller.New(
+		slog.Default(),
+		repo,
+		cronparser.New(),
+		1*time.Second,
+		"label",
+		controller.PreoomkillerAnnotationMemoryThresholdKey,
+		controller.PreoomkillerAnnotationRestartScheduleKey,
+		controller.PreoomkillerAnnotationTZKey,
+		controller.PreoomkillerAnnotationRestartAtKey,
+		30*time.Second,
+		0,
+		controller.WithNamespaceDefaults(),
+	)
+
+	pods := []controller.Pod{
+		{Name: "defaulted", Namespace: "batch", MemoryLimit: ptrQty(testQty("1Gi"))},
+		{
+			Name:      "own-threshold",
+			Namespace: "batch",
+			Annotations: map[string]string{
+				controller.PreoomkillerAnnotationMemoryThresholdKey: "768Mi",
+			},
+			MemoryLimit: ptrQty(testQty("1Gi")),
+		},
+		{
+			Name:      "own-window",
+			Namespace: "batch",
+			Annotations: map[string]string{
+				controller.PreoomkillerAnnotationRestartWindowKey: "invalid",
+			},
+			MemoryLimit: ptrQty(testQty("1Gi")),
+		},
+	}
+
+	ownAnnotations := pods[1].Annotations
+
+	repo.EXPECT().
+		ListPodsQuery(mock.Anything, "label").
+		Return(pods, nil).
+		Once()
+	repo.EXPECT().
+		ListNamespaceAnnotationsQuery(mock.Anything).
+		Return(map[string]map[string]string{
+			"batch": {
+				controller.PreoomkillerAnnotationDefaultMemoryThresholdKey: "256Mi",
+				controller.PreoomkillerAnnotationDefaultRestartScheduleKey: "0 3 * * *",
+			},
+			"default": {},
+		}, nil).
+		Once()
+	repo.EXPECT().
+		ListPodMetricsQuery(mock.Anything, "batch", "label").
+		Return(map[string]*controller.PodMetrics{
+			"batch/defaulted":     {MemoryUsage: ptrQty(testQty("512Mi"))},
+			"batch/own-threshold": {MemoryUsage: ptrQty(testQty("512Mi"))},
+			"batch/own-window":    {MemoryUsage: ptrQty(testQty("512Mi"))},
+		}, nil).
+		Once()
+
+	decisions, err := svc.SimulateQuery(t.Context())
+	require.NoError(t, err)
+	require.Len(t, decisions, 6)
+
+	byTrigger := make(map[string]controller.Decision, len(decisions))
+	for _, d := range decisions {
+		byTrigger[d.Name+"/"+string(d.Trigger)] = d
+	}
+
+	require.Equal(t, controller.ActionEvict, byTrigger["defaulted/threshold"].Action)
+	require.Equal(t, "256Mi", byTrigger["defaulted/threshold"].MemoryThreshold.String())
+	require.Equal(t, controller.ActionSchedule, byTrigger["defaulted/schedule"].Action)
+	require.Equal(t, "768Mi", byTrigger["own-threshold/threshold"].MemoryThreshold.String())
+	require.Equal(t, controller.ActionSchedule, byTrigger["own-threshold/schedule"].Action)
+	// The pod's own restart-window wins over the namespace default, even when invalid.
+	require.Equal(t, controller.SkipReasonInvalidSchedule, byTrigger["own-window/schedule"].SkipReason)
+	require.Len(t, ownAnnotations, 1, "annotations of listed pods must not be modified")
+}
+
+func TestService_SimulateQuery_MultipleLabelSelectors(t *testing.T) {
+	t.Parallel()
+
+	repo := mocks.NewMockRepository(t)
+	svc := controller.New(
+		slog.Default(),
+		repo,
+		cronparser.New(),
+		1*time.Second,
+		"legacy=true; new=true",
+		controller.PreoomkillerAnnotationMemoryThresholdKey,
+		controller.PreoomkillerAnnotationRestartScheduleKey,
+		controller.PreoomkillerAnnotationTZKey,
+		controller.PreoomkillerAnnotationRestartAtKey,
+		30*time.Second,
+		0,
+	)
+
+	podNamed := func(name string) controller.Pod {
+		return controller.Pod{
+			Name:      name,
+			Namespace: "default",
+			Annotations: map[string]string{
+				controller.PreoomkillerAnnotationRestartScheduleKey: "0 3 * * *",
+			},
+		}
+	}
+
+	repo.EXPECT().
+		ListPodsQuery(mock.Anything, "legacy=true").
+		Return([]controller.Pod{podNamed("legacy"), podNamed("both")}, nil).
+		Once()
+	repo.EXPECT().
+		ListPodsQuery(mock.Anything, "new=true").
+		Return([]controller.Pod{podNamed("both"), podNamed("new")}, nil).
+		Once()
+
+	decisions, err := svc.SimulateQuery(t.Context())
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(decisions))
+	for _, d := range decisions {
+		names = append(names, d.Name)
+	}
+
+	require.Equal(t, []string{"legacy", "both", "new"}, names)
+}
+
+func TestService_Start_Ready_Shutdown(t *testing.T) {
+	t.Parallel()
+
+	logger := slog.Default()
+	repo := mocks.NewMockRepository(t)
+	svc := controller.New(
+		logger,
+		repo,
+		cronparser.New(),
+		10*time.Second,
+		"",
+		controller.PreoomkillerAnnotationMemoryThresholdKey,
+		controller.PreoomkillerAnnotationRestartScheduleKey,
+		controller.PreoomkillerAnnotationTZKey,
+		controller.PreoomkillerAnnotationRestartAtKey,
+		30*time.Second,
+		0,
+	)
+
+	repo.EXPECT().
+		ListPodsQuery(mock.Anything, mock.Anything).
+		Return([]controller.Pod{}, nil).
+		Maybe()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	require.NoError(t, svc.Start(ctx))
+
+	select {
+	case <-svc.Ready():
+	case <-time.After(2 * time.Second):
+		t.Fatal("service did not become ready")
+	}
+
+	cancel()
+
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer shutdownCancel()
+
+	require.NoError(t, svc.Shutdown(shutdownCtx))
+}
+
+func TestService_ReadinessGate(t *testing.T) {
+	t.Parallel()
+
+	repo := mocks.NewMockRepository(t)
+	svc := controller.New(
+		slog.Default(),
+		repo,
+		cronparser.New(),
+		10*time.Second,
+		"label",
+		controller.PreoomkillerAnnotationMemoryThresholdKey,
+		controller.PreoomkillerAnnotationRestartScheduleKey,
+		controller.PreoomkillerAnnotationTZKey,
+		controller.PreoomkillerAnnotationRestartAtKey,
+		30*time.Second,
+		0,
+		controller.WithReadinessGate(),
+	)
+
+	pod := controller.Pod{
+		Name:      "test-pod",
+		Namespace: "default",
+		Annotations: map[string]string{
+			controller.PreoomkillerAnnotationMemoryThresholdKey: "256Mi",
+		},
+		MemoryLimit: ptrQty(testQty("1Gi")),
+	}
+	metricsErr := errors.New("pods.metrics.k8s.io is forbidden")
+
+	repo.EXPECT().
+		ListPodsQuery(mock.Anything, "label").
+		Return(nil, errors.New("pods is forbidden")).
+		Once()
+
+	require.Error(t, svc.ReconcileCommand(t.Context()))
+	require.ErrorIs(t, svc.Ping(t.Context()), controller.ErrReadinessGate, "the pods could not be listed")
+
+	repo.EXPECT().
+		ListPodsQuery(mock.Anything, "label").
+		Return([]controller.Pod{pod}, nil).
+		Times(2)
+	repo.EXPECT().
+		ListPodMetricsQuery(mock.Anything, "default", "label").
+		Return(nil, metricsErr).
+		Once()
+	repo.EXPECT().
+		GetPodMetricsQuery(mock.Anything, "default", "test-pod").
+		Return(nil, metricsErr).
+		Once()
+
+	require.Error(t, svc.ReconcileCommand(t.Context()))
+	require.ErrorIs(t, svc.Ping(t.Context()), controller.ErrReadinessGate, "the pod metrics could not be listed")
+
+	repo.EXPECT().
+		ListPodMetricsQuery(mock.Anything, "default", "label").
+		Return(map[string]*controller.PodMetrics{"default/test-pod": {MemoryUsage: ptrQty(testQty("128Mi"))}}, nil).
+		Once()
+
+	require.NoError(t, svc.ReconcileCommand(t.Context()))
+
+	select {
+	case <-svc.Ready():
+	default:
+		t.Fatal("service did not become ready after listing the pods and their metrics")
+	}
+}
+
+func TestService_Ping(t *testing.T) {
+	t.Parallel()
+
+	logger := slog.Default()
+
+	t.Run("before ready returns error", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mocks.NewMockRepository(t)
+		svc := controller.New(
+			logger,
+			repo,
+			cronparser.New(),
+			10*time.Second,
+			"",
+			controller.PreoomkillerAnnotationMemoryThresholdKey,
+			controller.PreoomkillerAnnotationRestartScheduleKey,
+			controller.PreoomkillerAnnotationTZKey,
+			controller.PreoomkillerAnnotationRestartAtKey,
+			30*time.Second,
+			0,
+		)
+
+		err := svc.Ping(t.Context())
+		require.Error(t, err)
+	})
+
+	t.Run("after ready returns nil", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mocks.NewMockRepository(t)
+		svc := controller.New(
+			logger,
+			repo,
+			cronparser.New(),
+			10*time.Second,
+			"",
+			controller.PreoomkillerAnnotationMemoryThresholdKey,
+			controller.PreoomkillerAnnotationRestartScheduleKey,
+			controller.PreoomkillerAnnotationTZKey,
+			controller.PreoomkillerAnnotationRestartAtKey,
+			30*time.Second,
+			0,
+		)
+
+		repo.EXPECT().
+			ListPodsQuery(mock.Anything, mock.Anything).
+			Return([]controller.Pod{}, nil).
+			Maybe()
+
+		ctx, cancel := context.WithCancel(t.Context())
+		defer cancel()
 
 		require.NoError(t, svc.Start(ctx))
 
@@ -420,4 +2607,44 @@ func TestService_Ping(t *testing.T) {
 		require.NoError(t, svc.Ping(t.Context()))
 		cancel()
 	})
+
+	t.Run("after overrun returns error", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mocks.NewMockRepository(t)
+		svc := controller.New(
+			logger,
+			repo,
+			cronparser.New(),
+			10*time.Second,
+			"",
+			controller.PreoomkillerAnnotationMemoryThresholdKey,
+			controller.PreoomkillerAnnotationRestartScheduleKey,
+			controller.PreoomkillerAnnotationTZKey,
+			controller.PreoomkillerAnnotationRestartAtKey,
+			30*time.Second,
+			0,
+			controller.WithReconcileTimeout(50*time.Millisecond),
+		)
+
+		// A hung API call.
+		repo.EXPECT().
+			ListPodsQuery(mock.Anything, mock.Anything).
+			RunAndReturn(func(ctx context.Context, _ string) ([]controller.Pod, error) {
+				<-ctx.Done()
+
+				return nil, ctx.Err()
+			}).
+			Maybe()
+
+		ctx, cancel := context.WithCancel(t.Context())
+		defer cancel()
+
+		require.NoError(t, svc.Start(ctx))
+
+		require.Eventually(t, func() bool {
+			return errors.Is(svc.Ping(t.Context()), controller.ErrReconcileOverrun)
+		}, 2*time.Second, 10*time.Millisecond)
+		cancel()
+	})
 }
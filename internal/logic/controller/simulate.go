@@ -0,0 +1,221 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+)
+
+// SimulateQuery evaluates all matching pods like ReconcileCommand does, but never evicts,
+// annotates or schedules anything. It returns one decision per pod and trigger.
+func (s *Service) SimulateQuery(ctx context.Context) ([]Decision, error) {
+	logger := s.logger.With("controller", "SimulateQuery")
+
+	pods, err := s.listPods(ctx, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	decisions := make([]Decision, 0, len(pods))
+	staggerOffsets := s.staggerOffsets(pods)
+	podMetrics, _ := s.listPodMetrics(ctx, logger, pods)
+	vpas := newVPAIndex()
+
+	for i := range pods {
+		pod := &pods[i]
+		podLogger := logger.With("pod", pod.Name, "namespace", pod.Namespace)
+
+		if _, hasSchedule := s.restartSpec(pod); hasSchedule {
+			offset := staggerOffsets[pod.Namespace+"/"+pod.Name]
+			decisions = append(decisions, s.simulateSchedule(ctx, podLogger, pod, offset, now))
+		}
+
+		if _, hasThreshold := pod.Annotations[s.annotationMemoryThresholdKey]; hasThreshold {
+			decisions = append(decisions, s.simulateThreshold(ctx, podLogger, pod, podMetrics, vpas, now))
+		}
+
+		if _, hasPromQL := pod.Annotations[PreoomkillerAnnotationPromQLKey]; hasPromQL {
+			decisions = append(decisions, s.simulatePromQL(ctx, podLogger, pod, now))
+		}
+	}
+
+	for i := range decisions {
+		decisions[i].Cluster = s.cluster
+	}
+
+	return decisions, nil
+}
+
+// simulateSchedule mirrors processScheduledRestart without side effects.
+func (s *Service) simulateSchedule(
+	ctx context.Context,
+	logger *slog.Logger,
+	pod *Pod,
+	staggerOffset time.Duration,
+	now time.Time,
+) Decision {
+	decision := Decision{
+		Namespace: pod.Namespace,
+		Name:      pod.Name,
+		Trigger:   TriggerSchedule,
+		Action:    ActionSchedule,
+	}
+
+	if restartAtStr, ok := pod.Annotations[s.annotationRestartAtKey]; ok && !s.restartAtStale(pod) {
+		restartAt, err := time.Parse(time.RFC3339, restartAtStr)
+		if err == nil {
+			decision.RestartAt = &restartAt
+
+			if restartAt.After(now) {
+				return decision
+			}
+
+			if pod.CreatedAt.Before(restartAt) {
+				return s.withEvictionSkipReason(ctx, logger, decision, pod, now)
+			}
+		}
+	}
+
+	nextRun, err := s.nextRestartAt(pod, now)
+	if err == nil {
+		nextRun, _, err = s.flapSafeRestartAt(pod, nextRun)
+	}
+
+	if err != nil {
+		decision.Action = ActionSkip
+		decision.SkipReason = SkipReasonInvalidSchedule
+		decision.RestartAt = nil
+
+		return decision
+	}
+
+	nextRun = nextRun.Add(staggerOffset)
+	decision.RestartAt = &nextRun
+
+	return decision
+}
+
+// simulateThreshold mirrors processPod without side effects.
+func (s *Service) simulateThreshold(
+	ctx context.Context,
+	logger *slog.Logger,
+	pod *Pod,
+	index podMetricsIndex,
+	vpas *vpaIndex,
+	now time.Time,
+) Decision {
+	decision := Decision{
+		Namespace: pod.Namespace,
+		Name:      pod.Name,
+		Trigger:   TriggerThreshold,
+		Action:    ActionNone,
+	}
+
+	check, err := s.checkThreshold(ctx, logger, *pod, index, vpas)
+	if err != nil {
+		decision.Action = ActionSkip
+		decision.SkipReason = SkipReasonMetricsError
+
+		if errors.Is(err, ErrMemoryThresholdParse) {
+			decision.SkipReason = SkipReasonInvalidThreshold
+		}
+
+		return decision
+	}
+
+	if check.skipReason != "" {
+		decision.Action = ActionSkip
+		decision.SkipReason = check.skipReason
+
+		return decision
+	}
+
+	decision.MemoryThreshold = &check.threshold
+	decision.MemoryUsage = &check.usage
+
+	if !check.breached {
+		return decision
+	}
+
+	samples := append(s.usageHistory.history(pod.UID), usageSample{at: now, usage: check.usage})
+
+	reason, err := risingForSkipReason(pod, samples, now, s.usageHistoryCoverage())
+	if err != nil {
+		reason = SkipReasonInvalidRisingFor
+	}
+
+	if reason != "" {
+		decision.Action = ActionSkip
+		decision.SkipReason = reason
+
+		return decision
+	}
+
+	return s.withEvictionSkipReason(ctx, logger, decision, pod, now)
+}
+
+// simulatePromQL mirrors processPromQL without side effects.
+func (s *Service) simulatePromQL(
+	ctx context.Context,
+	logger *slog.Logger,
+	pod *Pod,
+	now time.Time,
+) Decision {
+	decision := Decision{
+		Namespace: pod.Namespace,
+		Name:      pod.Name,
+		Trigger:   TriggerPromQL,
+		Action:    ActionNone,
+	}
+
+	holds, err := s.repo.EvaluatePromQLQuery(ctx, promQLExpr(pod))
+	if err != nil {
+		logger.WarnContext(ctx, "evaluate promql condition failed", "reason", err)
+
+		decision.Action = ActionSkip
+		decision.SkipReason = SkipReasonMetricsError
+
+		var target notConfigured
+		if errors.As(err, &target) {
+			decision.SkipReason = SkipReasonPromQLNotConfigured
+		}
+
+		return decision
+	}
+
+	if !holds {
+		return decision
+	}
+
+	return s.withEvictionSkipReason(ctx, logger, decision, pod, now)
+}
+
+// withEvictionSkipReason turns an evict decision into a skip when eviction guards block it.
+func (s *Service) withEvictionSkipReason(
+	ctx context.Context,
+	logger *slog.Logger,
+	decision Decision,
+	pod *Pod,
+	now time.Time,
+) Decision {
+	decision.Action = ActionEvict
+
+	reason := s.evictionSkipReason(pod, now)
+	if reason == "" {
+		var err error
+
+		reason, err = s.readyReplicasSkipReason(ctx, pod)
+		if err != nil {
+			logger.WarnContext(ctx, "ready replicas check failed", "reason", err)
+		}
+	}
+
+	if reason != "" {
+		decision.Action = ActionSkip
+		decision.SkipReason = reason
+	}
+
+	return decision
+}
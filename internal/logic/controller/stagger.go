@@ -0,0 +1,43 @@
+package controller
+
+import (
+	"slices"
+	"time"
+)
+
+// staggerOffsets assigns each scheduled pod an offset within the restart spread window.
+// Pods sharing an owner and a restart schedule are ordered by name and spaced evenly,
+// so the first replica restarts on schedule and the last one shortly before the window ends.
+// Returns nil when spreading is disabled.
+func (s *Service) staggerOffsets(pods []Pod) map[string]time.Duration {
+	if s.restartSpread <= 0 {
+		return nil
+	}
+
+	groups := make(map[string][]string)
+
+	for i := range pods {
+		pod := &pods[i]
+
+		spec, hasSchedule := s.restartSpec(pod)
+		if !hasSchedule || pod.Owner == nil {
+			continue
+		}
+
+		group := pod.Namespace + "/" + pod.Owner.UID + "/" + spec + "/" + pod.Annotations[s.annotationTZKey]
+		groups[group] = append(groups[group], pod.Namespace+"/"+pod.Name)
+	}
+
+	offsets := make(map[string]time.Duration)
+
+	for _, keys := range groups {
+		slices.Sort(keys)
+
+		step := s.restartSpread / time.Duration(len(keys))
+		for i, key := range keys {
+			offsets[key] = step * time.Duration(i)
+		}
+	}
+
+	return offsets
+}
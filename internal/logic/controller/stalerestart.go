@@ -0,0 +1,183 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+)
+
+// restartSpecFingerprint identifies the annotations the pod's restart-at is computed from.
+func (s *Service) restartSpecFingerprint(pod *Pod) string {
+	spec, _ := s.restartSpec(pod)
+
+	return strings.Join([]string{
+		spec,
+		pod.Annotations[s.annotationTZKey],
+		pod.Annotations[PreoomkillerAnnotationSkipDatesKey],
+	}, "|")
+}
+
+// restartAtStale reports whether the pod's restart-at was computed from a schedule that has since changed.
+// restart-at annotations written without a restart-at-spec are considered current.
+func (s *Service) restartAtStale(pod *Pod) bool {
+	recorded, ok := pod.Annotations[PreoomkillerAnnotationRestartAtSpecKey]
+
+	return ok && recorded != s.restartSpecFingerprint(pod)
+}
+
+// setRestartAt writes the restart-at annotation together with the schedule it was computed from.
+// Every restart-at is written through it, so that restartAtStale never compares a new restart-at
+// with the spec of an older one.
+func (s *Service) setRestartAt(ctx context.Context, logger *slog.Logger, pod *Pod, restartAtValue string) error {
+	if err := s.repo.SetAnnotationCommand(
+		ctx,
+		pod.Namespace,
+		pod.Name,
+		s.annotationRestartAtKey,
+		restartAtValue,
+	); err != nil {
+		return err
+	}
+
+	// Without restart-at-spec the restart-at is still honoured, so a failure here is not fatal.
+	if err := s.repo.SetAnnotationCommand(
+		ctx,
+		pod.Namespace,
+		pod.Name,
+		PreoomkillerAnnotationRestartAtSpecKey,
+		s.restartSpecFingerprint(pod),
+	); err != nil {
+		logger.WarnContext(ctx, "set restart-at-spec annotation", "reason", err)
+	}
+
+	return nil
+}
+
+// removeStaleRestartAt removes restart-at and restart-at-spec from a pod that no longer has a
+// restart-schedule or restart-window, and cancels its pending scheduled eviction.
+func (s *Service) removeStaleRestartAt(ctx context.Context, logger *slog.Logger, pod Pod) {
+	logger = logger.With("pod", pod.Name, "namespace", pod.Namespace)
+
+	if s.cancelPendingEviction(pod.Namespace + "/" + pod.Name) {
+		logger.InfoContext(ctx, "restart schedule removed, cancelled pending eviction")
+	}
+
+	logger.InfoContext(ctx, "restart schedule removed, removing restart-at annotation",
+		"restartAt", pod.Annotations[s.annotationRestartAtKey],
+	)
+
+	for _, key := range []string{s.annotationRestartAtKey, PreoomkillerAnnotationRestartAtSpecKey} {
+		if _, ok := pod.Annotations[key]; !ok {
+			continue
+		}
+
+		if err := s.repo.SetAnnotationCommand(ctx, pod.Namespace, pod.Name, key, ""); err != nil {
+			logger.ErrorContext(ctx, "remove annotation",
+				"key", key,
+				"reason", err,
+			)
+
+			return
+		}
+	}
+}
+
+// cancelPendingEviction stops the pending scheduled eviction for key ("namespace/name").
+// Returns false when there is none or it has already fired.
+func (s *Service) cancelPendingEviction(key string) bool {
+	if !s.stopPendingTimer(key) {
+		return false
+	}
+
+	namespace, name, _ := strings.Cut(key, "/")
+	s.forgetPendingEviction(s.logger, namespace, name)
+
+	return true
+}
+
+// cancelEvictedPodSchedule cancels the pending scheduled eviction of a pod just evicted by another
+// trigger, e.g. its memory threshold, so that the workload is not restarted twice minutes apart: a
+// StatefulSet pod recreated under the same name would otherwise be evicted again by the timer.
+func (s *Service) cancelEvictedPodSchedule(ctx context.Context, logger *slog.Logger, pod *Pod, trigger EvictionTrigger) {
+	if trigger == TriggerSchedule {
+		return
+	}
+
+	if s.cancelPendingEviction(pod.Namespace + "/" + pod.Name) {
+		logger.InfoContext(ctx, "pod evicted, cancelled its pending scheduled eviction",
+			"pod", pod.Name,
+			"namespace", pod.Namespace,
+			"trigger", trigger,
+		)
+	}
+}
+
+func (s *Service) stopPendingTimer(key string) bool {
+	s.timerMu.Lock()
+	defer s.timerMu.Unlock()
+
+	timer, ok := s.pendingTimers[key]
+	if !ok || !timer.Stop() {
+		// A fired timer removes its own entry once the eviction finishes.
+		return false
+	}
+
+	s.inFlightWg.Done()
+	s.deletePendingTimer(key)
+
+	return true
+}
+
+// scheduleStillValid reports whether a pending scheduled eviction of the freshly fetched pod should
+// still run: the pod must still have a restart-schedule or restart-window, and it must not have
+// changed since restart-at was computed.
+func (s *Service) scheduleStillValid(logger *slog.Logger, pod *Pod) bool {
+	logger = logger.With("pod", pod.Name, "namespace", pod.Namespace)
+
+	if _, ok := s.restartSpec(pod); !ok {
+		logger.Info("restart schedule removed, cancelling scheduled eviction")
+
+		return false
+	}
+
+	if s.restartAtStale(pod) {
+		logger.Info("restart schedule changed, cancelling scheduled eviction")
+
+		return false
+	}
+
+	return true
+}
+
+// cancelVanishedEvictions cancels pending scheduled evictions of pods that are no longer listed,
+// i.e. pods that were deleted or no longer match the label selector.
+func (s *Service) cancelVanishedEvictions(ctx context.Context, logger *slog.Logger, pods []Pod) {
+	listed := make(map[string]struct{}, len(pods))
+	for i := range pods {
+		listed[pods[i].Namespace+"/"+pods[i].Name] = struct{}{}
+	}
+
+	var cancelled []string
+
+	s.timerMu.Lock()
+
+	for key, timer := range s.pendingTimers {
+		if _, ok := listed[key]; ok || !timer.Stop() {
+			continue
+		}
+
+		s.inFlightWg.Done()
+		s.deletePendingTimer(key)
+
+		cancelled = append(cancelled, key)
+	}
+
+	s.timerMu.Unlock()
+
+	for _, key := range cancelled {
+		logger.InfoContext(ctx, "pod is gone, cancelled pending scheduled eviction", "pod", key)
+
+		namespace, name, _ := strings.Cut(key, "/")
+		s.forgetPendingEviction(logger, namespace, name)
+	}
+}
@@ -0,0 +1,158 @@
+package controller
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+)
+
+// suspendWorkload suspends evictions of the evicted pod's owner and reports it on the owner. The
+// suspension is persisted with the restart records, when they are kept, so that it survives
+// controller restarts.
+func (s *Service) suspendWorkload(logger *slog.Logger, evicted *Pod) {
+	owner := *evicted.Owner
+	workload := SuspendedWorkload{
+		Cluster:     s.cluster,
+		Namespace:   evicted.Namespace,
+		Owner:       owner,
+		Pod:         evicted.Name,
+		SuspendedAt: time.Now(),
+	}
+
+	s.workloadMu.Lock()
+	s.suspendedWorkloads[owner.UID] = workload
+	s.workloadMu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), _evictionTimeout)
+	defer cancel()
+
+	if s.recordRestarts {
+		if err := s.repo.SuspendWorkloadCommand(ctx, workload); err != nil {
+			logger.WarnContext(ctx, "persist workload suspension failed, it ends with the controller",
+				"reason", err,
+			)
+		}
+	}
+
+	s.emitOwnerEvent(ctx, logger, evicted.Namespace, owner, PodEvent{
+		Type:   EventTypeWarning,
+		Reason: EventReasonEvictionsSuspended,
+		Message: fmt.Sprintf("evictions suspended: no replacement of evicted pod %s became Ready within %s",
+			evicted.Name, s.verifyTimeout),
+	})
+}
+
+func (s *Service) isWorkloadSuspended(pod *Pod) bool {
+	return !s.workloadSuspendedAt(pod).IsZero()
+}
+
+// workloadSuspendedAt returns when evictions of the pod's owner were suspended, zero when they are not.
+func (s *Service) workloadSuspendedAt(pod *Pod) time.Time {
+	if pod.Owner == nil {
+		return time.Time{}
+	}
+
+	s.workloadMu.Lock()
+	defer s.workloadMu.Unlock()
+
+	return s.suspendedWorkloads[pod.Owner.UID].SuspendedAt
+}
+
+// restoreSuspendedWorkloads suspends again the evictions of the workloads suspended before the
+// controller restarted.
+func (s *Service) restoreSuspendedWorkloads(ctx context.Context, logger *slog.Logger) {
+	if !s.recordRestarts {
+		return
+	}
+
+	workloads, err := s.repo.ListSuspendedWorkloadsQuery(ctx)
+	if err != nil {
+		logger.WarnContext(ctx, "list persisted workload suspensions failed", "reason", err)
+
+		return
+	}
+
+	s.workloadMu.Lock()
+	for _, workload := range workloads {
+		workload.Cluster = s.cluster
+		s.suspendedWorkloads[workload.Owner.UID] = workload
+	}
+	s.workloadMu.Unlock()
+
+	logger.InfoContext(ctx, "restored suspended workloads", "count", len(workloads))
+}
+
+// ResumeWorkloadCommand resumes the suspended evictions of the workload, given by the kind (case
+// insensitive) and name of its controlling owner. Returns ErrWorkloadNotSuspended when its evictions
+// are not suspended.
+func (s *Service) ResumeWorkloadCommand(ctx context.Context, namespace, kind, name string) (SuspendedWorkload, error) {
+	logger := s.logger.With("controller", "ResumeWorkloadCommand")
+
+	s.workloadMu.Lock()
+	workload, found := s.findSuspendedWorkload(namespace, kind, name)
+	s.workloadMu.Unlock()
+
+	if !found {
+		return SuspendedWorkload{}, ErrWorkloadNotSuspended
+	}
+
+	if s.recordRestarts {
+		if err := s.repo.ResumeWorkloadCommand(ctx, workload.Owner); err != nil {
+			return SuspendedWorkload{}, fmt.Errorf("resume workload: %w", err)
+		}
+	}
+
+	s.workloadMu.Lock()
+	delete(s.suspendedWorkloads, workload.Owner.UID)
+	s.workloadMu.Unlock()
+
+	logger.InfoContext(ctx, "workload evictions resumed",
+		"namespace", namespace,
+		"ownerKind", workload.Owner.Kind,
+		"owner", workload.Owner.Name,
+		"suspendedAt", workload.SuspendedAt,
+	)
+
+	s.emitOwnerEvent(ctx, logger, namespace, workload.Owner, PodEvent{
+		Type:    EventTypeNormal,
+		Reason:  EventReasonEvictionsResumed,
+		Message: "evictions resumed through the admin API",
+	})
+
+	return workload, nil
+}
+
+// findSuspendedWorkload returns the suspended workload of the owner; the caller holds workloadMu.
+func (s *Service) findSuspendedWorkload(namespace, kind, name string) (SuspendedWorkload, bool) {
+	for _, workload := range s.suspendedWorkloads {
+		if workload.Namespace == namespace && strings.EqualFold(workload.Owner.Kind, kind) &&
+			workload.Owner.Name == name {
+			return workload, true
+		}
+	}
+
+	return SuspendedWorkload{}, false
+}
+
+// SuspendedWorkloadsQuery returns the workloads whose evictions are suspended, sorted by namespace,
+// owner kind and owner name.
+func (s *Service) SuspendedWorkloadsQuery() []SuspendedWorkload {
+	s.workloadMu.Lock()
+	workloads := slices.Collect(maps.Values(s.suspendedWorkloads))
+	s.workloadMu.Unlock()
+
+	slices.SortFunc(workloads, func(a, b SuspendedWorkload) int {
+		return cmp.Or(
+			cmp.Compare(a.Namespace, b.Namespace),
+			cmp.Compare(a.Owner.Kind, b.Owner.Kind),
+			cmp.Compare(a.Owner.Name, b.Owner.Name),
+		)
+	})
+
+	return workloads
+}
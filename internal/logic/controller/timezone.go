@@ -0,0 +1,44 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/skillcoder/preoomkiller-controller/internal/infra/metrics"
+)
+
+// _fallbackTZ is used for restart schedules whose tz annotation is not a valid IANA time zone.
+const _fallbackTZ = "UTC"
+
+// restartTZ returns the time zone the pod's restart schedule is evaluated in: its tz annotation,
+// or UTC when the annotation is not a valid IANA time zone.
+func (s *Service) restartTZ(pod *Pod) string {
+	tz := pod.Annotations[s.annotationTZKey]
+	if _, err := time.LoadLocation(tz); err != nil {
+		return _fallbackTZ
+	}
+
+	return tz
+}
+
+// reportInvalidTZ reports a tz annotation that is not a valid IANA time zone with a log entry,
+// a metric and, once per pod, a Warning Event. The restart schedule is evaluated in UTC instead.
+func (s *Service) reportInvalidTZ(ctx context.Context, logger *slog.Logger, pod *Pod) {
+	tz := pod.Annotations[s.annotationTZKey]
+
+	_, err := time.LoadLocation(tz)
+	if err == nil {
+		return
+	}
+
+	logger.WarnContext(ctx, "invalid tz annotation, using UTC",
+		"tz", tz,
+		"reason", err,
+	)
+	metrics.RecordInvalidTimezone(s.cluster, pod.Namespace, pod.Name)
+	s.reportMisconfiguration(ctx, logger, pod, EventReasonInvalidTimezone,
+		fmt.Sprintf("tz %q is not a valid IANA time zone; restart schedule is evaluated in %s", tz, _fallbackTZ),
+	)
+}
@@ -0,0 +1,31 @@
+package controller
+
+import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracer creates the spans of reconciles and evictions. Spans are dropped unless a tracer provider
+// is installed (see PREOOMKILLER_TRACING_ENABLED).
+var tracer = otel.Tracer("github.com/skillcoder/preoomkiller-controller/internal/logic/controller")
+
+// podAttributes identifies the pod on a span.
+func podAttributes(pod *Pod) trace.SpanStartEventOption {
+	return trace.WithAttributes(
+		attribute.String("k8s.namespace.name", pod.Namespace),
+		attribute.String("k8s.pod.name", pod.Name),
+		attribute.String("k8s.pod.uid", pod.UID),
+	)
+}
+
+// recordSpanError marks the span as failed with err; a nil err leaves it unchanged.
+func recordSpanError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
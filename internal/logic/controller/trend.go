@@ -0,0 +1,61 @@
+package controller
+
+import (
+	"fmt"
+	"time"
+)
+
+// risingForSkipReason returns SkipReasonNotRising when the pod has a rising-for condition and its
+// memory usage samples, oldest first, did not rise monotonically over the rising-for duration before
+// now; an empty reason when the eviction may proceed. A duration longer than coverage, how far back
+// the samples can reach, could never be satisfied and is an error.
+func risingForSkipReason(pod *Pod, samples []usageSample, now time.Time, coverage time.Duration) (SkipReason, error) {
+	spec, ok := pod.Annotations[PreoomkillerAnnotationRisingForKey]
+	if !ok {
+		return "", nil
+	}
+
+	window, err := time.ParseDuration(spec)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrRisingForParse, err)
+	}
+
+	if window <= 0 {
+		return "", fmt.Errorf("%w: duration must be positive, got %q", ErrRisingForParse, spec)
+	}
+
+	if window > coverage {
+		return "", fmt.Errorf("%w: %s is longer than the %s the usage history covers",
+			ErrRisingForUncovered, window, coverage)
+	}
+
+	if !isRising(samples, now.Add(-window)) {
+		return SkipReasonNotRising, nil
+	}
+
+	return "", nil
+}
+
+// isRising reports whether the usage never fell and grew overall from the last sample taken at or
+// before since to the last sample. It is false when the samples do not reach back to since.
+func isRising(samples []usageSample, since time.Time) bool {
+	start := -1
+
+	for i := range samples {
+		if !samples[i].at.After(since) {
+			start = i
+		}
+	}
+
+	if start < 0 {
+		return false
+	}
+
+	for i := start + 1; i < len(samples); i++ {
+		if samples[i].usage.Cmp(samples[i-1].usage) < 0 {
+			return false
+		}
+	}
+
+	return samples[len(samples)-1].usage.Cmp(samples[start].usage) > 0
+}
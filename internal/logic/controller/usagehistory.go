@@ -0,0 +1,153 @@
+package controller
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// _defaultUsageHistorySize is the default number of usage samples kept per pod.
+const _defaultUsageHistorySize = 10
+
+// usageSample is the memory usage of a pod at a threshold check.
+type usageSample struct {
+	at    time.Time
+	usage resource.Quantity
+	// threshold is the effective memory threshold of the check.
+	threshold resource.Quantity
+}
+
+// usageHistory keeps the most recent usage samples of each pod between reconciles, keyed by pod UID
+// so that a pod recreated under the same name starts afresh.
+type usageHistory struct {
+	size    int
+	mu      sync.Mutex
+	samples map[string][]usageSample
+}
+
+// WithUsageHistorySize keeps the last n memory usage samples of each pod, 10 by default.
+func WithUsageHistorySize(n int) Option {
+	return func(s *Service) {
+		s.usageHistory.size = max(n, 1)
+	}
+}
+
+func newUsageHistory(size int) *usageHistory {
+	return &usageHistory{size: size, samples: make(map[string][]usageSample)}
+}
+
+// record appends a sample to the pod history, dropping the oldest beyond the history size, unless it
+// was taken less than minSpacing after the previous sample. Returns the previous sample; false when
+// there is none.
+func (h *usageHistory) record(uid string, sample usageSample, minSpacing time.Duration) (usageSample, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	samples := h.samples[uid]
+
+	var (
+		previous usageSample
+		ok       bool
+	)
+
+	if len(samples) > 0 {
+		previous, ok = samples[len(samples)-1], true
+
+		if sample.at.Sub(previous.at) < minSpacing {
+			return previous, ok
+		}
+	}
+
+	if len(samples) >= h.size {
+		samples = append(samples[:0], samples[len(samples)-h.size+1:]...)
+	}
+
+	h.samples[uid] = append(samples, sample)
+
+	return previous, ok
+}
+
+// history returns a copy of the pod samples, oldest first.
+func (h *usageHistory) history(uid string) []usageSample {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return append([]usageSample(nil), h.samples[uid]...)
+}
+
+// retain drops the history of the pods whose UID is not in uids, e.g. because they were deleted.
+func (h *usageHistory) retain(uids map[string]struct{}) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for uid := range h.samples {
+		if _, ok := uids[uid]; !ok {
+			delete(h.samples, uid)
+		}
+	}
+}
+
+// clear drops the history of all pods, releasing the map rather than emptying it.
+func (h *usageHistory) clear() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.samples = make(map[string][]usageSample)
+}
+
+// usageHistoryCoverage returns how far back the usage history of a pod reaches once it is full: its
+// samples are one reconcile interval apart.
+func (s *Service) usageHistoryCoverage() time.Duration {
+	s.usageHistory.mu.Lock()
+	size := s.usageHistory.size
+	s.usageHistory.mu.Unlock()
+
+	return time.Duration(size-1) * s.currentInterval()
+}
+
+// forgetVanishedUsage drops the usage history of pods that are no longer listed.
+func (s *Service) forgetVanishedUsage(pods []Pod) {
+	listed := make(map[string]struct{}, len(pods))
+	for i := range pods {
+		listed[pods[i].UID] = struct{}{}
+	}
+
+	s.usageHistory.retain(listed)
+}
+
+// usageDelta is the change of a pod memory usage since its previous threshold check.
+type usageDelta struct {
+	delta resource.Quantity
+	since time.Duration
+}
+
+// String formats the delta for logs and events, e.g. "+12Mi in 5m0s".
+func (d usageDelta) String() string {
+	sign := ""
+	if d.delta.Sign() >= 0 {
+		sign = "+"
+	}
+
+	return fmt.Sprintf("%s%s in %s", sign, d.delta.String(), d.since.Round(time.Second))
+}
+
+// recordUsage adds the checked usage to the pod history, at most once per reconcile interval: checks
+// in between (retries, watch-triggered and manual reconciles) would shorten the time the history
+// covers. A tenth of the interval is tolerated for the variance of periodic reconciles. Returns the
+// change since the previous sample; nil on the first check of the pod.
+func (s *Service) recordUsage(pod *Pod, check thresholdCheck, now time.Time) *usageDelta {
+	minSpacing := s.currentInterval() * 9 / 10
+	sample := usageSample{at: now, usage: check.usage, threshold: check.threshold}
+
+	previous, ok := s.usageHistory.record(pod.UID, sample, minSpacing)
+	if !ok {
+		return nil
+	}
+
+	delta := check.usage.DeepCopy()
+	delta.Sub(previous.usage)
+
+	return &usageDelta{delta: delta, since: now.Sub(previous.at)}
+}
@@ -0,0 +1,82 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/skillcoder/preoomkiller-controller/internal/infra/metrics"
+)
+
+// startReplacementVerification checks in the background that a replacement for the evicted pod
+// becomes Ready within the verification timeout. Pods without a controlling owner are not verified.
+func (s *Service) startReplacementVerification(logger *slog.Logger, evicted Pod, evictedAt time.Time) {
+	if s.verifyTimeout <= 0 || evicted.Owner == nil || s.inShutdown.Load() {
+		return
+	}
+
+	// Pod creation timestamps have second precision.
+	evictedAt = evictedAt.Truncate(time.Second)
+
+	s.inFlightWg.Add(1)
+
+	go func() {
+		defer s.inFlightWg.Done()
+
+		s.verifyReplacement(logger, evicted, evictedAt)
+	}()
+}
+
+// verifyReplacement waits for a pod of the same owner created after evictedAt to become Ready.
+// On timeout it suspends evictions for the owner until they are resumed through the admin API or the
+// owner is replaced (e.g. a Deployment rollout creates a new ReplicaSet).
+func (s *Service) verifyReplacement(logger *slog.Logger, evicted Pod, evictedAt time.Time) {
+	owner := *evicted.Owner
+	logger = logger.With(
+		"pod", evicted.Name,
+		"namespace", evicted.Namespace,
+		"ownerKind", owner.Kind,
+		"owner", owner.Name,
+	)
+
+	timeout := time.NewTimer(s.verifyTimeout)
+	defer timeout.Stop()
+
+	ticker := time.NewTicker(_replacementPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		case <-timeout.C:
+			logger.Error("replacement pod did not become ready, suspending evictions for workload",
+				"timeout", s.verifyTimeout,
+			)
+			metrics.RecordReplacementNotReady(s.cluster, evicted.Namespace, owner.Kind, owner.Name)
+			s.suspendWorkload(logger, &evicted)
+
+			return
+		case <-ticker.C:
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), _evictionTimeout)
+		pods, err := s.repo.ListOwnerPodsQuery(ctx, evicted.Namespace, owner)
+
+		cancel()
+
+		if err != nil {
+			logger.Warn("list owner pods for replacement verification failed", "reason", err)
+
+			continue
+		}
+
+		for i := range pods {
+			if !pods[i].NotReady && !pods[i].CreatedAt.Before(evictedAt) {
+				logger.Info("replacement pod is ready", "replacement", pods[i].Name)
+
+				return
+			}
+		}
+	}
+}
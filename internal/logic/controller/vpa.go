@@ -0,0 +1,82 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+)
+
+// VPAMode selects how threshold evictions interact with VerticalPodAutoscalers.
+type VPAMode string
+
+const (
+	// VPAModeUpperBound compares usage against the VPA's recommended memory upper bound.
+	VPAModeUpperBound VPAMode = "upper-bound"
+	// VPAModeDefer skips threshold evictions of pods whose VPA restarts pods itself.
+	VPAModeDefer VPAMode = "defer"
+)
+
+// _vpaRestartingUpdateModes are the VPA update modes in which VPA restarts running pods itself. In the
+// other modes ("Off" and "Initial") VPA only recommends or sets resources at pod creation.
+var _vpaRestartingUpdateModes = []string{"Auto", "Recreate", "InPlaceOrRecreate"}
+
+// applyVPA adjusts a threshold check for the VerticalPodAutoscaler targeting the pod's workload.
+// Pods without an owner or VPA, and VPA lookup failures, leave the check unchanged.
+func (s *Service) applyVPA(
+	ctx context.Context,
+	logger *slog.Logger,
+	pod *Pod,
+	vpas *vpaIndex,
+	check thresholdCheck,
+) thresholdCheck {
+	if s.vpaMode == "" || pod.Owner == nil {
+		return check
+	}
+
+	// Defer mode only matters for pods that would be evicted.
+	if s.vpaMode == VPAModeDefer && !check.breached {
+		return check
+	}
+
+	vpa, err := s.lookupVPA(ctx, vpas, pod.Namespace, *pod.Owner)
+	if err != nil {
+		logger.WarnContext(ctx, "get vpa recommendation failed, using memory threshold", "reason", err)
+
+		return check
+	}
+
+	if vpa == nil {
+		return check
+	}
+
+	logger = logger.With("vpa", vpa.Name, "vpaUpdateMode", vpa.UpdateMode)
+
+	switch s.vpaMode {
+	case VPAModeUpperBound:
+		if vpa.MemoryUpperBound == nil {
+			logger.DebugContext(ctx, "vpa has no recommendation yet, using memory threshold")
+
+			return check
+		}
+
+		logger.DebugContext(ctx, "using vpa memory upper bound as threshold",
+			"vpaMemoryUpperBound", vpa.MemoryUpperBound.String(),
+		)
+
+		check.threshold = *vpa.MemoryUpperBound
+		check.breached = check.usage.Cmp(check.threshold) == 1
+	case VPAModeDefer:
+		if !slices.Contains(_vpaRestartingUpdateModes, vpa.UpdateMode) {
+			return check
+		}
+
+		logger.InfoContext(ctx, "memory threshold exceeded, leaving the restart to vpa",
+			"memoryUsage", check.usage.String(),
+		)
+
+		check.breached = false
+		check.skipReason = SkipReasonVPAManaged
+	}
+
+	return check
+}
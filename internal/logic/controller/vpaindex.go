@@ -0,0 +1,55 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// vpaIndex holds the VerticalPodAutoscalers of each namespace, listed at most once per reconcile and
+// keyed by the "kind/name" of the pod owners they apply to. A nil index makes lookups list the
+// namespace's VPAs for every pod.
+type vpaIndex struct {
+	// mu also serializes listing, so that concurrent reconcile workers list a namespace only once.
+	mu          sync.Mutex
+	byNamespace map[string]map[string]*VPARecommendation
+}
+
+func newVPAIndex() *vpaIndex {
+	return &vpaIndex{byNamespace: make(map[string]map[string]*VPARecommendation)}
+}
+
+// lookupVPA returns the VerticalPodAutoscaler that applies to the owner's pods, nil when there is none.
+func (s *Service) lookupVPA(
+	ctx context.Context,
+	index *vpaIndex,
+	namespace string,
+	owner Owner,
+) (*VPARecommendation, error) {
+	key := owner.Kind + "/" + owner.Name
+
+	if index == nil {
+		vpas, err := s.repo.ListVPARecommendationsQuery(ctx, namespace)
+		if err != nil {
+			return nil, fmt.Errorf("list vpa recommendations: %w", err)
+		}
+
+		return vpas[key], nil
+	}
+
+	index.mu.Lock()
+	defer index.mu.Unlock()
+
+	vpas, ok := index.byNamespace[namespace]
+	if !ok {
+		listed, err := s.repo.ListVPARecommendationsQuery(ctx, namespace)
+		if err != nil {
+			return nil, fmt.Errorf("list vpa recommendations: %w", err)
+		}
+
+		vpas = listed
+		index.byNamespace[namespace] = vpas
+	}
+
+	return vpas[key], nil
+}
@@ -0,0 +1,188 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// annotationWatch holds the pods whose annotations changed since the main loop last reconciled them,
+// keyed by "namespace/name".
+type annotationWatch struct {
+	mu      sync.Mutex
+	changed map[string]struct{}
+	// listed holds the pods listed by the last reconcile: only they are reconciled on a change, other
+	// pods wait for the next reconcile, which applies the label selector.
+	listed map[string]struct{}
+	// notify wakes the main loop; buffered so notifications coalesce.
+	notify chan struct{}
+}
+
+// WithAnnotationWatch reconciles a pod as soon as one of its preoomkiller annotations changes, e.g. an
+// edited memory threshold or restart schedule, instead of at the next reconcile. Pods are watched
+// through the repository.
+func WithAnnotationWatch() Option {
+	return func(s *Service) {
+		s.annotationWatch = &annotationWatch{
+			changed: make(map[string]struct{}),
+			listed:  make(map[string]struct{}),
+			notify:  make(chan struct{}, 1),
+		}
+	}
+}
+
+// watchedAnnotationKeys returns the annotations set by users on pods; the annotations the controller
+// sets itself, e.g. restart-at and restart-at-spec, are not watched, so its own writes do not wake it.
+func (s *Service) watchedAnnotationKeys() []string {
+	return []string{
+		s.annotationMemoryThresholdKey,
+		s.annotationRestartScheduleKey,
+		s.annotationTZKey,
+		PreoomkillerAnnotationRestartWindowKey,
+		PreoomkillerAnnotationSkipDatesKey,
+		PreoomkillerAnnotationPromQLKey,
+		PreoomkillerAnnotationContainerAggregationKey,
+		PreoomkillerAnnotationRisingForKey,
+	}
+}
+
+// watchAnnotations watches the annotations of the pods until ctx is done.
+func (s *Service) watchAnnotations(ctx context.Context) {
+	logger := s.logger.With("controller", "watchAnnotations")
+
+	err := s.repo.WatchPodAnnotationsQuery(ctx, s.watchedAnnotationKeys(), s.podAnnotationsChanged)
+	if err != nil && !errors.Is(err, context.Canceled) {
+		logger.ErrorContext(ctx, "watch pod annotations failed, changes wait for the next reconcile",
+			"reason", err,
+		)
+	}
+}
+
+// podAnnotationsChanged queues a reconcile of the pod and wakes the main loop.
+func (s *Service) podAnnotationsChanged(namespace, name string) {
+	w := s.annotationWatch
+
+	w.mu.Lock()
+	w.changed[namespace+"/"+name] = struct{}{}
+	w.mu.Unlock()
+
+	select {
+	case w.notify <- struct{}{}:
+	default:
+	}
+}
+
+// annotationChanges returns a channel receiving when pods are queued by podAnnotationsChanged; nil
+// when the annotation watch is disabled.
+func (s *Service) annotationChanges() <-chan struct{} {
+	if s.annotationWatch == nil {
+		return nil
+	}
+
+	return s.annotationWatch.notify
+}
+
+// rememberListedPods records the pods listed by a reconcile, which are reconciled on a change.
+func (s *Service) rememberListedPods(pods []Pod) {
+	if s.annotationWatch == nil {
+		return
+	}
+
+	listed := make(map[string]struct{}, len(pods))
+	for i := range pods {
+		listed[pods[i].Namespace+"/"+pods[i].Name] = struct{}{}
+	}
+
+	s.annotationWatch.mu.Lock()
+	s.annotationWatch.listed = listed
+	s.annotationWatch.mu.Unlock()
+}
+
+// popChangedPods removes the queued pods and returns those listed by the last reconcile, sorted.
+func (w *annotationWatch) popChangedPods() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	keys := slices.Sorted(maps.Keys(w.changed))
+	clear(w.changed)
+
+	return slices.DeleteFunc(keys, func(key string) bool {
+		_, ok := w.listed[key]
+
+		return !ok
+	})
+}
+
+// reconcileChangedPods reconciles the pods whose annotations changed like the main loop does, so an
+// edited restart schedule re-arms the pod's scheduled eviction right away.
+func (s *Service) reconcileChangedPods(ctx context.Context) {
+	logger := s.logger.With("controller", "reconcileChangedPods")
+
+	for _, key := range s.annotationWatch.popChangedPods() {
+		if ctx.Err() != nil {
+			return
+		}
+
+		namespace, name, _ := strings.Cut(key, "/")
+
+		pod, err := s.getPod(ctx, namespace, name)
+		if err != nil {
+			var target notFound
+			if !errors.As(err, &target) {
+				logger.WarnContext(ctx, "get changed pod failed", "pod", name, "namespace", namespace, "reason", err)
+			}
+
+			continue
+		}
+
+		logger.InfoContext(ctx, "pod annotations changed, reconciling pod", "pod", name, "namespace", namespace)
+
+		s.reconcileOnePod(ctx, logger, pod, &reconcileRun{
+			staggerOffsets: s.ownerStaggerOffsets(ctx, logger, &pod),
+			gauged:         make(map[string]struct{}),
+		})
+	}
+}
+
+// ownerStaggerOffsets returns the stagger offsets of the selected pods sharing the pod's owner, as
+// staggerOffsets computes them for a whole reconcile; nil when spreading is disabled or the pod has
+// no owner or no restart schedule.
+func (s *Service) ownerStaggerOffsets(ctx context.Context, logger *slog.Logger, pod *Pod) map[string]time.Duration {
+	if s.restartSpread <= 0 || pod.Owner == nil {
+		return nil
+	}
+
+	if _, hasSchedule := s.restartSpec(pod); !hasSchedule {
+		return nil
+	}
+
+	siblings, err := s.repo.ListOwnerPodsQuery(ctx, pod.Namespace, *pod.Owner)
+	if err != nil {
+		logger.WarnContext(ctx, "list owner pods failed, restart is not staggered",
+			"pod", pod.Name,
+			"namespace", pod.Namespace,
+			"reason", err,
+		)
+
+		return nil
+	}
+
+	s.annotationWatch.mu.Lock()
+	siblings = slices.DeleteFunc(siblings, func(sibling Pod) bool {
+		_, listed := s.annotationWatch.listed[sibling.Namespace+"/"+sibling.Name]
+
+		return !listed || sibling.Name == pod.Name
+	})
+	s.annotationWatch.mu.Unlock()
+
+	for i := range siblings {
+		s.applyDefaults(&siblings[i])
+	}
+
+	return s.staggerOffsets(append(siblings, *pod))
+}
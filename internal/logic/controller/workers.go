@@ -0,0 +1,110 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// _defaultPodPacing is the default pause of a reconcile worker between two pods.
+const _defaultPodPacing = 1 * time.Second
+
+// WithReconcileConcurrency processes the pods of a reconcile with n workers instead of one at a time.
+// Pods of the same owner are processed by the same worker, one after the other, so that eviction
+// guards counting an owner's Ready replicas see the evictions of its other pods.
+func WithReconcileConcurrency(n int) Option {
+	return func(s *Service) {
+		s.reconcileConcurrency = max(n, 1)
+	}
+}
+
+// WithPodPacing sets the pause of a reconcile worker between two pods, 1s by default; 0 disables it.
+// The pause spreads the API requests of a reconcile over time, but adds up on large fleets.
+func WithPodPacing(pacing time.Duration) Option {
+	return func(s *Service) {
+		s.podPacing = max(pacing, 0)
+	}
+}
+
+// reconcilePods processes the pods with the reconcile workers. Returns true if context is done.
+func (s *Service) reconcilePods(ctx context.Context, logger *slog.Logger, pods []Pod, run *reconcileRun) bool {
+	groups := groupPodsByOwner(pods)
+	work := make(chan []Pod)
+
+	var wg sync.WaitGroup
+
+	for range min(s.reconcileConcurrency, len(groups)) {
+		wg.Go(func() {
+			for group := range work {
+				s.reconcilePodGroup(ctx, logger, group, run)
+			}
+		})
+	}
+
+feed:
+	for _, group := range groups {
+		select {
+		case work <- group:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+
+	close(work)
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		logger.InfoContext(ctx, "context done, stopping reconciliation")
+
+		return true
+	}
+
+	return false
+}
+
+// reconcilePodGroup processes the pods of one owner in order, pausing between them.
+func (s *Service) reconcilePodGroup(ctx context.Context, logger *slog.Logger, pods []Pod, run *reconcileRun) {
+	for i := range pods {
+		if done := s.reconcileOnePod(ctx, logger, pods[i], run); done {
+			return
+		}
+
+		if s.podPacing <= 0 {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(s.podPacing):
+		}
+	}
+}
+
+// groupPodsByOwner groups the pods by their controlling owner, in the order the owners are first
+// seen. Pods without an owner are groups of their own.
+func groupPodsByOwner(pods []Pod) [][]Pod {
+	var groups [][]Pod
+
+	index := make(map[string]int, len(pods))
+
+	for i := range pods {
+		if pods[i].Owner == nil {
+			groups = append(groups, []Pod{pods[i]})
+
+			continue
+		}
+
+		if g, ok := index[pods[i].Owner.UID]; ok {
+			groups[g] = append(groups[g], pods[i])
+
+			continue
+		}
+
+		index[pods[i].Owner.UID] = len(groups)
+		groups = append(groups, []Pod{pods[i]})
+	}
+
+	return groups
+}